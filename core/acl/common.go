@@ -1,10 +1,12 @@
 package acl
 
+import "github.com/anoideaopen/foundation/core/helpers"
+
 // ChACL - ACL channel name,
 // CcACL - ACL chaincode name
 const (
-	ChACL = "acl"
-	CcACL = "acl"
+	ChACL = helpers.ACLChannel
+	CcACL = helpers.ACLChaincode
 )
 
 // acl chaincode functions
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/types"
+	pb "github.com/anoideaopen/foundation/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+)
+
+// AddressKeyTypeCompositeType is a composite key prefix for the key types used by addresses
+const AddressKeyTypeCompositeType = "address_key_type"
+
+var (
+	ErrKeyTypeMismatch        = errors.New("key type differs from the key type recorded for the address")
+	ErrAddressKeyTypeNotFound = errors.New("key type of the address is not recorded")
+)
+
+// QueryAddressKeyType returns the key type the address signs with.
+// The key type is recorded on the first signature of the address checked by the contract,
+// signatures of other key types are rejected with ErrKeyTypeMismatch until the key is rotated.
+func (bc *BaseContract) QueryAddressKeyType(address *types.Address) (string, error) {
+	keyType, ok, err := loadAddressKeyType(bc.GetStub(), address.String())
+	if err != nil {
+		return "", err
+	}
+
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrAddressKeyTypeNotFound, address)
+	}
+
+	return keyType.String(), nil
+}
+
+// checkAddressKeyType records the key type of the address on the first use
+// and returns ErrKeyTypeMismatch if the key type differs from the recorded one.
+func checkAddressKeyType(stub shim.ChaincodeStubInterface, address string, keyType pb.KeyType) error {
+	recorded, ok, err := loadAddressKeyType(stub, address)
+	if err != nil {
+		return err
+	}
+
+	if !ok {
+		return saveAddressKeyType(stub, address, keyType)
+	}
+
+	if recorded != keyType {
+		return fmt.Errorf("%w: address %s, recorded %s, used %s", ErrKeyTypeMismatch, address, recorded, keyType)
+	}
+
+	return nil
+}
+
+func loadAddressKeyType(stub shim.ChaincodeStubInterface, address string) (pb.KeyType, bool, error) {
+	key, err := stub.CreateCompositeKey(AddressKeyTypeCompositeType, []string{address})
+	if err != nil {
+		return 0, false, err
+	}
+
+	data, err := stub.GetState(key)
+	if err != nil {
+		return 0, false, err
+	}
+
+	if len(data) == 0 {
+		return 0, false, nil
+	}
+
+	keyType, ok := pb.KeyType_value[string(data)]
+	if !ok {
+		return 0, false, fmt.Errorf("unknown recorded key type '%s' of address %s", data, address)
+	}
+
+	return pb.KeyType(keyType), true, nil
+}
+
+func saveAddressKeyType(stub shim.ChaincodeStubInterface, address string, keyType pb.KeyType) error {
+	key, err := stub.CreateCompositeKey(AddressKeyTypeCompositeType, []string{address})
+	if err != nil {
+		return err
+	}
+
+	return stub.PutState(key, []byte(keyType.String()))
+}
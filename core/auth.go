@@ -1,6 +1,7 @@
 package core
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"strconv"
@@ -18,6 +19,11 @@ import (
 	"github.com/hyperledger/fabric-protos-go/peer"
 )
 
+var (
+	ErrInvalidSignaturePolicy = errors.New("invalid signature policy")
+	ErrWeightThresholdNotMet  = errors.New("weight of signers is less than threshold")
+)
+
 type invocationDetails struct {
 	chaincodeNameArg string
 	channelNameArg   string
@@ -87,13 +93,38 @@ func (cc *Chaincode) validateAndExtractInvocationContext(
 		}
 	}
 
+	if err = checkKeyTypesAccepted(invocation.keyTypes, cc.contract.ContractConfig().GetOptions()); err != nil {
+		return nil, nil, 0, err
+	}
+
 	// Form a message to verify the signature.
 	message := []byte(method.ChaincodeFunc + strings.Join(args[:len(args)-invocation.signersCount], ""))
 
-	if err = validateSignaturesInInvocation(invocation, message); err != nil {
+	validateSize := cc.contract.ContractConfig().GetOptions().GetValidateSignatureSize()
+	if err = validateSignaturesInInvocation(invocation, message, validateSize); err != nil {
 		return nil, nil, 0, err
 	}
 
+	if err = checkSignersWeight(invocation, acl.GetAddress().GetSignaturePolicy()); err != nil {
+		return nil, nil, 0, err
+	}
+
+	// Record the key type of the single signer address or check it against the recorded one,
+	// the signer key must also match the key the address is rotated to and is recorded for QueryPublicKey.
+	if invocation.signersCount == 1 {
+		address := (*types.Address)(acl.GetAddress().GetAddress()).String()
+		if err = checkAddressKeyType(stub, address, invocation.keyTypes[0]); err != nil {
+			return nil, nil, 0, err
+		}
+		publicKey := base58.Decode(invocation.signatureArgs[0])
+		if err = checkAddressPublicKey(stub, address, publicKey); err != nil {
+			return nil, nil, 0, err
+		}
+		if err = recordSignerPublicKey(stub, address, publicKey); err != nil {
+			return nil, nil, 0, err
+		}
+	}
+
 	// Update the address if it has changed.
 	if err = helpers.AddAddrIfChanged(stub, acl.GetAddress()); err != nil {
 		return nil, nil, 0, err
@@ -109,9 +140,12 @@ func (cc *Chaincode) validateAndExtractInvocationContext(
 	return acl.GetAddress().GetAddress(), args[3 : 3+(method.NumArgs-1)], nonce, nil
 }
 
+// validateSignaturesInInvocation verifies the present signatures of the invocation,
+// if validateSize is set the signatures are checked for the length of the key type first
 func validateSignaturesInInvocation(
 	invocation *invocationDetails,
 	message []byte,
+	validateSize bool,
 ) error {
 	for i := 0; i < invocation.signersCount; i++ {
 		if invocation.signatureArgs[i+invocation.signersCount] == "" {
@@ -123,6 +157,12 @@ func validateSignaturesInInvocation(
 			signatureBytes = base58.Decode(invocation.signatureArgs[i+invocation.signersCount])
 		)
 
+		if validateSize {
+			if err := keys.ValidateSignatureLength(invocation.keyTypes[i], signatureBytes); err != nil {
+				return err
+			}
+		}
+
 		// Verify the signature ED25519, SECP256K1 or GOST 34.10 2012
 		valid, err := keys.VerifySignatureByKeyType(invocation.keyTypes[i], publicKeyBytes, message, signatureBytes)
 		if err != nil {
@@ -135,6 +175,48 @@ func validateSignaturesInInvocation(
 	return nil
 }
 
+// checkSignersWeight checks that the sum of weights of the signers with present signatures
+// meets the weight threshold of the signature policy. Each key is counted once.
+// Policies without weights are not checked.
+func checkSignersWeight(invocation *invocationDetails, policy *pb.SignaturePolicy) error {
+	weights := policy.GetWeights()
+	if len(weights) == 0 {
+		return nil
+	}
+
+	if len(weights) != len(policy.GetPubKeys()) {
+		return fmt.Errorf(
+			"%w: %d weights for %d keys",
+			ErrInvalidSignaturePolicy,
+			len(weights),
+			len(policy.GetPubKeys()),
+		)
+	}
+
+	counted := make([]bool, len(weights))
+	total := uint64(0)
+	for i := 0; i < invocation.signersCount; i++ {
+		if invocation.signatureArgs[i+invocation.signersCount] == "" {
+			continue // Blank signatures do not count.
+		}
+
+		publicKeyBytes := base58.Decode(invocation.signatureArgs[i])
+		for j, key := range policy.GetPubKeys() {
+			if !counted[j] && bytes.Equal(key, publicKeyBytes) {
+				counted[j] = true
+				total += uint64(weights[j])
+				break
+			}
+		}
+	}
+
+	if total < uint64(policy.GetWeightThreshold()) {
+		return fmt.Errorf("%w: %d of %d", ErrWeightThresholdNotMet, total, policy.GetWeightThreshold())
+	}
+
+	return nil
+}
+
 func checkACLSignerStatus(stub shim.ChaincodeStubInterface, signers []string) (*pb.AclResponse, error) {
 	acl, err := helpers.CheckACL(stub, signers)
 	if err != nil {
@@ -0,0 +1,91 @@
+package balance
+
+import (
+	"math/big"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+)
+
+// Codec encodes and decodes balance values stored in the ledger.
+// Empty state is always read as zero balance and is not passed to Decode.
+type Codec interface {
+	Encode(value *big.Int) ([]byte, error)
+	Decode(data []byte) (*big.Int, error)
+}
+
+// DefaultCodec stores balance as big-endian bytes of its value.
+type DefaultCodec struct{}
+
+// Encode encodes value as big-endian bytes.
+func (DefaultCodec) Encode(value *big.Int) ([]byte, error) {
+	if value.Sign() < 0 {
+		return nil, ErrNegativeBalance
+	}
+
+	return value.Bytes(), nil
+}
+
+// Decode decodes value from big-endian bytes.
+func (DefaultCodec) Decode(data []byte) (*big.Int, error) {
+	return new(big.Int).SetBytes(data), nil
+}
+
+// MigrationCodec is used to migrate balances from the Legacy codec to the Current one.
+// Balances are written with the Current codec and read with the Current codec
+// falling back to the Legacy codec on error, so Current must fail to decode data it did not encode.
+type MigrationCodec struct {
+	Current Codec
+	Legacy  Codec
+}
+
+// Encode encodes value with the Current codec.
+func (c MigrationCodec) Encode(value *big.Int) ([]byte, error) {
+	return c.Current.Encode(value)
+}
+
+// Decode decodes data with the Current codec or with the Legacy codec if the Current one fails.
+func (c MigrationCodec) Decode(data []byte) (*big.Int, error) {
+	value, err := c.Current.Decode(data)
+	if err == nil {
+		return value, nil
+	}
+
+	return c.Legacy.Decode(data)
+}
+
+// codecStub binds the balance codec to the stub.
+type codecStub struct {
+	shim.ChaincodeStubInterface
+	codec Codec
+}
+
+// WithCodec returns stub for the functions of the package which encode and decode balances with codec.
+// If codec is nil, stub is returned as is and DefaultCodec is used.
+func WithCodec(stub shim.ChaincodeStubInterface, codec Codec) shim.ChaincodeStubInterface {
+	if codec == nil {
+		return stub
+	}
+
+	return &codecStub{ChaincodeStubInterface: stub, codec: codec}
+}
+
+func codecOf(stub shim.ChaincodeStubInterface) Codec {
+	if cs, ok := stub.(*codecStub); ok {
+		return cs.codec
+	}
+
+	return DefaultCodec{}
+}
+
+func encode(stub shim.ChaincodeStubInterface, value *big.Int) ([]byte, error) {
+	return codecOf(stub).Encode(value)
+}
+
+// Decode decodes the balance value read directly from the state with the codec bound to stub.
+func Decode(stub shim.ChaincodeStubInterface, data []byte) (*big.Int, error) {
+	if len(data) == 0 {
+		return new(big.Int), nil
+	}
+
+	return codecOf(stub).Decode(data)
+}
@@ -0,0 +1,148 @@
+package balance
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+)
+
+// ErrInvalidHistoryBookmark is returned if the bookmark does not match any modification of the balance.
+var ErrInvalidHistoryBookmark = errors.New("invalid balance history bookmark")
+
+// HistoryEntry represents a single modification of a balance.
+type HistoryEntry struct {
+	TxID      string
+	Timestamp int64
+	Balance   *big.Int
+	IsDeleted bool
+}
+
+// ListHistory fetches a page of modifications of the balance for the given address and token,
+// the most recent modification first.
+//
+// Parameters:
+//   - stub: shim.ChaincodeStubInterface - The chaincode stub interface for accessing ledger operations.
+//   - balanceType: BalanceType - The type of balance, which determines the state key's prefix.
+//   - address: string - The address associated with the balance.
+//   - token: string - The token identifier. If empty, the history of the balance associated with the address alone is fetched.
+//   - pageSize: int - The maximum number of entries to return.
+//   - bookmark: string - The transaction ID of the last entry of the previous page, empty for the first page.
+//
+// Returns:
+//   - []HistoryEntry - A slice of HistoryEntry structs representing modifications of the balance.
+//   - string - The bookmark of the next page, empty if there are no more entries.
+//   - error - An error if the retrieval fails, otherwise nil.
+func ListHistory(
+	stub shim.ChaincodeStubInterface,
+	balanceType BalanceType,
+	address string,
+	token string,
+	pageSize int,
+	bookmark string,
+) ([]HistoryEntry, string, error) {
+	compositeKeyAttributes := []string{address}
+	if token != "" {
+		compositeKeyAttributes = append(compositeKeyAttributes, token)
+	}
+
+	compositeKey, err := stub.CreateCompositeKey(balanceType.String(), compositeKeyAttributes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	historyIterator, err := stub.GetHistoryForKey(compositeKey)
+	if err != nil {
+		return nil, "", err
+	}
+	defer historyIterator.Close()
+
+	// Skip modifications up to and including the bookmarked one.
+	if bookmark != "" {
+		found := false
+		for !found && historyIterator.HasNext() {
+			modification, err := historyIterator.Next()
+			if err != nil {
+				return nil, "", err
+			}
+			found = modification.GetTxId() == bookmark
+		}
+
+		if !found {
+			return nil, "", ErrInvalidHistoryBookmark
+		}
+	}
+
+	entries := make([]HistoryEntry, 0, pageSize)
+	for len(entries) < pageSize && historyIterator.HasNext() {
+		modification, err := historyIterator.Next()
+		if err != nil {
+			return nil, "", err
+		}
+
+		balance, err := Decode(stub, modification.GetValue())
+		if err != nil {
+			return nil, "", err
+		}
+
+		entries = append(entries, HistoryEntry{
+			TxID:      modification.GetTxId(),
+			Timestamp: modification.GetTimestamp().GetSeconds(),
+			Balance:   balance,
+			IsDeleted: modification.GetIsDelete(),
+		})
+	}
+
+	if len(entries) == 0 || !historyIterator.HasNext() {
+		return entries, "", nil
+	}
+
+	return entries, entries[len(entries)-1].TxID, nil
+}
+
+// Written reports whether the balance for the given address and token has ever been written.
+// A balance reduced to zero is deleted from the state, so the key history is checked
+// if the balance is not set.
+//
+// Parameters:
+//   - stub: shim.ChaincodeStubInterface - The chaincode stub interface for accessing ledger operations.
+//   - balanceType: BalanceType - The type of balance, which determines the state key's prefix.
+//   - address: string - The address associated with the balance.
+//   - token: string - The token identifier. If empty, the balance associated with the address alone is checked.
+//
+// Returns:
+//   - bool - true if the balance is set or has been set before.
+//   - error - An error if the retrieval fails, otherwise nil.
+func Written(
+	stub shim.ChaincodeStubInterface,
+	balanceType BalanceType,
+	address string,
+	token string,
+) (bool, error) {
+	compositeKeyAttributes := []string{address}
+	if token != "" {
+		compositeKeyAttributes = append(compositeKeyAttributes, token)
+	}
+
+	compositeKey, err := stub.CreateCompositeKey(balanceType.String(), compositeKeyAttributes)
+	if err != nil {
+		return false, err
+	}
+
+	value, err := stub.GetState(compositeKey)
+	if err != nil {
+		return false, err
+	}
+
+	if len(value) != 0 {
+		return true, nil
+	}
+
+	historyIterator, err := stub.GetHistoryForKey(compositeKey)
+	if err != nil {
+		return false, err
+	}
+	defer historyIterator.Close()
+
+	return historyIterator.HasNext(), nil
+}
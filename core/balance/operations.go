@@ -11,6 +11,7 @@ import (
 var (
 	ErrAmountMustBeNonNegative = errors.New("amount must be non-negative")
 	ErrInsufficientBalance     = errors.New("insufficient balance")
+	ErrNegativeBalance         = errors.New("balance can not be negative")
 )
 
 // Add adds the given amount to the balance for the specified address and token, if the amount is greater than zero.
@@ -53,10 +53,15 @@ func ListBalancesByAddress(
 			continue
 		}
 
+		balance, err := Decode(stub, response.GetValue())
+		if err != nil {
+			return nil, err
+		}
+
 		balances = append(balances, TokenBalance{
 			Address: components[0],
 			Token:   components[1],
-			Balance: new(big.Int).SetBytes(response.GetValue()),
+			Balance: balance,
 		})
 	}
 
@@ -103,10 +108,15 @@ func ListOwnersByToken(
 			continue
 		}
 
+		balance, err := Decode(stub, response.GetValue())
+		if err != nil {
+			return nil, err
+		}
+
 		owners = append(owners, TokenBalance{
 			Token:   components[1],
 			Address: components[2],
-			Balance: new(big.Int).SetBytes(response.GetValue()),
+			Balance: balance,
 		})
 	}
 
@@ -44,9 +44,8 @@ func Get(
 		return nil, err
 	}
 
-	// Convert the balance from bytes to *big.Int and return.
-	balance := new(big.Int).SetBytes(balanceBytes)
-	return balance, nil
+	// Decode the balance with the codec bound to the stub.
+	return Decode(stub, balanceBytes)
 }
 
 // Put stores the balance for a given address and token into the ledger.
@@ -59,7 +58,7 @@ func Get(
 //   - value: *big.Int - The balance value to store associated with the address and token.
 //
 // Returns:
-//   - error - An error if the storage fails, otherwise nil.
+//   - error - ErrNegativeBalance if the value is negative, an error if the storage fails, otherwise nil.
 func Put(
 	stub shim.ChaincodeStubInterface,
 	balanceType BalanceType,
@@ -67,6 +66,11 @@ func Put(
 	token string,
 	value *big.Int,
 ) error {
+	// Bytes drops the sign, so a negative balance would be stored as its absolute value.
+	if value.Sign() < 0 {
+		return ErrNegativeBalance
+	}
+
 	// Create the primary composite key for the balance entry.
 	primaryAttributes := []string{address}
 	if token != "" {
@@ -77,8 +81,14 @@ func Put(
 		return err
 	}
 
+	// Encode the balance with the codec bound to the stub.
+	valueBytes, err := encode(stub, value)
+	if err != nil {
+		return err
+	}
+
 	// Store the balance using the primary composite key.
-	if err := stub.PutState(primaryCompositeKey, value.Bytes()); err != nil {
+	if err := stub.PutState(primaryCompositeKey, valueBytes); err != nil {
 		return err
 	}
 
@@ -97,5 +107,5 @@ func Put(
 	}
 
 	// Store the balance using the inverse composite key.
-	return stub.PutState(inverseCompositeKey, value.Bytes())
+	return stub.PutState(inverseCompositeKey, valueBytes)
 }
@@ -23,12 +23,12 @@ func (bc *BaseContract) tokenBalanceAdd(
 		tokenName = parts[len(parts)-1]
 	}
 
-	return balance.Add(bc.stub, balance.BalanceTypeToken, address.String(), tokenName, &amount.Int)
+	return balance.Add(bc.BalanceStub(), balance.BalanceTypeToken, address.String(), tokenName, &amount.Int)
 }
 
 func (bc *BaseContract) IndustrialBalanceGet(address *types.Address) (map[string]string, error) {
 	tokens, err := balance.ListBalancesByAddress(
-		bc.stub,
+		bc.BalanceStub(),
 		balance.BalanceTypeToken,
 		address.String(),
 	)
@@ -53,7 +53,7 @@ func (bc *BaseContract) IndustrialBalanceTransfer(
 	}
 
 	return balance.Move(
-		bc.stub,
+		bc.BalanceStub(),
 		balance.BalanceTypeToken,
 		from.String(),
 		balance.BalanceTypeToken,
@@ -81,7 +81,7 @@ func (bc *BaseContract) IndustrialBalanceAdd(
 		)
 	}
 
-	return balance.Add(bc.stub, balance.BalanceTypeToken, address.String(), token, &amount.Int)
+	return balance.Add(bc.BalanceStub(), balance.BalanceTypeToken, address.String(), token, &amount.Int)
 }
 
 func (bc *BaseContract) IndustrialBalanceSub(
@@ -102,7 +102,7 @@ func (bc *BaseContract) IndustrialBalanceSub(
 		)
 	}
 
-	return balance.Sub(bc.stub, balance.BalanceTypeToken, address.String(), token, &amount.Int)
+	return balance.Sub(bc.BalanceStub(), balance.BalanceTypeToken, address.String(), token, &amount.Int)
 }
 
 func (bc *BaseContract) TokenBalanceTransfer(
@@ -116,7 +116,7 @@ func (bc *BaseContract) TokenBalanceTransfer(
 	}
 
 	return balance.Move(
-		bc.stub,
+		bc.BalanceStub(),
 		balance.BalanceTypeToken,
 		from.String(),
 		balance.BalanceTypeToken,
@@ -133,12 +133,14 @@ func (bc *BaseContract) AllowedBalanceTransfer(
 	amount *big.Int,
 	reason string,
 ) error {
+	token = bc.ResolveToken(token)
+
 	if stub, ok := bc.GetStub().(*cachestub.TxCacheStub); ok {
 		stub.AddAccountingRecord(token, from, to, amount, reason)
 	}
 
 	return balance.Move(
-		bc.stub,
+		bc.BalanceStub(),
 		balance.BalanceTypeAllowed,
 		from.String(),
 		balance.BalanceTypeAllowed,
@@ -149,7 +151,7 @@ func (bc *BaseContract) AllowedBalanceTransfer(
 }
 
 func (bc *BaseContract) TokenBalanceGet(address *types.Address) (*big.Int, error) {
-	balance, err := balance.Get(bc.stub, balance.BalanceTypeToken, address.String(), "")
+	balance, err := balance.Get(bc.BalanceStub(), balance.BalanceTypeToken, address.String(), "")
 
 	return new(big.Int).SetBytes(balance.Bytes()), err
 }
@@ -163,7 +165,7 @@ func (bc *BaseContract) TokenBalanceAdd(
 		stub.AddAccountingRecord(bc.config.GetSymbol(), &types.Address{}, address, amount, reason)
 	}
 
-	return balance.Add(bc.stub, balance.BalanceTypeToken, address.String(), "", &amount.Int)
+	return balance.Add(bc.BalanceStub(), balance.BalanceTypeToken, address.String(), "", &amount.Int)
 }
 
 // TokenBalanceAddWithTicker adds a specified amount of tokens to an account's balance
@@ -196,7 +198,7 @@ func (bc *BaseContract) TokenBalanceAddWithTicker(
 	if stub, ok := bc.GetStub().(*cachestub.TxCacheStub); ok {
 		stub.AddAccountingRecord(bc.config.GetSymbol()+separator+token, address, &types.Address{}, amount, reason)
 	}
-	if err := balance.Add(bc.stub, balance.BalanceTypeToken, address.String(), token, &amount.Int); err != nil {
+	if err := balance.Add(bc.BalanceStub(), balance.BalanceTypeToken, address.String(), token, &amount.Int); err != nil {
 		return fmt.Errorf("failed to add token balance: %s", err.Error())
 	}
 
@@ -212,7 +214,7 @@ func (bc *BaseContract) TokenBalanceSub(
 		stub.AddAccountingRecord(bc.config.GetSymbol(), address, &types.Address{}, amount, reason)
 	}
 
-	return balance.Sub(bc.stub, balance.BalanceTypeToken, address.String(), "", &amount.Int)
+	return balance.Sub(bc.BalanceStub(), balance.BalanceTypeToken, address.String(), "", &amount.Int)
 }
 
 // TokenBalanceSubWithTicker subtracts a specified amount of tokens from an account's balance
@@ -245,7 +247,7 @@ func (bc *BaseContract) TokenBalanceSubWithTicker(
 	if stub, ok := bc.GetStub().(*cachestub.TxCacheStub); ok {
 		stub.AddAccountingRecord(bc.config.GetSymbol()+separator+token, address, &types.Address{}, amount, reason)
 	}
-	if err := balance.Sub(bc.stub, balance.BalanceTypeToken, address.String(), token, &amount.Int); err != nil {
+	if err := balance.Sub(bc.BalanceStub(), balance.BalanceTypeToken, address.String(), token, &amount.Int); err != nil {
 		return fmt.Errorf("failed to subtract token balance: %s", err.Error())
 	}
 
@@ -253,7 +255,7 @@ func (bc *BaseContract) TokenBalanceSubWithTicker(
 }
 
 func (bc *BaseContract) TokenBalanceGetLocked(address *types.Address) (*big.Int, error) {
-	balance, err := balance.Get(bc.stub, balance.BalanceTypeTokenLocked, address.String(), "")
+	balance, err := balance.Get(bc.BalanceStub(), balance.BalanceTypeTokenLocked, address.String(), "")
 
 	return new(big.Int).SetBytes(balance.Bytes()), err
 }
@@ -263,7 +265,7 @@ func (bc *BaseContract) TokenBalanceLock(address *types.Address, amount *big.Int
 		stub.AddAccountingRecord(bc.config.GetSymbol(), address, address, amount, "token balance lock")
 	}
 	return balance.Move(
-		bc.stub,
+		bc.BalanceStub(),
 		balance.BalanceTypeToken,
 		address.String(),
 		balance.BalanceTypeTokenLocked,
@@ -278,7 +280,7 @@ func (bc *BaseContract) TokenBalanceUnlock(address *types.Address, amount *big.I
 		stub.AddAccountingRecord(bc.config.GetSymbol(), address, address, amount, "token balance unlock")
 	}
 	return balance.Move(
-		bc.stub,
+		bc.BalanceStub(),
 		balance.BalanceTypeTokenLocked,
 		address.String(),
 		balance.BalanceTypeToken,
@@ -299,7 +301,7 @@ func (bc *BaseContract) TokenBalanceTransferLocked(
 	}
 
 	return balance.Move(
-		bc.stub,
+		bc.BalanceStub(),
 		balance.BalanceTypeTokenLocked,
 		from.String(),
 		balance.BalanceTypeToken,
@@ -319,7 +321,7 @@ func (bc *BaseContract) TokenBalanceBurnLocked(
 	}
 
 	return balance.Sub(
-		bc.stub,
+		bc.BalanceStub(),
 		balance.BalanceTypeTokenLocked,
 		address.String(),
 		"",
@@ -328,7 +330,9 @@ func (bc *BaseContract) TokenBalanceBurnLocked(
 }
 
 func (bc *BaseContract) AllowedBalanceGet(token string, address *types.Address) (*big.Int, error) {
-	balance, err := balance.Get(bc.stub, balance.BalanceTypeAllowed, address.String(), token)
+	token = bc.ResolveToken(token)
+
+	balance, err := balance.Get(bc.BalanceStub(), balance.BalanceTypeAllowed, address.String(), token)
 
 	return new(big.Int).SetBytes(balance.Bytes()), err
 }
@@ -339,11 +343,13 @@ func (bc *BaseContract) AllowedBalanceAdd(
 	amount *big.Int,
 	reason string,
 ) error {
+	token = bc.ResolveToken(token)
+
 	if stub, ok := bc.GetStub().(*cachestub.TxCacheStub); ok {
 		stub.AddAccountingRecord(token, &types.Address{}, address, amount, reason)
 	}
 
-	return balance.Add(bc.stub, balance.BalanceTypeAllowed, address.String(), token, &amount.Int)
+	return balance.Add(bc.BalanceStub(), balance.BalanceTypeAllowed, address.String(), token, &amount.Int)
 }
 
 func (bc *BaseContract) AllowedBalanceSub(
@@ -352,12 +358,14 @@ func (bc *BaseContract) AllowedBalanceSub(
 	amount *big.Int,
 	reason string,
 ) error {
+	token = bc.ResolveToken(token)
+
 	if stub, ok := bc.GetStub().(*cachestub.TxCacheStub); ok {
 		stub.AddAccountingRecord(token, address, &types.Address{}, amount, reason)
 	}
 
 	return balance.Sub(
-		bc.stub,
+		bc.BalanceStub(),
 		balance.BalanceTypeAllowed,
 		address.String(),
 		token,
@@ -378,7 +386,7 @@ func (bc *BaseContract) AllowedIndustrialBalanceTransfer(
 		}
 
 		if err := balance.Move(
-			bc.stub,
+			bc.BalanceStub(),
 			balance.BalanceTypeAllowed,
 			from.String(),
 			balance.BalanceTypeAllowed,
@@ -411,7 +419,7 @@ func (bc *BaseContract) AllowedIndustrialBalanceAdd(
 		}
 
 		if err := balance.Add(
-			bc.stub,
+			bc.BalanceStub(),
 			balance.BalanceTypeAllowed,
 			address.String(),
 			industrialAsset.GetGroup(),
@@ -436,7 +444,7 @@ func (bc *BaseContract) AllowedIndustrialBalanceSub(
 		}
 
 		if err := balance.Sub(
-			bc.stub,
+			bc.BalanceStub(),
 			balance.BalanceTypeAllowed,
 			address.String(),
 			asset.GetGroup(),
@@ -450,7 +458,9 @@ func (bc *BaseContract) AllowedIndustrialBalanceSub(
 }
 
 func (bc *BaseContract) AllowedBalanceGetLocked(token string, address *types.Address) (*big.Int, error) {
-	balanceValue, err := balance.Get(bc.stub, balance.BalanceTypeAllowedLocked, address.String(), token)
+	token = bc.ResolveToken(token)
+
+	balanceValue, err := balance.Get(bc.BalanceStub(), balance.BalanceTypeAllowedLocked, address.String(), token)
 	return new(big.Int).SetBytes(balanceValue.Bytes()), err
 }
 
@@ -459,12 +469,14 @@ func (bc *BaseContract) AllowedBalanceLock(
 	address *types.Address,
 	amount *big.Int,
 ) error {
+	token = bc.ResolveToken(token)
+
 	if stub, ok := bc.GetStub().(*cachestub.TxCacheStub); ok {
 		stub.AddAccountingRecord(bc.config.GetSymbol(), address, address, amount, "allowed balance lock")
 	}
 
 	return balance.Move(
-		bc.stub,
+		bc.BalanceStub(),
 		balance.BalanceTypeAllowed,
 		address.String(),
 		balance.BalanceTypeAllowedLocked,
@@ -479,12 +491,14 @@ func (bc *BaseContract) AllowedBalanceUnLock(
 	address *types.Address,
 	amount *big.Int,
 ) error {
+	token = bc.ResolveToken(token)
+
 	if stub, ok := bc.GetStub().(*cachestub.TxCacheStub); ok {
 		stub.AddAccountingRecord(bc.config.GetSymbol(), address, address, amount, "allowed balance unlock")
 	}
 
 	return balance.Move(
-		bc.stub,
+		bc.BalanceStub(),
 		balance.BalanceTypeAllowedLocked,
 		address.String(),
 		balance.BalanceTypeAllowed,
@@ -501,12 +515,14 @@ func (bc *BaseContract) AllowedBalanceTransferLocked(
 	amount *big.Int,
 	reason string,
 ) error {
+	token = bc.ResolveToken(token)
+
 	if stub, ok := bc.GetStub().(*cachestub.TxCacheStub); ok {
 		stub.AddAccountingRecord(token, from, to, amount, reason)
 	}
 
 	return balance.Move(
-		bc.stub,
+		bc.BalanceStub(),
 		balance.BalanceTypeAllowedLocked,
 		from.String(),
 		balance.BalanceTypeAllowed,
@@ -522,12 +538,14 @@ func (bc *BaseContract) AllowedBalanceBurnLocked(
 	amount *big.Int,
 	reason string,
 ) error {
+	token = bc.ResolveToken(token)
+
 	if stub, ok := bc.GetStub().(*cachestub.TxCacheStub); ok {
 		stub.AddAccountingRecord(token, address, &types.Address{}, amount, reason)
 	}
 
 	return balance.Sub(
-		bc.stub,
+		bc.BalanceStub(),
 		balance.BalanceTypeAllowedLocked,
 		address.String(),
 		token,
@@ -539,7 +557,7 @@ func (bc *BaseContract) IndustrialBalanceGetLocked(
 	address *types.Address,
 ) (map[string]string, error) {
 	tokens, err := balance.ListBalancesByAddress(
-		bc.stub,
+		bc.BalanceStub(),
 		balance.BalanceTypeTokenLocked,
 		address.String(),
 	)
@@ -562,7 +580,7 @@ func (bc *BaseContract) IndustrialBalanceLock(
 	}
 
 	return balance.Move(
-		bc.stub,
+		bc.BalanceStub(),
 		balance.BalanceTypeToken,
 		address.String(),
 		balance.BalanceTypeTokenLocked,
@@ -584,7 +602,7 @@ func (bc *BaseContract) IndustrialBalanceUnLock(
 	}
 
 	return balance.Move(
-		bc.stub,
+		bc.BalanceStub(),
 		balance.BalanceTypeTokenLocked,
 		address.String(),
 		balance.BalanceTypeToken,
@@ -608,7 +626,7 @@ func (bc *BaseContract) IndustrialBalanceTransferLocked(
 	}
 
 	return balance.Move(
-		bc.stub,
+		bc.BalanceStub(),
 		balance.BalanceTypeTokenLocked,
 		from.String(),
 		balance.BalanceTypeToken,
@@ -637,7 +655,7 @@ func (bc *BaseContract) IndustrialBalanceBurnLocked(
 	}
 
 	return balance.Sub(
-		bc.stub,
+		bc.BalanceStub(),
 		balance.BalanceTypeTokenLocked,
 		address.String(),
 		token,
@@ -647,7 +665,7 @@ func (bc *BaseContract) IndustrialBalanceBurnLocked(
 
 func (bc *BaseContract) AllowedBalanceGetAll(address *types.Address) (map[string]string, error) {
 	tokens, err := balance.ListBalancesByAddress(
-		bc.stub,
+		bc.BalanceStub(),
 		balance.BalanceTypeAllowed,
 		address.String(),
 	)
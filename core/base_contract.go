@@ -10,15 +10,19 @@ import (
 	"sort"
 	"strconv"
 
+	"github.com/anoideaopen/foundation/core/balance"
+	"github.com/anoideaopen/foundation/core/cachestub"
 	"github.com/anoideaopen/foundation/core/contract"
+	"github.com/anoideaopen/foundation/core/helpers"
 	"github.com/anoideaopen/foundation/core/reflectx"
 	"github.com/anoideaopen/foundation/core/stringsx"
 	"github.com/anoideaopen/foundation/core/telemetry"
 	"github.com/anoideaopen/foundation/core/types"
 	"github.com/anoideaopen/foundation/core/types/big"
+	"github.com/anoideaopen/foundation/keys"
 	pb "github.com/anoideaopen/foundation/proto"
 	"github.com/anoideaopen/foundation/version"
-	"github.com/golang/protobuf/proto" //nolint:staticcheck
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"go.opentelemetry.io/otel"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -31,9 +35,15 @@ type BaseContract struct {
 	srcFs          *embed.FS
 	config         *pb.ContractConfig
 	traceCtx       telemetry.TraceContext
+	txNonce        uint64
 	tracingHandler *telemetry.TracingHandler
 	isService      bool
 	router         contract.Router
+	denylisted     map[string]bool
+	balanceCodec   balance.Codec
+	// configurable is the contract embedding BaseContract, its config validators are run by QueryValidateConfig
+	// and its privileged methods are listed by QueryPrivilegedMethods
+	configurable contract.Base
 }
 
 var _ BaseContractInterface = &BaseContract{}
@@ -46,10 +56,29 @@ func (bc *BaseContract) Router() contract.Router {
 	return bc.router
 }
 
+func (bc *BaseContract) setConfigurable(configurable contract.Base) {
+	bc.configurable = configurable
+}
+
 func (bc *BaseContract) setSrcFs(srcFs *embed.FS) {
 	bc.srcFs = srcFs
 }
 
+func (bc *BaseContract) setBalanceCodec(codec balance.Codec) {
+	bc.balanceCodec = codec
+}
+
+// BalanceCodec returns the codec of the balances set by WithBalanceCodec, nil means balance.DefaultCodec
+func (bc *BaseContract) BalanceCodec() balance.Codec {
+	return bc.balanceCodec
+}
+
+// BalanceStub returns stub to pass to the functions of the balance package,
+// balances are encoded and decoded with the codec of the contract.
+func (bc *BaseContract) BalanceStub() shim.ChaincodeStubInterface {
+	return balance.WithCodec(bc.stub, bc.balanceCodec)
+}
+
 // GetStub returns stub
 func (bc *BaseContract) GetStub() shim.ChaincodeStubInterface {
 	return bc.stub
@@ -96,6 +125,7 @@ func (bc *BaseContract) isMethodDisabled(method contract.Method) bool {
 func (bc *BaseContract) SetStub(stub shim.ChaincodeStubInterface) {
 	bc.stub = stub
 	bc.noncePrefix = StateKeyNonce
+	bc.denylisted = nil
 }
 
 func (bc *BaseContract) QueryGetNonce(owner *types.Address) (string, error) {
@@ -105,23 +135,98 @@ func (bc *BaseContract) QueryGetNonce(owner *types.Address) (string, error) {
 		return "", err
 	}
 
-	data, err := bc.stub.GetState(key)
+	lastNonce, err := loadNonce(bc.stub, key)
+	if err != nil {
+		return "", err
+	}
+
+	// the recent nonces are kept in the journal if nonce_prune_threshold is set
+	journalKey, err := bc.stub.CreateCompositeKey(NonceJournalCompositeType, []string{owner.String()})
+	if err != nil {
+		return "", err
+	}
+	journal, err := loadNonce(bc.stub, journalKey)
 	if err != nil {
 		return "", err
 	}
 
-	exist := new(big.Int).String()
+	var greatest uint64
+	for _, nonces := range [][]uint64{lastNonce.GetNonce(), journal.GetNonce()} {
+		if len(nonces) != 0 && nonces[len(nonces)-1] > greatest {
+			greatest = nonces[len(nonces)-1]
+		}
+	}
+
+	return strconv.FormatUint(greatest, 10), nil
+}
+
+// NonceAcceptance is the result of QueryWouldAcceptNonce
+type NonceAcceptance struct {
+	Accepted bool `json:"accepted"`
+	// Reason is the error the nonce would be rejected with, empty if the nonce is accepted
+	Reason string `json:"reason,omitempty"`
+}
+
+// QueryWouldAcceptNonce checks whether the nonce of the owner's call of the chaincode function method
+// (e.g. "transfer") would be accepted now, so clients can validate the nonce before submitting the transaction.
+// The nonce is checked against the nonce list of the namespace of the method (see nonce_namespaces)
+// the same way as on the batch execution, including the clock correction, but the nonce list is not changed.
+// The nonce accepted by the query may still be rejected if another operation of the owner takes it first.
+func (bc *BaseContract) QueryWouldAcceptNonce(owner *types.Address, method string, nonce uint64) (*NonceAcceptance, error) {
+	txStub := cachestub.NewBatchCacheStub(bc.stub).NewTxCacheStub(bc.stub.GetTxID())
+
+	options := bc.config.GetOptions()
+	if err := checkNonce(txStub, types.NewSenderFromAddr(owner), options, nonceNamespace(options, method), nonce); err != nil {
+		return &NonceAcceptance{Reason: err.Error()}, nil
+	}
+
+	return &NonceAcceptance{Accepted: true}, nil
+}
+
+// QueryVerifySignature checks offline whether signature (base58) of payload was made by the key
+// publicKey (base58) of type keyType. The public key must belong to address according to ACL.
+// The same verification is used for signed invocations, so clients can check
+// signatures before submitting a transaction. If validate_signature_size option is set,
+// the signature of the wrong length returns keys.ErrMalformedSignature.
+func (bc *BaseContract) QueryVerifySignature(
+	address *types.Address,
+	publicKey string,
+	keyType string,
+	payload string,
+	signature string,
+) (bool, error) {
+	kt, ok := pb.KeyType_value[keyType]
+	if !ok {
+		return false, fmt.Errorf("unknown key type '%s'", keyType)
+	}
+
+	acl, err := helpers.CheckACL(bc.stub, []string{publicKey})
+	if err != nil {
+		return false, err
+	}
+
+	if !address.Equal((*types.Address)(acl.GetAddress().GetAddress())) {
+		return false, fmt.Errorf("public key does not belong to address %s", address.String())
+	}
 
-	lastNonce := new(pb.Nonce)
-	if len(data) > 0 {
-		if err = proto.Unmarshal(data, lastNonce); err != nil {
-			// let's just say it's an old nonsense
-			lastNonce.Nonce = []uint64{new(big.Int).SetBytes(data).Uint64()}
+	if bc.config.GetOptions().GetValidateSignatureSize() {
+		if err = keys.ValidateSignatureLength(pb.KeyType(kt), base58.Decode(signature)); err != nil {
+			return false, err
 		}
-		exist = strconv.FormatUint(lastNonce.GetNonce()[len(lastNonce.GetNonce())-1], 10)
 	}
 
-	return exist, nil
+	valid, err := keys.VerifySignatureByKeyType(
+		pb.KeyType(kt),
+		base58.Decode(publicKey),
+		[]byte(payload),
+		base58.Decode(signature),
+	)
+	if err != nil {
+		// signature can't be parsed for this key type, so it is not valid
+		return false, nil //nolint:nilerr
+	}
+
+	return valid, nil
 }
 
 // QuerySrcFile returns file
@@ -236,6 +341,12 @@ func (bc *BaseContract) QuerySystemEnv() (map[string]string, error) {
 	return res, nil
 }
 
+// QueryVersion returns the version of the deployed contract from its config.
+// The version is updated on upgrade of the chaincode.
+func (bc *BaseContract) QueryVersion() (string, error) {
+	return bc.ContractConfig().GetVersion(), nil
+}
+
 // TxHealthCheck can be called by an administrator of the contract for checking if
 // the business logic of the chaincode is still alive.
 func (bc *BaseContract) TxHealthCheck(_ *types.Sender) error {
@@ -287,6 +398,11 @@ func (bc *BaseContract) setTraceContext(traceCtx telemetry.TraceContext) {
 	bc.traceCtx = traceCtx
 }
 
+// setTxNonce sets the nonce of the sender's call executed by the contract, zero if it's unknown
+func (bc *BaseContract) setTxNonce(nonce uint64) {
+	bc.txNonce = nonce
+}
+
 // GetTraceContext returns trace context. Using for call methods only
 func (bc *BaseContract) GetTraceContext() telemetry.TraceContext {
 	return bc.traceCtx
@@ -341,6 +457,10 @@ func (bc *BaseContract) setupTracing() {
 	th := &telemetry.TracingHandler{}
 	th.Tracer = otel.Tracer(serviceName)
 	th.Propagators = otel.GetTextMapPropagator()
+	th.SetRedactedAttributes(
+		bc.ContractConfig().GetOptions().GetTracingRedactedAttributes(),
+		[]byte(os.Getenv(telemetry.TracingRedactionKeyEnv)),
+	)
 	th.TracingInit()
 
 	bc.setTracingHandler(th)
@@ -374,6 +494,8 @@ type BaseContractInterface interface { //nolint:interfacebloat
 	// and no more. Needs refactoring in the future.
 
 	setSrcFs(*embed.FS)
+	setBalanceCodec(balance.Codec)
+	BalanceCodec() balance.Codec
 	tokenBalanceAdd(address *types.Address, amount *big.Int, token string) error
 
 	// ------------------------------------------------------------------
@@ -404,7 +526,11 @@ type BaseContractInterface interface { //nolint:interfacebloat
 	AllowedIndustrialBalanceSub(address *types.Address, industrialAssets []*pb.Asset, reason string) error
 	AllowedIndustrialBalanceTransfer(from *types.Address, to *types.Address, industrialAssets []*pb.Asset, reason string) error
 
+	CheckDenylist(addresses ...*types.Address) error
+	WalletAuditAdd(address *types.Address, eventType string, token string, amount *big.Int, counterparty string) error
+
 	setTraceContext(traceCtx telemetry.TraceContext)
+	setTxNonce(nonce uint64)
 	GetTraceContext() telemetry.TraceContext
 
 	setTracingHandler(th *telemetry.TracingHandler)
@@ -415,4 +541,6 @@ type BaseContractInterface interface { //nolint:interfacebloat
 
 	setRouter(contract.Router)
 	Router() contract.Router
+
+	setConfigurable(contract.Base)
 }
@@ -1,10 +1,13 @@
 package core
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"runtime/debug"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -27,6 +30,8 @@ import (
 
 const robotSideTimeout = 300 // 5 minutes
 
+var ErrBatchDuplicateTx = errors.New("transaction repeats a transaction of the batch")
+
 func (cc *Chaincode) saveToBatch(
 	traceCtx telemetry.TraceContext,
 	stub shim.ChaincodeStubInterface,
@@ -89,9 +94,34 @@ func (cc *Chaincode) saveToBatch(
 	return stub.PutState(key, data)
 }
 
+// batchNonces are the nonces checked by the transactions of the batch mapped to the digests
+// of the transactions carrying them, the transactions sharing the nonce are allowed
+// by allow_batch_shared_nonce of the chaincode options
+type batchNonces map[string]map[string]struct{}
+
+func batchNonceKey(sender *types.Sender, namespace string, nonce uint64) string {
+	return sender.Address().String() + "/" + namespace + "/" + strconv.FormatUint(nonce, 10)
+}
+
+// batchTxDigest returns the digest of the method and the arguments of the pending transaction,
+// the copies of the signed transaction have the same digest
+func batchTxDigest(pending *proto.PendingTx) string {
+	h := sha256.New()
+	for _, s := range append([]string{pending.GetMethod()}, pending.GetArgs()...) {
+		_, _ = fmt.Fprintf(h, "%d:%s", len(s), s)
+	}
+
+	return string(h.Sum(nil))
+}
+
+// loadFromBatch loads the pending transaction of the batch and checks its nonce. The nonce already checked
+// by a previous transaction of the batch is accepted if nonces is not nil and the batch shared nonce is allowed,
+// unless the transaction repeats the method and the arguments of that transaction, as the copy
+// of the signed transaction submitted again does.
 func (cc *Chaincode) loadFromBatch(
 	stub shim.ChaincodeStubInterface,
 	txID string,
+	nonces batchNonces,
 ) (*proto.PendingTx, string, error) {
 	log := logger.Logger()
 	key, err := stub.CreateCompositeKey(config.BatchPrefix, []string{txID})
@@ -138,11 +168,29 @@ func (cc *Chaincode) loadFromBatch(
 	}
 
 	sender := types.NewSenderFromAddr((*types.Address)(pending.GetSender()))
-	if err = checkNonce(stub, sender, pending.GetNonce()); err != nil {
+	options := cc.contract.ContractConfig().GetOptions()
+	namespace := nonceNamespace(options, pending.GetMethod())
+	nonceKey := batchNonceKey(sender, namespace, pending.GetNonce())
+	digest := batchTxDigest(pending)
+	if digests, shared := nonces[nonceKey]; shared && options.GetAllowBatchSharedNonce() {
+		if _, ok := digests[digest]; ok {
+			log.Errorf("tx %s repeats a transaction with nonce %d", txID, pending.GetNonce())
+			return pending, key, fmt.Errorf("%w: nonce %d", ErrBatchDuplicateTx, pending.GetNonce())
+		}
+		digests[digest] = struct{}{}
+
+		return pending, key, nil
+	}
+
+	if err = checkNonce(stub, sender, options, namespace, pending.GetNonce()); err != nil {
 		log.Errorf("incorrect tx %s nonce: %s", txID, err.Error())
 		return pending, key, err
 	}
 
+	if nonces != nil {
+		nonces[nonceKey] = map[string]struct{}{digest: {}}
+	}
+
 	return pending, key, nil
 }
 
@@ -175,9 +223,10 @@ func (cc *Chaincode) batchExecute(
 
 	span.AddEvent("handle transactions in batch")
 	ids := make([]string, 0, len(batch.GetTxIDs()))
+	nonces := make(batchNonces)
 	for _, txID := range batch.GetTxIDs() {
 		ids = append(ids, hex.EncodeToString(txID))
-		resp, event := cc.batchedTxExecute(traceCtx, btchStub, txID)
+		resp, event := cc.batchedTxExecute(traceCtx, btchStub, txID, nonces)
 		response.TxResponses = append(response.TxResponses, resp)
 		events.Events = append(events.Events, event)
 	}
@@ -186,20 +235,20 @@ func (cc *Chaincode) batchExecute(
 	if !cc.contract.ContractConfig().GetOptions().GetDisableSwaps() {
 		span.AddEvent("handle swaps")
 		for _, s := range batch.GetSwaps() {
-			response.SwapResponses = append(response.SwapResponses, swap.Answer(btchStub, s, robotSideTimeout))
+			response.SwapResponses = append(response.SwapResponses, swap.Answer(btchStub, s, robotSideTimeout, cc.contract.BalanceCodec()))
 		}
 		for _, swapKey := range batch.GetKeys() {
-			response.SwapKeyResponses = append(response.SwapKeyResponses, swap.RobotDone(btchStub, swapKey.GetId(), swapKey.GetKey()))
+			response.SwapKeyResponses = append(response.SwapKeyResponses, swap.RobotDone(btchStub, swapKey.GetId(), swapKey.GetKey(), cc.contract.BalanceCodec()))
 		}
 	}
 
 	if !cc.contract.ContractConfig().GetOptions().GetDisableMultiSwaps() {
 		span.AddEvent("handle multi-swaps")
 		for _, s := range batch.GetMultiSwaps() {
-			response.SwapResponses = append(response.SwapResponses, multiswap.Answer(btchStub, s, robotSideTimeout))
+			response.SwapResponses = append(response.SwapResponses, multiswap.Answer(btchStub, s, robotSideTimeout, cc.contract.BalanceCodec()))
 		}
 		for _, swapKey := range batch.GetMultiSwapsKeys() {
-			response.SwapKeyResponses = append(response.SwapKeyResponses, multiswap.RobotDone(btchStub, swapKey.GetId(), swapKey.GetKey()))
+			response.SwapKeyResponses = append(response.SwapKeyResponses, multiswap.RobotDone(btchStub, swapKey.GetId(), swapKey.GetKey(), cc.contract.BalanceCodec()))
 		}
 	}
 
@@ -250,6 +299,7 @@ func (cc *Chaincode) batchedTxExecute(
 	traceCtx telemetry.TraceContext,
 	stub *cachestub.BatchCacheStub,
 	binaryTxID []byte,
+	nonces batchNonces,
 ) (r *proto.TxResponse, e *proto.BatchTxEvent) {
 	traceCtx, span := cc.contract.TracingHandler().StartNewSpan(traceCtx, "batchTxExecute")
 	defer span.End()
@@ -274,7 +324,7 @@ func (cc *Chaincode) batchedTxExecute(
 	}()
 
 	span.AddEvent("load from batch")
-	pending, key, err := cc.loadFromBatch(stub, txID)
+	pending, key, err := cc.loadFromBatch(stub, txID, nonces)
 	if err != nil && pending != nil {
 		if delErr := stub.DelState(key); delErr != nil {
 			log.Errorf("failed deleting key %s from state on txId: %s", key, delErr.Error())
@@ -318,7 +368,7 @@ func (cc *Chaincode) batchedTxExecute(
 	}
 
 	span.AddEvent("calling method")
-	response, err := cc.InvokeContractMethod(traceCtx, txStub, method, pending.GetSender(), pending.GetArgs())
+	response, err := cc.invokeContractMethodWithNonce(traceCtx, txStub, method, pending.GetSender(), pending.GetArgs(), pending.GetNonce())
 	if err != nil {
 		_ = stub.DelState(key)
 		ee := proto.ResponseError{Error: err.Error()}
@@ -330,6 +380,7 @@ func (cc *Chaincode) batchedTxExecute(
 
 	span.AddEvent("commit")
 	writes, events := txStub.Commit()
+	events = prefixEventNames(events, cc.contract.ContractConfig().GetOptions().GetEventNamePrefix())
 
 	sort.Slice(txStub.Accounting, func(i, j int) bool {
 		return strings.Compare(txStub.Accounting[i].String(), txStub.Accounting[j].String()) < 0
@@ -232,7 +232,7 @@ func TestSaveToBatchWithWrongSignedArgs(t *testing.T) {
 		wrongArgs,
 		uint64(batchTimestamp.Seconds),
 	)
-	require.EqualError(t, err, "invalid argument value: 'arg0': for type 'int64': validate TxTestFnWithSignedTwoArgs, argument 1")
+	require.EqualError(t, err, "invalid argument value: 'arg0': for type 'int64': 'invalid syntax': validate TxTestFnWithSignedTwoArgs, argument 1")
 }
 
 // TestSaveAndLoadToBatchWithWrongFnParameter - negative test with wrong Fn Name in saveToBatch
@@ -353,7 +353,7 @@ func SaveAndLoadToBatchTest(t *testing.T, ser *serieBatches, args []string) {
 
 	require.Equal(t, pending.Args, args)
 
-	pending, _, err = chainCode.loadFromBatch(ms, ser.testID)
+	pending, _, err = chainCode.loadFromBatch(ms, ser.testID, nil)
 	if err != nil {
 		require.Equal(t, ser.errorMsg, err.Error())
 	} else {
@@ -542,6 +542,7 @@ func TestBatchedTxExecute(t *testing.T) {
 		telemetry.TraceContext{},
 		btchStub,
 		txIDBytes,
+		nil,
 	)
 	require.NotNil(t, resp)
 	require.NotNil(t, event)
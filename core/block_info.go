@@ -0,0 +1,25 @@
+package core
+
+import "time"
+
+// BlockInfo is the chaincode view of the current block.
+// Fabric does not expose the block height to the chaincode, so only
+// the transaction timestamp set by the client in the proposal is available.
+type BlockInfo struct {
+	TxID      string    `json:"txId"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// QueryBlockInfo returns the timestamp of the current transaction.
+// It can be used by clients to synchronize off-chain state with the chaincode.
+func (bc *BaseContract) QueryBlockInfo() (*BlockInfo, error) {
+	ts, err := bc.GetStub().GetTxTimestamp()
+	if err != nil {
+		return nil, err
+	}
+
+	return &BlockInfo{
+		TxID:      bc.GetStub().GetTxID(),
+		Timestamp: ts.AsTime().UTC(),
+	}, nil
+}
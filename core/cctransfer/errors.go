@@ -13,11 +13,33 @@ var (
 	ErrInvalidToken          = errors.New("invalid argument token")
 	ErrInvalidChannel        = errors.New("invalid argument channel to")
 	ErrIDTransferExist       = errors.New("id transfer already exists")
+	ErrIDTransferConflict    = errors.New("id transfer already exists with different arguments")
 	ErrTransferCommit        = errors.New("transfer already commit")
 	ErrTransferNotCommit     = errors.New("transfer not commit")
+	ErrTransferPickedUp      = errors.New("transfer is picked up for the delivery")
 	ErrUnauthorizedOperation = errors.New("unauthorized operation")
 	ErrInvalidBookmark       = errors.New("invalid bookmark")
 	ErrPageSizeLessOrEqZero  = errors.New("page size is less or equal to zero")
 	ErrAdminNotSet           = errors.New("admin is not set in base config")
 	ErrUnauthorisedNotAdmin  = errors.New("unauthorised, sender is not an admin")
+	ErrZeroAmount            = errors.New("channel transfer amount must be positive")
+	ErrStatusNotIndexed      = errors.New("transfers are not indexed by status")
+	ErrEmptyTransfers        = errors.New("transfers list is empty")
+	ErrDuplicateIDTransfer   = errors.New("duplicate id transfer in the list")
+	ErrInvalidPruneAge       = errors.New("prune age must be a positive duration")
+	ErrTransferNotCompleted  = errors.New("transfer is not completed")
+	ErrInvalidTransferTTL    = errors.New("transfer ttl must be a positive duration")
+	ErrTransferExpired       = errors.New("transfer is expired")
+	ErrUnknownTargetToken    = errors.New("token is unknown to the channel to")
+	ErrInvalidTimeRange      = errors.New("time range start must be before its end")
+	ErrActiveTransfersLimit  = errors.New("active transfers limit of the address is reached")
+	ErrInvalidLinkedTransfer = errors.New("linked transfer does not match the backward transfer")
+	ErrTransferAlreadyLinked = errors.New("transfer is already linked")
+	ErrSourceNotSigner       = errors.New("debited address is not the signer")
+	ErrIDTransferNotReserved = errors.New("id transfer is not reserved")
+	ErrIDReservedByAnother   = errors.New("id transfer is reserved by another address")
+	ErrIDReservationExpired  = errors.New("id transfer reservation is expired")
+	ErrIDReservationUsed     = errors.New("reserved id transfer is already used")
+	ErrTokenNotRegistered    = errors.New("token is not registered in the channel to")
+	ErrConversionNotCounted  = errors.New("contract does not account the emission of the converted tokens")
 )
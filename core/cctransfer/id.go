@@ -0,0 +1,18 @@
+package cctransfer
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// DeriveID returns the transfer id derived from the sender address, the nonce and the arguments of the call.
+// The same sender, nonce and arguments always give the same id, so a replayed call collides with the
+// already created transfer. Clients can compute the id in advance to track the transfer.
+func DeriveID(sender string, nonce uint64, args ...string) string {
+	parts := append([]string{sender, strconv.FormatUint(nonce, 10)}, args...)
+	hash := sha3.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(hash[:])
+}
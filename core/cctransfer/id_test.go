@@ -0,0 +1,25 @@
+package cctransfer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeriveID(t *testing.T) {
+	const sender = "2datxk5TmB1spSNn9enVo11dcpgmUoSBSqCw5cfmyw6hmwpD8B"
+
+	id := DeriveID(sender, 1700000000000, "VT", "CC", "1000")
+	require.Len(t, id, 64)
+	require.Equal(t, id, DeriveID(sender, 1700000000000, "VT", "CC", "1000"))
+
+	for name, other := range map[string]string{
+		"sender":    DeriveID("2datxk5TmB1spSNn9enVo11dcpgmUoSBSqCw5cfmyw6hmwpD8C", 1700000000000, "VT", "CC", "1000"),
+		"nonce":     DeriveID(sender, 1700000000001, "VT", "CC", "1000"),
+		"amount":    DeriveID(sender, 1700000000000, "VT", "CC", "1001"),
+		"token":     DeriveID(sender, 1700000000000, "VT", "VT", "1000"),
+		"arguments": DeriveID(sender, 1700000000000, "VTC", "C", "1000"),
+	} {
+		require.NotEqual(t, id, other, name)
+	}
+}
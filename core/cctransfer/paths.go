@@ -17,6 +17,11 @@ func Base(fullPath string) string {
 	return path.Base(fullPath)
 }
 
+// CCTransfers returns path to store keys of both From and To entries.
+func CCTransfers() string {
+	return pathCrossChannelTransfer
+}
+
 // CCFromTransfers returns path to store key.
 func CCFromTransfers() string {
 	return pathTransferFrom
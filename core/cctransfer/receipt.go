@@ -0,0 +1,19 @@
+package cctransfer
+
+import (
+	"encoding/hex"
+	"strconv"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// ReceiptHash returns the receipt hash of the transfer. The hash is the hex encoded SHA3-256 of
+// the id, the channels from and to, the token, the user address, the decimal amount, the status
+// and the creation time in nanoseconds joined by the zero byte in this order,
+// so clients can recompute it from the transfer details.
+func ReceiptHash(id, from, to, token, user, amount, status string, timeAsNanos int64) string {
+	parts := []string{id, from, to, token, user, amount, status, strconv.FormatInt(timeAsNanos, 10)}
+	hash := sha3.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,31 @@
+package cctransfer
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+)
+
+func TestReceiptHash(t *testing.T) {
+	const user = "2datxk5TmB1spSNn9enVo11dcpgmUoSBSqCw5cfmyw6hmwpD8B"
+
+	expected := sha3.Sum256([]byte("1\x00CC\x00VT\x00CC\x00" + user + "\x00450\x00completed\x001700000000000000000"))
+
+	hash := ReceiptHash("1", "CC", "VT", "CC", user, "450", "completed", 1700000000000000000)
+	require.Equal(t, hex.EncodeToString(expected[:]), hash)
+
+	for name, other := range map[string]string{
+		"id":     ReceiptHash("2", "CC", "VT", "CC", user, "450", "completed", 1700000000000000000),
+		"from":   ReceiptHash("1", "VT", "VT", "CC", user, "450", "completed", 1700000000000000000),
+		"to":     ReceiptHash("1", "CC", "CC", "CC", user, "450", "completed", 1700000000000000000),
+		"token":  ReceiptHash("1", "CC", "VT", "VT", user, "450", "completed", 1700000000000000000),
+		"user":   ReceiptHash("1", "CC", "VT", "CC", user+"C", "450", "completed", 1700000000000000000),
+		"amount": ReceiptHash("1", "CC", "VT", "CC", user, "451", "completed", 1700000000000000000),
+		"status": ReceiptHash("1", "CC", "VT", "CC", user, "450", "committed", 1700000000000000000),
+		"time":   ReceiptHash("1", "CC", "VT", "CC", user, "450", "completed", 1700000000000000001),
+	} {
+		require.NotEqual(t, hash, other, name)
+	}
+}
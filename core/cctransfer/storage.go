@@ -10,6 +10,17 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 )
 
+// Encoding is an encoding of the transfer entries stored in state.
+// Entries are read in any encoding, so the encoding can be changed without migration of existing entries.
+type Encoding int
+
+const (
+	// EncodingJSON - entries are stored as protojson with all fields emitted
+	EncodingJSON Encoding = iota
+	// EncodingProto - entries are stored as binary protobuf
+	EncodingProto
+)
+
 // LoadCCFromTransfer returns entry by id.
 func LoadCCFromTransfer(stub shim.ChaincodeStubInterface, idArg string) (*pb.CCTransfer, error) {
 	key := CCFromTransfer(idArg)
@@ -18,17 +29,11 @@ func LoadCCFromTransfer(stub shim.ChaincodeStubInterface, idArg string) (*pb.CCT
 		return nil, err
 	}
 
-	cct := new(pb.CCTransfer)
 	if len(data) == 0 {
 		return nil, ErrNotFound
 	}
 
-	if err = protojson.Unmarshal(data, cct); err != nil {
-		if err = proto.Unmarshal(data, cct); err != nil {
-			return nil, fmt.Errorf("unmarshal: %w", err)
-		}
-	}
-	return cct, nil
+	return unmarshalTransfer(data)
 }
 
 // LoadCCFromTransfers returns entries by range.
@@ -55,11 +60,9 @@ func LoadCCFromTransfers(
 			return nil, err
 		}
 
-		cct := new(pb.CCTransfer)
-		if err = protojson.Unmarshal(kv.GetValue(), cct); err != nil {
-			if err = proto.Unmarshal(kv.GetValue(), cct); err != nil {
-				return nil, fmt.Errorf("unmarshal: %w", err)
-			}
+		cct, err := unmarshalTransfer(kv.GetValue())
+		if err != nil {
+			return nil, err
 		}
 
 		ccts.Ccts = append(ccts.Ccts, cct)
@@ -72,17 +75,14 @@ func LoadCCFromTransfers(
 	return ccts, nil
 }
 
-// SaveCCFromTransfer saves entry.
+// SaveCCFromTransfer saves entry in JSON encoding.
 func SaveCCFromTransfer(stub shim.ChaincodeStubInterface, cct *pb.CCTransfer) error {
-	if cct == nil {
-		return ErrSaveNilTransfer
-	}
-
-	if cct.GetId() == "" {
-		return ErrEmptyIDTransfer
-	}
+	return SaveCCFromTransferEncoded(stub, cct, EncodingJSON)
+}
 
-	data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(cct)
+// SaveCCFromTransferEncoded saves entry in the given encoding.
+func SaveCCFromTransferEncoded(stub shim.ChaincodeStubInterface, cct *pb.CCTransfer, encoding Encoding) error {
+	data, err := marshalTransfer(cct, encoding)
 	if err != nil {
 		return err
 	}
@@ -104,30 +104,21 @@ func LoadCCToTransfer(stub shim.ChaincodeStubInterface, idArg string) (*pb.CCTra
 		return nil, err
 	}
 
-	cct := new(pb.CCTransfer)
 	if len(data) == 0 {
 		return nil, ErrNotFound
 	}
 
-	if err = protojson.Unmarshal(data, cct); err != nil {
-		if err = proto.Unmarshal(data, cct); err != nil {
-			return nil, fmt.Errorf("unmarshal: %w", err)
-		}
-	}
-	return cct, nil
+	return unmarshalTransfer(data)
 }
 
-// SaveCCToTransfer saves entry.
+// SaveCCToTransfer saves entry in JSON encoding.
 func SaveCCToTransfer(stub shim.ChaincodeStubInterface, cct *pb.CCTransfer) error {
-	if cct == nil {
-		return ErrSaveNilTransfer
-	}
-
-	if cct.GetId() == "" {
-		return ErrEmptyIDTransfer
-	}
+	return SaveCCToTransferEncoded(stub, cct, EncodingJSON)
+}
 
-	data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(cct)
+// SaveCCToTransferEncoded saves entry in the given encoding.
+func SaveCCToTransferEncoded(stub shim.ChaincodeStubInterface, cct *pb.CCTransfer, encoding Encoding) error {
+	data, err := marshalTransfer(cct, encoding)
 	if err != nil {
 		return err
 	}
@@ -140,3 +131,66 @@ func DelCCToTransfer(stub shim.ChaincodeStubInterface, idArg string) error {
 	key := CCToTransfer(idArg)
 	return stub.DelState(key)
 }
+
+// LoadDeletedCCFromTransfer returns the last state of the deleted entry by id from the key history.
+func LoadDeletedCCFromTransfer(stub shim.ChaincodeStubInterface, idArg string) (*pb.CCTransfer, error) {
+	return loadDeleted(stub, CCFromTransfer(idArg))
+}
+
+// LoadDeletedCCToTransfer returns the last state of the deleted entry by id from the key history.
+func LoadDeletedCCToTransfer(stub shim.ChaincodeStubInterface, idArg string) (*pb.CCTransfer, error) {
+	return loadDeleted(stub, CCToTransfer(idArg))
+}
+
+func loadDeleted(stub shim.ChaincodeStubInterface, key string) (*pb.CCTransfer, error) {
+	iter, err := stub.GetHistoryForKey(key)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	// history is returned the most recent modification first
+	for iter.HasNext() {
+		modification, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		if modification.GetIsDelete() || len(modification.GetValue()) == 0 {
+			continue
+		}
+
+		return unmarshalTransfer(modification.GetValue())
+	}
+
+	return nil, ErrNotFound
+}
+
+func marshalTransfer(cct *pb.CCTransfer, encoding Encoding) ([]byte, error) {
+	if cct == nil {
+		return nil, ErrSaveNilTransfer
+	}
+
+	if cct.GetId() == "" {
+		return nil, ErrEmptyIDTransfer
+	}
+
+	if encoding == EncodingProto {
+		return proto.Marshal(cct)
+	}
+
+	return protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(cct)
+}
+
+func unmarshalTransfer(data []byte) (*pb.CCTransfer, error) {
+	cct := new(pb.CCTransfer)
+	if err := protojson.Unmarshal(data, cct); err != nil {
+		if err = proto.Unmarshal(data, cct); err != nil {
+			return nil, fmt.Errorf("unmarshal: %w", err)
+		}
+	}
+
+	return cct, nil
+}
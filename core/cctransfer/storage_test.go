@@ -0,0 +1,80 @@
+package cctransfer
+
+import (
+	"testing"
+
+	pb "github.com/anoideaopen/foundation/proto"
+	"github.com/golang/protobuf/proto"                    //nolint:staticcheck
+	"github.com/hyperledger/fabric-chaincode-go/shimtest" //nolint:staticcheck
+	"github.com/stretchr/testify/require"
+)
+
+func testTransfer(id string) *pb.CCTransfer {
+	return &pb.CCTransfer{
+		Id:               id,
+		From:             "CC",
+		To:               "VT",
+		Token:            "CC",
+		User:             make([]byte, 32),
+		Amount:           []byte{0x01, 0xc2},
+		ForwardDirection: true,
+		TimeAsNanos:      1660055050000000000,
+	}
+}
+
+func TestTransferEncodingRoundTrip(t *testing.T) {
+	stub := shimtest.NewMockStub("cctransfer", nil)
+
+	for _, encoding := range []Encoding{EncodingJSON, EncodingProto} {
+		id := "transfer"
+		if encoding == EncodingProto {
+			id = "transfer-proto"
+		}
+		tr := testTransfer(id)
+
+		stub.MockTransactionStart(id)
+		require.NoError(t, SaveCCFromTransferEncoded(stub, tr, encoding))
+		require.NoError(t, SaveCCToTransferEncoded(stub, tr, encoding))
+		stub.MockTransactionEnd(id)
+
+		from, err := LoadCCFromTransfer(stub, id)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(tr, from))
+
+		to, err := LoadCCToTransfer(stub, id)
+		require.NoError(t, err)
+		require.True(t, proto.Equal(tr, to))
+	}
+
+	raw := stub.State[CCFromTransfer("transfer-proto")]
+	stored := new(pb.CCTransfer)
+	require.NoError(t, proto.Unmarshal(raw, stored))
+	require.True(t, proto.Equal(testTransfer("transfer-proto"), stored))
+}
+
+func BenchmarkTransferEncoding(b *testing.B) {
+	tr := testTransfer("7a8b0c6d-4f3e-4b2a-9c1d-0e5f6a7b8c9d")
+
+	for _, bc := range []struct {
+		name     string
+		encoding Encoding
+	}{
+		{"json", EncodingJSON},
+		{"proto", EncodingProto},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			var size int
+			for i := 0; i < b.N; i++ {
+				data, err := marshalTransfer(tr, bc.encoding)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if _, err = unmarshalTransfer(data); err != nil {
+					b.Fatal(err)
+				}
+				size = len(data)
+			}
+			b.ReportMetric(float64(size), "stored-bytes")
+		})
+	}
+}
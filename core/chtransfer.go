@@ -8,8 +8,10 @@ import (
 
 	"github.com/anoideaopen/foundation/core/balance"
 	"github.com/anoideaopen/foundation/core/cctransfer"
+	"github.com/anoideaopen/foundation/core/telemetry"
 	"github.com/anoideaopen/foundation/core/types"
 	"github.com/anoideaopen/foundation/core/types/big"
+	"github.com/anoideaopen/foundation/core/walletaudit"
 	pb "github.com/anoideaopen/foundation/proto"
 	"github.com/golang/protobuf/proto" //nolint:staticcheck
 )
@@ -40,6 +42,8 @@ func (t typeOperation) String() string {
 // TxChannelTransferByCustomer - transaction initiating transfer between channels.
 // The owner of tokens signs. Tokens are transferred to themselveselves.
 // After the checks, a transfer record is created and the user's balances are reduced.
+// If derive_channel_transfer_ids option is set and idTransfer is empty, the id is derived
+// by cctransfer.DeriveID from the sender, the nonce and the arguments and returned instead of the tx id.
 func (bc *BaseContract) TxChannelTransferByCustomer(
 	sender *types.Sender,
 	idTransfer string,
@@ -47,12 +51,68 @@ func (bc *BaseContract) TxChannelTransferByCustomer(
 	token string,
 	amount *big.Int,
 ) (string, error) {
-	return bc.createCCTransferFrom(idTransfer, to, sender.Address(), token, amount)
+	return bc.channelTransferByCustomer(sender, sender.Address(), idTransfer, to, token, amount, 0)
+}
+
+// TxChannelTransferFromByCustomer initiates the transfer between channels like TxChannelTransferByCustomer
+// with the debited address from stated explicitly. The transfer is rejected with ErrSourceNotSigner
+// if from is not the signer, so the client can not debit the address it does not control.
+func (bc *BaseContract) TxChannelTransferFromByCustomer(
+	sender *types.Sender,
+	idTransfer string,
+	from *types.Address,
+	to string,
+	token string,
+	amount *big.Int,
+) (string, error) {
+	return bc.channelTransferByCustomer(sender, from, idTransfer, to, token, amount, 0)
+}
+
+// channelTransferByCustomer creates the transfer of the tokens of from signed by the sender,
+// the debited address from must be the sender. The id reserved by TxReserveTransferID is marked as used.
+func (bc *BaseContract) channelTransferByCustomer(
+	sender *types.Sender,
+	from *types.Address,
+	idTransfer string,
+	to string,
+	token string,
+	amount *big.Int,
+	expiresAt int64,
+) (string, error) {
+	if !sender.Equal(from) {
+		return "", fmt.Errorf("%w: %s", cctransfer.ErrSourceNotSigner, from.String())
+	}
+
+	if err := bc.checkActiveTransfersLimit(from); err != nil {
+		return "", err
+	}
+
+	if idTransfer != "" || !bc.config.GetOptions().GetDeriveChannelTransferIds() {
+		if err := bc.useTransferID(from, idTransfer); err != nil {
+			return "", err
+		}
+
+		return bc.createCCTransferFrom(idTransfer, to, from, token, amount, expiresAt)
+	}
+
+	// the nonce is known for the signed calls only
+	if bc.txNonce == 0 {
+		return "", cctransfer.ErrEmptyIDTransfer
+	}
+
+	idTransfer = cctransfer.DeriveID(from.String(), bc.txNonce, to, token, amount.String())
+	if _, err := bc.createCCTransferFrom(idTransfer, to, from, token, amount, expiresAt); err != nil {
+		return "", err
+	}
+
+	return idTransfer, nil
 }
 
 // TxChannelTransferByAdmin - transaction initiating transfer between channels.
 // Signed by the channel admin (site). The tokens are transferred from idUser to the same user.
 // After the checks, a transfer record is created and the user's balances are reduced.
+// Resubmission of the transfer with the same id and arguments succeeds without changes,
+// resubmission with the same id and different arguments returns ErrIDTransferConflict.
 func (bc *BaseContract) TxChannelTransferByAdmin(
 	sender *types.Sender,
 	idTransfer string,
@@ -61,25 +121,58 @@ func (bc *BaseContract) TxChannelTransferByAdmin(
 	token string,
 	amount *big.Int,
 ) (string, error) {
-	// Checks
+	if err := bc.checkChannelTransferAdmin(sender); err != nil {
+		return "", err
+	}
+
+	return bc.createCCTransferByAdmin(sender, idTransfer, to, idUser, token, amount)
+}
+
+// checkChannelTransferAdmin checks that the sender is the channel admin
+func (bc *BaseContract) checkChannelTransferAdmin(sender *types.Sender) error {
 	if !bc.config.IsAdminSet() {
-		return "", cctransfer.ErrAdminNotSet
+		return cctransfer.ErrAdminNotSet
 	}
 
-	if admin, err := types.AddrFromBase58Check(bc.config.GetAdmin().GetAddress()); err == nil {
-		if !sender.Equal(admin) {
-			return "", cctransfer.ErrUnauthorisedNotAdmin
-		}
-	} else {
-		return "", fmt.Errorf("creating admin address: %w", err)
+	admin, err := types.AddrFromBase58Check(bc.config.GetAdmin().GetAddress())
+	if err != nil {
+		return fmt.Errorf("creating admin address: %w", err)
 	}
 
+	if !sender.Equal(admin) {
+		return cctransfer.ErrUnauthorisedNotAdmin
+	}
+
+	return nil
+}
+
+// createCCTransferByAdmin creates the transfer of idUser tokens initiated by the admin
+func (bc *BaseContract) createCCTransferByAdmin(
+	sender *types.Sender,
+	idTransfer string,
+	to string,
+	idUser *types.Address,
+	token string,
+	amount *big.Int,
+) (string, error) {
 	if sender.Equal(idUser) {
 		return "", cctransfer.ErrInvalidIDUser
 	}
 
+	// retry of the already created transfer
+	if tr, err := cctransfer.LoadCCFromTransfer(bc.GetStub(), idTransfer); err == nil {
+		if tr.GetTo() != to ||
+			tr.GetToken() != bc.ResolveToken(token) ||
+			!idUser.Equal(types.AddrFromBytes(tr.GetUser())) ||
+			new(big.Int).SetBytes(tr.GetAmount()).Cmp(amount) != 0 {
+			return "", cctransfer.ErrIDTransferConflict
+		}
+
+		return bc.GetStub().GetTxID(), nil
+	}
+
 	// transfer business logic
-	return bc.createCCTransferFrom(idTransfer, to, idUser, token, amount)
+	return bc.createCCTransferFrom(idTransfer, to, idUser, token, amount, 0)
 }
 
 func (bc *BaseContract) createCCTransferFrom(
@@ -88,7 +181,24 @@ func (bc *BaseContract) createCCTransferFrom(
 	idUser *types.Address,
 	token string,
 	amount *big.Int,
+	expiresAt int64,
 ) (string, error) {
+	token = bc.ResolveToken(token)
+
+	bc.TracingHandler().SetAttributes(
+		bc.GetTraceContext(),
+		telemetry.TransferID(idTransfer),
+		telemetry.Token(token),
+	)
+
+	if err := bc.CheckPaused(); err != nil {
+		return "", err
+	}
+
+	if err := bc.CheckDenylist(idUser); err != nil {
+		return "", err
+	}
+
 	if strings.EqualFold(bc.config.GetSymbol(), to) {
 		return "", cctransfer.ErrInvalidChannel
 	}
@@ -99,6 +209,14 @@ func (bc *BaseContract) createCCTransferFrom(
 		return "", cctransfer.ErrInvalidToken
 	}
 
+	if err := bc.checkTargetToken(to); err != nil {
+		return "", err
+	}
+
+	if amount.Sign() == 0 {
+		return bc.createZeroAmountTransfer(idTransfer, to, idUser, token)
+	}
+
 	// Fulfillment
 	stub := bc.GetStub()
 
@@ -121,9 +239,22 @@ func (bc *BaseContract) createCCTransferFrom(
 		Amount:           amount.Bytes(),
 		ForwardDirection: strings.EqualFold(bc.config.GetSymbol(), t),
 		TimeAsNanos:      ts.AsTime().UnixNano(),
+		ExpiresAtNanos:   expiresAt,
+	}
+
+	if err = cctransfer.SaveCCFromTransferEncoded(stub, tr, bc.ccTransferEncoding()); err != nil {
+		return "", err
+	}
+
+	if err = bc.moveTransferStatus(tr.GetId(), "", TransferStatusCreated); err != nil {
+		return "", err
+	}
+
+	if err = bc.indexPendingTransfer(tr, true); err != nil {
+		return "", err
 	}
 
-	if err = cctransfer.SaveCCFromTransfer(stub, tr); err != nil {
+	if err = bc.indexTransferTime(tr, true); err != nil {
 		return "", err
 	}
 
@@ -141,6 +272,18 @@ func (bc *BaseContract) createCCTransferFrom(
 		return "", err
 	}
 
+	if err = bc.chargeChannelTransferFee(tr.GetForwardDirection(), idUser, tr.GetToken(), amount); err != nil {
+		return "", err
+	}
+
+	err = bc.updateChannelStats(tr.GetToken(), idUser, func(stats *ChannelStats) {
+		stats.Transfers++
+		stats.LockedAmount.Add(stats.LockedAmount, amount)
+	})
+	if err != nil {
+		return "", err
+	}
+
 	return bc.GetStub().GetTxID(), nil
 }
 
@@ -157,6 +300,12 @@ func (bc *BaseContract) TxCreateCCTransferTo(dataIn string) (string, error) {
 		}
 	}
 
+	bc.TracingHandler().SetAttributes(
+		bc.GetTraceContext(),
+		telemetry.TransferID(tr.GetId()),
+		telemetry.Token(tr.GetToken()),
+	)
+
 	// see if it's already there.
 	if _, err := cctransfer.LoadCCToTransfer(bc.GetStub(), tr.GetId()); err == nil {
 		return "", cctransfer.ErrIDTransferExist
@@ -180,8 +329,28 @@ func (bc *BaseContract) TxCreateCCTransferTo(dataIn string) (string, error) {
 		return "", cctransfer.ErrInvalidToken
 	}
 
+	if err := bc.checkNotExpired(&tr); err != nil {
+		return "", err
+	}
+
+	if err := bc.checkRegisteredToken(&tr); err != nil {
+		return "", err
+	}
+
 	tr.IsCommit = true
-	if err := cctransfer.SaveCCToTransfer(bc.GetStub(), &tr); err != nil {
+	if err := cctransfer.SaveCCToTransferEncoded(bc.GetStub(), &tr, bc.ccTransferEncoding()); err != nil {
+		return "", err
+	}
+
+	if err := bc.moveTransferStatus(tr.GetId(), "", TransferStatusToCreated); err != nil {
+		return "", err
+	}
+
+	if err := bc.indexTransferTime(&tr, false); err != nil {
+		return "", err
+	}
+
+	if err := bc.indexLinkedTransfer(&tr, true); err != nil {
 		return "", err
 	}
 
@@ -199,6 +368,13 @@ func (bc *BaseContract) TxCreateCCTransferTo(dataIn string) (string, error) {
 		return "", err
 	}
 
+	err = bc.updateChannelStats(tr.GetToken(), types.AddrFromBytes(tr.GetUser()), func(stats *ChannelStats) {
+		stats.Received++
+	})
+	if err != nil {
+		return "", err
+	}
+
 	return bc.GetStub().GetTxID(), nil
 }
 
@@ -208,6 +384,8 @@ func (bc *BaseContract) TxCreateCCTransferTo(dataIn string) (string, error) {
 // After TxChannelTransferByAdmin or TxChannelTransferByCustomer
 // This transaction is sent only by the channel-transfer service with a "robot" certificate
 func (bc *BaseContract) TxCancelCCTransferFrom(id string) error {
+	bc.TracingHandler().SetAttributes(bc.GetTraceContext(), telemetry.TransferID(id))
+
 	// see if it's already gone
 	tr, err := cctransfer.LoadCCFromTransfer(bc.GetStub(), id)
 	if err != nil {
@@ -219,8 +397,40 @@ func (bc *BaseContract) TxCancelCCTransferFrom(id string) error {
 		return cctransfer.ErrTransferCommit
 	}
 
+	return bc.cancelCCTransferFrom(tr)
+}
+
+// TxChannelTransferCancelByCustomer - transaction cancels the transfer in the From channel
+// on behalf of the customer initiated it and returns balances to the customer.
+// The From channel does not see the record in the To channel, so the transfer can be cancelled
+// only until the channel-transfer service picks it up for the delivery (NBTxPickUpCCTransferFrom).
+func (bc *BaseContract) TxChannelTransferCancelByCustomer(sender *types.Sender, id string) error {
+	bc.TracingHandler().SetAttributes(bc.GetTraceContext(), telemetry.TransferID(id))
+
+	tr, err := cctransfer.LoadCCFromTransfer(bc.GetStub(), id)
+	if err != nil {
+		return cctransfer.ErrNotFound
+	}
+
+	if !sender.Equal(types.AddrFromBytes(tr.GetUser())) {
+		return cctransfer.ErrUnauthorizedOperation
+	}
+
+	if tr.GetIsCommit() {
+		return cctransfer.ErrTransferCommit
+	}
+
+	if tr.GetPickedUp() {
+		return cctransfer.ErrTransferPickedUp
+	}
+
+	return bc.cancelCCTransferFrom(tr)
+}
+
+// cancelCCTransferFrom returns balances of the not committed transfer to the user and deletes the transfer record
+func (bc *BaseContract) cancelCCTransferFrom(tr *pb.CCTransfer) error {
 	// rebalancing
-	err = bc.ccTransferChangeBalance(
+	err := bc.ccTransferChangeBalance(
 		CancelFrom,
 		tr.GetForwardDirection(),
 		types.AddrFromBytes(tr.GetUser()),
@@ -233,7 +443,56 @@ func (bc *BaseContract) TxCancelCCTransferFrom(id string) error {
 		return err
 	}
 
-	return cctransfer.DelCCFromTransfer(bc.GetStub(), id)
+	err = bc.updateChannelStats(tr.GetToken(), types.AddrFromBytes(tr.GetUser()), func(stats *ChannelStats) {
+		stats.Cancelled++
+		stats.unlock(new(big.Int).SetBytes(tr.GetAmount()))
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = bc.moveTransferStatus(tr.GetId(), TransferStatusCreated, ""); err != nil {
+		return err
+	}
+
+	if err = bc.indexPendingTransfer(tr, false); err != nil {
+		return err
+	}
+
+	if err = bc.unindexTransferTime(tr); err != nil {
+		return err
+	}
+
+	if err = bc.indexLinkedTransfer(tr, false); err != nil {
+		return err
+	}
+
+	return cctransfer.DelCCFromTransfer(bc.GetStub(), tr.GetId())
+}
+
+// NBTxPickUpCCTransferFrom - transaction marks the transfer in the From channel as picked up for the delivery,
+// so the customer can no longer cancel it by TxChannelTransferCancelByCustomer.
+// Executed before the creation of a mating part in the channel To (TxCreateCCTransferTo),
+// repeated calls do nothing. The picked up transfer is still cancelled by TxCancelCCTransferFrom.
+// This transaction is sent only by the channel-transfer service with a "robot" certificate
+func (bc *BaseContract) NBTxPickUpCCTransferFrom(id string) error {
+	// see if it's already gone
+	tr, err := cctransfer.LoadCCFromTransfer(bc.GetStub(), id)
+	if err != nil {
+		return cctransfer.ErrNotFound
+	}
+
+	// if it's already committed, it's an error
+	if tr.GetIsCommit() {
+		return cctransfer.ErrTransferCommit
+	}
+
+	if tr.GetPickedUp() {
+		return nil
+	}
+
+	tr.PickedUp = true
+	return cctransfer.SaveCCFromTransferEncoded(bc.GetStub(), tr, bc.ccTransferEncoding())
 }
 
 // NBTxCommitCCTransferFrom - transaction writes the commit flag in the transfer in the From channel.
@@ -251,8 +510,24 @@ func (bc *BaseContract) NBTxCommitCCTransferFrom(id string) error {
 		return cctransfer.ErrTransferCommit
 	}
 
+	err = bc.updateChannelStats(tr.GetToken(), types.AddrFromBytes(tr.GetUser()), func(stats *ChannelStats) {
+		stats.Committed++
+		stats.unlock(new(big.Int).SetBytes(tr.GetAmount()))
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = bc.moveTransferStatus(id, TransferStatusCreated, TransferStatusCommitted); err != nil {
+		return err
+	}
+
+	if err = bc.indexPendingTransfer(tr, false); err != nil {
+		return err
+	}
+
 	tr.IsCommit = true
-	return cctransfer.SaveCCFromTransfer(bc.GetStub(), tr)
+	return cctransfer.SaveCCFromTransferEncoded(bc.GetStub(), tr, bc.ccTransferEncoding())
 }
 
 // NBTxDeleteCCTransferFrom - transaction deletes the transfer record in the channel From.
@@ -270,6 +545,10 @@ func (bc *BaseContract) NBTxDeleteCCTransferFrom(id string) error {
 		return cctransfer.ErrTransferNotCommit
 	}
 
+	if err = bc.moveTransferStatus(id, TransferStatusCommitted, ""); err != nil {
+		return err
+	}
+
 	return cctransfer.DelCCFromTransfer(bc.GetStub(), id)
 }
 
@@ -288,6 +567,10 @@ func (bc *BaseContract) NBTxDeleteCCTransferTo(id string) error {
 		return cctransfer.ErrTransferNotCommit
 	}
 
+	if err = bc.moveTransferStatus(id, TransferStatusToCreated, ""); err != nil {
+		return err
+	}
+
 	return cctransfer.DelCCToTransfer(bc.GetStub(), id)
 }
 
@@ -325,11 +608,15 @@ func (bc *BaseContract) QueryChannelTransfersFrom(pageSize int64, bookmark strin
 		return nil, cctransfer.ErrInvalidBookmark
 	}
 
+	pageSize, clamped := bc.ClampPageSize(pageSize)
+
 	trs, err := cctransfer.LoadCCFromTransfers(bc.GetStub(), startKey, endKey, bookmark, int32(pageSize))
 	if err != nil {
 		return nil, err
 	}
 
+	trs.PageSizeClamped = clamped
+
 	return trs, nil
 }
 
@@ -362,7 +649,19 @@ func (bc *BaseContract) ccTransferChangeBalance( //nolint:gocognit
 				return err
 			}
 
-			if err = balance.Add(bc.GetStub(), balance.BalanceTypeGiven, strings.ToUpper(to), "", &amount.Int); err != nil {
+			if err = balance.Add(bc.BalanceStub(), balance.BalanceTypeGiven, strings.ToUpper(to), "", &amount.Int); err != nil {
+				return err
+			}
+		} else if bc.config.GetOptions().GetConvertAllowedBalanceOnReceipt() {
+			// the tokens converted on receipt are returned from the native balance and burnt
+			var emission convertedEmission
+			if emission, err = bc.convertedEmission(); err != nil {
+				return err
+			}
+			if err = bc.TokenBalanceSub(user, amount, reason); err != nil {
+				return err
+			}
+			if err = emission.EmissionSub(amount); err != nil {
 				return err
 			}
 		} else {
@@ -371,15 +670,27 @@ func (bc *BaseContract) ccTransferChangeBalance( //nolint:gocognit
 			}
 		}
 	case CreateTo:
-		if forwardDirection {
+		switch {
+		case forwardDirection && bc.config.GetOptions().GetConvertAllowedBalanceOnReceipt():
+			var emission convertedEmission
+			if emission, err = bc.convertedEmission(); err != nil {
+				return err
+			}
+			if err = bc.TokenBalanceAdd(user, amount, reason); err != nil {
+				return err
+			}
+			if err = emission.EmissionAddTo(user, amount); err != nil {
+				return err
+			}
+		case forwardDirection:
 			if err = bc.AllowedBalanceAdd(token, user, amount, reason); err != nil {
 				return err
 			}
-		} else {
+		default:
 			if err = bc.TokenBalanceAddWithTicker(user, amount, token, reason); err != nil {
 				return err
 			}
-			if err = balance.Sub(bc.GetStub(), balance.BalanceTypeGiven, strings.ToUpper(from), "", &amount.Int); err != nil {
+			if err = balance.Sub(bc.BalanceStub(), balance.BalanceTypeGiven, strings.ToUpper(from), "", &amount.Int); err != nil {
 				return err
 			}
 		}
@@ -388,7 +699,18 @@ func (bc *BaseContract) ccTransferChangeBalance( //nolint:gocognit
 			if err = bc.TokenBalanceAddWithTicker(user, amount, token, reason); err != nil {
 				return err
 			}
-			if err = balance.Sub(bc.GetStub(), balance.BalanceTypeGiven, strings.ToUpper(to), "", &amount.Int); err != nil {
+			if err = balance.Sub(bc.BalanceStub(), balance.BalanceTypeGiven, strings.ToUpper(to), "", &amount.Int); err != nil {
+				return err
+			}
+		} else if bc.config.GetOptions().GetConvertAllowedBalanceOnReceipt() {
+			var emission convertedEmission
+			if emission, err = bc.convertedEmission(); err != nil {
+				return err
+			}
+			if err = bc.TokenBalanceAdd(user, amount, reason); err != nil {
+				return err
+			}
+			if err = emission.EmissionAdd(amount); err != nil {
 				return err
 			}
 		} else {
@@ -400,7 +722,55 @@ func (bc *BaseContract) ccTransferChangeBalance( //nolint:gocognit
 		return cctransfer.ErrUnauthorizedOperation
 	}
 
-	return nil
+	return bc.walletAuditCCTransfer(t, user, amount, from, to, token)
+}
+
+// walletAuditCCTransfer appends the channel transfer operation t to the audit trail of the user
+func (bc *BaseContract) walletAuditCCTransfer(
+	t typeOperation,
+	user *types.Address,
+	amount *big.Int,
+	from string,
+	to string,
+	token string,
+) error {
+	switch t {
+	case CreateFrom:
+		return bc.WalletAuditAdd(user, walletaudit.ChannelTransferOut, token, amount, to)
+	case CreateTo:
+		return bc.WalletAuditAdd(user, walletaudit.ChannelTransferIn, token, amount, from)
+	default:
+		return bc.WalletAuditAdd(user, walletaudit.ChannelTransferCancel, token, amount, to)
+	}
+}
+
+// convertedEmission is implemented by the contracts accounting the token emission.
+// The tokens converted on receipt by convert_allowed_balance_on_receipt are emitted
+// when received and burnt when returned by the backward transfer.
+type convertedEmission interface {
+	EmissionAdd(amount *big.Int) error
+	EmissionAddTo(address *types.Address, amount *big.Int) error
+	EmissionSub(amount *big.Int) error
+}
+
+// convertedEmission returns the emission accounting of the contract for the converted tokens,
+// it fails with cctransfer.ErrConversionNotCounted if the contract does not account the emission
+func (bc *BaseContract) convertedEmission() (convertedEmission, error) {
+	emission, ok := bc.configurable.(convertedEmission)
+	if !ok {
+		return nil, cctransfer.ErrConversionNotCounted
+	}
+
+	return emission, nil
+}
+
+// ccTransferEncoding returns the encoding of the transfer records according to the contract options
+func (bc *BaseContract) ccTransferEncoding() cctransfer.Encoding {
+	if bc.config.GetOptions().GetStoreChannelTransfersAsProto() {
+		return cctransfer.EncodingProto
+	}
+
+	return cctransfer.EncodingJSON
 }
 
 func tokenSymbol(token string) string {
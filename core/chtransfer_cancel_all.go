@@ -0,0 +1,140 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/cctransfer"
+	"github.com/anoideaopen/foundation/core/types"
+	pb "github.com/anoideaopen/foundation/proto"
+)
+
+// ChannelTransferUserCompositeType is a composite key prefix for the index of the not committed
+// transfers of the channel From by the address initiated them
+const ChannelTransferUserCompositeType = "ch_transfer_user"
+
+// CancelledTransfers is the result of TxCancelAllTransfersForAddress
+type CancelledTransfers struct {
+	Cancelled []string `json:"cancelled"`
+}
+
+// TxCancelAllTransfersForAddress cancels all not committed transfers of the channel From
+// initiated by the address and returns balances to it. Committed transfers are delivered
+// to the channel To and can not be cancelled. Transfers picked up by the channel-transfer service
+// may be delivered already and are skipped like by TxChannelTransferCancelByCustomer.
+// Transfers are found by the address index, transfers created before the index was introduced are not cancelled.
+// Repeated calls cancel nothing but the transfers created since the previous call.
+// Method can be called by the channel admin only.
+func (bc *BaseContract) TxCancelAllTransfersForAddress(
+	sender *types.Sender,
+	address *types.Address,
+) (*CancelledTransfers, error) {
+	if err := bc.checkChannelTransferAdmin(sender); err != nil {
+		return nil, err
+	}
+
+	transfers, err := bc.pendingTransfersOf(address)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CancelledTransfers{Cancelled: []string{}}
+	for _, tr := range transfers {
+		if err = bc.cancelCCTransferFrom(tr); err != nil {
+			return nil, err
+		}
+		result.Cancelled = append(result.Cancelled, tr.GetId())
+	}
+
+	return result, nil
+}
+
+// pendingTransfersOf returns the not committed and not picked up transfers of the channel From
+// initiated by the address
+func (bc *BaseContract) pendingTransfersOf(address *types.Address) ([]*pb.CCTransfer, error) {
+	stub := bc.GetStub()
+
+	iter, err := stub.GetStateByPartialCompositeKey(ChannelTransferUserCompositeType, []string{address.String()})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	var transfers []*pb.CCTransfer
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		_, components, err := stub.SplitCompositeKey(kv.GetKey())
+		if err != nil {
+			return nil, err
+		}
+
+		if len(components) != 2 {
+			continue
+		}
+
+		tr, err := cctransfer.LoadCCFromTransfer(stub, components[1])
+		if err != nil {
+			return nil, err
+		}
+
+		if !tr.GetIsCommit() && !tr.GetPickedUp() {
+			transfers = append(transfers, tr)
+		}
+	}
+
+	return transfers, nil
+}
+
+// checkActiveTransfersLimit returns ErrActiveTransfersLimit if the address has as many not committed transfers
+// as max_active_transfers_per_address allows. Transfers are counted by the address index.
+func (bc *BaseContract) checkActiveTransfersLimit(address *types.Address) error {
+	limit := bc.config.GetOptions().GetMaxActiveTransfersPerAddress()
+	if limit == 0 {
+		return nil
+	}
+
+	iter, err := bc.GetStub().GetStateByPartialCompositeKey(ChannelTransferUserCompositeType, []string{address.String()})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	var active uint32
+	for iter.HasNext() {
+		if _, err = iter.Next(); err != nil {
+			return err
+		}
+
+		if active++; active >= limit {
+			return fmt.Errorf("%w: %s has %d", cctransfer.ErrActiveTransfersLimit, address.String(), active)
+		}
+	}
+
+	return nil
+}
+
+// indexPendingTransfer adds the not committed transfer to the address index or removes it if pending is false
+func (bc *BaseContract) indexPendingTransfer(tr *pb.CCTransfer, pending bool) error {
+	stub := bc.GetStub()
+
+	key, err := stub.CreateCompositeKey(
+		ChannelTransferUserCompositeType,
+		[]string{types.AddrFromBytes(tr.GetUser()).String(), tr.GetId()},
+	)
+	if err != nil {
+		return err
+	}
+
+	if !pending {
+		return stub.DelState(key)
+	}
+
+	return stub.PutState(key, []byte{1})
+}
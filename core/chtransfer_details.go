@@ -0,0 +1,111 @@
+package core
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/anoideaopen/foundation/core/cctransfer"
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
+	pb "github.com/anoideaopen/foundation/proto"
+)
+
+// ChannelTransferDetails is the decoded channel transfer record
+type ChannelTransferDetails struct {
+	// ID is the id of the transfer
+	ID string `json:"id"`
+	// From is the channel the tokens are transferred from
+	From string `json:"from"`
+	// To is the channel the tokens are transferred to
+	To string `json:"to"`
+	// Token is the transferred token
+	Token string `json:"token"`
+	// User is the address of the owner of the transferred tokens
+	User string `json:"user"`
+	// Amount is the transferred amount
+	Amount *big.Int `json:"amount"`
+	// ForwardDirection is true if the token of the From channel is transferred
+	ForwardDirection bool `json:"forwardDirection"`
+	// Status is the lifecycle status of the transfer as it is visible on the queried channel
+	Status TransferStatus `json:"status"`
+	// Memo is true if the transfer is a zero amount transfer recorded as a memo only,
+	// such transfers do not change balances and are not delivered to the To channel
+	Memo bool `json:"memo"`
+	// CreatedAt is the timestamp of the transaction created the transfer
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// QueryChannelTransfer returns the decoded channel transfer by id.
+// The same lookup order as in QueryTransferStatus is used, so the peer history database
+// must be enabled to get details of the deleted transfers.
+func (bc *BaseContract) QueryChannelTransfer(id string) (*ChannelTransferDetails, error) {
+	status, err := bc.QueryTransferStatus(id)
+	if errors.Is(err, cctransfer.ErrNotFound) {
+		return bc.channelTransferMemoDetails(id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	tr, err := bc.loadAnyCCTransfer(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChannelTransferDetails{
+		ID:               tr.GetId(),
+		From:             tr.GetFrom(),
+		To:               tr.GetTo(),
+		Token:            tr.GetToken(),
+		User:             types.AddrFromBytes(tr.GetUser()).String(),
+		Amount:           new(big.Int).SetBytes(tr.GetAmount()),
+		ForwardDirection: tr.GetForwardDirection(),
+		Status:           status,
+		CreatedAt:        time.Unix(0, tr.GetTimeAsNanos()).UTC(),
+	}, nil
+}
+
+// loadAnyCCTransfer loads the transfer record of the From or To channel including deleted ones
+func (bc *BaseContract) loadAnyCCTransfer(id string) (*pb.CCTransfer, error) {
+	stub := bc.GetStub()
+
+	loaders := []func() (*pb.CCTransfer, error){
+		func() (*pb.CCTransfer, error) { return cctransfer.LoadCCFromTransfer(stub, id) },
+		func() (*pb.CCTransfer, error) { return cctransfer.LoadCCToTransfer(stub, id) },
+		func() (*pb.CCTransfer, error) { return cctransfer.LoadDeletedCCFromTransfer(stub, id) },
+		func() (*pb.CCTransfer, error) { return cctransfer.LoadDeletedCCToTransfer(stub, id) },
+	}
+
+	for _, load := range loaders {
+		tr, err := load()
+		if err == nil {
+			return tr, nil
+		}
+		if !errors.Is(err, cctransfer.ErrNotFound) {
+			return nil, err
+		}
+	}
+
+	return nil, cctransfer.ErrNotFound
+}
+
+func (bc *BaseContract) channelTransferMemoDetails(id string) (*ChannelTransferDetails, error) {
+	memo, err := bc.QueryChannelTransferMemo(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChannelTransferDetails{
+		ID:               memo.ID,
+		From:             memo.From,
+		To:               memo.To,
+		Token:            memo.Token,
+		User:             memo.User,
+		Amount:           big.NewInt(0),
+		ForwardDirection: strings.EqualFold(tokenSymbol(memo.Token), memo.From),
+		Status:           TransferStatusCompleted,
+		Memo:             true,
+		CreatedAt:        time.Unix(0, memo.TimeAsNanos).UTC(),
+	}, nil
+}
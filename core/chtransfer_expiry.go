@@ -0,0 +1,142 @@
+package core
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/anoideaopen/foundation/core/cctransfer"
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
+	pb "github.com/anoideaopen/foundation/proto"
+)
+
+// ReapedTransfers is the result of NBTxReapExpiredTransfers
+type ReapedTransfers struct {
+	Reaped []string `json:"reaped"`
+}
+
+// TxChannelTransferByCustomerWithExpiry initiates the transfer between channels like TxChannelTransferByCustomer
+// expiring in ttl (e.g. "1h") after the creation. The channel To rejects the expired transfer,
+// and the expired not committed transfer is cancelled with the refund to the customer by NBTxReapExpiredTransfers.
+// The ttl must exceed the time the channel-transfer service takes to deliver the transfer to the channel To,
+// as the transfer created in the channel To before the expiration can not be reaped after the commit.
+func (bc *BaseContract) TxChannelTransferByCustomerWithExpiry(
+	sender *types.Sender,
+	idTransfer string,
+	to string,
+	token string,
+	amount *big.Int,
+	ttl string,
+) (string, error) {
+	duration, err := time.ParseDuration(ttl)
+	if err != nil || duration <= 0 {
+		return "", fmt.Errorf("%w: '%s'", cctransfer.ErrInvalidTransferTTL, ttl)
+	}
+
+	ts, err := bc.GetStub().GetTxTimestamp()
+	if err != nil {
+		return "", err
+	}
+
+	return bc.channelTransferByCustomer(sender, sender.Address(), idTransfer, to, token, amount, ts.AsTime().Add(duration).UnixNano())
+}
+
+// NBTxReapExpiredTransfers cancels up to pageSize expired not committed transfers of the channel From
+// and returns balances to the customers. Ids of the cancelled transfers are returned,
+// less than pageSize ids mean that there are no more expired transfers.
+// Only the transfers indexed by status are reaped.
+// This transaction is sent only by the channel-transfer service with a "robot" certificate
+func (bc *BaseContract) NBTxReapExpiredTransfers(pageSize int64) (*ReapedTransfers, error) {
+	if pageSize <= 0 {
+		return nil, cctransfer.ErrPageSizeLessOrEqZero
+	}
+
+	stub := bc.GetStub()
+
+	ts, err := stub.GetTxTimestamp()
+	if err != nil {
+		return nil, err
+	}
+	now := ts.AsTime().UnixNano()
+
+	expired, err := bc.expiredTransfers(now, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ReapedTransfers{Reaped: []string{}}
+	for _, tr := range expired {
+		if err = bc.cancelCCTransferFrom(tr); err != nil {
+			return nil, err
+		}
+		result.Reaped = append(result.Reaped, tr.GetId())
+	}
+
+	return result, nil
+}
+
+// expiredTransfers returns up to limit not committed transfers of the channel From expired at now
+func (bc *BaseContract) expiredTransfers(now int64, limit int64) ([]*pb.CCTransfer, error) {
+	stub := bc.GetStub()
+
+	iter, err := stub.GetStateByPartialCompositeKey(
+		ChannelTransferStatusCompositeType,
+		[]string{string(TransferStatusCreated)},
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	var expired []*pb.CCTransfer
+	for iter.HasNext() && int64(len(expired)) < limit {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		_, components, err := stub.SplitCompositeKey(kv.GetKey())
+		if err != nil {
+			return nil, err
+		}
+
+		if len(components) != 2 {
+			continue
+		}
+
+		tr, err := cctransfer.LoadCCFromTransfer(stub, components[1])
+		if err != nil {
+			return nil, err
+		}
+
+		if isExpired(tr, now) {
+			expired = append(expired, tr)
+		}
+	}
+
+	return expired, nil
+}
+
+// checkNotExpired returns cctransfer.ErrTransferExpired if the transfer is expired at the tx time
+func (bc *BaseContract) checkNotExpired(tr *pb.CCTransfer) error {
+	if tr.GetExpiresAtNanos() == 0 {
+		return nil
+	}
+
+	ts, err := bc.GetStub().GetTxTimestamp()
+	if err != nil {
+		return err
+	}
+
+	if isExpired(tr, ts.AsTime().UnixNano()) {
+		return fmt.Errorf("%w: %s", cctransfer.ErrTransferExpired, tr.GetId())
+	}
+
+	return nil
+}
+
+func isExpired(tr *pb.CCTransfer, now int64) bool {
+	return !tr.GetIsCommit() && tr.GetExpiresAtNanos() != 0 && tr.GetExpiresAtNanos() <= now
+}
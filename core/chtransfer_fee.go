@@ -0,0 +1,122 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
+	pb "github.com/anoideaopen/foundation/proto"
+)
+
+// channelTransferFeeDecimals is the number of decimal places in the channel transfer fee rate
+const channelTransferFeeDecimals = 8
+
+var ErrInvalidChannelTransferFee = errors.New("channel transfer fee rate, floor and cap must be non-negative integers")
+
+// QueryPredictChannelTransferFee returns the fee charged for the channel transfer of amount
+func (bc *BaseContract) QueryPredictChannelTransferFee(amount *big.Int) (*big.Int, error) {
+	return calcChannelTransferFee(bc.config.GetChannelTransferFee(), amount)
+}
+
+// chargeChannelTransferFee transfers the channel transfer fee from the user to the fee address.
+// The fee is charged in the transferred token from the same balance the amount is taken from
+// and it is not returned if the transfer is cancelled.
+func (bc *BaseContract) chargeChannelTransferFee(
+	forwardDirection bool,
+	user *types.Address,
+	token string,
+	amount *big.Int,
+) error {
+	cfg := bc.config.GetChannelTransferFee()
+	if cfg == nil {
+		return nil
+	}
+
+	fee, err := calcChannelTransferFee(cfg, amount)
+	if err != nil {
+		return err
+	}
+
+	if fee.Sign() == 0 {
+		return nil
+	}
+
+	feeAddr, err := types.AddrFromBase58Check(cfg.GetAddress().GetAddress())
+	if err != nil {
+		return fmt.Errorf("creating channel transfer fee address: %w", err)
+	}
+
+	if user.Equal(feeAddr) {
+		return nil
+	}
+
+	const reason = "channel transfer fee"
+
+	if !forwardDirection {
+		if err = bc.AllowedBalanceTransfer(token, user, feeAddr, fee, reason); err != nil {
+			return err
+		}
+
+		return bc.WalletAuditFee(user, feeAddr, token, fee)
+	}
+
+	if err = bc.TokenBalanceSubWithTicker(user, fee, token, reason); err != nil {
+		return err
+	}
+
+	if err = bc.TokenBalanceAddWithTicker(feeAddr, fee, token, reason); err != nil {
+		return err
+	}
+
+	return bc.WalletAuditFee(user, feeAddr, token, fee)
+}
+
+func calcChannelTransferFee(cfg *pb.ChannelTransferFee, amount *big.Int) (*big.Int, error) {
+	if cfg == nil {
+		return big.NewInt(0), nil
+	}
+
+	rate, ok := parseChannelTransferFeeValue(cfg.GetRate())
+	if !ok {
+		return nil, ErrInvalidChannelTransferFee
+	}
+
+	floor, ok := parseChannelTransferFeeValue(cfg.GetFloor())
+	if !ok {
+		return nil, ErrInvalidChannelTransferFee
+	}
+
+	cp, ok := parseChannelTransferFeeValue(cfg.GetCap())
+	if !ok {
+		return nil, ErrInvalidChannelTransferFee
+	}
+
+	fee := new(big.Int).Div(
+		new(big.Int).Mul(amount, rate),
+		new(big.Int).Exp(big.NewInt(10), big.NewInt(channelTransferFeeDecimals), nil), //nolint:gomnd
+	)
+
+	if fee.Cmp(floor) < 0 {
+		fee = floor
+	}
+
+	if cp.Sign() > 0 && fee.Cmp(cp) > 0 {
+		fee = cp
+	}
+
+	return fee, nil
+}
+
+func parseChannelTransferFeeValue(value string) (*big.Int, bool) {
+	if value == "" {
+		return big.NewInt(0), true
+	}
+
+	v, ok := new(big.Int).SetString(value, 10) //nolint:gomnd
+	if !ok || v.Sign() < 0 {
+		return nil, false
+	}
+
+	return v, true
+}
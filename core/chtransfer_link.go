@@ -0,0 +1,180 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anoideaopen/foundation/core/cctransfer"
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
+	pb "github.com/anoideaopen/foundation/proto"
+)
+
+// ChannelTransferLinkCompositeType is a composite key prefix for the index of the backward transfers
+// by the id of the linked forward transfer
+const ChannelTransferLinkCompositeType = "ch_transfer_link"
+
+// NetTransfer is the summary of the round trip of the forward transfer and the linked backward transfer
+type NetTransfer struct {
+	// ForwardID is the id of the forward transfer
+	ForwardID string `json:"forwardId"`
+	// BackwardID is the id of the linked backward transfer, empty if the tokens are not returned yet
+	BackwardID string `json:"backwardId"`
+	// Token is the transferred token
+	Token string `json:"token"`
+	// User is the address of the owner of the transferred tokens
+	User string `json:"user"`
+	// ForwardAmount is the amount of the forward transfer
+	ForwardAmount *big.Int `json:"forwardAmount"`
+	// BackwardAmount is the amount of the backward transfer
+	BackwardAmount *big.Int `json:"backwardAmount"`
+	// NetAmount is the amount remaining in the channel To of the forward transfer
+	NetAmount *big.Int `json:"netAmount"`
+}
+
+// TxChannelTransferLinkedByCustomer initiates the backward transfer like TxChannelTransferByCustomer
+// returning the tokens received by the forward transfer linkedID. The transfer must be signed by the user
+// of the forward transfer, return the same token to the channel it came from and not exceed its amount.
+// A forward transfer can be linked by one backward transfer only. The link is delivered to the channel To
+// with the transfer, so the round trip is summarized by QueryNetTransfer in both channels.
+func (bc *BaseContract) TxChannelTransferLinkedByCustomer(
+	sender *types.Sender,
+	idTransfer string,
+	linkedID string,
+	to string,
+	token string,
+	amount *big.Int,
+) (string, error) {
+	if amount.Sign() == 0 {
+		return "", cctransfer.ErrZeroAmount
+	}
+
+	token = bc.ResolveToken(token)
+
+	forward, err := bc.loadAnyCCTransfer(linkedID)
+	if err != nil {
+		return "", fmt.Errorf("loading linked transfer %s: %w", linkedID, err)
+	}
+
+	if !forward.GetForwardDirection() ||
+		!strings.EqualFold(forward.GetTo(), bc.config.GetSymbol()) ||
+		!strings.EqualFold(forward.GetFrom(), to) ||
+		forward.GetToken() != token ||
+		types.AddrFromBytes(forward.GetUser()).String() != sender.Address().String() ||
+		new(big.Int).SetBytes(forward.GetAmount()).Cmp(amount) < 0 {
+		return "", fmt.Errorf("%w: %s", cctransfer.ErrInvalidLinkedTransfer, linkedID)
+	}
+
+	backwardID, err := bc.linkedTransferID(linkedID)
+	if err != nil {
+		return "", err
+	}
+	if backwardID != "" {
+		return "", fmt.Errorf("%w: %s is linked by %s", cctransfer.ErrTransferAlreadyLinked, linkedID, backwardID)
+	}
+
+	result, err := bc.channelTransferByCustomer(sender, sender.Address(), idTransfer, to, token, amount, 0)
+	if err != nil {
+		return "", err
+	}
+
+	// the id is derived if it is not set
+	if idTransfer == "" {
+		idTransfer = result
+	}
+
+	stub := bc.GetStub()
+
+	tr, err := cctransfer.LoadCCFromTransfer(stub, idTransfer)
+	if err != nil {
+		return "", err
+	}
+
+	tr.LinkedId = linkedID
+	if err = cctransfer.SaveCCFromTransferEncoded(stub, tr, bc.ccTransferEncoding()); err != nil {
+		return "", err
+	}
+
+	if err = bc.indexLinkedTransfer(tr, true); err != nil {
+		return "", err
+	}
+
+	return result, nil
+}
+
+// QueryNetTransfer returns the summary of the round trip of the forward transfer forwardID
+// and the backward transfer linked to it by TxChannelTransferLinkedByCustomer.
+// The net amount is zero if all the tokens are returned. The peer history database
+// must be enabled to summarize the completed transfers as their records are deleted.
+func (bc *BaseContract) QueryNetTransfer(forwardID string) (*NetTransfer, error) {
+	forward, err := bc.loadAnyCCTransfer(forwardID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !forward.GetForwardDirection() {
+		return nil, fmt.Errorf("%w: %s is not a forward transfer", cctransfer.ErrInvalidLinkedTransfer, forwardID)
+	}
+
+	net := &NetTransfer{
+		ForwardID:      forward.GetId(),
+		Token:          forward.GetToken(),
+		User:           types.AddrFromBytes(forward.GetUser()).String(),
+		ForwardAmount:  new(big.Int).SetBytes(forward.GetAmount()),
+		BackwardAmount: big.NewInt(0),
+	}
+
+	if net.BackwardID, err = bc.linkedTransferID(forwardID); err != nil {
+		return nil, err
+	}
+
+	if net.BackwardID != "" {
+		backward, err := bc.loadAnyCCTransfer(net.BackwardID)
+		if err != nil {
+			return nil, err
+		}
+		net.BackwardAmount.SetBytes(backward.GetAmount())
+	}
+
+	net.NetAmount = new(big.Int).Sub(net.ForwardAmount, net.BackwardAmount)
+
+	return net, nil
+}
+
+// indexLinkedTransfer adds the backward transfer to the index by the linked forward transfer id
+// or removes it if linked is false, so the cancelled backward transfer does not count in the round trip
+func (bc *BaseContract) indexLinkedTransfer(tr *pb.CCTransfer, linked bool) error {
+	if tr.GetLinkedId() == "" {
+		return nil
+	}
+
+	stub := bc.GetStub()
+
+	key, err := stub.CreateCompositeKey(ChannelTransferLinkCompositeType, []string{tr.GetLinkedId()})
+	if err != nil {
+		return err
+	}
+
+	if !linked {
+		return stub.DelState(key)
+	}
+
+	return stub.PutState(key, []byte(tr.GetId()))
+}
+
+// linkedTransferID returns the id of the backward transfer linked to the forward transfer, empty if there is none
+func (bc *BaseContract) linkedTransferID(forwardID string) (string, error) {
+	stub := bc.GetStub()
+
+	key, err := stub.CreateCompositeKey(ChannelTransferLinkCompositeType, []string{forwardID})
+	if err != nil {
+		return "", err
+	}
+
+	data, err := stub.GetState(key)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
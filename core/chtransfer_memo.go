@@ -0,0 +1,104 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/cctransfer"
+	"github.com/anoideaopen/foundation/core/types"
+)
+
+// ChannelTransferMemoCompositeType is a composite key prefix for the memos of zero amount channel transfers
+const ChannelTransferMemoCompositeType = "ch_transfer_memo"
+
+// ChannelTransferMemo is the record of the zero amount channel transfer.
+// Such transfers are accepted only if allow_zero_amount_channel_transfers option is set,
+// they do not change balances and are not delivered to the To channel.
+type ChannelTransferMemo struct {
+	ID          string `json:"id"`
+	From        string `json:"from"`
+	To          string `json:"to"`
+	Token       string `json:"token"`
+	User        string `json:"user"`
+	TimeAsNanos int64  `json:"timeAsNanos"`
+}
+
+// QueryChannelTransferMemo returns the memo of the zero amount channel transfer by id
+func (bc *BaseContract) QueryChannelTransferMemo(id string) (*ChannelTransferMemo, error) {
+	key, err := bc.GetStub().CreateCompositeKey(ChannelTransferMemoCompositeType, []string{id})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := bc.GetStub().GetState(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: %s", cctransfer.ErrNotFound, id)
+	}
+
+	memo := &ChannelTransferMemo{}
+	if err = json.Unmarshal(data, memo); err != nil {
+		return nil, err
+	}
+
+	return memo, nil
+}
+
+// createZeroAmountTransfer handles the channel transfer of zero amount according to the contract options.
+// In strict mode (default) the transfer is rejected, in permissive mode only the memo of the transfer is recorded.
+func (bc *BaseContract) createZeroAmountTransfer(
+	idTransfer string,
+	to string,
+	idUser *types.Address,
+	token string,
+) (string, error) {
+	if !bc.config.GetOptions().GetAllowZeroAmountChannelTransfers() {
+		return "", cctransfer.ErrZeroAmount
+	}
+
+	stub := bc.GetStub()
+
+	if _, err := cctransfer.LoadCCFromTransfer(stub, idTransfer); err == nil {
+		return "", cctransfer.ErrIDTransferExist
+	}
+
+	key, err := stub.CreateCompositeKey(ChannelTransferMemoCompositeType, []string{idTransfer})
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := stub.GetState(key)
+	if err != nil {
+		return "", err
+	}
+
+	if len(existing) != 0 {
+		return "", cctransfer.ErrIDTransferExist
+	}
+
+	ts, err := stub.GetTxTimestamp()
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(ChannelTransferMemo{
+		ID:          idTransfer,
+		From:        bc.config.GetSymbol(),
+		To:          to,
+		Token:       token,
+		User:        idUser.String(),
+		TimeAsNanos: ts.AsTime().UnixNano(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	if err = stub.PutState(key, data); err != nil {
+		return "", err
+	}
+
+	return stub.GetTxID(), nil
+}
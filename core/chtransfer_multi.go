@@ -0,0 +1,59 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/cctransfer"
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
+)
+
+// AdminChannelTransfer is a transfer of user tokens created by TxChannelMultiTransferByAdmin
+type AdminChannelTransfer struct {
+	ID     string         `json:"id"`
+	User   *types.Address `json:"user"`
+	Token  string         `json:"token"`
+	Amount *big.Int       `json:"amount"`
+}
+
+// TxChannelMultiTransferByAdmin - transaction initiating transfers between channels for several users.
+// Signed by the channel admin (site). Each transfer is checked and created as by TxChannelTransferByAdmin.
+// Transfers are created all or nothing: an error of any transfer fails the whole transaction.
+func (bc *BaseContract) TxChannelMultiTransferByAdmin(
+	sender *types.Sender,
+	to string,
+	transfers []AdminChannelTransfer,
+) (string, error) {
+	if err := bc.checkChannelTransferAdmin(sender); err != nil {
+		return "", err
+	}
+
+	if len(transfers) == 0 {
+		return "", cctransfer.ErrEmptyTransfers
+	}
+
+	ids := make(map[string]struct{}, len(transfers))
+	for _, tr := range transfers {
+		if _, ok := ids[tr.ID]; ok {
+			return "", fmt.Errorf("%w: %s", cctransfer.ErrDuplicateIDTransfer, tr.ID)
+		}
+		ids[tr.ID] = struct{}{}
+	}
+
+	for i, tr := range transfers {
+		if tr.User == nil {
+			return "", fmt.Errorf("transfer %d '%s': %w", i, tr.ID, cctransfer.ErrInvalidIDUser)
+		}
+
+		amount := tr.Amount
+		if amount == nil {
+			amount = big.NewInt(0)
+		}
+
+		if _, err := bc.createCCTransferByAdmin(sender, tr.ID, to, tr.User, tr.Token, amount); err != nil {
+			return "", fmt.Errorf("transfer %d '%s': %w", i, tr.ID, err)
+		}
+	}
+
+	return bc.GetStub().GetTxID(), nil
+}
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/anoideaopen/foundation/core/cctransfer"
+	"github.com/anoideaopen/foundation/core/types"
+	pb "github.com/anoideaopen/foundation/proto"
+)
+
+// PrunedTransfers is the result of TxPruneTransfers
+type PrunedTransfers struct {
+	Pruned   uint64 `json:"pruned"`
+	Bookmark string `json:"bookmark,omitempty"`
+}
+
+// TxPruneTransfers deletes the terminal transfer records created more than olderThan (e.g. "720h") ago.
+// Terminal records are the committed records of the From channel and the records of the To channel,
+// the balances of their transfers are already changed and the records are kept only to be deleted
+// by the channel-transfer service. Not committed records of the From channel are never deleted,
+// abandoned transfers must be cancelled to return the locked balances.
+// Records are processed in pages of pageSize in the order of their keys starting after the bookmark.
+// The bookmark of the next page is returned, an empty bookmark means that all records are processed.
+// Method can be called by the channel admin only.
+func (bc *BaseContract) TxPruneTransfers(
+	sender *types.Sender,
+	olderThan string,
+	pageSize int64,
+	bookmark string,
+) (*PrunedTransfers, error) {
+	if err := bc.checkChannelTransferAdmin(sender); err != nil {
+		return nil, err
+	}
+
+	age, err := time.ParseDuration(olderThan)
+	if err != nil || age <= 0 {
+		return nil, fmt.Errorf("%w: '%s'", cctransfer.ErrInvalidPruneAge, olderThan)
+	}
+
+	if pageSize <= 0 {
+		return nil, cctransfer.ErrPageSizeLessOrEqZero
+	}
+
+	prefix := cctransfer.CCTransfers()
+	if bookmark != "" && !strings.HasPrefix(bookmark, prefix) {
+		return nil, cctransfer.ErrInvalidBookmark
+	}
+
+	stub := bc.GetStub()
+
+	ts, err := stub.GetTxTimestamp()
+	if err != nil {
+		return nil, err
+	}
+	threshold := ts.AsTime().Add(-age).UnixNano()
+
+	// paginated queries are not available in transactions, so the range starts right after the bookmark
+	startKey := prefix
+	if bookmark != "" {
+		startKey = bookmark + "\x00"
+	}
+
+	iter, err := stub.GetStateByRange(startKey, prefix+string(utf8.MaxRune))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	result := &PrunedTransfers{}
+	processed, more := int64(0), false
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		if processed == pageSize {
+			more = true
+			break
+		}
+		processed++
+		result.Bookmark = kv.GetKey()
+
+		pruned, err := bc.pruneTransfer(kv.GetKey(), threshold)
+		if err != nil {
+			return nil, err
+		}
+
+		if pruned {
+			result.Pruned++
+		}
+	}
+
+	if !more {
+		result.Bookmark = ""
+	}
+
+	return result, nil
+}
+
+// pruneTransfer deletes the terminal transfer record stored by the key if it is created before threshold
+func (bc *BaseContract) pruneTransfer(key string, threshold int64) (bool, error) {
+	stub := bc.GetStub()
+	id := cctransfer.Base(key)
+
+	var (
+		tr     *pb.CCTransfer
+		status TransferStatus
+		err    error
+	)
+	if strings.HasPrefix(key, cctransfer.CCFromTransfers()) {
+		if tr, err = cctransfer.LoadCCFromTransfer(stub, id); err != nil {
+			return false, err
+		}
+
+		if !tr.GetIsCommit() {
+			return false, nil
+		}
+		status = TransferStatusCommitted
+	} else {
+		if tr, err = cctransfer.LoadCCToTransfer(stub, id); err != nil {
+			return false, err
+		}
+		status = TransferStatusToCreated
+	}
+
+	if tr.GetTimeAsNanos() >= threshold {
+		return false, nil
+	}
+
+	if err = bc.moveTransferStatus(id, status, ""); err != nil {
+		return false, err
+	}
+
+	if err = bc.unindexTransferTime(tr); err != nil {
+		return false, err
+	}
+
+	return true, stub.DelState(key)
+}
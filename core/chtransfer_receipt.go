@@ -0,0 +1,32 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/cctransfer"
+)
+
+// QueryChannelTransferReceipt returns the receipt hash of the completed channel transfer
+// computed by cctransfer.ReceiptHash over the fields of QueryChannelTransfer details.
+// The peer history database must be enabled as the records of completed transfers are deleted.
+func (bc *BaseContract) QueryChannelTransferReceipt(id string) (string, error) {
+	details, err := bc.QueryChannelTransfer(id)
+	if err != nil {
+		return "", err
+	}
+
+	if details.Status != TransferStatusCompleted {
+		return "", fmt.Errorf("%w: %s is %s", cctransfer.ErrTransferNotCompleted, id, details.Status)
+	}
+
+	return cctransfer.ReceiptHash(
+		details.ID,
+		details.From,
+		details.To,
+		details.Token,
+		details.User,
+		details.Amount.String(),
+		string(details.Status),
+		details.CreatedAt.UnixNano(),
+	), nil
+}
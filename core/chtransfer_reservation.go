@@ -0,0 +1,116 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/anoideaopen/foundation/core/cctransfer"
+	"github.com/anoideaopen/foundation/core/types"
+)
+
+// ChannelTransferReservationCompositeType is a composite key prefix for the reserved transfer ids
+const ChannelTransferReservationCompositeType = "ch_transfer_reservation"
+
+// TransferIDReservationTTL is the time the reserved transfer id can be used in
+const TransferIDReservationTTL = time.Hour
+
+// TransferIDReservation is the transfer id reserved by TxReserveTransferID
+type TransferIDReservation struct {
+	// Owner is the address which has reserved the id
+	Owner string `json:"owner"`
+	// ExpiresAt is the time in nanoseconds the reservation expires at if the id is not used
+	ExpiresAt int64 `json:"expiresAt"`
+	// Used is true if the transfer with the id is created
+	Used bool `json:"used"`
+}
+
+// TxReserveTransferID reserves a unique transfer id for the sender and returns it.
+// The id must be used by the sender in channelTransferByCustomer within TransferIDReservationTTL,
+// it can be used once and by the sender only. If require_reserved_transfer_ids option is set,
+// channelTransferByCustomer does not accept the ids not reserved this way.
+func (bc *BaseContract) TxReserveTransferID(sender *types.Sender) (string, error) {
+	stub := bc.GetStub()
+
+	ts, err := stub.GetTxTimestamp()
+	if err != nil {
+		return "", err
+	}
+
+	id := stub.GetTxID()
+
+	data, err := json.Marshal(TransferIDReservation{
+		Owner:     sender.Address().String(),
+		ExpiresAt: ts.AsTime().Add(TransferIDReservationTTL).UnixNano(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	key, err := stub.CreateCompositeKey(ChannelTransferReservationCompositeType, []string{id})
+	if err != nil {
+		return "", err
+	}
+
+	if err = stub.PutState(key, data); err != nil {
+		return "", err
+	}
+
+	return id, nil
+}
+
+// useTransferID marks the reservation of the transfer id as used by the owner,
+// the not reserved id is accepted unless require_reserved_transfer_ids option is set
+func (bc *BaseContract) useTransferID(owner *types.Address, idTransfer string) error {
+	if idTransfer == "" {
+		return nil
+	}
+
+	stub := bc.GetStub()
+
+	key, err := stub.CreateCompositeKey(ChannelTransferReservationCompositeType, []string{idTransfer})
+	if err != nil {
+		return err
+	}
+
+	data, err := stub.GetState(key)
+	if err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		if bc.config.GetOptions().GetRequireReservedTransferIds() {
+			return fmt.Errorf("%w: %s", cctransfer.ErrIDTransferNotReserved, idTransfer)
+		}
+		return nil
+	}
+
+	var reservation TransferIDReservation
+	if err = json.Unmarshal(data, &reservation); err != nil {
+		return fmt.Errorf("unmarshalling reservation of %s: %w", idTransfer, err)
+	}
+
+	if reservation.Owner != owner.String() {
+		return fmt.Errorf("%w: %s", cctransfer.ErrIDReservedByAnother, idTransfer)
+	}
+
+	if reservation.Used {
+		return fmt.Errorf("%w: %s", cctransfer.ErrIDReservationUsed, idTransfer)
+	}
+
+	ts, err := stub.GetTxTimestamp()
+	if err != nil {
+		return err
+	}
+
+	if ts.AsTime().UnixNano() > reservation.ExpiresAt {
+		return fmt.Errorf("%w: %s", cctransfer.ErrIDReservationExpired, idTransfer)
+	}
+
+	reservation.Used = true
+	if data, err = json.Marshal(reservation); err != nil {
+		return err
+	}
+
+	return stub.PutState(key, data)
+}
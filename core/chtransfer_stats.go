@@ -0,0 +1,114 @@
+package core
+
+import (
+	"encoding/json"
+
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
+)
+
+// ChannelStatsCompositeType is a composite key prefix for the channel transfers statistics of the token
+const ChannelStatsCompositeType = "channel_stats"
+
+// ChannelStatsParticipantCompositeType is a composite key prefix for the index of the channel transfers
+// participants of the token
+const ChannelStatsParticipantCompositeType = "channel_stats_participant"
+
+// ChannelStats is the aggregate statistics of the channel transfers of the token in the channel
+type ChannelStats struct {
+	// Transfers is the number of transfers created in the channel as the From channel
+	Transfers uint64 `json:"transfers"`
+	// Committed is the number of transfers committed in the From channel
+	Committed uint64 `json:"committed"`
+	// Cancelled is the number of transfers cancelled in the From channel
+	Cancelled uint64 `json:"cancelled"`
+	// Received is the number of transfers created in the channel as the To channel
+	Received uint64 `json:"received"`
+	// LockedAmount is the total amount of the token of the transfers
+	// created in the From channel that are neither committed nor cancelled yet
+	LockedAmount *big.Int `json:"lockedAmount"`
+	// Participants is the number of unique users of the transfers of the token
+	Participants uint64 `json:"participants"`
+}
+
+// QueryChannelStats returns the aggregate statistics of the channel transfers of the token.
+// Counters are updated on transfer creation, commit and cancellation. Each token has its own
+// statistics, so the transfers of different tokens do not update the same state key.
+func (bc *BaseContract) QueryChannelStats(token string) (*ChannelStats, error) {
+	return bc.loadChannelStats(bc.ResolveToken(token))
+}
+
+// unlock subtracts amount of the committed or cancelled transfer from the locked amount.
+// Transfers created before the statistics was introduced are not counted in the locked amount,
+// so it is not reduced below zero.
+func (s *ChannelStats) unlock(amount *big.Int) {
+	s.LockedAmount.Sub(s.LockedAmount, amount)
+	if s.LockedAmount.Sign() < 0 {
+		s.LockedAmount.SetInt64(0)
+	}
+}
+
+func (bc *BaseContract) loadChannelStats(token string) (*ChannelStats, error) {
+	key, err := bc.GetStub().CreateCompositeKey(ChannelStatsCompositeType, []string{token})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := bc.GetStub().GetState(key)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &ChannelStats{}
+	if len(data) != 0 {
+		if err = json.Unmarshal(data, stats); err != nil {
+			return nil, err
+		}
+	}
+
+	if stats.LockedAmount == nil {
+		stats.LockedAmount = big.NewInt(0)
+	}
+
+	return stats, nil
+}
+
+// updateChannelStats applies update to the channel transfers statistics of the token
+// and counts the user as a participant if it takes part in a transfer of the token for the first time.
+func (bc *BaseContract) updateChannelStats(token string, user *types.Address, update func(stats *ChannelStats)) error {
+	stats, err := bc.loadChannelStats(token)
+	if err != nil {
+		return err
+	}
+
+	update(stats)
+
+	participantKey, err := bc.GetStub().CreateCompositeKey(ChannelStatsParticipantCompositeType, []string{token, user.String()})
+	if err != nil {
+		return err
+	}
+
+	participant, err := bc.GetStub().GetState(participantKey)
+	if err != nil {
+		return err
+	}
+
+	if len(participant) == 0 {
+		stats.Participants++
+		if err = bc.GetStub().PutState(participantKey, []byte{1}); err != nil {
+			return err
+		}
+	}
+
+	data, err := json.Marshal(stats)
+	if err != nil {
+		return err
+	}
+
+	key, err := bc.GetStub().CreateCompositeKey(ChannelStatsCompositeType, []string{token})
+	if err != nil {
+		return err
+	}
+
+	return bc.GetStub().PutState(key, data)
+}
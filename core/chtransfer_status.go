@@ -0,0 +1,220 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/cctransfer"
+	"github.com/anoideaopen/foundation/core/types/big"
+	pb "github.com/anoideaopen/foundation/proto"
+)
+
+// ChannelTransferStatusCompositeType is a composite key prefix for the index of the channel transfers by status
+const ChannelTransferStatusCompositeType = "ch_transfer_status"
+
+// TransferStatus is a lifecycle status of the channel transfer as it is visible on the queried channel
+type TransferStatus string
+
+const (
+	// TransferStatusCreated - the transfer is created in the From channel and is not committed yet
+	TransferStatusCreated TransferStatus = "created"
+	// TransferStatusToCreated - the transfer is created in the To channel
+	TransferStatusToCreated TransferStatus = "to_created"
+	// TransferStatusCommitted - the transfer is committed in the From channel
+	TransferStatusCommitted TransferStatus = "committed"
+	// TransferStatusCancelled - the transfer is cancelled in the From channel before commit
+	TransferStatusCancelled TransferStatus = "cancelled"
+	// TransferStatusCompleted - the committed transfer record is deleted from the channel
+	TransferStatusCompleted TransferStatus = "completed"
+)
+
+// QueryTransferStatus returns the lifecycle status of the channel transfer derived from
+// the transfer records of the queried channel. For deleted records the status is derived
+// from the key history, so the peer history database must be enabled to tell
+// cancelled transfers from completed ones.
+func (bc *BaseContract) QueryTransferStatus(id string) (TransferStatus, error) {
+	stub := bc.GetStub()
+
+	from, err := cctransfer.LoadCCFromTransfer(stub, id)
+	if err == nil {
+		if from.GetIsCommit() {
+			return TransferStatusCommitted, nil
+		}
+		return TransferStatusCreated, nil
+	} else if !errors.Is(err, cctransfer.ErrNotFound) {
+		return "", err
+	}
+
+	if _, err = cctransfer.LoadCCToTransfer(stub, id); err == nil {
+		return TransferStatusToCreated, nil
+	} else if !errors.Is(err, cctransfer.ErrNotFound) {
+		return "", err
+	}
+
+	if from, err = cctransfer.LoadDeletedCCFromTransfer(stub, id); err == nil {
+		if from.GetIsCommit() {
+			return TransferStatusCompleted, nil
+		}
+		return TransferStatusCancelled, nil
+	} else if !errors.Is(err, cctransfer.ErrNotFound) {
+		return "", err
+	}
+
+	if _, err = cctransfer.LoadDeletedCCToTransfer(stub, id); err != nil {
+		return "", err
+	}
+
+	return TransferStatusCompleted, nil
+}
+
+// QueryTransfersByStatus returns the transfer records of the queried channel having the status.
+// Only the statuses of the existing records are indexed: created, committed and to_created.
+// Transfers created before the index was introduced are not returned.
+// You can receive them in parts (chunks)
+func (bc *BaseContract) QueryTransfersByStatus(
+	status TransferStatus,
+	pageSize int64,
+	bookmark string,
+) (*pb.CCTransfers, error) {
+	if pageSize <= 0 {
+		return nil, cctransfer.ErrPageSizeLessOrEqZero
+	}
+
+	load := cctransfer.LoadCCFromTransfer
+	switch status {
+	case TransferStatusCreated, TransferStatusCommitted:
+	case TransferStatusToCreated:
+		load = cctransfer.LoadCCToTransfer
+	default:
+		return nil, fmt.Errorf("%w: %s", cctransfer.ErrStatusNotIndexed, status)
+	}
+
+	pageSize, clamped := bc.ClampPageSize(pageSize)
+
+	stub := bc.GetStub()
+
+	iter, meta, err := stub.GetStateByPartialCompositeKeyWithPagination(
+		ChannelTransferStatusCompositeType,
+		[]string{string(status)},
+		int32(pageSize),
+		bookmark,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	trs := &pb.CCTransfers{Ccts: []*pb.CCTransfer{}, PageSizeClamped: clamped}
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		_, components, err := stub.SplitCompositeKey(kv.GetKey())
+		if err != nil {
+			return nil, err
+		}
+
+		if len(components) != 2 {
+			continue
+		}
+
+		tr, err := load(stub, components[1])
+		if err != nil {
+			return nil, err
+		}
+
+		trs.Ccts = append(trs.Ccts, tr)
+	}
+
+	trs.Bookmark = meta.GetBookmark()
+
+	return trs, nil
+}
+
+// QueryTotalLocked returns the total amount of the token debited by the transfers of the channel From
+// which are not completed or cancelled yet, i.e. the created and the committed transfers.
+// Transfers created before the status index was introduced are not counted.
+func (bc *BaseContract) QueryTotalLocked(token string) (*big.Int, error) {
+	token = bc.ResolveToken(token)
+
+	total := big.NewInt(0)
+	for _, status := range []TransferStatus{TransferStatusCreated, TransferStatusCommitted} {
+		if err := bc.sumTransfersByStatus(status, token, total); err != nil {
+			return nil, err
+		}
+	}
+
+	return total, nil
+}
+
+// sumTransfersByStatus adds the amounts of the token of the channel From transfers having the status to total
+func (bc *BaseContract) sumTransfersByStatus(status TransferStatus, token string, total *big.Int) error {
+	stub := bc.GetStub()
+
+	iter, err := stub.GetStateByPartialCompositeKey(ChannelTransferStatusCompositeType, []string{string(status)})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return err
+		}
+
+		_, components, err := stub.SplitCompositeKey(kv.GetKey())
+		if err != nil {
+			return err
+		}
+
+		if len(components) != 2 {
+			continue
+		}
+
+		tr, err := cctransfer.LoadCCFromTransfer(stub, components[1])
+		if err != nil {
+			return err
+		}
+
+		if tr.GetToken() == token {
+			total.Add(total, new(big.Int).SetBytes(tr.GetAmount()))
+		}
+	}
+
+	return nil
+}
+
+// moveTransferStatus moves the transfer in the status index from the old status to the new one.
+// An empty status means that the transfer is absent in the index.
+func (bc *BaseContract) moveTransferStatus(id string, from TransferStatus, to TransferStatus) error {
+	stub := bc.GetStub()
+
+	if from != "" {
+		key, err := stub.CreateCompositeKey(ChannelTransferStatusCompositeType, []string{string(from), id})
+		if err != nil {
+			return err
+		}
+
+		if err = stub.DelState(key); err != nil {
+			return err
+		}
+	}
+
+	if to == "" {
+		return nil
+	}
+
+	key, err := stub.CreateCompositeKey(ChannelTransferStatusCompositeType, []string{string(to), id})
+	if err != nil {
+		return err
+	}
+
+	return stub.PutState(key, []byte{1})
+}
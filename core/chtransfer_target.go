@@ -0,0 +1,66 @@
+package core
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/anoideaopen/foundation/core/cctransfer"
+	pb "github.com/anoideaopen/foundation/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+)
+
+// targetMetadataQuery is the query of the token metadata in the channel To
+const targetMetadataQuery = "metadata"
+
+// checkTargetToken queries the metadata of the contract of the channel To and returns
+// cctransfer.ErrUnknownTargetToken if the contract does not respond with the symbol of the channel To.
+// The check is performed only if check_channel_transfer_target_token is enabled in the chaincode options.
+func (bc *BaseContract) checkTargetToken(to string) error {
+	if !bc.config.GetOptions().GetCheckChannelTransferTargetToken() {
+		return nil
+	}
+
+	name := strings.ToLower(to)
+
+	resp := bc.GetStub().InvokeChaincode(name, [][]byte{[]byte(targetMetadataQuery)}, name)
+	if resp.GetStatus() != shim.OK {
+		return fmt.Errorf("%w: %s: %s", cctransfer.ErrUnknownTargetToken, to, resp.GetMessage())
+	}
+
+	var metadata struct {
+		Symbol string `json:"symbol"`
+	}
+	if err := json.Unmarshal(resp.GetPayload(), &metadata); err != nil {
+		return fmt.Errorf("%w: %s: unmarshalling metadata: %s", cctransfer.ErrUnknownTargetToken, to, err)
+	}
+
+	if !strings.EqualFold(metadata.Symbol, to) {
+		return fmt.Errorf("%w: %s: channel contract symbol is '%s'", cctransfer.ErrUnknownTargetToken, to, metadata.Symbol)
+	}
+
+	return nil
+}
+
+// checkRegisteredToken returns cctransfer.ErrTokenNotRegistered if the transfer received in the channel To
+// credits the allowed balance of the token not listed in registered_transfer_tokens.
+// The check is performed only if require_registered_transfer_tokens is enabled in the chaincode options.
+func (bc *BaseContract) checkRegisteredToken(tr *pb.CCTransfer) error {
+	opts := bc.config.GetOptions()
+	if !opts.GetRequireRegisteredTransferTokens() {
+		return nil
+	}
+
+	// the backward transfer returns the own token and the converted transfer credits the own token
+	if !tr.GetForwardDirection() || opts.GetConvertAllowedBalanceOnReceipt() {
+		return nil
+	}
+
+	for _, token := range opts.GetRegisteredTransferTokens() {
+		if strings.EqualFold(token, tr.GetToken()) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", cctransfer.ErrTokenNotRegistered, tr.GetToken())
+}
@@ -0,0 +1,132 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/anoideaopen/foundation/core/cctransfer"
+	pb "github.com/anoideaopen/foundation/proto"
+)
+
+// ChannelTransferTimeIndexPrefix is a key prefix for the index of the channel transfers by creation time.
+// The index keys are simple keys to be queried by range: prefix, zero padded time in nanoseconds and id.
+const ChannelTransferTimeIndexPrefix = "/transfer_time/"
+
+const (
+	transferTimeIndexFrom = "from"
+	transferTimeIndexTo   = "to"
+	// transferTimeDigits is the number of digits of the greatest time in nanoseconds
+	transferTimeDigits = 19
+)
+
+// QueryTransfersByTimeRange returns the transfer records of the queried channel created within
+// the time range [from, to): from is inclusive and to is exclusive. Bounds are RFC 3339 times,
+// e.g. "2024-01-02T15:04:05Z". Both transfers from and to the channel are returned in the creation order,
+// the time of the transfer to the channel is the time it is created in the channel From.
+// Deleted records are restored from the key history, so the peer history database must be enabled
+// to get completed transfers. Cancelled and pruned transfers, as well as transfers created
+// before the index was introduced, are not returned.
+// You can receive them in parts (chunks)
+func (bc *BaseContract) QueryTransfersByTimeRange(
+	from string,
+	to string,
+	pageSize int64,
+	bookmark string,
+) (*pb.CCTransfers, error) {
+	if pageSize <= 0 {
+		return nil, cctransfer.ErrPageSizeLessOrEqZero
+	}
+
+	fromTime, err := time.Parse(time.RFC3339Nano, from)
+	if err != nil {
+		return nil, fmt.Errorf("parsing range start: %w", err)
+	}
+
+	toTime, err := time.Parse(time.RFC3339Nano, to)
+	if err != nil {
+		return nil, fmt.Errorf("parsing range end: %w", err)
+	}
+
+	if !fromTime.Before(toTime) {
+		return nil, fmt.Errorf("%w: [%s, %s)", cctransfer.ErrInvalidTimeRange, from, to)
+	}
+
+	if bookmark != "" && !strings.HasPrefix(bookmark, ChannelTransferTimeIndexPrefix) {
+		return nil, cctransfer.ErrInvalidBookmark
+	}
+
+	pageSize, clamped := bc.ClampPageSize(pageSize)
+
+	stub := bc.GetStub()
+
+	iter, meta, err := stub.GetStateByRangeWithPagination(
+		transferTimeIndexKey(fromTime.UnixNano(), ""),
+		transferTimeIndexKey(toTime.UnixNano(), ""),
+		int32(pageSize),
+		bookmark,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	trs := &pb.CCTransfers{Ccts: []*pb.CCTransfer{}, PageSizeClamped: clamped}
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		id := cctransfer.Base(kv.GetKey())
+
+		load, loadDeleted := cctransfer.LoadCCFromTransfer, cctransfer.LoadDeletedCCFromTransfer
+		if string(kv.GetValue()) == transferTimeIndexTo {
+			load, loadDeleted = cctransfer.LoadCCToTransfer, cctransfer.LoadDeletedCCToTransfer
+		}
+
+		tr, err := load(stub, id)
+		if errors.Is(err, cctransfer.ErrNotFound) {
+			tr, err = loadDeleted(stub, id)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		trs.Ccts = append(trs.Ccts, tr)
+	}
+
+	if meta != nil {
+		trs.Bookmark = meta.GetBookmark()
+	}
+
+	return trs, nil
+}
+
+// indexTransferTime adds the transfer record to the time index, the record is from the channel From if from is true
+func (bc *BaseContract) indexTransferTime(tr *pb.CCTransfer, from bool) error {
+	side := transferTimeIndexTo
+	if from {
+		side = transferTimeIndexFrom
+	}
+
+	return bc.GetStub().PutState(transferTimeIndexKey(tr.GetTimeAsNanos(), tr.GetId()), []byte(side))
+}
+
+// unindexTransferTime removes the transfer record from the time index
+func (bc *BaseContract) unindexTransferTime(tr *pb.CCTransfer) error {
+	return bc.GetStub().DelState(transferTimeIndexKey(tr.GetTimeAsNanos(), tr.GetId()))
+}
+
+// transferTimeIndexKey returns the time index key of the transfer id or the range bound if id is empty
+func transferTimeIndexKey(timeAsNanos int64, id string) string {
+	key := fmt.Sprintf("%s%0*d", ChannelTransferTimeIndexPrefix, transferTimeDigits, timeAsNanos)
+	if id == "" {
+		return key
+	}
+
+	return key + "/" + id
+}
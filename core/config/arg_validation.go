@@ -0,0 +1,110 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anoideaopen/foundation/proto"
+)
+
+// ArgField binds a field of the config mapped from positional init args to the argument it is taken from.
+// Path is a dot separated path of the config field, e.g. "Token.Issuer.Address".
+type ArgField struct {
+	Path  string
+	Index int
+	Name  string
+}
+
+// Fields of the configs mapped by FromArgs functions.
+var (
+	// ArgsWithAdmin are fields of the config mapped by FromArgsWithAdmin.
+	ArgsWithAdmin = []ArgField{
+		{Path: "Contract.RobotSKI", Index: 1, Name: "robotSKI"},
+		{Path: "Contract.Admin", Index: 2, Name: "adminAddress"},
+		{Path: "Token.Issuer", Index: 2, Name: "adminAddress"},
+	}
+
+	// ArgsWithIssuerAndAdmin are fields of the config mapped by FromArgsWithIssuerAndAdmin.
+	ArgsWithIssuerAndAdmin = []ArgField{
+		{Path: "Contract.RobotSKI", Index: 1, Name: "robotSKI"},
+		{Path: "Token.Issuer", Index: 2, Name: "issuerAddress"},
+		{Path: "Contract.Admin", Index: 3, Name: "adminAddress"},
+	}
+
+	// ArgsWithIssuerFeeSetterAndFeeAddressSetter are fields of the config mapped by
+	// FromArgsWithIssuerFeeSetterAndFeeAddressSetter.
+	ArgsWithIssuerFeeSetterAndFeeAddressSetter = []ArgField{
+		{Path: "Contract.RobotSKI", Index: 1, Name: "robotSKI"},
+		{Path: "Contract.Admin", Index: 2, Name: "issuerAddress"},
+		{Path: "Token.Issuer", Index: 2, Name: "issuerAddress"},
+		{Path: "Token.FeeSetter", Index: 3, Name: "feeSetter"},
+		{Path: "Token.FeeAddressSetter", Index: 4, Name: "feeAddressSetter"},
+	}
+
+	// ArgsWithIssuerAndFeeSetter are fields of the config mapped by FromArgsWithIssuerAndFeeSetter.
+	ArgsWithIssuerAndFeeSetter = []ArgField{
+		{Path: "Contract.RobotSKI", Index: 1, Name: "robotSKI"},
+		{Path: "Contract.Admin", Index: 2, Name: "issuerAddress"},
+		{Path: "Token.Issuer", Index: 2, Name: "issuerAddress"},
+		{Path: "Token.FeeSetter", Index: 3, Name: "feeSetter"},
+	}
+)
+
+// ArgValidationError is returned by ValidateMapped when the config field mapped from an init argument is invalid.
+type ArgValidationError struct {
+	Index int
+	Name  string
+	Path  string
+	Err   error
+}
+
+func (e *ArgValidationError) Error() string {
+	return fmt.Sprintf("init arg %d '%s' (%s): %s", e.Index, e.Name, e.Path, e.Err)
+}
+
+func (e *ArgValidationError) Unwrap() error {
+	return e.Err
+}
+
+// ValidateMapped validates the config mapped from positional init args.
+// If the failed field is mapped from one of the fields, the error is wrapped with ArgValidationError.
+func ValidateMapped(cfg *proto.Config, fields []ArgField) error {
+	err := cfg.Validate()
+	if err == nil {
+		return nil
+	}
+
+	path := validationErrorPath(err)
+	for _, field := range fields {
+		if path == field.Path || strings.HasPrefix(path, field.Path+".") {
+			return &ArgValidationError{Index: field.Index, Name: field.Name, Path: path, Err: err}
+		}
+	}
+
+	return err
+}
+
+// validationErrorPath returns the dot separated path of the field which failed validation.
+func validationErrorPath(err error) string {
+	type fieldError interface {
+		Field() string
+		Cause() error
+	}
+
+	var path []string
+	for {
+		var fe fieldError
+		if !errors.As(err, &fe) {
+			break
+		}
+
+		path = append(path, fe.Field())
+		if fe.Cause() == nil {
+			break
+		}
+		err = fe.Cause()
+	}
+
+	return strings.Join(path, ".")
+}
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/config"
+	"golang.org/x/crypto/sha3"
+	"google.golang.org/protobuf/proto"
+)
+
+// QueryConfigHash returns the hex encoded SHA3-256 hash of the applied config, so operators can check
+// that all peers applied the same config. The hash is computed over the deterministic protobuf
+// encoding of the config, so it does not depend on the JSON formatting or the order of the fields
+// the config is initialized with.
+func (bc *BaseContract) QueryConfigHash() (string, error) {
+	cfgBytes, err := config.Load(bc.GetStub())
+	if err != nil {
+		return "", err
+	}
+
+	cfg, err := config.FromBytes(cfgBytes)
+	if err != nil {
+		return "", fmt.Errorf("parsing config: %w", err)
+	}
+
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(cfg)
+	if err != nil {
+		return "", fmt.Errorf("marshalling config: %w", err)
+	}
+
+	digest := sha3.Sum256(data)
+
+	return hex.EncodeToString(digest[:]), nil
+}
@@ -0,0 +1,112 @@
+package core
+
+import (
+	"errors"
+
+	"github.com/anoideaopen/foundation/core/contract"
+)
+
+const (
+	ConfigSectionContract = "contract"
+	ConfigSectionToken    = "token"
+	ConfigSectionExternal = "external"
+)
+
+// ConfigValidationError is a validation error of the config
+type ConfigValidationError struct {
+	// Section is the config section the error found in: contract, token or external
+	Section string `json:"section"`
+	// Field is the dot separated path of the invalid field, empty if the error is not bound to a field
+	Field string `json:"field,omitempty"`
+	// Reason is the description of the error
+	Reason string `json:"reason"`
+}
+
+// ConfigValidation is the result of the config validation
+type ConfigValidation struct {
+	Valid  bool                    `json:"valid"`
+	Errors []ConfigValidationError `json:"errors,omitempty"`
+}
+
+// QueryValidateConfig validates the JSON config by the validators of the running contract
+// the same way as the config is validated on init, without applying it.
+// Every section of the config is validated, so errors of all sections are returned.
+func (bc *BaseContract) QueryValidateConfig(rawCfg string) (*ConfigValidation, error) {
+	var configurable contract.Base = bc
+	if bc.configurable != nil {
+		configurable = bc.configurable
+	}
+
+	cfg := []byte(rawCfg)
+	result := &ConfigValidation{}
+
+	result.add(ConfigSectionContract, configurable.ValidateConfig(cfg))
+
+	if tokenConfigurator, ok := configurable.(contract.TokenConfigurator); ok {
+		result.add(ConfigSectionToken, tokenConfigurator.ValidateTokenConfig(cfg))
+	}
+
+	if externalConfigurator, ok := configurable.(contract.ExternalConfigurator); ok {
+		result.add(ConfigSectionExternal, externalConfigurator.ValidateExtConfig(cfg))
+	}
+
+	result.Valid = len(result.Errors) == 0
+
+	return result, nil
+}
+
+type multiError interface {
+	AllErrors() []error
+}
+
+type fieldError interface {
+	Field() string
+	Reason() string
+	Cause() error
+}
+
+// add appends the errors of the section flattening multi errors and nested field errors
+func (cv *ConfigValidation) add(section string, err error) {
+	if err == nil {
+		return
+	}
+
+	var multi multiError
+	if errors.As(err, &multi) {
+		for _, e := range multi.AllErrors() {
+			cv.addField(section, "", e)
+		}
+		return
+	}
+
+	cv.addField(section, "", err)
+}
+
+func (cv *ConfigValidation) addField(section string, prefix string, err error) {
+	var field fieldError
+	if !errors.As(err, &field) {
+		cv.Errors = append(cv.Errors, ConfigValidationError{Section: section, Field: prefix, Reason: err.Error()})
+		return
+	}
+
+	path := field.Field()
+	if prefix != "" {
+		path = prefix + "." + path
+	}
+
+	cause := field.Cause()
+	if cause == nil {
+		cv.Errors = append(cv.Errors, ConfigValidationError{Section: section, Field: path, Reason: field.Reason()})
+		return
+	}
+
+	var multi multiError
+	if errors.As(cause, &multi) {
+		for _, e := range multi.AllErrors() {
+			cv.addField(section, path, e)
+		}
+		return
+	}
+
+	cv.addField(section, path, cause)
+}
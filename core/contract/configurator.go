@@ -22,6 +22,33 @@ func (c ConfigMapperFunc) MapConfig(args []string) (*proto.Config, error) {
 	return c(args)
 }
 
+// ValidatingConfigMapper is a ConfigMapper validating the config mapped from Init arguments.
+// Validation failures of the fields mapped from the arguments are wrapped with
+// config.ArgValidationError naming the responsible argument.
+type ValidatingConfigMapper struct {
+	Mapper ConfigMapper
+	Fields []config.ArgField
+}
+
+// NewValidatingConfigMapper creates a ValidatingConfigMapper with fields mapped by mapper.
+func NewValidatingConfigMapper(mapper ConfigMapper, fields ...config.ArgField) *ValidatingConfigMapper {
+	return &ValidatingConfigMapper{Mapper: mapper, Fields: fields}
+}
+
+// MapConfig maps the provided arguments to a proto.Config instance and validates it.
+func (m *ValidatingConfigMapper) MapConfig(args []string) (*proto.Config, error) {
+	cfg, err := m.Mapper.MapConfig(args)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = config.ValidateMapped(cfg, m.Fields); err != nil {
+		return nil, err
+	}
+
+	return cfg, nil
+}
+
 // Configurator defines methods for validating, applying, and retrieving contract configuration.
 type Configurator interface {
 	// ValidateConfig validates the provided contract configuration data.
@@ -20,6 +20,7 @@ type Method struct {
 	MethodName    string     // The actual method name to be invoked.
 	RequiresAuth  bool       // Indicates if the method requires authentication.
 	NumArgs       int        // Number of arguments the method takes (excluding the receiver).
+	AmountArg     int        // Position of the amount argument counting from 1, zero if the method has none.
 }
 
 // Router defines the interface for managing contract methods and routing calls.
@@ -59,6 +59,7 @@ const (
 var (
 	ErrSwapDisabled      = errors.New("swap is disabled")
 	ErrMultiSwapDisabled = errors.New("multi-swap is disabled")
+	ErrArgsSizeExceeded  = errors.New("arguments size exceeds the limit")
 )
 
 const (
@@ -70,8 +71,14 @@ const (
 	CommitCCTransferFrom = "commitCCTransferFrom"
 	CancelCCTransferFrom = "cancelCCTransferFrom"
 	DeleteCCTransferFrom = "deleteCCTransferFrom"
+	PickUpCCTransferFrom = "pickUpCCTransferFrom"
 	CreateIndex          = "createIndex"
 	ExecuteTasks         = "executeTasks"
+	SignedBatch          = "signedBatch"
+	ReapExpiredTransfers = "reapExpiredTransfers"
+	ProposeOperation     = "proposeOperation"
+	SubmitSignature      = "submitSignature"
+	Simulate             = "simulate"
 )
 
 // ChaincodeOption represents a function that applies configuration options to
@@ -97,6 +104,8 @@ type chaincodeOptions struct {
 	TLS          *TLS                  // TLS contains the TLS configuration for the chaincode.
 	ConfigMapper contract.ConfigMapper // ConfigMapper maps the arguments to a proto.Config instance.
 	Router       contract.Router       // Router for routing contract calls.
+	BalanceCodec balance.Codec         // BalanceCodec encodes and decodes balances stored in the ledger.
+	PreConfig    []string              // PreConfig are the functions allowed before the config is applied.
 }
 
 // Chaincode defines the structure for a chaincode instance, with methods,
@@ -104,6 +113,7 @@ type chaincodeOptions struct {
 type Chaincode struct {
 	contract     BaseContractInterface // Contract interface containing the chaincode logic.
 	configMapper contract.ConfigMapper // ConfigMapper maps the arguments to a proto.Config instance.
+	preConfig    []string              // Functions allowed before the config is applied.
 }
 
 // Router returns the contract router for the Chaincode.
@@ -241,6 +251,33 @@ func WithSrcFS(fs *embed.FS) ChaincodeOption {
 	}
 }
 
+// WithBalanceCodec is a ChaincodeOption that specifies the codec of the balances stored in the ledger.
+//
+// codec: A balance.Codec used to encode and decode balances instead of balance.DefaultCodec.
+// To migrate the existing balances use balance.MigrationCodec with balance.DefaultCodec as the Legacy codec.
+//
+// It returns a ChaincodeOption that sets the BalanceCodec field in the chaincodeOptions.
+func WithBalanceCodec(codec balance.Codec) ChaincodeOption {
+	return func(o *chaincodeOptions) error {
+		o.BalanceCodec = codec
+		return nil
+	}
+}
+
+// WithPreConfigFunctions is a ChaincodeOption that specifies the functions allowed to be invoked
+// before the config is applied by Init, replacing DefaultPreConfigFunctions.
+//
+// functions: The chaincode functions, e.g. "healthCheck". The contract is not configured
+// during their invocation, so they must not rely on the config.
+//
+// It returns a ChaincodeOption that sets the PreConfig field in the chaincodeOptions.
+func WithPreConfigFunctions(functions ...string) ChaincodeOption {
+	return func(o *chaincodeOptions) error {
+		o.PreConfig = functions
+		return nil
+	}
+}
+
 // WithTLS is a ChaincodeOption that specifies the TLS configuration for the ChainCode.
 //
 // tls: A pointer to a TLS structure containing the TLS certificates and keys.
@@ -367,7 +404,7 @@ func NewCC(
 	empty := new(Chaincode) // Empty chaincode result fixes integration tests.
 
 	// Apply chaincode options provided by the caller.
-	chOpts := chaincodeOptions{}
+	chOpts := chaincodeOptions{PreConfig: DefaultPreConfigFunctions}
 	for _, option := range chOptions {
 		if option == nil {
 			continue
@@ -381,11 +418,14 @@ func NewCC(
 	// Initialize the contract.
 	cc.setSrcFs(chOpts.SrcFS)
 	cc.setRouter(chOpts.Router)
+	cc.setBalanceCodec(chOpts.BalanceCodec)
+	cc.setConfigurable(cc)
 
 	// Set up the ChainCode structure.
 	out := &Chaincode{
 		contract:     cc,
 		configMapper: chOpts.ConfigMapper,
+		preConfig:    chOpts.PreConfig,
 	}
 
 	return out, nil
@@ -450,7 +490,12 @@ func (cc *Chaincode) Init(stub shim.ChaincodeStubInterface) peer.Response {
 	}
 
 	// Check for duplicate methods.
-	if _, err = reflectx.NewRouter(cc.contract); err != nil {
+	router, err := reflectx.NewRouter(cc.contract)
+	if err != nil {
+		return shim.Error("init: validating contract methods: " + err.Error())
+	}
+
+	if err = checkReservedMethods(cc.contract, router.Methods()); err != nil {
 		return shim.Error("init: validating contract methods: " + err.Error())
 	}
 
@@ -479,7 +524,13 @@ func (cc *Chaincode) Invoke(stub shim.ChaincodeStubInterface) (r peer.Response)
 
 	// getting contract config
 	cfgBytes, err := config.Load(stub)
-	if err != nil {
+	switch {
+	case errors.Is(err, config.ErrCfgBytesEmpty):
+		// the config is not applied by Init yet, the contract stays not configured
+		if err = cc.checkPreConfigFunction(stub); err != nil {
+			return shim.Error("invoke: " + err.Error())
+		}
+	case err != nil:
 		return shim.Error("invoke: loading raw config: " + err.Error())
 	}
 
@@ -566,7 +617,9 @@ func (cc *Chaincode) Invoke(stub shim.ChaincodeStubInterface) (r peer.Response)
 		DeleteCCTransferTo,
 		CommitCCTransferFrom,
 		CancelCCTransferFrom,
-		DeleteCCTransferFrom:
+		DeleteCCTransferFrom,
+		PickUpCCTransferFrom,
+		ReapExpiredTransfers:
 
 		robotSKIBytes, _ := hex.DecodeString(cc.contract.ContractConfig().GetRobotSKI())
 		err = hlfcreator.ValidateSKI(robotSKIBytes, creatorSKI, hashedCert)
@@ -598,6 +651,18 @@ func (cc *Chaincode) Invoke(stub shim.ChaincodeStubInterface) (r peer.Response)
 		}
 
 		return shim.Success(bytes)
+
+	case SignedBatch:
+		return cc.signedBatchHandler(traceCtx, stub, arguments)
+
+	case ProposeOperation:
+		return cc.proposeOperationHandler(stub, arguments)
+
+	case SubmitSignature:
+		return cc.submitSignatureHandler(traceCtx, stub, arguments)
+
+	case Simulate:
+		return cc.simulateHandler(traceCtx, stub, arguments)
 	}
 
 	method, err := cc.Method(functionName)
@@ -607,19 +672,20 @@ func (cc *Chaincode) Invoke(stub shim.ChaincodeStubInterface) (r peer.Response)
 		return shim.Error(errMsg)
 	}
 
-	if cc.contract.ContractConfig().GetOptions() != nil {
-		var (
-			swapMethods      = []string{"QuerySwapGet", "TxSwapBegin", "TxSwapCancel"}
-			multiSwapMethods = []string{"QueryMultiSwapGet", "TxMultiSwapBegin", "TxMultiSwapCancel"}
-			method           = method.MethodName
-			opts             = cc.contract.ContractConfig().GetOptions()
-		)
+	if err = checkArgsSize(arguments, cc.contract.ContractConfig().GetOptions().GetMaxArgsSize()); err != nil {
+		errMsg := "invoke: validating arguments size: " + err.Error()
+		span.SetStatus(codes.Error, errMsg)
+		return shim.Error(errMsg)
+	}
 
-		if stringsx.OneOf(method, opts.GetDisabledFunctions()...) ||
-			(opts.GetDisableSwaps() && stringsx.OneOf(method, swapMethods...)) ||
-			(opts.GetDisableMultiSwaps() && stringsx.OneOf(method, multiSwapMethods...)) {
-			return shim.Error(fmt.Sprintf("invoke: finding method: method '%s' not found", functionName))
-		}
+	if isMethodDisabled(method.MethodName, cc.contract.ContractConfig().GetOptions()) {
+		return shim.Error(fmt.Sprintf("invoke: finding method: method '%s' not found", functionName))
+	}
+
+	if err = checkMaintenanceMode(stub, method, cc.contract.ContractConfig().GetOptions().GetMaintenanceAllowedFunctions()); err != nil {
+		errMsg := "invoke: " + err.Error()
+		span.SetStatus(codes.Error, errMsg)
+		return shim.Error(errMsg)
 	}
 
 	// handle invoke and query methods executed without batch process
@@ -632,6 +698,38 @@ func (cc *Chaincode) Invoke(stub shim.ChaincodeStubInterface) (r peer.Response)
 	return cc.BatchHandler(traceCtx, stub, method, arguments)
 }
 
+// isMethodDisabled reports whether the method is disabled by the chaincode options
+// directly or as a part of disabled swaps or multi-swaps.
+func isMethodDisabled(method string, opts *proto.ChaincodeOptions) bool {
+	var (
+		swapMethods      = []string{"QuerySwapGet", "TxSwapBegin", "TxSwapCancel"}
+		multiSwapMethods = []string{"QueryMultiSwapGet", "TxMultiSwapBegin", "TxMultiSwapCancel"}
+	)
+
+	return stringsx.OneOf(method, opts.GetDisabledFunctions()...) ||
+		(opts.GetDisableSwaps() && stringsx.OneOf(method, swapMethods...)) ||
+		(opts.GetDisableMultiSwaps() && stringsx.OneOf(method, multiSwapMethods...))
+}
+
+// checkArgsSize checks that the total size of arguments does not exceed maxSize bytes.
+// A zero maxSize disables the check.
+func checkArgsSize(args []string, maxSize uint32) error {
+	if maxSize == 0 {
+		return nil
+	}
+
+	var size uint64
+	for _, arg := range args {
+		size += uint64(len(arg))
+	}
+
+	if size > uint64(maxSize) {
+		return fmt.Errorf("%w: %d bytes, max %d bytes", ErrArgsSizeExceeded, size, maxSize)
+	}
+
+	return nil
+}
+
 // ValidateTxID validates the transaction ID to ensure it is correctly formatted.
 //
 // Args:
@@ -711,10 +809,21 @@ func (cc *Chaincode) noBatchHandler(
 
 	if method.Type == contract.MethodTypeQuery {
 		stub = newQueryStub(stub)
+	} else {
+		stub = withEventNamePrefix(stub, cc.contract.ContractConfig().GetOptions().GetEventNamePrefix())
+	}
+
+	if isQueryProtected(method, cc.contract.ContractConfig().GetOptions()) {
+		span.AddEvent("authenticating query")
+		var err error
+		if args, err = cc.authenticateQuery(stub, method, args); err != nil {
+			span.SetStatus(codes.Error, "authenticating query failed")
+			return shim.Error(err.Error())
+		}
 	}
 
 	span.AddEvent("validating sender")
-	sender, args, _, err := cc.validateAndExtractInvocationContext(stub, method, args)
+	sender, args, nonce, err := cc.validateAndExtractInvocationContext(stub, method, args)
 	if err != nil {
 		span.SetStatus(codes.Error, "validating sender failed")
 		return shim.Error(err.Error())
@@ -727,7 +836,7 @@ func (cc *Chaincode) noBatchHandler(
 	}
 
 	span.AddEvent("calling method")
-	resp, err := cc.InvokeContractMethod(traceCtx, stub, method, sender, args)
+	resp, err := cc.invokeContractMethodWithNonce(traceCtx, stub, method, sender, args, nonce)
 	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
 		return shim.Error(err.Error())
@@ -85,3 +85,10 @@ func TestWithTLSFromFiles(t *testing.T) {
 		t.Errorf("WithTLSFromFiles did not set the expected TLS values")
 	}
 }
+
+func TestCheckArgsSize(t *testing.T) {
+	require.NoError(t, checkArgsSize([]string{"1234567890"}, 0))
+	require.NoError(t, checkArgsSize([]string{"12345", "67890"}, 10))
+	require.ErrorIs(t, checkArgsSize([]string{"12345", "678901"}, 10), ErrArgsSizeExceeded)
+	require.ErrorIs(t, checkArgsSize([]string{"12345678901"}, 10), ErrArgsSizeExceeded)
+}
@@ -0,0 +1,45 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/helpers"
+	"github.com/anoideaopen/foundation/core/types"
+)
+
+var ErrAddressDenylisted = errors.New("address is denylisted")
+
+// CheckDenylist queries the ACL channel for the status of the addresses and returns
+// ErrAddressDenylisted if any of them is blacklisted. The check is performed only if
+// check_denylist is enabled in the chaincode options. Statuses are cached within the invocation.
+func (bc *BaseContract) CheckDenylist(addresses ...*types.Address) error {
+	if !bc.config.GetOptions().GetCheckDenylist() {
+		return nil
+	}
+
+	if bc.denylisted == nil {
+		bc.denylisted = make(map[string]bool)
+	}
+
+	for _, address := range addresses {
+		addr := address.String()
+
+		denylisted, ok := bc.denylisted[addr]
+		if !ok {
+			info, err := helpers.GetAccountInfo(bc.GetStub(), addr)
+			if err != nil {
+				return fmt.Errorf("getting account info of %s: %w", addr, err)
+			}
+
+			denylisted = info.GetBlackListed()
+			bc.denylisted[addr] = denylisted
+		}
+
+		if denylisted {
+			return fmt.Errorf("%w: %s", ErrAddressDenylisted, addr)
+		}
+	}
+
+	return nil
+}
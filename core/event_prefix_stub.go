@@ -0,0 +1,42 @@
+package core
+
+import (
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+)
+
+// eventPrefixStub prepends the configured prefix to names of events set by contract methods
+type eventPrefixStub struct {
+	shim.ChaincodeStubInterface
+	prefix string
+}
+
+// withEventNamePrefix returns stub setting events with names prefixed with prefix,
+// or stub itself if prefix is empty
+func withEventNamePrefix(stub shim.ChaincodeStubInterface, prefix string) shim.ChaincodeStubInterface {
+	if prefix == "" {
+		return stub
+	}
+
+	return &eventPrefixStub{
+		ChaincodeStubInterface: stub,
+		prefix:                 prefix,
+	}
+}
+
+func (es *eventPrefixStub) SetEvent(name string, payload []byte) error {
+	return es.ChaincodeStubInterface.SetEvent(es.prefix+name, payload)
+}
+
+// prefixEventNames prepends prefix to names of events of the batched transaction
+func prefixEventNames(events []*proto.Event, prefix string) []*proto.Event {
+	if prefix == "" {
+		return events
+	}
+
+	for _, event := range events {
+		event.Name = prefix + event.GetName()
+	}
+
+	return events
+}
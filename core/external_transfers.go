@@ -7,6 +7,7 @@ import (
 
 	"github.com/anoideaopen/foundation/core/balance"
 	"github.com/anoideaopen/foundation/core/types"
+	corebig "github.com/anoideaopen/foundation/core/types/big"
 	"github.com/anoideaopen/foundation/proto"
 )
 
@@ -21,6 +22,10 @@ func (bc *BaseContract) TxTransferBalance(
 	sender *types.Sender,
 	req *proto.TransferRequest,
 ) error {
+	if err := bc.CheckPaused(); err != nil {
+		return err
+	}
+
 	if !bc.config.IsAdminSet() {
 		return ErrAdminNotSet
 	}
@@ -60,13 +65,28 @@ func (bc *BaseContract) TxTransferBalance(
 		return ErrAmountMustBeGreaterThanZero
 	}
 
-	return balance.Move(
-		bc.GetStub(),
+	if !bc.IsTrustedAddress(fromAddress) {
+		if err = bc.CheckDenylist(fromAddress, toAddress); err != nil {
+			return err
+		}
+	}
+
+	if err = balance.Move(
+		bc.BalanceStub(),
 		balance.BalanceType(req.GetBalanceType()),
 		fromAddress.String(),
 		balance.BalanceType(req.GetBalanceType()),
 		toAddress.String(),
 		req.GetToken(),
 		amount,
-	)
+	); err != nil {
+		return err
+	}
+
+	token := req.GetToken()
+	if token == "" {
+		token = bc.config.GetSymbol()
+	}
+
+	return bc.WalletAuditTransfer(fromAddress, toAddress, token, new(corebig.Int).SetBytes(amount.Bytes()))
 }
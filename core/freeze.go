@@ -0,0 +1,171 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/anoideaopen/foundation/core/types"
+)
+
+// FrozenAddressCompositeType is a composite key prefix for the index of frozen addresses
+const FrozenAddressCompositeType = "frozen"
+
+var (
+	ErrAddressFrozen         = errors.New("address is frozen")
+	ErrInvalidFrozenPageSize = errors.New("page size must be positive")
+	ErrAddressAlreadyFrozen  = errors.New("address is already frozen")
+	ErrAddressNotFrozen      = errors.New("address is not frozen")
+)
+
+// FrozenAddresses is a page of frozen addresses
+type FrozenAddresses struct {
+	Addresses       []string `json:"addresses"`
+	Bookmark        string   `json:"bookmark,omitempty"`
+	PageSizeClamped bool     `json:"pageSizeClamped,omitempty"`
+}
+
+// TxFreezeAddress freezes address, tokens of the frozen address can not be transferred.
+// Method can be called by the contract admin only.
+func (bc *BaseContract) TxFreezeAddress(sender *types.Sender, address *types.Address) error {
+	if err := bc.CheckAdminSender(sender); err != nil {
+		return err
+	}
+
+	frozen, err := bc.isFrozen(address)
+	if err != nil {
+		return err
+	}
+
+	if frozen {
+		return fmt.Errorf("%w: %s", ErrAddressAlreadyFrozen, address)
+	}
+
+	key, err := bc.GetStub().CreateCompositeKey(FrozenAddressCompositeType, []string{address.String()})
+	if err != nil {
+		return err
+	}
+
+	return bc.GetStub().PutState(key, []byte{1})
+}
+
+// TxUnfreezeAddress unfreezes address and removes it from the list of frozen addresses.
+// Method can be called by the contract admin only.
+func (bc *BaseContract) TxUnfreezeAddress(sender *types.Sender, address *types.Address) error {
+	if err := bc.CheckAdminSender(sender); err != nil {
+		return err
+	}
+
+	frozen, err := bc.isFrozen(address)
+	if err != nil {
+		return err
+	}
+
+	if !frozen {
+		return fmt.Errorf("%w: %s", ErrAddressNotFrozen, address)
+	}
+
+	key, err := bc.GetStub().CreateCompositeKey(FrozenAddressCompositeType, []string{address.String()})
+	if err != nil {
+		return err
+	}
+
+	return bc.GetStub().DelState(key)
+}
+
+// QueryFrozenAddresses returns a page of frozen addresses.
+// Pass the returned bookmark to get the next page, an empty bookmark means that all addresses are listed.
+func (bc *BaseContract) QueryFrozenAddresses(pageSize int64, bookmark string) (*FrozenAddresses, error) {
+	if pageSize <= 0 || pageSize > math.MaxInt32 {
+		return nil, ErrInvalidFrozenPageSize
+	}
+
+	pageSize, clamped := bc.ClampPageSize(pageSize)
+
+	stub := bc.GetStub()
+
+	iter, meta, err := stub.GetStateByPartialCompositeKeyWithPagination(
+		FrozenAddressCompositeType,
+		[]string{},
+		int32(pageSize),
+		bookmark,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	result := &FrozenAddresses{Addresses: []string{}, PageSizeClamped: clamped}
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		_, components, err := stub.SplitCompositeKey(kv.GetKey())
+		if err != nil {
+			return nil, err
+		}
+
+		if len(components) == 0 {
+			continue
+		}
+
+		result.Addresses = append(result.Addresses, components[0])
+	}
+
+	result.Bookmark = meta.GetBookmark()
+
+	return result, nil
+}
+
+// CheckFrozen returns ErrAddressFrozen if any of addresses is frozen
+func (bc *BaseContract) CheckFrozen(addresses ...*types.Address) error {
+	for _, address := range addresses {
+		frozen, err := bc.isFrozen(address)
+		if err != nil {
+			return err
+		}
+
+		if frozen {
+			return fmt.Errorf("%w: %s", ErrAddressFrozen, address)
+		}
+	}
+
+	return nil
+}
+
+func (bc *BaseContract) isFrozen(address *types.Address) (bool, error) {
+	key, err := bc.GetStub().CreateCompositeKey(FrozenAddressCompositeType, []string{address.String()})
+	if err != nil {
+		return false, err
+	}
+
+	data, err := bc.GetStub().GetState(key)
+	if err != nil {
+		return false, err
+	}
+
+	return len(data) != 0, nil
+}
+
+// CheckAdminSender returns ErrUnauthorisedNotAdmin if the sender is not the contract admin
+// and ErrAdminNotSet if the admin is not set in the contract config
+func (bc *BaseContract) CheckAdminSender(sender *types.Sender) error {
+	if !bc.config.IsAdminSet() {
+		return ErrAdminNotSet
+	}
+
+	admin, err := types.AddrFromBase58Check(bc.config.GetAdmin().GetAddress())
+	if err != nil {
+		return fmt.Errorf("creating admin address: %w", err)
+	}
+
+	if !sender.Equal(admin) {
+		return ErrUnauthorisedNotAdmin
+	}
+
+	return nil
+}
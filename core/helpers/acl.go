@@ -13,6 +13,13 @@ import (
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 )
 
+// ACLChannel is the name of the channel of the ACL chaincode,
+// ACLChaincode is the name of the ACL chaincode
+const (
+	ACLChannel   = "acl"
+	ACLChaincode = "acl"
+)
+
 const (
 	// accInfoPrefix         = "accountinfo"
 	replaceTxChangePrefix = "replacetx"
@@ -129,10 +136,10 @@ func CheckACL(stub shim.ChaincodeStubInterface, keys []string) (*pb.AclResponse,
 
 // GetAddress returns pb.AclResponse from the ACL
 func GetAddress(stub shim.ChaincodeStubInterface, keys string) (*pb.AclResponse, error) {
-	resp := stub.InvokeChaincode("acl", [][]byte{
+	resp := stub.InvokeChaincode(ACLChaincode, [][]byte{
 		[]byte("checkKeys"),
 		[]byte(keys),
-	}, "acl")
+	}, ACLChannel)
 
 	if resp.GetStatus() != http.StatusOK {
 		return nil, errors.New(resp.GetMessage())
@@ -152,10 +159,10 @@ func GetAddress(stub shim.ChaincodeStubInterface, keys string) (*pb.AclResponse,
 
 // GetFullAddress returns pb.Address from the ACL
 func GetFullAddress(stub shim.ChaincodeStubInterface, key string) (*pb.Address, error) {
-	resp := stub.InvokeChaincode("acl", [][]byte{
+	resp := stub.InvokeChaincode(ACLChaincode, [][]byte{
 		[]byte("checkAddress"),
 		[]byte(key),
-	}, "acl")
+	}, ACLChannel)
 
 	if resp.GetStatus() != http.StatusOK {
 		return nil, errors.New(resp.GetMessage())
@@ -175,10 +182,10 @@ func GetFullAddress(stub shim.ChaincodeStubInterface, key string) (*pb.Address,
 
 // GetAccountInfo returns pb.AccountInfo from the ACL
 func GetAccountInfo(stub shim.ChaincodeStubInterface, addr string) (*pb.AccountInfo, error) {
-	resp := stub.InvokeChaincode("acl", [][]byte{
+	resp := stub.InvokeChaincode(ACLChaincode, [][]byte{
 		[]byte("getAccountInfo"),
 		[]byte(addr),
-	}, "acl")
+	}, ACLChannel)
 
 	if resp.GetStatus() != http.StatusOK {
 		return nil, fmt.Errorf(
@@ -0,0 +1,249 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
+	"github.com/anoideaopen/foundation/proto"
+	pb "github.com/golang/protobuf/proto" //nolint:staticcheck
+	"golang.org/x/crypto/sha3"
+)
+
+// HTLCCompositeType is a composite key prefix for hash time locked transfers
+const HTLCCompositeType = "htlc"
+
+var (
+	ErrHTLCNotFound        = errors.New("htlc not found")
+	ErrHTLCWrongPreimage   = errors.New("preimage does not match hashlock")
+	ErrHTLCExpired         = errors.New("htlc timeout has expired")
+	ErrHTLCNotExpired      = errors.New("htlc timeout has not expired yet")
+	ErrHTLCInvalidHashlock = errors.New("hashlock must be a SHA3-256 hash")
+	ErrHTLCInvalidTimeout  = errors.New("timeout must be positive")
+	ErrHTLCNotRecipient    = errors.New("sender is not a recipient of htlc")
+	ErrHTLCNotSender       = errors.New("sender is not an owner of htlc")
+	ErrHTLCExists          = errors.New("htlc already exists")
+)
+
+// QueryLockedHTLC returns hash time locked transfer by id
+func (bc *BaseContract) QueryLockedHTLC(id string) (*proto.HTLC, error) {
+	return bc.loadHTLC(id)
+}
+
+// TxLockHTLC locks amount of tokens on the sender balance for recipient to.
+// Tokens can be claimed by recipient with TxClaimHTLC, presenting the preimage of hashlock
+// (SHA3-256) within timeout seconds, or refunded to the sender with TxRefundHTLC after timeout.
+// Returns id of the created HTLC: the transaction id, followed by the sequence number of the lock
+// if the transaction locks several HTLCs, e.g. the signed batch.
+func (bc *BaseContract) TxLockHTLC(
+	sender *types.Sender,
+	to *types.Address,
+	amount *big.Int,
+	hashlock types.Hex,
+	timeout int64,
+) (string, error) {
+	if err := bc.CheckPaused(); err != nil {
+		return "", err
+	}
+
+	if sender.Equal(to) {
+		return "", ErrSameAddresses
+	}
+
+	if amount.Sign() <= 0 {
+		return "", ErrAmountMustBeGreaterThanZero
+	}
+
+	if len(hashlock) != sha3.New256().Size() {
+		return "", ErrHTLCInvalidHashlock
+	}
+
+	if timeout <= 0 {
+		return "", ErrHTLCInvalidTimeout
+	}
+
+	ts, err := bc.GetStub().GetTxTimestamp()
+	if err != nil {
+		return "", err
+	}
+
+	id, err := bc.newHTLCID()
+	if err != nil {
+		return "", err
+	}
+
+	htlc := &proto.HTLC{
+		Id:        id,
+		Sender:    sender.Address().Bytes(),
+		Recipient: to.Bytes(),
+		Amount:    amount.Bytes(),
+		Hashlock:  hashlock,
+		Timeout:   ts.GetSeconds() + timeout,
+	}
+
+	if err = bc.TokenBalanceLock(sender.Address(), amount); err != nil {
+		return "", err
+	}
+
+	if err = bc.saveHTLC(htlc); err != nil {
+		return "", err
+	}
+
+	return htlc.GetId(), nil
+}
+
+// TxClaimHTLC transfers locked tokens to the recipient of HTLC
+// if the preimage hashes to the hashlock and the timeout has not expired.
+// The claim is recorded to the wallet audit as the transfer, the lock and the refund are not.
+func (bc *BaseContract) TxClaimHTLC(sender *types.Sender, id string, preimage string) error {
+	if err := bc.CheckPaused(); err != nil {
+		return err
+	}
+
+	htlc, err := bc.loadHTLC(id)
+	if err != nil {
+		return err
+	}
+
+	if !sender.Equal(types.AddrFromBytes(htlc.GetRecipient())) {
+		return ErrHTLCNotRecipient
+	}
+
+	hash := sha3.Sum256([]byte(preimage))
+	if !bytes.Equal(htlc.GetHashlock(), hash[:]) {
+		return ErrHTLCWrongPreimage
+	}
+
+	ts, err := bc.GetStub().GetTxTimestamp()
+	if err != nil {
+		return err
+	}
+
+	if ts.GetSeconds() >= htlc.GetTimeout() {
+		return ErrHTLCExpired
+	}
+
+	htlcSender := types.AddrFromBytes(htlc.GetSender())
+	if !bc.IsTrustedAddress(htlcSender) {
+		if err = bc.CheckDenylist(htlcSender); err != nil {
+			return err
+		}
+	}
+
+	amount := new(big.Int).SetBytes(htlc.GetAmount())
+	if err = bc.TokenBalanceTransferLocked(
+		htlcSender,
+		types.AddrFromBytes(htlc.GetRecipient()),
+		amount,
+		"htlc claim",
+	); err != nil {
+		return err
+	}
+
+	if err = bc.WalletAuditTransfer(htlcSender, types.AddrFromBytes(htlc.GetRecipient()), bc.config.GetSymbol(), amount); err != nil {
+		return err
+	}
+
+	return bc.deleteHTLC(id)
+}
+
+// TxRefundHTLC returns locked tokens to the sender of HTLC after the timeout has expired
+func (bc *BaseContract) TxRefundHTLC(sender *types.Sender, id string) error {
+	htlc, err := bc.loadHTLC(id)
+	if err != nil {
+		return err
+	}
+
+	if !sender.Equal(types.AddrFromBytes(htlc.GetSender())) {
+		return ErrHTLCNotSender
+	}
+
+	ts, err := bc.GetStub().GetTxTimestamp()
+	if err != nil {
+		return err
+	}
+
+	if ts.GetSeconds() < htlc.GetTimeout() {
+		return ErrHTLCNotExpired
+	}
+
+	if err = bc.TokenBalanceUnlock(sender.Address(), new(big.Int).SetBytes(htlc.GetAmount())); err != nil {
+		return err
+	}
+
+	return bc.deleteHTLC(id)
+}
+
+func (bc *BaseContract) loadHTLC(id string) (*proto.HTLC, error) {
+	key, err := bc.GetStub().CreateCompositeKey(HTLCCompositeType, []string{id})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := bc.GetStub().GetState(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, ErrHTLCNotFound
+	}
+
+	htlc := &proto.HTLC{}
+	if err = pb.Unmarshal(data, htlc); err != nil {
+		return nil, err
+	}
+
+	return htlc, nil
+}
+
+// newHTLCID returns the first id of the transaction not used by the HTLCs locked before in it
+func (bc *BaseContract) newHTLCID() (string, error) {
+	txID := bc.GetStub().GetTxID()
+	for seq := 0; ; seq++ {
+		id := txID
+		if seq != 0 {
+			id = fmt.Sprintf("%s.%d", txID, seq)
+		}
+
+		if _, err := bc.loadHTLC(id); errors.Is(err, ErrHTLCNotFound) {
+			return id, nil
+		} else if err != nil {
+			return "", err
+		}
+	}
+}
+
+// saveHTLC stores the new HTLC, the existing one is never overwritten
+func (bc *BaseContract) saveHTLC(htlc *proto.HTLC) error {
+	key, err := bc.GetStub().CreateCompositeKey(HTLCCompositeType, []string{htlc.GetId()})
+	if err != nil {
+		return err
+	}
+
+	existing, err := bc.GetStub().GetState(key)
+	if err != nil {
+		return err
+	}
+	if len(existing) != 0 {
+		return fmt.Errorf("%w: %s", ErrHTLCExists, htlc.GetId())
+	}
+
+	data, err := pb.Marshal(htlc)
+	if err != nil {
+		return err
+	}
+
+	return bc.GetStub().PutState(key, data)
+}
+
+func (bc *BaseContract) deleteHTLC(id string) error {
+	key, err := bc.GetStub().CreateCompositeKey(HTLCCompositeType, []string{id})
+	if err != nil {
+		return err
+	}
+
+	return bc.GetStub().DelState(key)
+}
@@ -3,6 +3,7 @@ package core
 import (
 	"github.com/anoideaopen/foundation/core/contract"
 	"github.com/anoideaopen/foundation/core/telemetry"
+	"github.com/anoideaopen/foundation/core/types"
 	"github.com/anoideaopen/foundation/proto"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"go.opentelemetry.io/otel/codes"
@@ -24,7 +25,7 @@ import (
 // The function performs the following steps:
 //  1. Initializes a new span for tracing.
 //  2. Adds the sender's address to the arguments if provided.
-//  3. Sets trace attributes for the arguments.
+//  3. Sets trace attributes for the method, the sender and the amount if the method takes one.
 //  4. Checks the number of arguments, ensuring it matches the expected count.
 //  5. Applies the configuration data to the contract.
 //  6. Calls the contract method via the router.
@@ -37,13 +38,24 @@ func (cc *Chaincode) InvokeContractMethod(
 	sender *proto.Address,
 	args []string,
 ) ([]byte, error) {
-	_, span := cc.contract.TracingHandler().StartNewSpan(traceCtx, "chaincode.CallMethod")
+	traceCtx, span := cc.contract.TracingHandler().StartNewSpan(traceCtx, "chaincode.CallMethod")
 	defer span.End()
 
 	cc.contract.SetStub(stub)
+	cc.contract.setTraceContext(traceCtx)
+
+	args = cc.PrependSender(method, sender, args)
+
+	cc.contract.TracingHandler().SetAttributes(traceCtx, telemetry.Method(method.ChaincodeFunc))
+	if sender != nil {
+		cc.contract.TracingHandler().SetAttributes(traceCtx, telemetry.Sender((*types.Address)(sender).String()))
+	}
+	if method.AmountArg > 0 && method.AmountArg <= len(args) {
+		cc.contract.TracingHandler().SetAttributes(traceCtx, telemetry.Amount(args[method.AmountArg-1]))
+	}
 
 	span.AddEvent("call")
-	result, err := cc.Router().Invoke(method.MethodName, cc.PrependSender(method, sender, args)...)
+	result, err := cc.Router().Invoke(method.MethodName, args...)
 	if err != nil {
 		span.SetStatus(codes.Error, err.Error())
 		return nil, err
@@ -52,3 +64,19 @@ func (cc *Chaincode) InvokeContractMethod(
 	span.SetStatus(codes.Ok, "")
 	return result, nil
 }
+
+// invokeContractMethodWithNonce calls InvokeContractMethod with the nonce of the sender's call
+// known to the contract during the call, so the method may rely on it (e.g. to derive the channel transfer id)
+func (cc *Chaincode) invokeContractMethodWithNonce(
+	traceCtx telemetry.TraceContext,
+	stub shim.ChaincodeStubInterface,
+	method contract.Method,
+	sender *proto.Address,
+	args []string,
+	nonce uint64,
+) ([]byte, error) {
+	cc.contract.setTxNonce(nonce)
+	defer cc.contract.setTxNonce(0)
+
+	return cc.InvokeContractMethod(traceCtx, stub, method, sender, args)
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	pb "github.com/anoideaopen/foundation/proto"
+)
+
+var ErrKeyTypeNotAccepted = errors.New("key type is not accepted by the contract")
+
+// Capabilities describes features of the contract clients can adapt to.
+type Capabilities struct {
+	// KeyTypes are the names of the signature key types accepted by the contract.
+	KeyTypes []string `json:"keyTypes"`
+}
+
+// QueryCapabilities returns the capabilities of the contract,
+// clients use it to choose the signature key type supported by the contract.
+func (bc *BaseContract) QueryCapabilities() (*Capabilities, error) {
+	keyTypes := acceptedKeyTypes(bc.config.GetOptions())
+
+	result := &Capabilities{KeyTypes: make([]string, 0, len(keyTypes))}
+	for _, keyType := range keyTypes {
+		result.KeyTypes = append(result.KeyTypes, keyType.String())
+	}
+
+	return result, nil
+}
+
+// acceptedKeyTypes returns key types accepted by the contract, all key types if the option is not set.
+func acceptedKeyTypes(opts *pb.ChaincodeOptions) []pb.KeyType {
+	if len(opts.GetAcceptedKeyTypes()) != 0 {
+		return opts.GetAcceptedKeyTypes()
+	}
+
+	keyTypes := make([]pb.KeyType, 0, len(pb.KeyType_name))
+	for i := 0; i < len(pb.KeyType_name); i++ {
+		keyTypes = append(keyTypes, pb.KeyType(i))
+	}
+
+	return keyTypes
+}
+
+// checkKeyTypesAccepted returns ErrKeyTypeNotAccepted if any of signer key types is not accepted by the contract.
+func checkKeyTypesAccepted(keyTypes []pb.KeyType, opts *pb.ChaincodeOptions) error {
+	if len(opts.GetAcceptedKeyTypes()) == 0 {
+		return nil
+	}
+
+	for _, keyType := range keyTypes {
+		accepted := false
+		for _, acceptedType := range opts.GetAcceptedKeyTypes() {
+			if keyType == acceptedType {
+				accepted = true
+				break
+			}
+		}
+
+		if !accepted {
+			return fmt.Errorf("%w: %s", ErrKeyTypeNotAccepted, keyType)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/anoideaopen/foundation/core/contract"
+	"github.com/anoideaopen/foundation/core/stringsx"
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+)
+
+// MaintenanceModeKey is a state key of the maintenance mode flag
+const MaintenanceModeKey = "__maintenance"
+
+// setMaintenanceModeMethod is always allowed in maintenance mode to be able to turn it off
+const setMaintenanceModeMethod = "TxSetMaintenanceMode"
+
+var ErrMaintenanceMode = errors.New("contract is in maintenance mode")
+
+// QueryMaintenanceMode returns true if the contract is in maintenance mode
+func (bc *BaseContract) QueryMaintenanceMode() (bool, error) {
+	return isMaintenanceMode(bc.GetStub())
+}
+
+// TxSetMaintenanceMode turns maintenance mode on or off. In maintenance mode all transaction
+// methods are rejected except ones listed in maintenance_allowed_functions option, queries remain available.
+// Method can be called by the contract admin only.
+func (bc *BaseContract) TxSetMaintenanceMode(sender *types.Sender, enabled bool) error {
+	if err := bc.CheckAdminSender(sender); err != nil {
+		return err
+	}
+
+	if !enabled {
+		return bc.GetStub().DelState(MaintenanceModeKey)
+	}
+
+	return bc.GetStub().PutState(MaintenanceModeKey, []byte(strconv.FormatBool(enabled)))
+}
+
+func isMaintenanceMode(stub shim.ChaincodeStubInterface) (bool, error) {
+	data, err := stub.GetState(MaintenanceModeKey)
+	if err != nil {
+		return false, err
+	}
+
+	return len(data) != 0, nil
+}
+
+// checkMaintenanceMode returns ErrMaintenanceMode if the contract is in maintenance mode
+// and the method is a transaction that is not allowed in this mode.
+func checkMaintenanceMode(stub shim.ChaincodeStubInterface, method contract.Method, allowed []string) error {
+	if method.Type == contract.MethodTypeQuery ||
+		method.MethodName == setMaintenanceModeMethod ||
+		stringsx.OneOf(method.MethodName, allowed...) {
+		return nil
+	}
+
+	maintenance, err := isMaintenanceMode(stub)
+	if err != nil {
+		return err
+	}
+
+	if maintenance {
+		return fmt.Errorf("%w: method '%s' is not allowed", ErrMaintenanceMode, method.MethodName)
+	}
+
+	return nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/anoideaopen/foundation/core/multiswap"
 	"github.com/anoideaopen/foundation/core/types"
 	"github.com/anoideaopen/foundation/core/types/big"
+	"github.com/anoideaopen/foundation/core/walletaudit"
 	"github.com/anoideaopen/foundation/proto"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"github.com/hyperledger/fabric-protos-go/peer"
@@ -46,6 +47,14 @@ func (bc *BaseContract) QueryMultiSwapGet(swapID string) (*proto.MultiSwap, erro
 
 // TxMultiSwapBegin - creates multiswap
 func (bc *BaseContract) TxMultiSwapBegin(sender *types.Sender, token string, multiSwapAssets types.MultiSwapAssets, contractTo string, hash types.Hex) (string, error) {
+	if err := bc.CheckPaused(); err != nil {
+		return "", err
+	}
+
+	if err := bc.CheckDenylist(sender.Address()); err != nil {
+		return "", err
+	}
+
 	id, err := hex.DecodeString(bc.GetStub().GetTxID())
 	if err != nil {
 		return "", err
@@ -67,7 +76,7 @@ func (bc *BaseContract) TxMultiSwapBegin(sender *types.Sender, token string, mul
 		Creator: sender.Address().Bytes(),
 		Owner:   sender.Address().Bytes(),
 		Assets:  assets,
-		Token:   token,
+		Token:   bc.ResolveToken(token),
 		From:    bc.config.GetSymbol(),
 		To:      contractTo,
 		Hash:    hash,
@@ -89,6 +98,10 @@ func (bc *BaseContract) TxMultiSwapBegin(sender *types.Sender, token string, mul
 		return "", errors.New(multiswap.ErrIncorrectMultiSwap)
 	}
 
+	if err = bc.walletAuditAssets(types.AddrFromBytes(swap.GetOwner()), walletaudit.SwapOut, swap.GetAssets(), swap.GetTo()); err != nil {
+		return "", err
+	}
+
 	if err = multiswap.Save(bc.GetStub(), bc.GetStub().GetTxID(), &swap); err != nil {
 		return "", err
 	}
@@ -125,13 +138,19 @@ func (bc *BaseContract) TxMultiSwapCancel(sender *types.Sender, swapID string) e
 				return err
 			}
 		}
+		if err = bc.walletAuditAssets(types.AddrFromBytes(swap.GetOwner()), walletaudit.SwapCancel, swap.GetAssets(), swap.GetTo()); err != nil {
+			return err
+		}
 	case bytes.Equal(swap.GetCreator(), swap.GetOwner()) && swap.GetToken() == swap.GetTo():
 		if err = bc.AllowedIndustrialBalanceAdd(types.AddrFromBytes(swap.GetOwner()), swap.GetAssets(), "reverse multi-swap cancel"); err != nil {
 			return err
 		}
+		if err = bc.walletAuditAssets(types.AddrFromBytes(swap.GetOwner()), walletaudit.SwapCancel, swap.GetAssets(), swap.GetTo()); err != nil {
+			return err
+		}
 	case bytes.Equal(swap.GetCreator(), []byte("0000")) && swap.GetToken() == swap.GetTo():
 		for _, asset := range swap.GetAssets() {
-			if err = balance.Add(bc.GetStub(), balance.BalanceTypeGiven, strings.ToUpper(swap.GetFrom()), "", new(mathbig.Int).SetBytes(asset.GetAmount())); err != nil {
+			if err = balance.Add(bc.BalanceStub(), balance.BalanceTypeGiven, strings.ToUpper(swap.GetFrom()), "", new(mathbig.Int).SetBytes(asset.GetAmount())); err != nil {
 				return err
 			}
 		}
@@ -13,6 +13,7 @@ import (
 	"github.com/anoideaopen/foundation/core/cachestub"
 	"github.com/anoideaopen/foundation/core/types"
 	"github.com/anoideaopen/foundation/core/types/big"
+	"github.com/anoideaopen/foundation/core/walletaudit"
 	"github.com/anoideaopen/foundation/proto"
 	pb "github.com/golang/protobuf/proto" //nolint:staticcheck
 	"github.com/hyperledger/fabric-chaincode-go/shim"
@@ -37,9 +38,11 @@ type BaseContractInterface interface {
 	GetStub() shim.ChaincodeStubInterface
 	TokenBalanceAddWithTicker(address *types.Address, amount *big.Int, ticker string, reason string) error
 	AllowedIndustrialBalanceAdd(address *types.Address, industrialAssets []*proto.Asset, reason string) error
+	CheckDenylist(addresses ...*types.Address) error
+	WalletAuditAdd(address *types.Address, eventType string, token string, amount *big.Int, counterparty string) error
 }
 
-func Answer(stub *cachestub.BatchCacheStub, swap *proto.MultiSwap, robotSideTimeout int64) (r *proto.SwapResponse) {
+func Answer(stub *cachestub.BatchCacheStub, swap *proto.MultiSwap, robotSideTimeout int64, codec balance.Codec) (r *proto.SwapResponse) {
 	r = &proto.SwapResponse{Id: swap.GetId(), Error: &proto.ResponseError{Error: "panic multiSwapAnswer"}}
 	defer func() {
 		if rc := recover(); rc != nil {
@@ -61,7 +64,7 @@ func Answer(stub *cachestub.BatchCacheStub, swap *proto.MultiSwap, robotSideTime
 		// nothing to do
 	case swap.GetToken() == swap.GetTo():
 		for _, asset := range swap.GetAssets() {
-			if err = balance.Sub(txStub, balance.BalanceTypeGiven, strings.ToUpper(swap.GetFrom()), "", new(mathbig.Int).SetBytes(asset.GetAmount())); err != nil {
+			if err = balance.Sub(balance.WithCodec(txStub, codec), balance.BalanceTypeGiven, strings.ToUpper(swap.GetFrom()), "", new(mathbig.Int).SetBytes(asset.GetAmount())); err != nil {
 				return &proto.SwapResponse{Id: swap.GetId(), Error: &proto.ResponseError{Error: err.Error()}}
 			}
 		}
@@ -76,7 +79,7 @@ func Answer(stub *cachestub.BatchCacheStub, swap *proto.MultiSwap, robotSideTime
 	return &proto.SwapResponse{Id: swap.GetId(), Writes: writes}
 }
 
-func RobotDone(stub *cachestub.BatchCacheStub, swapID []byte, key string) (r *proto.SwapResponse) {
+func RobotDone(stub *cachestub.BatchCacheStub, swapID []byte, key string, codec balance.Codec) (r *proto.SwapResponse) {
 	r = &proto.SwapResponse{Id: swapID, Error: &proto.ResponseError{Error: "panic multiSwapRobotDone"}}
 	defer func() {
 		if rc := recover(); rc != nil {
@@ -96,7 +99,7 @@ func RobotDone(stub *cachestub.BatchCacheStub, swapID []byte, key string) (r *pr
 
 	if swap.GetToken() == swap.GetFrom() {
 		for _, asset := range swap.GetAssets() {
-			if err = balance.Add(txStub, balance.BalanceTypeGiven, strings.ToUpper(swap.GetTo()), "", new(mathbig.Int).SetBytes(asset.GetAmount())); err != nil {
+			if err = balance.Add(balance.WithCodec(txStub, codec), balance.BalanceTypeGiven, strings.ToUpper(swap.GetTo()), "", new(mathbig.Int).SetBytes(asset.GetAmount())); err != nil {
 				return &proto.SwapResponse{Id: swapID, Error: &proto.ResponseError{Error: err.Error()}}
 			}
 		}
@@ -122,6 +125,10 @@ func UserDone(bc BaseContractInterface, swapID string, key string) peer.Response
 	if bytes.Equal(swap.GetCreator(), swap.GetOwner()) {
 		return shim.Error(ErrIncorrectMultiSwap)
 	}
+
+	if err = bc.CheckDenylist(types.AddrFromBytes(swap.GetOwner())); err != nil {
+		return shim.Error(err.Error())
+	}
 	if swap.GetToken() == swap.GetFrom() {
 		if err = bc.AllowedIndustrialBalanceAdd(types.AddrFromBytes(swap.GetOwner()), swap.GetAssets(), "multi-swap done"); err != nil {
 			return shim.Error(err.Error())
@@ -134,6 +141,12 @@ func UserDone(bc BaseContractInterface, swapID string, key string) peer.Response
 		}
 	}
 
+	for _, asset := range swap.GetAssets() {
+		if err = bc.WalletAuditAdd(types.AddrFromBytes(swap.GetOwner()), walletaudit.SwapIn, asset.GetGroup(), new(big.Int).SetBytes(asset.GetAmount()), swap.GetFrom()); err != nil {
+			return shim.Error(err.Error())
+		}
+	}
+
 	if err = Delete(bc.GetStub(), swapID); err != nil {
 		return shim.Error(err.Error())
 	}
@@ -18,6 +18,10 @@ import (
 
 const StateKeyNonce byte = 42 // hex: 2a
 
+// NonceJournalCompositeType is a composite key prefix for the journal of the recent nonces of the sender
+// kept apart from the nonce list if nonce_prune_threshold of the chaincode options is set
+const NonceJournalCompositeType = "nonce_journal"
+
 const (
 	doublingMemoryCoef    = 2
 	LenTimeInMilliseconds = 13
@@ -25,51 +29,275 @@ const (
 	// that is older than the maximum nonce (at the current moment) by more than NonceTTL,
 	// we will not execute it and return an error.
 	defaultNonceTTL = 50
+	// maxNonceSubMillisecondDigits is the maximum number of digits below milliseconds (microseconds)
+	maxNonceSubMillisecondDigits = 3
 )
 
+// checkNonce checks the nonce of the sender's operation and stores it to the sender's nonce list.
+// The nonce is the time of the operation in milliseconds, so two distinct operations of the sender
+// made in the same millisecond carry the same nonce and the second one is rejected as a repeat.
+// Clients must generate distinct nonce values for every operation, e.g. by incrementing the nonce
+// when the clock is not moved since the previous operation.
+// Each nonce namespace of the sender has its own nonce list, the empty namespace is the default one.
+// The nonce format and the tolerance are set by nonce_sub_millisecond_digits and nonce_tolerance_ms
+// of the chaincode options, the backward correction of the sender clock is allowed by nonce_clock_correction_ms.
 func checkNonce(
 	stub shim.ChaincodeStubInterface,
 	sender *types.Sender,
+	options *pb.ChaincodeOptions,
+	namespace string,
 	nonce uint64,
 ) error {
-	noncePrefix := hex.EncodeToString([]byte{StateKeyNonce})
-	nonceKey, err := stub.CreateCompositeKey(noncePrefix, []string{sender.Address().String()})
+	attributes := []string{sender.Address().String()}
+	if namespace != "" {
+		attributes = append(attributes, namespace)
+	}
+
+	nonceKey, err := stub.CreateCompositeKey(hex.EncodeToString([]byte{StateKeyNonce}), attributes)
+	if err != nil {
+		return err
+	}
+	lastNonce, err := loadNonce(stub, nonceKey)
 	if err != nil {
 		return err
 	}
-	data, err := stub.GetState(nonceKey)
+
+	subMillisecondDigits := options.GetNonceSubMillisecondDigits()
+	threshold := int(options.GetNoncePruneThreshold())
+
+	var (
+		journalKey string
+		journal    = new(pb.Nonce)
+	)
+	if threshold != 0 {
+		if journalKey, err = stub.CreateCompositeKey(NonceJournalCompositeType, attributes); err != nil {
+			return err
+		}
+		if journal, err = loadNonce(stub, journalKey); err != nil {
+			return err
+		}
+		if len(journal.GetNonce()) != 0 {
+			lastNonce.Nonce = mergeNonces(
+				scaleNonces(lastNonce.GetNonce(), subMillisecondDigits),
+				scaleNonces(journal.GetNonce(), subMillisecondDigits),
+			)
+		}
+	}
+	corrected := len(lastNonce.GetBeforeClockCorrection()) != 0
+
+	tolerance := time.Second * defaultNonceTTL
+	if ms := options.GetNonceToleranceMs(); ms != 0 {
+		tolerance = time.Millisecond * time.Duration(ms)
+	}
+
+	allowance := time.Millisecond * time.Duration(options.GetNonceClockCorrectionMs())
+	// the used nonces are kept for the tolerance and the allowance, so the correction never accepts
+	// the nonce dropped from the list
+	retention := tolerance + allowance
+	scaled := scaleNonce(nonce, subMillisecondDigits)
+
+	if clockCorrection(nonce, lastNonce, subMillisecondDigits, tolerance, allowance) {
+		if err = checkNonceNotUsed(scaled, scaleNonces(lastNonce.GetNonce(), subMillisecondDigits)); err != nil {
+			return err
+		}
+
+		logger.Logger().Warningf("nonce %d of %s is accepted as the clock correction", nonce, sender.Address())
+		lastNonce.BeforeClockCorrection = lastNonce.GetNonce()
+		lastNonce.Nonce = []uint64{nonce}
+	} else {
+		before := scaleNonces(lastNonce.GetBeforeClockCorrection(), subMillisecondDigits)
+		window := uint64(retention.Milliseconds()) * pow10(subMillisecondDigits)
+
+		if len(before) != 0 {
+			// the nonces before the correction older than the retention are dropped, so they are unknown
+			if floor := before[len(before)-1]; floor > window && scaled < floor-window {
+				return fmt.Errorf("incorrect nonce %d, less than %d kept during the clock correction",
+					nonce, floor-window)
+			}
+
+			if err = checkNonceNotUsed(scaled, before); err != nil {
+				return err
+			}
+		}
+
+		lastNonce.Nonce, err = setNonceWithPrecision(
+			nonce,
+			lastNonce.GetNonce(),
+			subMillisecondDigits,
+			tolerance,
+			retention,
+		)
+		if err != nil {
+			return err
+		}
+
+		// the correction is over when the nonces before the correction leave the retention of the greatest nonce,
+		// so the list rejects them by the tolerance
+		if greatest := lastNonce.GetNonce()[len(lastNonce.GetNonce())-1]; len(before) != 0 &&
+			greatest > before[len(before)-1] && greatest-before[len(before)-1] > window {
+			lastNonce.BeforeClockCorrection = nil
+		}
+	}
+
+	// the nonce is appended to the journal until the journal reaches the threshold,
+	// the clock correction and its end change the list, so the list is written then as well
+	if threshold != 0 && len(journal.GetNonce())+1 < threshold &&
+		corrected == (len(lastNonce.GetBeforeClockCorrection()) != 0) {
+		journal.Nonce = mergeNonces(scaleNonces(journal.GetNonce(), subMillisecondDigits), []uint64{scaled})
+
+		data, err := proto.Marshal(journal)
+		if err != nil {
+			return err
+		}
+
+		return stub.PutState(journalKey, data)
+	}
+
+	data, err := proto.Marshal(lastNonce)
 	if err != nil {
 		return err
 	}
 
+	if err = stub.PutState(nonceKey, data); err != nil {
+		return err
+	}
+
+	if len(journal.GetNonce()) != 0 {
+		return stub.DelState(journalKey)
+	}
+
+	return nil
+}
+
+// loadNonce reads the nonce list stored by the key, the old nonce stored as a number is read as the list of one nonce
+func loadNonce(stub shim.ChaincodeStubInterface, key string) (*pb.Nonce, error) {
+	data, err := stub.GetState(key)
+	if err != nil {
+		return nil, err
+	}
+
 	lastNonce := new(pb.Nonce)
 	if len(data) > 0 {
 		if err = proto.Unmarshal(data, lastNonce); err != nil {
 			log := logger.Logger()
 			log.Warningf("error unmarshal nonce, maybe old nonce. error: %v", err)
 			// let's just say it's an old nonse
-			lastNonce.Nonce = []uint64{new(big.Int).SetBytes(data).Uint64()}
+			oldNonce, err := new(big.Int).SetBytes(data).Uint64Checked()
+			if err != nil {
+				return nil, fmt.Errorf("old nonce: %w", err)
+			}
+			lastNonce.Nonce = []uint64{oldNonce}
 		}
 	}
 
-	lastNonce.Nonce, err = setNonce(nonce, lastNonce.GetNonce(), defaultNonceTTL)
-	if err != nil {
-		return err
+	return lastNonce, nil
+}
+
+// mergeNonces merges two sorted nonce lists of the same precision into one sorted list without duplicates
+func mergeNonces(a []uint64, b []uint64) []uint64 {
+	merged := make([]uint64, 0, len(a)+len(b))
+	for len(a) != 0 || len(b) != 0 {
+		var nonce uint64
+		switch {
+		case len(b) == 0 || (len(a) != 0 && a[0] <= b[0]):
+			nonce, a = a[0], a[1:]
+		default:
+			nonce, b = b[0], b[1:]
+		}
+
+		if len(merged) != 0 && merged[len(merged)-1] == nonce {
+			continue
+		}
+		merged = append(merged, nonce)
 	}
 
-	data, err = proto.Marshal(lastNonce)
-	if err != nil {
-		return err
+	return merged
+}
+
+// clockCorrection reports if the nonce is the backward jump of the sender clock within allowance:
+// the nonce lags behind the greatest nonce of the list beyond the tolerance by no more than allowance,
+// and no other correction is pending.
+// The nonces of the list are kept for the tolerance and the allowance, and the nonces before the correction
+// are kept until they leave that retention of the greatest corrected nonce, so the repeated operations
+// are rejected. The nonces older than the kept ones are rejected until the correction is over.
+func clockCorrection(
+	nonce uint64,
+	lastNonce *pb.Nonce,
+	subMillisecondDigits uint32,
+	tolerance time.Duration,
+	allowance time.Duration,
+) bool {
+	if allowance <= 0 || len(lastNonce.GetNonce()) == 0 || len(lastNonce.GetBeforeClockCorrection()) != 0 {
+		return false
+	}
+
+	length := len(strconv.FormatUint(nonce, 10))
+	if subMillisecondDigits > maxNonceSubMillisecondDigits ||
+		length < LenTimeInMilliseconds || length > LenTimeInMilliseconds+int(subMillisecondDigits) {
+		return false
 	}
 
-	return stub.PutState(nonceKey, data)
+	nonce = scaleNonce(nonce, subMillisecondDigits)
+	last := scaleNonce(lastNonce.GetNonce()[len(lastNonce.GetNonce())-1], subMillisecondDigits)
+	if nonce >= last {
+		return false
+	}
+
+	scale := pow10(subMillisecondDigits)
+	window := uint64(tolerance.Milliseconds()) * scale
+	lag := last - nonce
+
+	return lag > window && lag <= window+uint64(allowance.Milliseconds())*scale
+}
+
+// checkNonceNotUsed returns an error if the nonce is found in the sorted list of used nonces
+func checkNonceNotUsed(nonce uint64, used []uint64) error {
+	index := sort.Search(len(used), func(i int) bool { return used[i] >= nonce })
+	if index != len(used) && used[index] == nonce {
+		return fmt.Errorf("nonce %d already exists", nonce)
+	}
+
+	return nil
+}
+
+// nonceNamespace returns the nonce namespace of the chaincode function set in nonce_namespaces
+// of the chaincode options or the default namespace if the function is not listed
+func nonceNamespace(options *pb.ChaincodeOptions, fn string) string {
+	return options.GetNonceNamespaces()[fn]
 }
 
+// setNonce inserts the nonce to the sorted list of the sender's nonces within TTL of the maximum one.
+// Nonces may arrive in any order within TTL, but every nonce value is accepted only once.
 func setNonce(nonce uint64, lastNonce []uint64, nonceTTL uint) ([]uint64, error) {
-	if len(strconv.FormatUint(nonce, 10)) != LenTimeInMilliseconds {
+	ttl := time.Second * time.Duration(nonceTTL)
+	return setNonceWithPrecision(nonce, lastNonce, 0, ttl, ttl)
+}
+
+// setNonceWithPrecision is setNonce for nonces carrying up to subMillisecondDigits digits below milliseconds.
+// Nonces of the list and the nonce are brought to the same precision before the comparison,
+// so the list stays valid when the precision is changed. The nonces beyond the retention, not less than
+// the tolerance, are pruned. The nonces kept beyond the tolerance do not affect the check,
+// as they are less than any accepted nonce.
+func setNonceWithPrecision(
+	nonce uint64,
+	lastNonce []uint64,
+	subMillisecondDigits uint32,
+	tolerance time.Duration,
+	retention time.Duration,
+) ([]uint64, error) {
+	if subMillisecondDigits > maxNonceSubMillisecondDigits {
+		return lastNonce, fmt.Errorf("nonce sub millisecond digits %d exceed %d",
+			subMillisecondDigits, maxNonceSubMillisecondDigits)
+	}
+
+	length := len(strconv.FormatUint(nonce, 10))
+	if length < LenTimeInMilliseconds || length > LenTimeInMilliseconds+int(subMillisecondDigits) {
 		return lastNonce, errors.New("incorrect nonce format")
 	}
 
+	nonce = scaleNonce(nonce, subMillisecondDigits)
+	lastNonce = scaleNonces(lastNonce, subMillisecondDigits)
+
 	if len(lastNonce) == 0 {
 		return []uint64{nonce}, nil
 	}
@@ -78,18 +306,22 @@ func setNonce(nonce uint64, lastNonce []uint64, nonceTTL uint) ([]uint64, error)
 
 	last := lastNonce[l-1]
 
-	ttl := time.Second * time.Duration(nonceTTL)
+	window := uint64(tolerance.Milliseconds()) * pow10(subMillisecondDigits)
+	kept := window
+	if retention > tolerance {
+		kept = uint64(retention.Milliseconds()) * pow10(subMillisecondDigits)
+	}
 
 	if nonce > last {
 		lastNonce = append(lastNonce, nonce)
 		l = len(lastNonce)
 		last = lastNonce[l-1]
 
-		index := sort.Search(l, func(i int) bool { return last-lastNonce[i] <= uint64(ttl.Milliseconds()) })
+		index := sort.Search(l, func(i int) bool { return last-lastNonce[i] <= kept })
 		return lastNonce[index:], nil
 	}
 
-	if last-nonce > uint64(ttl.Milliseconds()) {
+	if last-nonce > window {
 		return lastNonce, fmt.Errorf("incorrect nonce %d, less than %d", nonce, last)
 	}
 
@@ -113,3 +345,41 @@ func setNonce(nonce uint64, lastNonce []uint64, nonceTTL uint) ([]uint64, error)
 
 	return lastNonce, nil
 }
+
+// scaleNonce brings the nonce to the precision of subMillisecondDigits digits below milliseconds.
+// Nonces of the higher precision lose the extra digits.
+func scaleNonce(nonce uint64, subMillisecondDigits uint32) uint64 {
+	digits := len(strconv.FormatUint(nonce, 10)) - LenTimeInMilliseconds
+	switch {
+	case digits < int(subMillisecondDigits):
+		return nonce * pow10(subMillisecondDigits-uint32(digits))
+	case digits > int(subMillisecondDigits):
+		return nonce / pow10(uint32(digits)-subMillisecondDigits)
+	default:
+		return nonce
+	}
+}
+
+// scaleNonces brings the sorted nonce list to the precision of subMillisecondDigits digits below milliseconds
+// keeping it sorted and without duplicates
+func scaleNonces(nonces []uint64, subMillisecondDigits uint32) []uint64 {
+	scaled := make([]uint64, 0, len(nonces))
+	for _, nonce := range nonces {
+		nonce = scaleNonce(nonce, subMillisecondDigits)
+		if len(scaled) != 0 && scaled[len(scaled)-1] >= nonce {
+			continue
+		}
+		scaled = append(scaled, nonce)
+	}
+
+	return scaled
+}
+
+func pow10(n uint32) uint64 {
+	result := uint64(1)
+	for i := uint32(0); i < n; i++ {
+		result *= 10
+	}
+
+	return result
+}
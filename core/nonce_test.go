@@ -1,10 +1,15 @@
 package core
 
 import (
+	"encoding/hex"
 	"testing"
 	"time"
 
+	"github.com/anoideaopen/foundation/core/types"
 	pb "github.com/anoideaopen/foundation/proto"
+	"github.com/golang/protobuf/proto" //nolint:staticcheck
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-chaincode-go/shimtest" //nolint:staticcheck
 	"github.com/stretchr/testify/require"
 )
 
@@ -131,3 +136,351 @@ func TestNonceFailRepeat(t *testing.T) {
 	require.EqualError(t, err, "nonce 1660055050020 already exists")
 	require.Equal(t, []uint64{1660055050000, 1660055050010, 1660055050020}, lastNonce.Nonce)
 }
+
+func TestNonceSameMillisecond(t *testing.T) {
+	stub := shimtest.NewMockStub("nonce", nil)
+	sender := types.NewSenderFromAddr(types.AddrFromBytes(make([]byte, 32)))
+
+	// two distinct operations of the sender made in the same millisecond
+	stub.MockTransactionStart("tx1")
+	require.NoError(t, checkNonce(stub, sender, nil, "", 1660055050000))
+	stub.MockTransactionEnd("tx1")
+
+	stub.MockTransactionStart("tx2")
+	require.EqualError(t, checkNonce(stub, sender, nil, "", 1660055050000), "nonce 1660055050000 already exists")
+	stub.MockTransactionEnd("tx2")
+
+	// the same operation with the incremented nonce is accepted
+	stub.MockTransactionStart("tx3")
+	require.NoError(t, checkNonce(stub, sender, nil, "", 1660055050001))
+	stub.MockTransactionEnd("tx3")
+
+	// other sender is not affected by the nonces of the first one
+	other := types.NewSenderFromAddr(types.AddrFromBytes(append(make([]byte, 31), 1)))
+	stub.MockTransactionStart("tx4")
+	require.NoError(t, checkNonce(stub, other, nil, "", 1660055050000))
+	stub.MockTransactionEnd("tx4")
+}
+
+func TestNonceNamespaces(t *testing.T) {
+	stub := shimtest.NewMockStub("nonce", nil)
+	sender := types.NewSenderFromAddr(types.AddrFromBytes(make([]byte, 32)))
+
+	stub.MockTransactionStart("tx1")
+	require.NoError(t, checkNonce(stub, sender, nil, "", 1660055050000))
+	stub.MockTransactionEnd("tx1")
+
+	// the same nonce is accepted once in every namespace
+	stub.MockTransactionStart("tx2")
+	require.NoError(t, checkNonce(stub, sender, nil, "admin", 1660055050000))
+	stub.MockTransactionEnd("tx2")
+
+	stub.MockTransactionStart("tx3")
+	require.EqualError(t, checkNonce(stub, sender, nil, "admin", 1660055050000), "nonce 1660055050000 already exists")
+	stub.MockTransactionEnd("tx3")
+
+	// the nonce far behind the default namespace is accepted in the namespace within TTL of its own nonces
+	stub.MockTransactionStart("tx4")
+	require.NoError(t, checkNonce(stub, sender, nil, "", 1660055150000))
+	stub.MockTransactionEnd("tx4")
+
+	stub.MockTransactionStart("tx5")
+	require.NoError(t, checkNonce(stub, sender, nil, "admin", 1660055050001))
+	stub.MockTransactionEnd("tx5")
+}
+
+func TestNonceTolerance(t *testing.T) {
+	lastNonce, err := setNonceWithPrecision(1660055050000, nil, 0, time.Second, time.Second)
+	require.NoError(t, err)
+
+	// just inside the tolerance
+	lastNonce, err = setNonceWithPrecision(1660055049000, lastNonce, 0, time.Second, time.Second)
+	require.NoError(t, err)
+
+	// just outside the tolerance
+	_, err = setNonceWithPrecision(1660055048999, lastNonce, 0, time.Second, time.Second)
+	require.EqualError(t, err, "incorrect nonce 1660055048999, less than 1660055050000")
+}
+
+func TestNonceSubMillisecondDigits(t *testing.T) {
+	// sub millisecond nonces are rejected unless enabled
+	_, err := setNonceWithPrecision(1660055050000001, nil, 0, time.Second, time.Second)
+	require.EqualError(t, err, "incorrect nonce format")
+
+	_, err = setNonceWithPrecision(1660055050000001, nil, 4, time.Second, time.Second)
+	require.EqualError(t, err, "nonce sub millisecond digits 4 exceed 3")
+
+	// the millisecond nonce is the nonce with zero sub millisecond digits
+	lastNonce, err := setNonceWithPrecision(1660055050000, nil, 3, time.Second, time.Second)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1660055050000000}, lastNonce)
+
+	// operations of the same millisecond are distinguished by the sub millisecond digits
+	lastNonce, err = setNonceWithPrecision(1660055050000001, lastNonce, 3, time.Second, time.Second)
+	require.NoError(t, err)
+	lastNonce, err = setNonceWithPrecision(1660055050000002, lastNonce, 3, time.Second, time.Second)
+	require.NoError(t, err)
+
+	// but every nonce value is still accepted once
+	_, err = setNonceWithPrecision(1660055050000002, lastNonce, 3, time.Second, time.Second)
+	require.EqualError(t, err, "nonce 1660055050000002 already exists")
+	_, err = setNonceWithPrecision(1660055050000, lastNonce, 3, time.Second, time.Second)
+	require.EqualError(t, err, "nonce 1660055050000000 already exists")
+
+	// the tolerance is applied in milliseconds
+	lastNonce, err = setNonceWithPrecision(1660055049000002, lastNonce, 3, time.Second, time.Second)
+	require.NoError(t, err)
+	_, err = setNonceWithPrecision(1660055049000001, lastNonce, 3, time.Second, time.Second)
+	require.EqualError(t, err, "incorrect nonce 1660055049000001, less than 1660055050000002")
+
+	// the list is brought to the millisecond precision when the digits are disabled
+	lastNonce, err = setNonceWithPrecision(1660055050003, lastNonce, 0, time.Second, time.Second)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{1660055050000, 1660055050003}, lastNonce)
+}
+
+func TestCheckNonceOptions(t *testing.T) {
+	stub := shimtest.NewMockStub("nonce", nil)
+	sender := types.NewSenderFromAddr(types.AddrFromBytes(make([]byte, 32)))
+	options := &pb.ChaincodeOptions{NonceSubMillisecondDigits: 3, NonceToleranceMs: 1000}
+
+	stub.MockTransactionStart("tx1")
+	require.NoError(t, checkNonce(stub, sender, options, "", 1660055050000001))
+	stub.MockTransactionEnd("tx1")
+
+	stub.MockTransactionStart("tx2")
+	require.NoError(t, checkNonce(stub, sender, options, "", 1660055050000002))
+	stub.MockTransactionEnd("tx2")
+
+	stub.MockTransactionStart("tx3")
+	require.NoError(t, checkNonce(stub, sender, options, "", 1660055049000002))
+	stub.MockTransactionEnd("tx3")
+
+	stub.MockTransactionStart("tx4")
+	require.EqualError(t, checkNonce(stub, sender, options, "", 1660055049000001),
+		"incorrect nonce 1660055049000001, less than 1660055050000002")
+	stub.MockTransactionEnd("tx4")
+}
+
+func TestNonceClockCorrection(t *testing.T) {
+	stub := shimtest.NewMockStub("nonce", nil)
+	sender := types.NewSenderFromAddr(types.AddrFromBytes(make([]byte, 32)))
+	options := &pb.ChaincodeOptions{NonceToleranceMs: 1000, NonceClockCorrectionMs: 5000}
+
+	check := func(nonce uint64) error {
+		stub.MockTransactionStart("tx")
+		defer stub.MockTransactionEnd("tx")
+
+		return checkNonce(stub, sender, options, "", nonce)
+	}
+
+	require.NoError(t, check(1660055050000))
+
+	// the backward jump beyond the allowance is rejected
+	require.EqualError(t, check(1660055043999), "incorrect nonce 1660055043999, less than 1660055050000")
+
+	// the backward jump within the allowance restarts the nonce list
+	require.NoError(t, check(1660055046000))
+	require.NoError(t, check(1660055046001))
+	require.EqualError(t, check(1660055046000), "nonce 1660055046000 already exists")
+
+	// strict ordering resumes from the corrected nonce
+	require.EqualError(t, check(1660055045000), "incorrect nonce 1660055045000, less than 1660055046001")
+
+	// the nonces used before the correction are still rejected
+	require.EqualError(t, check(1660055050000), "nonce 1660055050000 already exists")
+
+	// the next correction is rejected until the nonces pass the greatest nonce before the correction
+	require.NoError(t, check(1660055049999))
+	require.EqualError(t, check(1660055046000), "incorrect nonce 1660055046000, less than 1660055049999")
+
+	// the correction is pending until the nonces before it leave the retention of the tolerance and the allowance
+	require.NoError(t, check(1660055050001))
+	require.EqualError(t, check(1660055046500), "incorrect nonce 1660055046500, less than 1660055050001")
+
+	require.NoError(t, check(1660055056002))
+	require.NoError(t, check(1660055051500))
+
+	// the corrections are disabled by default
+	options.NonceClockCorrectionMs = 0
+	require.NoError(t, check(1660055062003))
+	require.EqualError(t, check(1660055059000), "incorrect nonce 1660055059000, less than 1660055062003")
+}
+
+func TestNonceClockCorrectionReplay(t *testing.T) {
+	options := &pb.ChaincodeOptions{NonceToleranceMs: 1000, NonceClockCorrectionMs: 5000}
+
+	newCheck := func() func(nonce uint64) error {
+		stub := shimtest.NewMockStub("nonce", nil)
+		sender := types.NewSenderFromAddr(types.AddrFromBytes(make([]byte, 32)))
+
+		return func(nonce uint64) error {
+			stub.MockTransactionStart("tx")
+			defer stub.MockTransactionEnd("tx")
+
+			return checkNonce(stub, sender, options, "", nonce)
+		}
+	}
+
+	t.Run("nonce beyond the tolerance is not replayed as the correction", func(t *testing.T) {
+		check := newCheck()
+		require.NoError(t, check(1660055050000))
+		require.NoError(t, check(1660055055500))
+		require.EqualError(t, check(1660055050000), "nonce 1660055050000 already exists")
+	})
+
+	t.Run("nonce dropped before the correction is not replayed", func(t *testing.T) {
+		check := newCheck()
+		require.NoError(t, check(1660055040800))
+		require.NoError(t, check(1660055047000))
+
+		require.NoError(t, check(1660055041500))
+		require.EqualError(t, check(1660055040800),
+			"incorrect nonce 1660055040800, less than 1660055041000 kept during the clock correction")
+
+		// the correction is over, the old nonces are rejected by the tolerance
+		require.NoError(t, check(1660055053001))
+		require.EqualError(t, check(1660055040800), "incorrect nonce 1660055040800, less than 1660055053001")
+	})
+}
+
+func TestNoncePruneThreshold(t *testing.T) {
+	stub := shimtest.NewMockStub("nonce", nil)
+	sender := types.NewSenderFromAddr(types.AddrFromBytes(make([]byte, 32)))
+	options := &pb.ChaincodeOptions{NonceToleranceMs: 1000, NoncePruneThreshold: 4}
+
+	check := func(nonce uint64) error {
+		stub.MockTransactionStart("tx")
+		defer stub.MockTransactionEnd("tx")
+
+		return checkNonce(stub, sender, options, "", nonce)
+	}
+
+	stored := func(prefix string) []uint64 {
+		key, err := stub.CreateCompositeKey(prefix, []string{sender.Address().String()})
+		require.NoError(t, err)
+
+		data, ok := stub.State[key]
+		if !ok {
+			return nil
+		}
+
+		lastNonce := new(pb.Nonce)
+		require.NoError(t, proto.Unmarshal(data, lastNonce))
+
+		return lastNonce.GetNonce()
+	}
+
+	require.NoError(t, check(1660055050000))
+	require.NoError(t, check(1660055051000))
+	require.NoError(t, check(1660055052000))
+
+	// the nonces are written to the journal only until it reaches the threshold
+	require.Nil(t, stored(hex.EncodeToString([]byte{StateKeyNonce})))
+	require.Equal(t, []uint64{1660055050000, 1660055051000, 1660055052000}, stored(NonceJournalCompositeType))
+
+	// the greatest nonce is returned from the journal
+	bc := new(BaseContract)
+	bc.SetStub(stub)
+	greatest, err := bc.QueryGetNonce(sender.Address())
+	require.NoError(t, err)
+	require.Equal(t, "1660055052000", greatest)
+
+	// the replays are rejected by the journal
+	require.EqualError(t, check(1660055052000), "nonce 1660055052000 already exists")
+	require.EqualError(t, check(1660055050500), "incorrect nonce 1660055050500, less than 1660055052000")
+
+	// the journal is merged into the pruned list
+	require.NoError(t, check(1660055053000))
+	require.Equal(t, []uint64{1660055052000, 1660055053000}, stored(hex.EncodeToString([]byte{StateKeyNonce})))
+	require.Nil(t, stored(NonceJournalCompositeType))
+
+	// the replays are rejected by the list and the journal
+	require.NoError(t, check(1660055052500))
+	require.NoError(t, check(1660055054000))
+	require.Equal(t, []uint64{1660055052500, 1660055054000}, stored(NonceJournalCompositeType))
+	require.EqualError(t, check(1660055053000), "nonce 1660055053000 already exists")
+	require.EqualError(t, check(1660055054000), "nonce 1660055054000 already exists")
+	require.EqualError(t, check(1660055052500), "incorrect nonce 1660055052500, less than 1660055054000")
+}
+
+func TestNoncePruneThresholdReplay(t *testing.T) {
+	// the nonces replayed, reordered, too old and corrected by the clock are checked alike whatever the threshold is
+	nonces := []uint64{
+		1660055050000, 1660055050000, 1660055049500, 1660055048999, 1660055051000, 1660055050500,
+		1660055050500, 1660055052000, 1660055051001, 1660055053000, 1660055045000, 1660055049000,
+		1660055049000, 1660055053000, 1660055048000, 1660055053001, 1660055058000, 1660055050000,
+		1660055057500, 1660055057500, 1660055064000, 1660055063000, 1660055064001, 1660055060000,
+	}
+
+	check := func(threshold uint32) []string {
+		stub := shimtest.NewMockStub("nonce", nil)
+		sender := types.NewSenderFromAddr(types.AddrFromBytes(make([]byte, 32)))
+		options := &pb.ChaincodeOptions{
+			NonceToleranceMs:       1000,
+			NonceClockCorrectionMs: 5000,
+			NoncePruneThreshold:    threshold,
+		}
+
+		results := make([]string, 0, len(nonces))
+		for _, nonce := range nonces {
+			stub.MockTransactionStart("tx")
+			err := checkNonce(stub, sender, options, "", nonce)
+			stub.MockTransactionEnd("tx")
+
+			result := "accepted"
+			if err != nil {
+				result = err.Error()
+			}
+			results = append(results, result)
+		}
+
+		return results
+	}
+
+	expected := check(0)
+	for _, threshold := range []uint32{1, 2, 3, 5, 64} {
+		require.Equal(t, expected, check(threshold), "threshold %d", threshold)
+	}
+}
+
+// writeCountingStub counts the bytes written to the state
+type writeCountingStub struct {
+	shim.ChaincodeStubInterface
+	written int
+}
+
+func (s *writeCountingStub) PutState(key string, value []byte) error {
+	s.written += len(value)
+	return s.ChaincodeStubInterface.PutState(key, value)
+}
+
+// BenchmarkCheckNonceHotWallet reports the bytes of the nonce state written per operation
+// of the wallet signing an operation every 10 milliseconds
+func BenchmarkCheckNonceHotWallet(b *testing.B) {
+	for _, bench := range []struct {
+		name      string
+		threshold uint32
+	}{
+		{name: "write list every operation"},
+		{name: "journal threshold 16", threshold: 16},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			mockStub := shimtest.NewMockStub("nonce", nil)
+			stub := &writeCountingStub{ChaincodeStubInterface: mockStub}
+			sender := types.NewSenderFromAddr(types.AddrFromBytes(make([]byte, 32)))
+			options := &pb.ChaincodeOptions{NonceToleranceMs: 1000, NoncePruneThreshold: bench.threshold}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				mockStub.MockTransactionStart("tx")
+				if err := checkNonce(stub, sender, options, "", uint64(etlMili)+uint64(i)*10); err != nil {
+					b.Fatal(err)
+				}
+				mockStub.MockTransactionEnd("tx")
+			}
+
+			b.ReportMetric(float64(stub.written)/float64(b.N), "bytes/op")
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package core
+
+// ClampPageSize limits the page size of paginated queries by max_page_size of the contract options
+// and returns the page size to use and whether the requested page size was clamped.
+func (bc *BaseContract) ClampPageSize(pageSize int64) (int64, bool) {
+	limit := int64(bc.config.GetOptions().GetMaxPageSize())
+	if limit == 0 || pageSize <= limit {
+		return pageSize, false
+	}
+
+	return limit, true
+}
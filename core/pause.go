@@ -0,0 +1,79 @@
+package core
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/anoideaopen/foundation/core/types"
+)
+
+// PausedKey is a state key of the contract pause flag
+const PausedKey = "__paused"
+
+var (
+	ErrPaused        = errors.New("contract is paused")
+	ErrAlreadyPaused = errors.New("contract is already paused")
+	ErrNotPaused     = errors.New("contract is not paused")
+)
+
+// TxPause pauses the contract: transfers, channel transfers, swaps and emissions are rejected with ErrPaused
+// until the contract is unpaused. Queries, admin methods and completion of already started
+// channel transfers and swaps remain available. Method can be called by the contract admin only.
+func (bc *BaseContract) TxPause(sender *types.Sender) error {
+	if err := bc.CheckAdminSender(sender); err != nil {
+		return err
+	}
+
+	paused, err := bc.QueryIsPaused()
+	if err != nil {
+		return err
+	}
+
+	if paused {
+		return ErrAlreadyPaused
+	}
+
+	return bc.GetStub().PutState(PausedKey, []byte(strconv.FormatBool(true)))
+}
+
+// TxUnpause unpauses the contract paused by TxPause. Method can be called by the contract admin only.
+func (bc *BaseContract) TxUnpause(sender *types.Sender) error {
+	if err := bc.CheckAdminSender(sender); err != nil {
+		return err
+	}
+
+	paused, err := bc.QueryIsPaused()
+	if err != nil {
+		return err
+	}
+
+	if !paused {
+		return ErrNotPaused
+	}
+
+	return bc.GetStub().DelState(PausedKey)
+}
+
+// QueryIsPaused returns true if the contract is paused
+func (bc *BaseContract) QueryIsPaused() (bool, error) {
+	data, err := bc.GetStub().GetState(PausedKey)
+	if err != nil {
+		return false, err
+	}
+
+	return len(data) != 0, nil
+}
+
+// CheckPaused returns ErrPaused if the contract is paused
+func (bc *BaseContract) CheckPaused() error {
+	paused, err := bc.QueryIsPaused()
+	if err != nil {
+		return err
+	}
+
+	if paused {
+		return ErrPaused
+	}
+
+	return nil
+}
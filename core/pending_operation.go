@@ -0,0 +1,307 @@
+package core
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anoideaopen/foundation/core/contract"
+	"github.com/anoideaopen/foundation/core/stringsx"
+	"github.com/anoideaopen/foundation/core/telemetry"
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"go.opentelemetry.io/otel/codes"
+	"golang.org/x/crypto/sha3"
+)
+
+// PendingOperationCompositeType is a composite key prefix for the multisig operations collecting signatures
+const PendingOperationCompositeType = "pending_operation"
+
+var (
+	ErrPendingOperationMethod    = errors.New("only signed transaction methods can be pending operations")
+	ErrPendingOperationExists    = errors.New("pending operation already exists")
+	ErrPendingOperationNotFound  = errors.New("pending operation not found")
+	ErrPendingOperationNoSigners = errors.New("pending operation has no signers")
+	ErrUnknownOperationSigner    = errors.New("public key is not a signer of the pending operation")
+	ErrOperationAlreadySigned    = errors.New("pending operation is already signed by the key")
+)
+
+// PendingOperation is a call of the signed method by the multisig address collecting the signatures
+// of its keys in separate transactions.
+// Args are the arguments of the call without signatures:
+// [requestID, chaincode, channel, method args..., nonce, public keys...].
+// Signatures are in the order of the public keys, missing signatures are empty.
+type PendingOperation struct {
+	ID         string   `json:"id"`
+	Method     string   `json:"method"`
+	Args       []string `json:"args"`
+	Signatures []string `json:"signatures"`
+}
+
+// SubmittedSignature is the result of submitSignature
+type SubmittedSignature struct {
+	// Executed is true if the signature completed the operation and it is executed
+	Executed bool `json:"executed"`
+	// Result is the result of the executed operation
+	Result json.RawMessage `json:"result,omitempty"`
+}
+
+// proposeOperationHandler creates the pending operation from the call of the signed method without signatures.
+// Arguments are the chaincode function followed by the arguments of the call:
+// [function, requestID, chaincode, channel, method args..., nonce, public keys...].
+// The id of the operation is the hex encoded SHA3-256 hash of the message the keys sign,
+// so the operation can not be replaced by another one with the same id.
+// Returns the id of the operation.
+func (cc *Chaincode) proposeOperationHandler(stub shim.ChaincodeStubInterface, args []string) peer.Response {
+	id, err := cc.proposeOperation(stub, args)
+	if err != nil {
+		return shim.Error("propose operation: " + err.Error())
+	}
+
+	return shim.Success([]byte(id))
+}
+
+func (cc *Chaincode) proposeOperation(stub shim.ChaincodeStubInterface, args []string) (string, error) {
+	if err := checkArgsSize(args, cc.contract.ContractConfig().GetOptions().GetMaxArgsSize()); err != nil {
+		return "", err
+	}
+
+	if len(args) == 0 {
+		return "", ErrPendingOperationMethod
+	}
+
+	method, err := cc.pendingOperationMethod(args[0])
+	if err != nil {
+		return "", err
+	}
+
+	invocation := args[1:]
+
+	signersStart := (method.NumArgs - 1) + 4 //nolint:gomnd // +4 for reqId, cc, ch, nonce
+	if len(invocation) <= signersStart {
+		return "", ErrPendingOperationNoSigners
+	}
+
+	if err = checkChaincodeAndChannelName(stub, invocation[1], invocation[2]); err != nil {
+		return "", err
+	}
+
+	if _, err = checkACLSignerStatus(stub, invocation[signersStart:]); err != nil {
+		return "", err
+	}
+
+	op := &PendingOperation{
+		ID:         pendingOperationID(method, invocation),
+		Method:     method.ChaincodeFunc,
+		Args:       invocation,
+		Signatures: make([]string, len(invocation)-signersStart),
+	}
+
+	if _, err = loadPendingOperation(stub, op.ID); err == nil {
+		return "", fmt.Errorf("%w: %s", ErrPendingOperationExists, op.ID)
+	}
+	if !errors.Is(err, ErrPendingOperationNotFound) {
+		return "", err
+	}
+
+	if err = savePendingOperation(stub, op); err != nil {
+		return "", err
+	}
+
+	return op.ID, nil
+}
+
+// submitSignatureHandler adds the signature of the key to the pending operation.
+// Arguments are [operation id, public key, signature], the signature is made over the same message
+// as the signature of the method call. The operation is executed by the transaction submitting
+// the signature that meets the signature policy of the multisig address: the weight threshold
+// if the policy has weights, or the signatures of all keys otherwise. The executed operation is deleted.
+// The nonce of the operation is checked on the execution, so the signatures must be collected
+// within the nonce tolerance.
+func (cc *Chaincode) submitSignatureHandler(
+	traceCtx telemetry.TraceContext,
+	stub shim.ChaincodeStubInterface,
+	args []string,
+) peer.Response {
+	traceCtx, span := cc.contract.TracingHandler().StartNewSpan(traceCtx, "chaincode.SubmitSignatureHandler")
+	defer span.End()
+
+	result, err := cc.submitSignature(traceCtx, stub, args)
+	if err != nil {
+		errMsg := "submit signature: " + err.Error()
+		span.SetStatus(codes.Error, errMsg)
+		return shim.Error(errMsg)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return shim.Success(data)
+}
+
+func (cc *Chaincode) submitSignature(
+	traceCtx telemetry.TraceContext,
+	stub shim.ChaincodeStubInterface,
+	args []string,
+) (*SubmittedSignature, error) {
+	const submitSignatureArgs = 3
+	if len(args) != submitSignatureArgs {
+		return nil, fmt.Errorf("incorrect number of arguments: found %d but expected %d", len(args), submitSignatureArgs)
+	}
+
+	op, err := loadPendingOperation(stub, args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	method, err := cc.pendingOperationMethod(op.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKeys := op.Args[len(op.Args)-len(op.Signatures):]
+
+	index := -1
+	for i, key := range publicKeys {
+		if key == args[1] {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownOperationSigner, args[1])
+	}
+	if op.Signatures[index] != "" {
+		return nil, fmt.Errorf("%w: %s", ErrOperationAlreadySigned, args[1])
+	}
+	op.Signatures[index] = args[2]
+
+	acl, err := checkACLSignerStatus(stub, publicKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	// the present signatures are verified and the weight threshold is checked
+	signedArgs := append(append([]string{}, op.Args...), op.Signatures...)
+	sender, methodArgs, nonce, err := cc.validateAndExtractInvocationContext(stub, method, signedArgs)
+	if err != nil && !errors.Is(err, ErrWeightThresholdNotMet) {
+		return nil, err
+	}
+
+	weighted := len(acl.GetAddress().GetSignaturePolicy().GetWeights()) != 0
+	if err != nil || (!weighted && stringsx.OneOf("", op.Signatures...)) {
+		return &SubmittedSignature{}, savePendingOperation(stub, op)
+	}
+
+	stub = withEventNamePrefix(stub, cc.contract.ContractConfig().GetOptions().GetEventNamePrefix())
+
+	options := cc.contract.ContractConfig().GetOptions()
+	namespace := nonceNamespace(options, method.ChaincodeFunc)
+	if err = checkNonce(stub, types.NewSenderFromAddr((*types.Address)(sender)), options, namespace, nonce); err != nil {
+		return nil, err
+	}
+
+	if err = checkMaintenanceMode(stub, method, options.GetMaintenanceAllowedFunctions()); err != nil {
+		return nil, err
+	}
+
+	if err = cc.Router().Check(method.MethodName, cc.PrependSender(method, sender, methodArgs)...); err != nil {
+		return nil, err
+	}
+
+	result, err := cc.invokeContractMethodWithNonce(traceCtx, stub, method, sender, methodArgs, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = deletePendingOperation(stub, op.ID); err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		result = []byte("null")
+	}
+
+	return &SubmittedSignature{Executed: true, Result: result}, nil
+}
+
+// QueryPendingOperation returns the multisig operation collecting signatures by id
+func (bc *BaseContract) QueryPendingOperation(id string) (*PendingOperation, error) {
+	return loadPendingOperation(bc.GetStub(), id)
+}
+
+// pendingOperationMethod returns the enabled signed transaction method of the chaincode function
+func (cc *Chaincode) pendingOperationMethod(fn string) (contract.Method, error) {
+	method, err := cc.Method(fn)
+	if err != nil {
+		return contract.Method{}, err
+	}
+
+	if method.Type == contract.MethodTypeQuery || !method.RequiresAuth {
+		return contract.Method{}, fmt.Errorf("%w: '%s'", ErrPendingOperationMethod, fn)
+	}
+
+	if isMethodDisabled(method.MethodName, cc.contract.ContractConfig().GetOptions()) {
+		return contract.Method{}, fmt.Errorf("method '%s' not found", fn)
+	}
+
+	return method, nil
+}
+
+// pendingOperationID returns the hex encoded hash of the message signed by the keys of the operation
+func pendingOperationID(method contract.Method, args []string) string {
+	digest := sha3.Sum256([]byte(method.ChaincodeFunc + strings.Join(args, "")))
+	return hex.EncodeToString(digest[:])
+}
+
+func loadPendingOperation(stub shim.ChaincodeStubInterface, id string) (*PendingOperation, error) {
+	key, err := stub.CreateCompositeKey(PendingOperationCompositeType, []string{id})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := stub.GetState(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrPendingOperationNotFound, id)
+	}
+
+	op := &PendingOperation{}
+	if err = json.Unmarshal(data, op); err != nil {
+		return nil, err
+	}
+
+	return op, nil
+}
+
+func savePendingOperation(stub shim.ChaincodeStubInterface, op *PendingOperation) error {
+	key, err := stub.CreateCompositeKey(PendingOperationCompositeType, []string{op.ID})
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(op)
+	if err != nil {
+		return err
+	}
+
+	return stub.PutState(key, data)
+}
+
+func deletePendingOperation(stub shim.ChaincodeStubInterface, id string) error {
+	key, err := stub.CreateCompositeKey(PendingOperationCompositeType, []string{id})
+	if err != nil {
+		return err
+	}
+
+	return stub.DelState(key)
+}
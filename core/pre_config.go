@@ -0,0 +1,33 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/stringsx"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+)
+
+var ErrNotConfigured = errors.New("chaincode is not configured")
+
+// DefaultPreConfigFunctions are the functions allowed before the config is applied by default:
+// the health checks and the queries not relying on the config, so operators can probe
+// the chaincode which is not initialized. Use WithPreConfigFunctions to replace them.
+var DefaultPreConfigFunctions = []string{
+	"healthCheck",
+	"healthCheckNb",
+	"validateConfig",
+	"buildInfo",
+	"coreChaincodeIDName",
+}
+
+// checkPreConfigFunction returns ErrNotConfigured if the invoked function is not allowed
+// before the config is applied
+func (cc *Chaincode) checkPreConfigFunction(stub shim.ChaincodeStubInterface) error {
+	functionName, _ := stub.GetFunctionAndParameters()
+	if stringsx.OneOf(functionName, cc.preConfig...) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: function '%s' is not allowed before the config is applied", ErrNotConfigured, functionName)
+}
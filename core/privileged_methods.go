@@ -0,0 +1,56 @@
+package core
+
+// Roles the privileged methods are gated behind
+const (
+	RoleAdmin            = "admin"
+	RoleRobot            = "robot"
+	RoleIssuer           = "issuer"
+	RoleFeeSetter        = "feeSetter"
+	RoleFeeAddressSetter = "feeAddressSetter"
+	RoleEmissionApprover = "emissionApprover"
+)
+
+// PrivilegedMethodsLister is implemented by contracts listing their methods gated behind roles.
+// Contracts adding privileged methods extend the list of the embedded contract.
+type PrivilegedMethodsLister interface {
+	PrivilegedMethods() map[string][]string
+}
+
+// PrivilegedMethods returns the methods of the base contract mapped to the roles required to call them
+func (bc *BaseContract) PrivilegedMethods() map[string][]string {
+	return map[string][]string{
+		"channelTransferByAdmin":       {RoleAdmin},
+		"channelMultiTransferByAdmin":  {RoleAdmin},
+		"cancelAllTransfersForAddress": {RoleAdmin},
+		"pruneTransfers":               {RoleAdmin},
+		"freezeAddress":                {RoleAdmin},
+		"unfreezeAddress":              {RoleAdmin},
+		"setMaintenanceMode":           {RoleAdmin},
+		"pause":                        {RoleAdmin},
+		"unpause":                      {RoleAdmin},
+		"rawState":                     {RoleAdmin},
+		"transferBalance":              {RoleAdmin},
+		"lockTokenBalance":             {RoleAdmin},
+		"unlockTokenBalance":           {RoleAdmin},
+		"lockAllowedBalance":           {RoleAdmin},
+		"unlockAllowedBalance":         {RoleAdmin},
+		CreateCCTransferTo:             {RoleRobot},
+		DeleteCCTransferTo:             {RoleRobot},
+		CommitCCTransferFrom:           {RoleRobot},
+		CancelCCTransferFrom:           {RoleRobot},
+		DeleteCCTransferFrom:           {RoleRobot},
+		PickUpCCTransferFrom:           {RoleRobot},
+		ReapExpiredTransfers:           {RoleRobot},
+	}
+}
+
+// QueryPrivilegedMethods returns the methods of the contract gated behind privileged roles
+// mapped to the roles required to call them, for the security review of the contract.
+func (bc *BaseContract) QueryPrivilegedMethods() (map[string][]string, error) {
+	var lister PrivilegedMethodsLister = bc
+	if configurable, ok := bc.configurable.(PrivilegedMethodsLister); ok {
+		lister = configurable
+	}
+
+	return lister.PrivilegedMethods(), nil
+}
@@ -0,0 +1,85 @@
+package core
+
+import (
+	"bytes"
+
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+)
+
+// SignerPublicKeyCompositeType is a composite key prefix for the public keys addresses are signed with
+const SignerPublicKeyCompositeType = "signer_public_key"
+
+// AddressPublicKey is the public key of the address and its key type
+type AddressPublicKey struct {
+	// PublicKey is the base58 encoded public key, empty if the key of the address is not known
+	PublicKey string `json:"publicKey,omitempty"`
+	KeyType   string `json:"keyType,omitempty"`
+}
+
+// QueryPublicKey returns the public key the address signs with for client-side verification
+// of its signatures. The key the address is rotated to by TxRotateKey is returned if it is set,
+// otherwise the key of the last single signature of the address checked by the contract.
+// Empty result is returned for the address which has not signed yet.
+func (bc *BaseContract) QueryPublicKey(address *types.Address) (*AddressPublicKey, error) {
+	stub := bc.GetStub()
+
+	result := &AddressPublicKey{}
+
+	publicKey, err := loadAddressPublicKey(stub, AddressPublicKeyCompositeType, address.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if len(publicKey) == 0 {
+		if publicKey, err = loadAddressPublicKey(stub, SignerPublicKeyCompositeType, address.String()); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(publicKey) == 0 {
+		return result, nil
+	}
+
+	result.PublicKey = base58.Encode(publicKey)
+
+	keyType, ok, err := loadAddressKeyType(stub, address.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if ok {
+		result.KeyType = keyType.String()
+	}
+
+	return result, nil
+}
+
+// recordSignerPublicKey records the public key of the single signature of the address if it is changed
+func recordSignerPublicKey(stub shim.ChaincodeStubInterface, address string, publicKey []byte) error {
+	recorded, err := loadAddressPublicKey(stub, SignerPublicKeyCompositeType, address)
+	if err != nil {
+		return err
+	}
+
+	if bytes.Equal(recorded, publicKey) {
+		return nil
+	}
+
+	key, err := stub.CreateCompositeKey(SignerPublicKeyCompositeType, []string{address})
+	if err != nil {
+		return err
+	}
+
+	return stub.PutState(key, publicKey)
+}
+
+func loadAddressPublicKey(stub shim.ChaincodeStubInterface, objectType string, address string) ([]byte, error) {
+	key, err := stub.CreateCompositeKey(objectType, []string{address})
+	if err != nil {
+		return nil, err
+	}
+
+	return stub.GetState(key)
+}
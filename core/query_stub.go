@@ -10,6 +10,8 @@ const (
 	errFuncNotImplemented   = ErrMethodNotImplemented + ": %s"
 )
 
+// queryStub is a stub of query invocations discarding state changes and events,
+// so queries are free of side effects even if they share code with transactions, e.g. nonce updates
 type queryStub struct {
 	shim.ChaincodeStubInterface
 }
@@ -0,0 +1,61 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/balance"
+	"github.com/anoideaopen/foundation/core/types"
+)
+
+var ErrRawStateNotAllowed = errors.New("reading raw state of the object type is not allowed")
+
+// rawStateObjectTypes is the allowlist of composite key prefixes available by QueryRawState.
+// Balances are public by design, keys of other object types may hold sensitive data.
+var rawStateObjectTypes = map[string]struct{}{
+	balance.BalanceTypeToken.String():                 {},
+	balance.BalanceTypeTokenLocked.String():           {},
+	balance.BalanceTypeTokenExternalLocked.String():   {},
+	balance.BalanceTypeAllowed.String():               {},
+	balance.BalanceTypeAllowedLocked.String():         {},
+	balance.BalanceTypeAllowedExternalLocked.String(): {},
+	balance.BalanceTypeGiven.String():                 {},
+}
+
+// RawState is the raw stored value of a state key
+type RawState struct {
+	Key    string `json:"key"`
+	Value  []byte `json:"value"`
+	Exists bool   `json:"exists"`
+}
+
+// QueryRawState returns the raw bytes stored at the composite state key made of objectType and attributes,
+// e.g. objectType "2b" and attributes [address] for the token balance of the address.
+// Only the balance object types are allowed. Method can be called by the contract admin only.
+func (bc *BaseContract) QueryRawState(sender *types.Sender, objectType string, attributes []string) (*RawState, error) {
+	if err := bc.CheckAdminSender(sender); err != nil {
+		return nil, err
+	}
+
+	if _, ok := rawStateObjectTypes[objectType]; !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrRawStateNotAllowed, objectType)
+	}
+
+	stub := bc.GetStub()
+
+	key, err := stub.CreateCompositeKey(objectType, attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := stub.GetState(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RawState{
+		Key:    key,
+		Value:  value,
+		Exists: len(value) != 0,
+	}, nil
+}
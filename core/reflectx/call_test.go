@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"strconv"
 	"testing"
 	"time"
 
@@ -90,6 +91,10 @@ func (t *TestStructForCall) Method13(in *TestMultiSwapAssets) *TestMultiSwapAsse
 	return in
 }
 
+func (t *TestStructForCall) Method14(flag bool, count int, limit uint8) {
+	fmt.Printf("flag: %v, count: %v, limit: %v\n", flag, count, limit)
+}
+
 func TestCall(t *testing.T) {
 	input := &TestStructForCall{}
 
@@ -240,6 +245,20 @@ func TestCall(t *testing.T) {
 			wantLen: 0,
 			wantErr: false,
 		},
+		{
+			name:    "Method9 with incorrect int input",
+			method:  "Method9",
+			args:    []string{"12a4"},
+			wantLen: 0,
+			wantErr: true,
+		},
+		{
+			name:    "Method14 with bool and integer flags",
+			method:  "Method14",
+			args:    []string{"True", "+10", "255"},
+			wantLen: 0,
+			wantErr: false,
+		},
 		{
 			name:      "Method10 with a complex MultiSwapAssets input and output",
 			method:    "Method10",
@@ -289,3 +308,61 @@ func TestCall(t *testing.T) {
 		})
 	}
 }
+
+func TestCallCoercionErrors(t *testing.T) {
+	input := &TestStructForCall{}
+
+	tests := []struct {
+		name    string
+		method  string
+		args    []string
+		wantErr error
+		wantMsg string
+	}{
+		{
+			name:    "malformed bool",
+			method:  "Method14",
+			args:    []string{"yes", "1", "1"},
+			wantErr: strconv.ErrSyntax,
+			wantMsg: "invalid argument value: 'yes': for type 'bool': 'invalid syntax': call Method14, argument 0",
+		},
+		{
+			name:    "malformed int",
+			method:  "Method14",
+			args:    []string{"true", "1.5", "1"},
+			wantErr: strconv.ErrSyntax,
+			wantMsg: "invalid argument value: '1.5': for type 'int': 'invalid syntax': call Method14, argument 1",
+		},
+		{
+			name:    "uint out of range",
+			method:  "Method14",
+			args:    []string{"true", "1", "256"},
+			wantErr: strconv.ErrRange,
+			wantMsg: "invalid argument value: '256': for type 'uint8': 'value out of range': call Method14, argument 2",
+		},
+		{
+			name:    "malformed int pointer",
+			method:  "Method9",
+			args:    []string{"ten"},
+			wantErr: strconv.ErrSyntax,
+			wantMsg: "invalid argument value: 'ten': for type '*int': 'invalid syntax': call Method9, argument 0",
+		},
+		{
+			name:    "malformed big.Int",
+			method:  "Method6",
+			args:    []string{"ten"},
+			wantErr: ErrInvalidArgumentValue,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Call(input, tt.method, nil, tt.args...)
+			require.ErrorIs(t, err, ErrInvalidArgumentValue)
+			require.ErrorIs(t, err, tt.wantErr)
+			if tt.wantMsg != "" {
+				require.Equal(t, tt.wantMsg, err.Error())
+			}
+		})
+	}
+}
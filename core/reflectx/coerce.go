@@ -0,0 +1,45 @@
+package reflectx
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+)
+
+// coerceValue parses the string representation of a boolean or an integer argument with strconv,
+// so the flags like "1" or "True" and the integers like "+10" are accepted in addition to the JSON ones.
+// The value is set to out, which must be addressable.
+//
+// Returns:
+//   - bool: False if the kind of out is not a boolean or an integer, the value is not set in this case.
+//   - error: An error if the string is malformed or out of range of the type.
+func coerceValue(s string, out reflect.Value) (bool, error) {
+	var err error
+
+	switch out.Kind() {
+	case reflect.Bool:
+		var v bool
+		if v, err = strconv.ParseBool(s); err == nil {
+			out.SetBool(v)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var v int64
+		if v, err = strconv.ParseInt(s, 10, out.Type().Bits()); err == nil {
+			out.SetInt(v)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var v uint64
+		if v, err = strconv.ParseUint(s, 10, out.Type().Bits()); err == nil {
+			out.SetUint(v)
+		}
+	default:
+		return false, nil
+	}
+
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		return true, numErr.Err
+	}
+
+	return true, err
+}
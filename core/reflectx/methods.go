@@ -77,6 +77,35 @@ func IsArgOfType(v any, method string, i int, argType any) bool {
 	return methodType.In(i) == expectedType
 }
 
+// ArgIndexOfType returns the index of the first argument of the specified method on value 'v'
+// of the given type 'argType'.
+//
+// Parameters:
+//   - v: The value whose method's arguments are to be checked.
+//   - method: The name of the method to inspect.
+//   - argType: An example value of the desired type.
+//
+// Returns:
+//   - int: The index of the argument (0-based), or -1 if there is no argument of the specified type.
+func ArgIndexOfType(v any, method string, argType any) int {
+	inputVal := reflect.ValueOf(v)
+
+	methodVal := inputVal.MethodByName(method)
+	if !methodVal.IsValid() {
+		return -1
+	}
+
+	methodType := methodVal.Type()
+	expectedType := reflect.TypeOf(argType)
+	for i := 0; i < methodType.NumIn(); i++ {
+		if methodType.In(i) == expectedType {
+			return i
+		}
+	}
+
+	return -1
+}
+
 // MethodReturnsError checks if the last return value of the specified method on value 'v' is of type error.
 //
 // Parameters:
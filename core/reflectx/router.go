@@ -9,6 +9,7 @@ import (
 	"github.com/anoideaopen/foundation/core/contract"
 	"github.com/anoideaopen/foundation/core/stringsx"
 	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 )
 
@@ -87,6 +88,10 @@ func (r *Router) Check(method string, args ...string) error {
 // Returns:
 //   - []byte: A slice of bytes (JSON) representing the return values.
 //   - error: An error if the invocation fails.
+//
+// Return values are serialized with encoding/json, so the output is deterministic: struct fields
+// are emitted in declaration order (field number order for generated proto messages) and map keys
+// are sorted. Do not switch to protojson here, its output is intentionally unstable.
 func (r *Router) Invoke(method string, args ...string) ([]byte, error) {
 	var stub shim.ChaincodeStubInterface
 	if stubGetter, ok := r.contract.(contract.StubGetSetter); ok {
@@ -179,6 +184,7 @@ func newReflectEndpoint(name string, of any) (*contract.Method, error) {
 	method.ChaincodeFunc = stringsx.LowerFirstChar(method.ChaincodeFunc)
 	method.NumArgs, _ = MethodParamCounts(of, method.MethodName)
 	method.RequiresAuth = IsArgOfType(of, method.MethodName, 0, &types.Sender{})
+	method.AmountArg = ArgIndexOfType(of, method.MethodName, &big.Int{}) + 1
 
 	return method, nil
 }
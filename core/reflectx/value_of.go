@@ -24,6 +24,7 @@ import (
 //
 // The function follows these steps:
 //  1. Checks if the target type is a string or a pointer to a string and handles these cases directly.
+//     Booleans, integers and pointers to them are parsed by strconv.
 //  2. Attempts to unmarshal the string using the BytesDecoder or StubBytesDecoder code interface if implemented.
 //  3. Attempts to unmarshal the string as JSON if it is valid JSON. Note that simple values such as numbers,
 //     booleans, and null are also valid JSON if they are represented as strings.
@@ -55,6 +56,14 @@ func valueOf(s string, t reflect.Type, stub shim.ChaincodeStubInterface) (reflec
 		return outValue, nil
 	}
 
+	if ok, err := coerceValue(s, argValue.Elem()); ok {
+		if err != nil {
+			return outValue, NewValueError(s, t, err)
+		}
+
+		return outValue, nil
+	}
+
 	argInterface := argValue.Interface()
 
 	if decoder, ok := argInterface.(BytesDecoder); ok {
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+
+	"github.com/anoideaopen/foundation/core/contract"
+	"github.com/anoideaopen/foundation/core/stringsx"
+)
+
+// autogeneratedFile is a file name reported by the runtime for methods promoted from embedded structs
+const autogeneratedFile = "<autogenerated>"
+
+var ErrReservedMethodName = errors.New("method name is reserved by the framework")
+
+// reservedFunctions are handled by the chaincode itself and never reach the contract router.
+var reservedFunctions = []string{
+	BatchExecute,
+	SwapDone,
+	MultiSwapDone,
+	CreateIndex,
+	ExecuteTasks,
+	SignedBatch,
+	ProposeOperation,
+	SubmitSignature,
+	Simulate,
+}
+
+// reservedBaseFunctions are implemented by BaseContract and relied upon by the framework
+// and its clients, so contracts can not redefine them.
+var reservedBaseFunctions = []string{
+	"getNonce",
+	"healthCheck",
+	"healthCheckNb",
+	CreateCCTransferTo,
+	DeleteCCTransferTo,
+	CommitCCTransferFrom,
+	CancelCCTransferFrom,
+	DeleteCCTransferFrom,
+	PickUpCCTransferFrom,
+}
+
+// checkReservedMethods returns ErrReservedMethodName if the contract defines
+// a method colliding with a name reserved by the framework.
+func checkReservedMethods(of any, methods map[contract.Function]contract.Method) error {
+	for fn, method := range methods {
+		if stringsx.OneOf(fn, reservedFunctions...) ||
+			(stringsx.OneOf(fn, reservedBaseFunctions...) && !isBaseContractMethod(of, method.MethodName)) {
+			return fmt.Errorf("%w: method '%s' (%s)", ErrReservedMethodName, fn, method.MethodName)
+		}
+	}
+
+	return nil
+}
+
+// isBaseContractMethod reports whether the method of the contract is the one
+// of BaseContract, either directly or promoted through the embedded struct.
+func isBaseContractMethod(of any, name string) bool {
+	m, ok := reflect.TypeOf(of).MethodByName(name)
+	if !ok {
+		return false
+	}
+
+	base, ok := reflect.TypeOf(&BaseContract{}).MethodByName(name)
+	if ok && base.Func.Pointer() == m.Func.Pointer() {
+		return true
+	}
+
+	fn := runtime.FuncForPC(m.Func.Pointer())
+	if fn == nil {
+		return false
+	}
+
+	file, _ := fn.FileLine(fn.Entry())
+
+	return ok && file == autogeneratedFile
+}
@@ -0,0 +1,33 @@
+package core
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/anoideaopen/foundation/hlfcreator"
+)
+
+var ErrNotRobotCreator = errors.New("unauthorized: robotSKI is not equal creatorSKI and hashedCert")
+
+// CheckRobotCreator returns ErrNotRobotCreator if the transaction is not created with the robot certificate
+// set by robotSKI of the contract config. Contract methods executed by the robot call it the same way
+// as the chaincode checks the creator of the channel transfer functions.
+func (bc *BaseContract) CheckRobotCreator() error {
+	creator, err := bc.GetStub().GetCreator()
+	if err != nil {
+		return fmt.Errorf("getting creator: %w", err)
+	}
+
+	creatorSKI, hashedCert, err := hlfcreator.CreatorSKIAndHashedCert(creator)
+	if err != nil {
+		return fmt.Errorf("validating creator: %w", err)
+	}
+
+	robotSKIBytes, _ := hex.DecodeString(bc.ContractConfig().GetRobotSKI())
+	if err = hlfcreator.ValidateSKI(robotSKIBytes, creatorSKI, hashedCert); err != nil {
+		return fmt.Errorf("%w: %s", ErrNotRobotCreator, err.Error())
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/keys"
+	pb "github.com/anoideaopen/foundation/proto"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+)
+
+// AddressPublicKeyCompositeType is a composite key prefix for the public keys addresses are rotated to
+const AddressPublicKeyCompositeType = "address_public_key"
+
+var (
+	ErrInvalidPublicKey  = errors.New("invalid public key")
+	ErrUnknownKeyType    = errors.New("unknown key type")
+	ErrPublicKeyMismatch = errors.New("public key differs from the public key the address is rotated to")
+)
+
+// TxRotateKey rotates the key of the sender address to newPublicKey (base58 encoded) of newKeyType.
+// The transaction must be signed by the current key of the address. After the rotation the contract
+// accepts single signatures of the address made by the new key only.
+//
+// The address is derived from the public key by ACL, so the contract does not change
+// the address itself: the key of the address must be changed in ACL to the same new key,
+// otherwise signatures of the new key resolve to another address.
+func (bc *BaseContract) TxRotateKey(sender *types.Sender, newPublicKey string, newKeyType string) error {
+	value, ok := pb.KeyType_value[newKeyType]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownKeyType, newKeyType)
+	}
+	keyType := pb.KeyType(value)
+
+	publicKey := base58.Decode(newPublicKey)
+	if !validPublicKeyLength(publicKey, keyType) {
+		return fmt.Errorf("%w: %d bytes key of type %s", ErrInvalidPublicKey, len(publicKey), keyType)
+	}
+
+	stub := bc.GetStub()
+	address := sender.Address().String()
+
+	if err := saveAddressKeyType(stub, address, keyType); err != nil {
+		return err
+	}
+
+	return saveAddressPublicKey(stub, address, publicKey)
+}
+
+// checkAddressPublicKey returns ErrPublicKeyMismatch if the key of the address is rotated
+// and the public key differs from the rotated one.
+func checkAddressPublicKey(stub shim.ChaincodeStubInterface, address string, publicKey []byte) error {
+	rotated, err := loadAddressPublicKey(stub, AddressPublicKeyCompositeType, address)
+	if err != nil {
+		return err
+	}
+
+	if len(rotated) == 0 || bytes.Equal(rotated, publicKey) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: address %s", ErrPublicKeyMismatch, address)
+}
+
+func saveAddressPublicKey(stub shim.ChaincodeStubInterface, address string, publicKey []byte) error {
+	key, err := stub.CreateCompositeKey(AddressPublicKeyCompositeType, []string{address})
+	if err != nil {
+		return err
+	}
+
+	return stub.PutState(key, publicKey)
+}
+
+func validPublicKeyLength(publicKey []byte, keyType pb.KeyType) bool {
+	switch keyType {
+	case pb.KeyType_ed25519:
+		return len(publicKey) == keys.KeyLengthEd25519
+	case pb.KeyType_secp256k1:
+		return len(publicKey) == keys.KeyLengthSecp256k1 && publicKey[0] == keys.PrefixUncompressedSecp259k1Key
+	case pb.KeyType_gost:
+		return len(publicKey) == keys.KeyLengthGOST
+	default:
+		return false
+	}
+}
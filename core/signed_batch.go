@@ -0,0 +1,149 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/cachestub"
+	"github.com/anoideaopen/foundation/core/contract"
+	"github.com/anoideaopen/foundation/core/telemetry"
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"go.opentelemetry.io/otel/codes"
+)
+
+var (
+	ErrSignedBatchEmpty       = errors.New("signed batch has no calls")
+	ErrSignedBatchQueryMethod = errors.New("query methods are not allowed in signed batch")
+)
+
+// SignedBatchCall is a single method call of the signed batch.
+// Args are the method arguments without the sender.
+type SignedBatchCall struct {
+	Method string   `json:"method"`
+	Args   []string `json:"args"`
+}
+
+// signedBatchMethod describes the signedBatch function for the signature validation:
+// the sender and the JSON encoded list of calls.
+var signedBatchMethod = contract.Method{
+	Type:          contract.MethodTypeInvoke,
+	ChaincodeFunc: SignedBatch,
+	MethodName:    SignedBatch,
+	RequiresAuth:  true,
+	NumArgs:       2, //nolint:gomnd
+}
+
+// signedBatchHandler executes several method calls authorized by one signature and nonce.
+// Arguments have the same layout as for any signed method with the single argument
+// containing the JSON encoded list of SignedBatchCall:
+// [requestID, chaincode, channel, calls, nonce, public keys..., signatures...].
+//
+// The calls are executed one by one on the cached state which is written to the ledger
+// only if all of them succeed, so the batch is applied atomically.
+// Returns the JSON encoded list of the call results.
+func (cc *Chaincode) signedBatchHandler(
+	traceCtx telemetry.TraceContext,
+	stub shim.ChaincodeStubInterface,
+	args []string,
+) peer.Response {
+	traceCtx, span := cc.contract.TracingHandler().StartNewSpan(traceCtx, "chaincode.SignedBatchHandler")
+	defer span.End()
+
+	result, err := cc.signedBatch(traceCtx, stub, args)
+	if err != nil {
+		errMsg := "signed batch: " + err.Error()
+		span.SetStatus(codes.Error, errMsg)
+		return shim.Error(errMsg)
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return shim.Success(result)
+}
+
+func (cc *Chaincode) signedBatch(
+	traceCtx telemetry.TraceContext,
+	stub shim.ChaincodeStubInterface,
+	args []string,
+) ([]byte, error) {
+	if err := checkArgsSize(args, cc.contract.ContractConfig().GetOptions().GetMaxArgsSize()); err != nil {
+		return nil, err
+	}
+
+	sender, args, nonce, err := cc.validateAndExtractInvocationContext(stub, signedBatchMethod, args)
+	if err != nil {
+		return nil, err
+	}
+
+	var calls []SignedBatchCall
+	if err = json.Unmarshal([]byte(args[0]), &calls); err != nil {
+		return nil, fmt.Errorf("unmarshalling calls: %w", err)
+	}
+
+	if len(calls) == 0 {
+		return nil, ErrSignedBatchEmpty
+	}
+
+	batchStub := cachestub.NewBatchCacheStub(
+		withEventNamePrefix(stub, cc.contract.ContractConfig().GetOptions().GetEventNamePrefix()),
+	)
+
+	options := cc.contract.ContractConfig().GetOptions()
+	namespace := nonceNamespace(options, signedBatchMethod.ChaincodeFunc)
+	if err = checkNonce(batchStub, types.NewSenderFromAddr((*types.Address)(sender)), options, namespace, nonce); err != nil {
+		return nil, err
+	}
+
+	results := make([]json.RawMessage, 0, len(calls))
+	for i, call := range calls {
+		result, err := cc.signedBatchCall(traceCtx, batchStub, sender, call, nonce)
+		if err != nil {
+			return nil, fmt.Errorf("call %d '%s': %w", i, call.Method, err)
+		}
+
+		if len(result) == 0 {
+			result = []byte("null")
+		}
+		results = append(results, result)
+	}
+
+	if err = batchStub.Commit(); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(results)
+}
+
+func (cc *Chaincode) signedBatchCall(
+	traceCtx telemetry.TraceContext,
+	stub *cachestub.BatchCacheStub,
+	sender *proto.Address,
+	call SignedBatchCall,
+	nonce uint64,
+) ([]byte, error) {
+	method, err := cc.Method(call.Method)
+	if err != nil {
+		return nil, err
+	}
+
+	if method.Type == contract.MethodTypeQuery {
+		return nil, ErrSignedBatchQueryMethod
+	}
+
+	if isMethodDisabled(method.MethodName, cc.contract.ContractConfig().GetOptions()) {
+		return nil, fmt.Errorf("method '%s' not found", call.Method)
+	}
+
+	if err = checkMaintenanceMode(stub, method, cc.contract.ContractConfig().GetOptions().GetMaintenanceAllowedFunctions()); err != nil {
+		return nil, err
+	}
+
+	if err = cc.Router().Check(method.MethodName, cc.PrependSender(method, sender, call.Args)...); err != nil {
+		return nil, err
+	}
+
+	return cc.invokeContractMethodWithNonce(traceCtx, stub, method, sender, call.Args, nonce)
+}
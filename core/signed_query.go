@@ -0,0 +1,48 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/contract"
+	"github.com/anoideaopen/foundation/core/stringsx"
+	"github.com/anoideaopen/foundation/core/types"
+	pb "github.com/anoideaopen/foundation/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+)
+
+var ErrQueryNotAuthenticated = errors.New("query must be signed by the queried address")
+
+// isQueryProtected reports whether the query method is in the protected queries of the chaincode options.
+// Signed queries do not need the protection, they are always authenticated.
+func isQueryProtected(method contract.Method, opts *pb.ChaincodeOptions) bool {
+	return method.Type == contract.MethodTypeQuery &&
+		!method.RequiresAuth &&
+		stringsx.OneOf(method.MethodName, opts.GetProtectedQueries()...)
+}
+
+// authenticateQuery verifies the signature of the protected query the same way as the signature
+// of a signed method and checks that the signer is the queried address, the first argument of the query.
+// Returns the query arguments without the authorization arguments.
+// The nonce of the query is not checked, since queries do not change the state.
+func (cc *Chaincode) authenticateQuery(
+	stub shim.ChaincodeStubInterface,
+	method contract.Method,
+	args []string,
+) ([]string, error) {
+	// the signer is validated as the sender argument the query does not have
+	signed := method
+	signed.RequiresAuth = true
+	signed.NumArgs++
+
+	sender, queryArgs, _, err := cc.validateAndExtractInvocationContext(stub, signed, args)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrQueryNotAuthenticated, err.Error())
+	}
+
+	if len(queryArgs) == 0 || queryArgs[0] != (*types.Address)(sender).String() {
+		return nil, ErrQueryNotAuthenticated
+	}
+
+	return queryArgs, nil
+}
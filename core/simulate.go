@@ -0,0 +1,141 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/anoideaopen/foundation/core/cachestub"
+	"github.com/anoideaopen/foundation/core/contract"
+	"github.com/anoideaopen/foundation/core/telemetry"
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"go.opentelemetry.io/otel/codes"
+)
+
+var (
+	ErrSimulateNoMethod    = errors.New("method to simulate is not specified")
+	ErrSimulateQueryMethod = errors.New("query methods can not be simulated")
+)
+
+// Simulation is the result of the simulated transaction
+type Simulation struct {
+	Method string `json:"method"`
+	// Error is the error the transaction would fail with, the other fields are empty then
+	Error string `json:"error,omitempty"`
+	// Result is the result the method would return
+	Result json.RawMessage `json:"result,omitempty"`
+	// Writes are the state changes the transaction would make ordered by key
+	Writes []*proto.WriteElement `json:"writes,omitempty"`
+	// Events are the events the transaction would set
+	Events []*proto.Event `json:"events,omitempty"`
+	// Accounting are the balance changes the transaction would make
+	Accounting []*proto.AccountingRecord `json:"accounting,omitempty"`
+}
+
+// simulateHandler executes the transaction method on the cached state without writing it to the ledger
+// and returns the JSON encoded Simulation. Arguments are the chaincode function followed by the arguments
+// of its call, signed methods must be signed as for the invocation: [function, args...].
+// The transaction is checked the same way as on the invocation and executed immediately,
+// the batched methods are checked for the nonce like on the batch execution.
+// The simulation is meant to be evaluated as a query, but does not change the state even if submitted.
+func (cc *Chaincode) simulateHandler(
+	traceCtx telemetry.TraceContext,
+	stub shim.ChaincodeStubInterface,
+	args []string,
+) peer.Response {
+	traceCtx, span := cc.contract.TracingHandler().StartNewSpan(traceCtx, "chaincode.SimulateHandler")
+	defer span.End()
+
+	if len(args) == 0 {
+		errMsg := "simulate: " + ErrSimulateNoMethod.Error()
+		span.SetStatus(codes.Error, errMsg)
+		return shim.Error(errMsg)
+	}
+
+	simulation := &Simulation{Method: args[0]}
+	if err := cc.simulate(traceCtx, stub, simulation, args[1:]); err != nil {
+		*simulation = Simulation{Method: args[0], Error: err.Error()}
+	}
+
+	data, err := json.Marshal(simulation)
+	if err != nil {
+		return shim.Error(err.Error())
+	}
+
+	span.SetStatus(codes.Ok, "")
+	return shim.Success(data)
+}
+
+func (cc *Chaincode) simulate(
+	traceCtx telemetry.TraceContext,
+	stub shim.ChaincodeStubInterface,
+	simulation *Simulation,
+	args []string,
+) error {
+	options := cc.contract.ContractConfig().GetOptions()
+
+	if err := checkArgsSize(args, options.GetMaxArgsSize()); err != nil {
+		return err
+	}
+
+	method, err := cc.Method(simulation.Method)
+	if err != nil {
+		return err
+	}
+
+	if method.Type == contract.MethodTypeQuery {
+		return ErrSimulateQueryMethod
+	}
+
+	if isMethodDisabled(method.MethodName, options) {
+		return fmt.Errorf("method '%s' not found", simulation.Method)
+	}
+
+	txStub := cachestub.NewBatchCacheStub(stub).NewTxCacheStub(stub.GetTxID())
+
+	if err = checkMaintenanceMode(txStub, method, options.GetMaintenanceAllowedFunctions()); err != nil {
+		return err
+	}
+
+	sender, args, nonce, err := cc.validateAndExtractInvocationContext(txStub, method, args)
+	if err != nil {
+		return err
+	}
+
+	if err = cc.Router().Check(method.MethodName, cc.PrependSender(method, sender, args)...); err != nil {
+		return err
+	}
+
+	// the nonce is checked on the batch execution only
+	if method.Type == contract.MethodTypeTransaction && method.RequiresAuth {
+		namespace := nonceNamespace(options, method.ChaincodeFunc)
+		if err = checkNonce(txStub, types.NewSenderFromAddr((*types.Address)(sender)), options, namespace, nonce); err != nil {
+			return err
+		}
+	}
+
+	result, err := cc.invokeContractMethodWithNonce(traceCtx, txStub, method, sender, args, nonce)
+	if err != nil {
+		return err
+	}
+
+	if len(result) != 0 {
+		simulation.Result = result
+	}
+
+	writes, events := txStub.Commit()
+	simulation.Writes = writes
+	simulation.Events = prefixEventNames(events, options.GetEventNamePrefix())
+
+	sort.Slice(txStub.Accounting, func(i, j int) bool {
+		return strings.Compare(txStub.Accounting[i].String(), txStub.Accounting[j].String()) < 0
+	})
+	simulation.Accounting = txStub.Accounting
+
+	return nil
+}
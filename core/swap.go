@@ -12,6 +12,7 @@ import (
 	"github.com/anoideaopen/foundation/core/swap"
 	"github.com/anoideaopen/foundation/core/types"
 	"github.com/anoideaopen/foundation/core/types/big"
+	"github.com/anoideaopen/foundation/core/walletaudit"
 	"github.com/anoideaopen/foundation/proto"
 	"github.com/hyperledger/fabric-chaincode-go/shim"
 	"github.com/hyperledger/fabric-protos-go/peer"
@@ -21,6 +22,8 @@ const (
 	userSideTimeout = 10800 // 3 hours
 )
 
+var ErrInvalidSwapsPageSize = errors.New("page size must be positive")
+
 // swapDoneHandler processes a request to mark a swap as done.
 // If the ChainCode is configured to disable swaps, it will immediately return an error.
 //
@@ -47,6 +50,67 @@ func (bc *BaseContract) QuerySwapGet(swapID string) (*proto.Swap, error) {
 	return swap, nil
 }
 
+// SwapsPage is a page of the swaps
+type SwapsPage struct {
+	Swaps           []*proto.Swap `json:"swaps"`
+	Bookmark        string        `json:"bookmark,omitempty"`
+	PageSizeClamped bool          `json:"pageSizeClamped,omitempty"`
+}
+
+// QuerySwapsByToken returns a page of the active swaps of the token symbol. The grouped tokens
+// (e.g. "TT_testGroup") are matched by the base symbol, so the swaps of all groups of the token are returned.
+// Swaps created before the index was introduced are not returned.
+func (bc *BaseContract) QuerySwapsByToken(symbol string, pageSize int64, bookmark string) (*SwapsPage, error) {
+	if pageSize <= 0 {
+		return nil, ErrInvalidSwapsPageSize
+	}
+
+	pageSize, clamped := bc.ClampPageSize(pageSize)
+
+	stub := bc.GetStub()
+
+	iter, meta, err := stub.GetStateByPartialCompositeKeyWithPagination(
+		swap.SwapTokenCompositeType,
+		[]string{strings.ToUpper(tokenSymbol(bc.ResolveToken(symbol)))},
+		int32(pageSize),
+		bookmark,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	page := &SwapsPage{Swaps: []*proto.Swap{}, PageSizeClamped: clamped}
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		_, components, err := stub.SplitCompositeKey(kv.GetKey())
+		if err != nil {
+			return nil, err
+		}
+
+		if len(components) != 2 {
+			continue
+		}
+
+		s, err := swap.Load(stub, components[1])
+		if err != nil {
+			return nil, err
+		}
+
+		page.Swaps = append(page.Swaps, s)
+	}
+
+	page.Bookmark = meta.GetBookmark()
+
+	return page, nil
+}
+
 // TxSwapBegin creates swap
 func (bc *BaseContract) TxSwapBegin(
 	sender *types.Sender,
@@ -55,6 +119,14 @@ func (bc *BaseContract) TxSwapBegin(
 	amount *big.Int,
 	hash types.Hex,
 ) (string, error) {
+	if err := bc.CheckPaused(); err != nil {
+		return "", err
+	}
+
+	if err := bc.CheckDenylist(sender.Address()); err != nil {
+		return "", err
+	}
+
 	id, err := hex.DecodeString(bc.GetStub().GetTxID())
 	if err != nil {
 		return "", err
@@ -67,7 +139,7 @@ func (bc *BaseContract) TxSwapBegin(
 		Id:      id,
 		Creator: sender.Address().Bytes(),
 		Owner:   sender.Address().Bytes(),
-		Token:   token,
+		Token:   bc.ResolveToken(token),
 		Amount:  amount.Bytes(),
 		From:    bc.config.GetSymbol(),
 		To:      contractTo,
@@ -88,6 +160,10 @@ func (bc *BaseContract) TxSwapBegin(
 		return "", errors.New(swap.ErrIncorrectSwap)
 	}
 
+	if err = bc.WalletAuditAdd(types.AddrFromBytes(s.GetOwner()), walletaudit.SwapOut, s.GetToken(), amount, s.GetTo()); err != nil {
+		return "", err
+	}
+
 	if err = swap.Save(bc.GetStub(), bc.GetStub().GetTxID(), &s); err != nil {
 		return "", err
 	}
@@ -127,12 +203,18 @@ func (bc *BaseContract) TxSwapCancel(_ *types.Sender, swapID string) error { //
 		if err = bc.TokenBalanceAddWithTicker(types.AddrFromBytes(s.GetOwner()), new(big.Int).SetBytes(s.GetAmount()), s.GetToken(), "swap cancel"); err != nil {
 			return err
 		}
+		if err = bc.WalletAuditAdd(types.AddrFromBytes(s.GetOwner()), walletaudit.SwapCancel, s.GetToken(), new(big.Int).SetBytes(s.GetAmount()), s.GetTo()); err != nil {
+			return err
+		}
 	case bytes.Equal(s.GetCreator(), s.GetOwner()) && s.TokenSymbol() == s.GetTo():
 		if err = bc.AllowedBalanceAdd(s.GetToken(), types.AddrFromBytes(s.GetOwner()), new(big.Int).SetBytes(s.GetAmount()), "reverse swap cancel"); err != nil {
 			return err
 		}
+		if err = bc.WalletAuditAdd(types.AddrFromBytes(s.GetOwner()), walletaudit.SwapCancel, s.GetToken(), new(big.Int).SetBytes(s.GetAmount()), s.GetTo()); err != nil {
+			return err
+		}
 	case bytes.Equal(s.GetCreator(), []byte("0000")) && s.TokenSymbol() == s.GetTo():
-		if err = balance.Add(bc.GetStub(), balance.BalanceTypeGiven, strings.ToUpper(s.GetFrom()), "", new(mathbig.Int).SetBytes(s.GetAmount())); err != nil {
+		if err = balance.Add(bc.BalanceStub(), balance.BalanceTypeGiven, strings.ToUpper(s.GetFrom()), "", new(mathbig.Int).SetBytes(s.GetAmount())); err != nil {
 			return err
 		}
 	}
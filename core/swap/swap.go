@@ -13,6 +13,7 @@ import (
 	"github.com/anoideaopen/foundation/core/cachestub"
 	"github.com/anoideaopen/foundation/core/types"
 	"github.com/anoideaopen/foundation/core/types/big"
+	"github.com/anoideaopen/foundation/core/walletaudit"
 	"github.com/anoideaopen/foundation/proto"
 	pb "github.com/golang/protobuf/proto" //nolint:staticcheck
 	"github.com/hyperledger/fabric-chaincode-go/shim"
@@ -23,6 +24,8 @@ import (
 const (
 	// SwapCompositeType is a composite key for swap
 	SwapCompositeType = "swaps"
+	// SwapTokenCompositeType is a composite key for the index of swaps by the token symbol
+	SwapTokenCompositeType = "swap_token"
 	// SwapKeyEvent is a reason for swap
 	SwapKeyEvent = "key"
 
@@ -37,9 +40,11 @@ type BaseContractInterface interface {
 	GetStub() shim.ChaincodeStubInterface
 	AllowedBalanceAdd(token string, address *types.Address, amount *big.Int, reason string) error
 	TokenBalanceAdd(address *types.Address, amount *big.Int, reason string) error
+	CheckDenylist(addresses ...*types.Address) error
+	WalletAuditAdd(address *types.Address, eventType string, token string, amount *big.Int, counterparty string) error
 }
 
-func Answer(stub *cachestub.BatchCacheStub, swap *proto.Swap, robotSideTimeout int64) (r *proto.SwapResponse) {
+func Answer(stub *cachestub.BatchCacheStub, swap *proto.Swap, robotSideTimeout int64, codec balance.Codec) (r *proto.SwapResponse) {
 	r = &proto.SwapResponse{Id: swap.GetId(), Error: &proto.ResponseError{Error: "panic swapAnswer"}}
 	defer func() {
 		if rc := recover(); rc != nil {
@@ -60,7 +65,7 @@ func Answer(stub *cachestub.BatchCacheStub, swap *proto.Swap, robotSideTimeout i
 	case swap.TokenSymbol() == swap.GetFrom():
 		// nothing to do
 	case swap.TokenSymbol() == swap.GetTo():
-		if err = balance.Sub(txStub, balance.BalanceTypeGiven, strings.ToUpper(swap.GetFrom()), "", new(mathbig.Int).SetBytes(swap.GetAmount())); err != nil {
+		if err = balance.Sub(balance.WithCodec(txStub, codec), balance.BalanceTypeGiven, strings.ToUpper(swap.GetFrom()), "", new(mathbig.Int).SetBytes(swap.GetAmount())); err != nil {
 			return &proto.SwapResponse{Id: swap.GetId(), Error: &proto.ResponseError{Error: err.Error()}}
 		}
 	default:
@@ -74,7 +79,7 @@ func Answer(stub *cachestub.BatchCacheStub, swap *proto.Swap, robotSideTimeout i
 	return &proto.SwapResponse{Id: swap.GetId(), Writes: writes}
 }
 
-func RobotDone(stub *cachestub.BatchCacheStub, swapID []byte, key string) (r *proto.SwapResponse) {
+func RobotDone(stub *cachestub.BatchCacheStub, swapID []byte, key string, codec balance.Codec) (r *proto.SwapResponse) {
 	r = &proto.SwapResponse{Id: swapID, Error: &proto.ResponseError{Error: "panic swapRobotDone"}}
 	defer func() {
 		if rc := recover(); rc != nil {
@@ -93,7 +98,7 @@ func RobotDone(stub *cachestub.BatchCacheStub, swapID []byte, key string) (r *pr
 	}
 
 	if s.TokenSymbol() == s.GetFrom() {
-		if err = balance.Add(txStub, balance.BalanceTypeGiven, strings.ToUpper(s.GetTo()), "", new(mathbig.Int).SetBytes(s.GetAmount())); err != nil {
+		if err = balance.Add(balance.WithCodec(txStub, codec), balance.BalanceTypeGiven, strings.ToUpper(s.GetTo()), "", new(mathbig.Int).SetBytes(s.GetAmount())); err != nil {
 			return &proto.SwapResponse{Id: swapID, Error: &proto.ResponseError{Error: err.Error()}}
 		}
 	}
@@ -117,6 +122,10 @@ func UserDone(bci BaseContractInterface, swapID string, key string) peer.Respons
 	if bytes.Equal(s.GetCreator(), s.GetOwner()) {
 		return shim.Error(ErrIncorrectSwap)
 	}
+
+	if err = bci.CheckDenylist(types.AddrFromBytes(s.GetOwner())); err != nil {
+		return shim.Error(err.Error())
+	}
 	if s.TokenSymbol() == s.GetFrom() {
 		if err = bci.AllowedBalanceAdd(s.GetToken(), types.AddrFromBytes(s.GetOwner()), new(big.Int).SetBytes(s.GetAmount()), "swap done"); err != nil {
 			return shim.Error(err.Error())
@@ -127,6 +136,10 @@ func UserDone(bci BaseContractInterface, swapID string, key string) peer.Respons
 		}
 	}
 
+	if err = bci.WalletAuditAdd(types.AddrFromBytes(s.GetOwner()), walletaudit.SwapIn, s.GetToken(), new(big.Int).SetBytes(s.GetAmount()), s.GetFrom()); err != nil {
+		return shim.Error(err.Error())
+	}
+
 	if err = Delete(bci.GetStub(), swapID); err != nil {
 		return shim.Error(err.Error())
 	}
@@ -178,7 +191,7 @@ func Load(stub shim.ChaincodeStubInterface, swapID string) (*proto.Swap, error)
 	return &s, nil
 }
 
-// Save saves swap
+// Save saves swap and indexes it by the token symbol
 func Save(stub shim.ChaincodeStubInterface, swapID string, s *proto.Swap) error {
 	key, err := stub.CreateCompositeKey(SwapCompositeType, []string{swapID})
 	if err != nil {
@@ -188,14 +201,45 @@ func Save(stub shim.ChaincodeStubInterface, swapID string, s *proto.Swap) error
 	if err != nil {
 		return err
 	}
-	return stub.PutState(key, data)
+	if err = stub.PutState(key, data); err != nil {
+		return err
+	}
+
+	indexKey, err := TokenIndexKey(stub, s.TokenSymbol(), swapID)
+	if err != nil {
+		return err
+	}
+	return stub.PutState(indexKey, []byte{1})
 }
 
-// Delete deletes swap
+// Delete deletes swap and its token index entry
 func Delete(stub shim.ChaincodeStubInterface, swapID string) error {
 	key, err := stub.CreateCompositeKey(SwapCompositeType, []string{swapID})
 	if err != nil {
 		return err
 	}
+	data, err := stub.GetState(key)
+	if err != nil {
+		return err
+	}
+	if data != nil {
+		var s proto.Swap
+		if err = pb.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		indexKey, err := TokenIndexKey(stub, s.TokenSymbol(), swapID)
+		if err != nil {
+			return err
+		}
+		if err = stub.DelState(indexKey); err != nil {
+			return err
+		}
+	}
 	return stub.DelState(key)
 }
+
+// TokenIndexKey returns the key of the swap in the index by the token symbol.
+// The symbol is the base symbol of the token without the group, e.g. "TT" for "TT_testGroup".
+func TokenIndexKey(stub shim.ChaincodeStubInterface, symbol string, swapID string) (string, error) {
+	return stub.CreateCompositeKey(SwapTokenCompositeType, []string{strings.ToUpper(symbol), swapID})
+}
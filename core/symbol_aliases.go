@@ -0,0 +1,28 @@
+package core
+
+import (
+	"strings"
+)
+
+// ResolveToken returns the token with the symbol replaced by the canonical one if the symbol is an alias
+// listed in symbol_aliases of the contract config, e.g. "CCX" and "CCX_T1" are resolved to "CC" and "CC_T1"
+// by the alias CCX of CC. Other tokens are returned unchanged.
+func (bc *BaseContract) ResolveToken(token string) string {
+	aliases := bc.config.GetSymbolAliases()
+	if len(aliases) == 0 {
+		return token
+	}
+
+	symbol, ticker, group := strings.Cut(token, "_")
+
+	canonical, ok := aliases[symbol]
+	if !ok {
+		return token
+	}
+
+	if !group {
+		return canonical
+	}
+
+	return canonical + "_" + ticker
+}
@@ -129,12 +129,13 @@ func (e *TaskExecutor) ExecuteTasks(
 	return batchResponse, batchEvent, nil
 }
 
-// validatedTxSenderMethodAndArgs validates the sender, method, and arguments for a transaction.
+// validatedTxSenderMethodAndArgs validates the sender, method, and arguments for a transaction
+// and returns them with the nonce of the task.
 func (e *TaskExecutor) validatedTxSenderMethodAndArgs(
 	traceCtx telemetry.TraceContext,
 	stub *cachestub.BatchCacheStub,
 	task *proto.Task,
-) (*proto.Address, contract.Method, []string, error) {
+) (*proto.Address, contract.Method, []string, uint64, error) {
 	_, span := e.TracingHandler.StartNewSpan(traceCtx, "TaskExecutor.validatedTxSenderMethodAndArgs")
 	defer span.End()
 
@@ -143,7 +144,7 @@ func (e *TaskExecutor) validatedTxSenderMethodAndArgs(
 	if err != nil {
 		err = fmt.Errorf("failed to parse chaincode method '%s' for task %s: %w", task.GetMethod(), task.GetId(), err)
 		span.SetStatus(codes.Error, err.Error())
-		return nil, contract.Method{}, nil, err
+		return nil, contract.Method{}, nil, 0, err
 	}
 
 	span.AddEvent("validating and extracting invocation context")
@@ -151,14 +152,14 @@ func (e *TaskExecutor) validatedTxSenderMethodAndArgs(
 	if err != nil {
 		err = fmt.Errorf("failed to validate and extract invocation context for task %s: %w", task.GetId(), err)
 		span.SetStatus(codes.Error, err.Error())
-		return nil, contract.Method{}, nil, err
+		return nil, contract.Method{}, nil, 0, err
 	}
 
 	span.AddEvent("validating authorization")
 	if !method.RequiresAuth || senderAddress == nil {
 		err = fmt.Errorf("failed to validate authorization for task %s: sender address is missing", task.GetId())
 		span.SetStatus(codes.Error, err.Error())
-		return nil, contract.Method{}, nil, err
+		return nil, contract.Method{}, nil, 0, err
 	}
 	argsToValidate := append([]string{senderAddress.AddrString()}, args...)
 
@@ -166,19 +167,20 @@ func (e *TaskExecutor) validatedTxSenderMethodAndArgs(
 	if err = e.Chaincode.Router().Check(method.MethodName, argsToValidate...); err != nil {
 		err = fmt.Errorf("failed to validate arguments for task %s: %w", task.GetId(), err)
 		span.SetStatus(codes.Error, err.Error())
-		return nil, contract.Method{}, nil, err
+		return nil, contract.Method{}, nil, 0, err
 	}
 
 	span.AddEvent("validating nonce")
 	sender := types.NewSenderFromAddr((*types.Address)(senderAddress))
-	err = checkNonce(stub, sender, nonce)
+	options := e.Chaincode.contract.ContractConfig().GetOptions()
+	err = checkNonce(stub, sender, options, nonceNamespace(options, method.ChaincodeFunc), nonce)
 	if err != nil {
 		err = fmt.Errorf("failed to validate nonce for task %s, nonce %d: %w", task.GetId(), nonce, err)
 		span.SetStatus(codes.Error, err.Error())
-		return nil, contract.Method{}, nil, err
+		return nil, contract.Method{}, nil, 0, err
 	}
 
-	return senderAddress, method, args[:method.NumArgs-1], nil
+	return senderAddress, method, args[:method.NumArgs-1], nonce, nil
 }
 
 // ExecuteTask processes an individual task, returning a transaction response and event.
@@ -203,20 +205,21 @@ func (e *TaskExecutor) ExecuteTask(
 	txCacheStub := stub.NewTxCacheStub(task.GetId())
 
 	span.AddEvent("validating tx sender method and args")
-	senderAddress, method, args, err := e.validatedTxSenderMethodAndArgs(traceCtx, stub, task)
+	senderAddress, method, args, nonce, err := e.validatedTxSenderMethodAndArgs(traceCtx, stub, task)
 	if err != nil {
 		err = fmt.Errorf("failed to validate transaction sender, method, and arguments for task %s: %w", task.GetId(), err)
 		return handleTaskError(span, task, err)
 	}
 
 	span.AddEvent("calling method")
-	response, err := e.Chaincode.InvokeContractMethod(traceCtx, txCacheStub, method, senderAddress, args)
+	response, err := e.Chaincode.invokeContractMethodWithNonce(traceCtx, txCacheStub, method, senderAddress, args, nonce)
 	if err != nil {
 		return handleTaskError(span, task, err)
 	}
 
 	span.AddEvent("commit")
 	writes, events := txCacheStub.Commit()
+	events = prefixEventNames(events, e.Chaincode.contract.ContractConfig().GetOptions().GetEventNamePrefix())
 
 	sort.Slice(txCacheStub.Accounting, func(i, j int) bool {
 		return strings.Compare(txCacheStub.Accounting[i].String(), txCacheStub.Accounting[j].String()) < 0
@@ -1,6 +1,12 @@
 package telemetry
 
-import "go.opentelemetry.io/otel/attribute"
+import (
+	"crypto/hmac"
+	"encoding/hex"
+
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/crypto/sha3"
+)
 
 type MethodTypeNum int
 
@@ -29,3 +35,52 @@ const (
 func MethodType(t MethodTypeNum) attribute.KeyValue {
 	return attribute.String("method_type", t.String())
 }
+
+// Keys of the standard span attributes set for contract method calls.
+const (
+	AttributeMethod     = "method"
+	AttributeSender     = "sender"
+	AttributeAmount     = "amount"
+	AttributeToken      = "token"
+	AttributeTransferID = "transfer_id"
+)
+
+// Method returns method name attribute
+func Method(name string) attribute.KeyValue {
+	return attribute.String(AttributeMethod, name)
+}
+
+// Sender returns sender address attribute
+func Sender(address string) attribute.KeyValue {
+	return attribute.String(AttributeSender, address)
+}
+
+// Amount returns operation amount attribute
+func Amount(amount string) attribute.KeyValue {
+	return attribute.String(AttributeAmount, amount)
+}
+
+// Token returns token symbol attribute
+func Token(symbol string) attribute.KeyValue {
+	return attribute.String(AttributeToken, symbol)
+}
+
+// TransferID returns transfer id attribute
+func TransferID(id string) attribute.KeyValue {
+	return attribute.String(AttributeTransferID, id)
+}
+
+// RedactedValue replaces the value of the redacted attribute if no redaction key is set
+const RedactedValue = "redacted"
+
+// redact replaces the attribute value with the hex encoded HMAC-SHA3-256 of its string
+// representation keyed by key, or with RedactedValue if key is empty.
+func redact(kv attribute.KeyValue, key []byte) attribute.KeyValue {
+	if len(key) == 0 {
+		return kv.Key.String(RedactedValue)
+	}
+
+	mac := hmac.New(sha3.New256, key)
+	_, _ = mac.Write([]byte(kv.Value.Emit()))
+	return kv.Key.String(hex.EncodeToString(mac.Sum(nil)))
+}
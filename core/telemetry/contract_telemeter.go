@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 )
@@ -18,6 +19,8 @@ type TracingHandler struct {
 	Tracer      trace.Tracer
 	Propagators propagation.TextMapPropagator
 	isInit      bool
+	redacted    map[attribute.Key]struct{}
+	redactKey   []byte
 }
 
 // TracingIsInit checks if telemetry was initialized
@@ -30,6 +33,32 @@ func (th *TracingHandler) TracingInit() {
 	th.isInit = true
 }
 
+// SetRedactedAttributes sets keys of span attributes whose values must be redacted in SetAttributes.
+// The values are replaced by the HMAC keyed by redactKey, or dropped if redactKey is empty.
+func (th *TracingHandler) SetRedactedAttributes(keys []string, redactKey []byte) {
+	th.redacted = make(map[attribute.Key]struct{}, len(keys))
+	for _, key := range keys {
+		th.redacted[attribute.Key(key)] = struct{}{}
+	}
+	th.redactKey = redactKey
+}
+
+// SetAttributes sets attributes to the current span of traceCtx redacting configured values
+func (th *TracingHandler) SetAttributes(traceCtx TraceContext, attrs ...attribute.KeyValue) {
+	span := trace.SpanFromContext(traceCtx.ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	for i, kv := range attrs {
+		if _, ok := th.redacted[kv.Key]; ok {
+			attrs[i] = redact(kv, th.redactKey)
+		}
+	}
+
+	span.SetAttributes(attrs...)
+}
+
 // StartNewSpan starts new span
 func (th *TracingHandler) StartNewSpan(traceCtx TraceContext, spanName string, opts ...trace.SpanStartOption) (TraceContext, trace.Span) {
 	if traceCtx.ctx == nil {
@@ -26,6 +26,10 @@ const (
 	TracingCollectorAuthHeaderKey   = "CHAINCODE_TRACING_COLLECTOR_AUTH_HEADER_KEY"
 	TracingCollectorAuthHeaderValue = "CHAINCODE_TRACING_COLLECTOR_AUTH_HEADER_VALUE"
 	TracingCollectorCaPem           = "CHAINCODE_TRACING_COLLECTOR_CAPEM"
+
+	// TracingRedactionKeyEnv is the secret key of the HMAC replacing the values of the redacted span attributes,
+	// the values are dropped if it is not set
+	TracingRedactionKeyEnv = "CHAINCODE_TRACING_REDACTION_KEY"
 )
 
 // InstallTraceProvider returns trace provider based on http otlp exporter .
@@ -0,0 +1,36 @@
+package core
+
+import (
+	"github.com/anoideaopen/foundation/core/helpers"
+)
+
+// ChaincodeCoordinates is the channel and the name of the chaincode
+type ChaincodeCoordinates struct {
+	Channel   string `json:"channel"`
+	Chaincode string `json:"chaincode"`
+}
+
+// Topology describes the chaincodes the contract consults
+type Topology struct {
+	// Channel is the channel of the contract
+	Channel string `json:"channel"`
+	// ACL is the ACL chaincode checking the signers, the accounts and the rights
+	ACL ChaincodeCoordinates `json:"acl"`
+	// ChannelTransferTargets is true if the contracts of the channels To are queried
+	// on the channel transfer creation (check_channel_transfer_target_token).
+	// The contract of the channel To is the chaincode named by the lowercased channel To in that channel.
+	ChannelTransferTargets bool `json:"channelTransferTargets"`
+}
+
+// QueryTopology returns the coordinates of the chaincodes the contract consults,
+// so the clients do not hardcode them
+func (bc *BaseContract) QueryTopology() (*Topology, error) {
+	return &Topology{
+		Channel: bc.GetStub().GetChannelID(),
+		ACL: ChaincodeCoordinates{
+			Channel:   helpers.ACLChannel,
+			Chaincode: helpers.ACLChaincode,
+		},
+		ChannelTransferTargets: bc.config.GetOptions().GetCheckChannelTransferTargetToken(),
+	}, nil
+}
@@ -0,0 +1,17 @@
+package core
+
+import (
+	"github.com/anoideaopen/foundation/core/types"
+)
+
+// IsTrustedAddress reports if address is listed in trusted_addresses of the contract config.
+// Transfers from trusted addresses skip the optional guards like the denylist check.
+func (bc *BaseContract) IsTrustedAddress(address *types.Address) bool {
+	for _, trusted := range bc.config.GetTrustedAddresses() {
+		if trusted.GetAddress() == address.String() {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,46 @@
+package big
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors of the lossy conversions of Int to fixed-width types.
+var (
+	ErrUint64Overflow = errors.New("value does not fit into uint64")
+	ErrInt64Overflow  = errors.New("value does not fit into int64")
+	ErrNegativeBytes  = errors.New("negative value can not be encoded as bytes")
+)
+
+// Uint64Checked returns the uint64 representation of z.
+// Unlike Uint64, it returns ErrUint64Overflow instead of the truncated value
+// if z can not be represented in an uint64.
+func (z *Int) Uint64Checked() (uint64, error) {
+	if !z.IsUint64() {
+		return 0, fmt.Errorf("%w: %s", ErrUint64Overflow, z.String())
+	}
+
+	return z.Uint64(), nil
+}
+
+// Int64Checked returns the int64 representation of z.
+// Unlike Int64, it returns ErrInt64Overflow instead of the truncated value
+// if z can not be represented in an int64.
+func (z *Int) Int64Checked() (int64, error) {
+	if !z.IsInt64() {
+		return 0, fmt.Errorf("%w: %s", ErrInt64Overflow, z.String())
+	}
+
+	return z.Int64(), nil
+}
+
+// BytesChecked returns the absolute value of z as a big-endian byte slice, like Bytes.
+// Bytes drops the sign, so BytesChecked returns ErrNegativeBytes for negative z
+// to prevent storing the absolute value instead of the negative one.
+func (z *Int) BytesChecked() ([]byte, error) {
+	if z.Sign() < 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNegativeBytes, z.String())
+	}
+
+	return z.Bytes(), nil
+}
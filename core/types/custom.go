@@ -17,6 +17,9 @@ import (
 // AddressLength is expected bytes len for business entity Address
 const AddressLength = 32
 
+// ErrInvalidAddress is returned when the address string is not a valid base58check encoded address
+var ErrInvalidAddress = errors.New("invalid address")
+
 // Address might be more complicated structure
 // contains fields like isIndustrial bool or isMultisig bool
 type Address pb.Address
@@ -30,11 +33,16 @@ func AddrFromBytes(in []byte) *Address {
 	return addr
 }
 
-// AddrFromBase58Check creates address from base58 string
+// AddrFromBase58Check creates address from base58 string.
+// ErrInvalidAddress is returned if the string is malformed, has a wrong checksum or a wrong length.
 func AddrFromBase58Check(in string) (*Address, error) {
 	value, ver, err := base58.CheckDecode(in)
 	if err != nil {
-		return &Address{}, fmt.Errorf("decoding base58 '%s' failed, err: %w", in, err)
+		return &Address{}, fmt.Errorf("%w '%s': decoding base58 failed, err: %s", ErrInvalidAddress, in, err)
+	}
+
+	if len(value)+1 != AddressLength {
+		return &Address{}, fmt.Errorf("%w '%s': wrong length %d, expected %d", ErrInvalidAddress, in, len(value)+1, AddressLength)
 	}
 
 	addr := &Address{}
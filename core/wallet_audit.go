@@ -0,0 +1,123 @@
+package core
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
+	"github.com/anoideaopen/foundation/core/walletaudit"
+	pb "github.com/anoideaopen/foundation/proto"
+)
+
+var (
+	ErrWalletAuditDisabled        = errors.New("wallet audit is not enabled by the contract options")
+	ErrInvalidWalletAuditPageSize = errors.New("page size must be positive")
+)
+
+// QueryWalletAudit returns a page of the audit trail of the address in the order the events are made:
+// the emissions received, the transfers in and out, the channel transfers, the swaps and the fees paid and received.
+// Pass the returned bookmark to get the next page, an empty bookmark means that all events are returned.
+// The trail is kept only if the wallet_audit option is set, events made before are not returned.
+func (bc *BaseContract) QueryWalletAudit(address *types.Address, pageSize int64, bookmark string) (*walletaudit.Page, error) {
+	if !bc.config.GetOptions().GetWalletAudit() {
+		return nil, ErrWalletAuditDisabled
+	}
+
+	if pageSize <= 0 {
+		return nil, ErrInvalidWalletAuditPageSize
+	}
+
+	pageSize, clamped := bc.ClampPageSize(pageSize)
+
+	iter, meta, err := bc.GetStub().GetStateByPartialCompositeKeyWithPagination(
+		walletaudit.CompositeType,
+		[]string{address.String()},
+		int32(pageSize),
+		bookmark,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	page := &walletaudit.Page{Events: []walletaudit.Event{}, PageSizeClamped: clamped}
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var event walletaudit.Event
+		if err = json.Unmarshal(kv.GetValue(), &event); err != nil {
+			return nil, fmt.Errorf("unmarshalling wallet audit event %s: %w", kv.GetKey(), err)
+		}
+
+		page.Events = append(page.Events, event)
+	}
+
+	if meta != nil {
+		page.Bookmark = meta.GetBookmark()
+	}
+
+	return page, nil
+}
+
+// WalletAuditAdd appends the event of type eventType to the audit trail of address
+// if the wallet_audit option is set, counterparty is empty if there is none
+func (bc *BaseContract) WalletAuditAdd(
+	address *types.Address,
+	eventType string,
+	token string,
+	amount *big.Int,
+	counterparty string,
+) error {
+	if !bc.config.GetOptions().GetWalletAudit() {
+		return nil
+	}
+
+	return walletaudit.Record(bc.GetStub(), address.String(), eventType, token, amount, counterparty)
+}
+
+// WalletAuditFee appends the fee of token paid by payer to the audit trails of the payer and the fee address
+func (bc *BaseContract) WalletAuditFee(payer *types.Address, feeAddr *types.Address, token string, fee *big.Int) error {
+	if err := bc.WalletAuditAdd(payer, walletaudit.Fee, token, fee, feeAddr.String()); err != nil {
+		return err
+	}
+
+	return bc.WalletAuditAdd(feeAddr, walletaudit.FeeIn, token, fee, payer.String())
+}
+
+// WalletAuditTransfer appends the transfer of amount of token to the audit trails of from and to
+func (bc *BaseContract) WalletAuditTransfer(from *types.Address, to *types.Address, token string, amount *big.Int) error {
+	if err := bc.WalletAuditAdd(from, walletaudit.TransferOut, token, amount, to.String()); err != nil {
+		return err
+	}
+
+	return bc.WalletAuditAdd(to, walletaudit.TransferIn, token, amount, from.String())
+}
+
+// walletAuditAssets appends the event of type eventType to the audit trail of address for each of the assets
+func (bc *BaseContract) walletAuditAssets(
+	address *types.Address,
+	eventType string,
+	assets []*pb.Asset,
+	counterparty string,
+) error {
+	for _, asset := range assets {
+		if err := bc.WalletAuditAdd(
+			address,
+			eventType,
+			asset.GetGroup(),
+			new(big.Int).SetBytes(asset.GetAmount()),
+			counterparty,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
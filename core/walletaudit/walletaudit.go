@@ -0,0 +1,126 @@
+package walletaudit
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/anoideaopen/foundation/core/types/big"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+)
+
+const (
+	// CompositeType is a composite key prefix for the audit trail of the wallets.
+	// The attributes are the address and the zero padded sequence number of the event of the address,
+	// so the events of the address are iterated in the order they are made.
+	CompositeType = "wallet_audit"
+	// CountCompositeType is a composite key prefix for the number of the audit events of the address
+	CountCompositeType = "wallet_audit_count"
+
+	// seqDigits is the number of digits of the greatest sequence number
+	seqDigits = 19
+)
+
+// Types of the wallet audit events
+const (
+	// Emission is the emission received
+	Emission = "emission"
+	// TransferIn and TransferOut are the transfers within the channel,
+	// the counterparty is the other address of the transfer
+	TransferIn  = "transferIn"
+	TransferOut = "transferOut"
+	// Fee is the fee paid and FeeIn is the fee received by the fee address,
+	// the counterparty is the other address of the fee
+	Fee   = "fee"
+	FeeIn = "feeIn"
+	// ChannelTransferIn and ChannelTransferOut are the channel transfers,
+	// ChannelTransferCancel is the return of the cancelled channel transfer.
+	// The counterparty is the other channel of the transfer
+	ChannelTransferIn     = "channelTransferIn"
+	ChannelTransferOut    = "channelTransferOut"
+	ChannelTransferCancel = "channelTransferCancel"
+	// SwapIn and SwapOut are the swaps, SwapCancel is the return of the cancelled swap.
+	// The counterparty is the other channel of the swap
+	SwapIn     = "swapIn"
+	SwapOut    = "swapOut"
+	SwapCancel = "swapCancel"
+)
+
+// Event is a single event of the wallet audit trail
+type Event struct {
+	Type string `json:"type"`
+	// Token is the token of the amount, the fee currency for the fee
+	Token  string   `json:"token"`
+	Amount *big.Int `json:"amount"`
+	// Counterparty is the other address or the other channel of the event, empty for the emission
+	Counterparty string `json:"counterparty,omitempty"`
+	Timestamp    int64  `json:"timestamp"`
+	TxID         string `json:"txId"`
+}
+
+// Page is a page of the wallet audit trail
+type Page struct {
+	Events          []Event `json:"events"`
+	Bookmark        string  `json:"bookmark,omitempty"`
+	PageSizeClamped bool    `json:"pageSizeClamped,omitempty"`
+}
+
+// Record appends the event of type eventType to the audit trail of address,
+// counterparty is empty if there is none
+func Record(
+	stub shim.ChaincodeStubInterface,
+	address string,
+	eventType string,
+	token string,
+	amount *big.Int,
+	counterparty string,
+) error {
+	countKey, err := stub.CreateCompositeKey(CountCompositeType, []string{address})
+	if err != nil {
+		return err
+	}
+
+	data, err := stub.GetState(countKey)
+	if err != nil {
+		return err
+	}
+
+	var seq uint64 = 1
+	if len(data) != 0 {
+		if seq, err = strconv.ParseUint(string(data), 10, 64); err != nil {
+			return fmt.Errorf("parsing wallet audit count of %s: %w", address, err)
+		}
+		seq++
+	}
+
+	ts, err := stub.GetTxTimestamp()
+	if err != nil {
+		return err
+	}
+
+	value, err := json.Marshal(Event{
+		Type:         eventType,
+		Token:        token,
+		Amount:       new(big.Int).Set(amount),
+		Counterparty: counterparty,
+		Timestamp:    ts.GetSeconds(),
+		TxID:         stub.GetTxID(),
+	})
+	if err != nil {
+		return err
+	}
+
+	key, err := stub.CreateCompositeKey(CompositeType, []string{
+		address,
+		fmt.Sprintf("%0*d", seqDigits, seq),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = stub.PutState(key, value); err != nil {
+		return err
+	}
+
+	return stub.PutState(countKey, []byte(strconv.FormatUint(seq, 10)))
+}
@@ -0,0 +1,185 @@
+package keys
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/anoideaopen/foundation/keys/eth"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// HardenedKeyStart is the index of the first hardened child key
+const HardenedKeyStart uint32 = 0x80000000
+
+const (
+	ed25519SeedKey   = "ed25519 seed"
+	secp256k1SeedKey = "Bitcoin seed"
+)
+
+var (
+	ErrInvalidDerivationPath = errors.New("invalid derivation path")
+	ErrHDKeyTypeNotSupported = errors.New("key type is not supported for derivation")
+	ErrNotHardenedIndex      = errors.New("ed25519 supports hardened derivation only")
+)
+
+// DeriveKeysFromSeed derives keys of the specified type from the seed by the derivation path
+// according to SLIP-0010, e.g. "m/44'/0'/0'". Hardened indexes are marked with ' or h.
+// For secp256k1 the keys are compatible with BIP-32, ed25519 supports hardened indexes only.
+func DeriveKeysFromSeed(keyType proto.KeyType, seed []byte, path string) (*Keys, error) {
+	indexes, err := ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := &Keys{KeyType: keyType}
+	switch keyType {
+	case proto.KeyType_ed25519:
+		key, err := deriveEd25519(seed, indexes)
+		if err != nil {
+			return nil, err
+		}
+		sKey := ed25519.NewKeyFromSeed(key)
+		pKey, ok := sKey.Public().(ed25519.PublicKey)
+		if !ok {
+			return nil, errors.New("error converting private key to public")
+		}
+		keys.PrivateKeyEd25519 = sKey
+		keys.PublicKeyEd25519 = pKey
+		keys.PrivateKeyBytes = sKey
+		keys.PublicKeyBytes = pKey
+	case proto.KeyType_secp256k1:
+		key, err := deriveSecp256k1(seed, indexes)
+		if err != nil {
+			return nil, err
+		}
+		sKey, err := eth.PrivateKeyFromBytes(key)
+		if err != nil {
+			return nil, err
+		}
+		keys.PrivateKeySecp256k1 = sKey
+		keys.PublicKeySecp256k1 = &sKey.PublicKey
+		keys.PrivateKeyBytes = eth.PrivateKeyBytes(sKey)
+		keys.PublicKeyBytes = eth.PublicKeyBytes(&sKey.PublicKey)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrHDKeyTypeNotSupported, keyType)
+	}
+
+	keys.PublicKeyBase58 = base58.Encode(keys.PublicKeyBytes)
+	return keys, nil
+}
+
+// ParseDerivationPath parses derivation path like "m/44'/0'/0'" into the list of child indexes
+func ParseDerivationPath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("%w: path must start with 'm': %s", ErrInvalidDerivationPath, path)
+	}
+
+	indexes := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "h")
+		if hardened {
+			part = part[:len(part)-1]
+		}
+
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || uint32(index) >= HardenedKeyStart {
+			return nil, fmt.Errorf("%w: invalid index '%s': %s", ErrInvalidDerivationPath, part, path)
+		}
+
+		if hardened {
+			index += uint64(HardenedKeyStart)
+		}
+		indexes = append(indexes, uint32(index))
+	}
+
+	return indexes, nil
+}
+
+func deriveEd25519(seed []byte, indexes []uint32) ([]byte, error) {
+	key, chainCode := hmacSHA512([]byte(ed25519SeedKey), seed)
+	for _, index := range indexes {
+		if index < HardenedKeyStart {
+			return nil, ErrNotHardenedIndex
+		}
+
+		key, chainCode = hmacSHA512(chainCode, childData(0, key, index))
+	}
+
+	return key, nil
+}
+
+func deriveSecp256k1(seed []byte, indexes []uint32) ([]byte, error) {
+	n := crypto.S256().Params().N
+
+	data := seed
+	key, chainCode := hmacSHA512([]byte(secp256k1SeedKey), data)
+	for !validSecp256k1Key(key, n) {
+		data = append(key, chainCode...) //nolint:gocritic
+		key, chainCode = hmacSHA512([]byte(secp256k1SeedKey), data)
+	}
+
+	for _, index := range indexes {
+		var data []byte
+		if index >= HardenedKeyStart {
+			data = childData(0, key, index)
+		} else {
+			sKey, err := eth.PrivateKeyFromBytes(key)
+			if err != nil {
+				return nil, err
+			}
+			data = appendUint32(crypto.CompressPubkey(&sKey.PublicKey), index)
+		}
+
+		for {
+			il, ir := hmacSHA512(chainCode, data)
+			child := new(big.Int).SetBytes(il)
+			if child.Cmp(n) < 0 {
+				child.Add(child, new(big.Int).SetBytes(key))
+				child.Mod(child, n)
+				if child.Sign() != 0 {
+					key, chainCode = child.FillBytes(make([]byte, 32)), ir //nolint:gomnd
+					break
+				}
+			}
+			data = childData(1, ir, index)
+		}
+	}
+
+	return key, nil
+}
+
+func validSecp256k1Key(key []byte, n *big.Int) bool {
+	k := new(big.Int).SetBytes(key)
+	return k.Sign() != 0 && k.Cmp(n) < 0
+}
+
+// childData returns prefix || key || index
+func childData(prefix byte, key []byte, index uint32) []byte {
+	data := make([]byte, 0, 1+len(key)+4) //nolint:gomnd
+	data = append(data, prefix)
+	data = append(data, key...)
+	return appendUint32(data, index)
+}
+
+func appendUint32(data []byte, value uint32) []byte {
+	var buf [4]byte
+	binary.BigEndian.PutUint32(buf[:], value)
+	return append(data, buf[:]...)
+}
+
+func hmacSHA512(key []byte, data []byte) ([]byte, []byte) {
+	mac := hmac.New(sha512.New, key)
+	_, _ = mac.Write(data)
+	sum := mac.Sum(nil)
+	return sum[:32], sum[32:]
+}
@@ -0,0 +1,84 @@
+package keys
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/anoideaopen/foundation/keys/eth"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/btcsuite/btcutil/base58"
+)
+
+// SignedInvocation is a signed chaincode method invocation
+type SignedInvocation struct {
+	// Args are the arguments to pass to the chaincode after the method name:
+	// requestID, channel, chaincode, method args, nonce, public key and signature.
+	Args []string
+	// Nonce is the nonce the invocation is signed with
+	Nonce string
+	// PublicKey is the base58 encoded public key of the signer
+	PublicKey string
+	// Signature is the base58 encoded signature of the invocation
+	Signature string
+}
+
+// NewNonce returns a nonce based on the current time in milliseconds
+func NewNonce() string {
+	return strconv.FormatInt(time.Now().UnixMilli(), 10)
+}
+
+// SignInvocation signs the invocation of the chaincode method the same way the chaincode verifies it,
+// so the invocation can be prepared outside the chaincode, e.g. by an SDK service.
+// An empty nonce is replaced with NewNonce.
+func SignInvocation(
+	keys *Keys,
+	nonce string,
+	method string,
+	requestID string,
+	channel string,
+	chaincode string,
+	args ...string,
+) (*SignedInvocation, error) {
+	if nonce == "" {
+		nonce = NewNonce()
+	}
+
+	publicKey, err := publicKeyBytes(keys)
+	if err != nil {
+		return nil, err
+	}
+	publicKeyBase58 := base58.Encode(publicKey)
+
+	chunks := make([]string, 0, len(args)+6) //nolint:gomnd
+	chunks = append(chunks, method, requestID, channel, chaincode)
+	chunks = append(chunks, args...)
+	chunks = append(chunks, nonce, publicKeyBase58)
+
+	_, signature, err := SignMessageByKeyType(keys.KeyType, keys, []byte(strings.Join(chunks, "")))
+	if err != nil {
+		return nil, err
+	}
+	signatureBase58 := base58.Encode(signature)
+
+	return &SignedInvocation{
+		Args:      append(chunks[1:], signatureBase58),
+		Nonce:     nonce,
+		PublicKey: publicKeyBase58,
+		Signature: signatureBase58,
+	}, nil
+}
+
+func publicKeyBytes(keys *Keys) ([]byte, error) {
+	switch keys.KeyType {
+	case proto.KeyType_ed25519:
+		return keys.PublicKeyEd25519, nil
+	case proto.KeyType_secp256k1:
+		return eth.PublicKeyBytes(keys.PublicKeySecp256k1), nil
+	case proto.KeyType_gost:
+		return keys.PublicKeyGOST.Raw(), nil
+	default:
+		return nil, fmt.Errorf("unexpected key type: %s", keys.KeyType.String())
+	}
+}
@@ -1,6 +1,7 @@
 package keys
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/anoideaopen/foundation/keys/eth"
@@ -18,6 +19,39 @@ const (
 
 const PrefixUncompressedSecp259k1Key = 0x04
 
+const (
+	SignatureLengthEd25519 = 64
+	// SignatureLengthSecp256k1 is the length of the non-recoverable signature [R || S]
+	SignatureLengthSecp256k1 = 64
+	// SignatureLengthSecp256k1Recoverable is the length of the recoverable signature [R || S || V]
+	SignatureLengthSecp256k1Recoverable = 65
+	SignatureLengthGOST                 = 64
+)
+
+var ErrMalformedSignature = errors.New("malformed signature")
+
+// ValidateSignatureLength returns ErrMalformedSignature if the length of signature
+// does not match the key type, so the signature is rejected before the verification
+func ValidateSignatureLength(keyType pb.KeyType, signature []byte) error {
+	valid := false
+	switch keyType {
+	case pb.KeyType_ed25519:
+		valid = len(signature) == SignatureLengthEd25519
+	case pb.KeyType_secp256k1:
+		valid = len(signature) == SignatureLengthSecp256k1 || len(signature) == SignatureLengthSecp256k1Recoverable
+	case pb.KeyType_gost:
+		valid = len(signature) == SignatureLengthGOST
+	default:
+		return fmt.Errorf("invalid key type: %s", keyType.String())
+	}
+
+	if !valid {
+		return fmt.Errorf("%w: %d bytes signature of %s key", ErrMalformedSignature, len(signature), keyType.String())
+	}
+
+	return nil
+}
+
 func ValidateKeyLength(key []byte) bool {
 	if len(key) == KeyLengthEd25519 {
 		return true
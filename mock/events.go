@@ -0,0 +1,58 @@
+package mock
+
+import (
+	"bytes"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/proto"
+	pb "github.com/golang/protobuf/proto" //nolint:staticcheck
+	"github.com/hyperledger/fabric-protos-go/peer"
+	"github.com/stretchr/testify/require"
+)
+
+// Events returns all events emitted by the chaincode in the order of emission.
+// Events of the transactions executed in batches and tasks are unpacked
+// from batchExecute and executeTasks events and returned in place of them.
+func (l *Ledger) Events(ch string) []*peer.ChaincodeEvent {
+	stub, ok := l.stubs[ch]
+	require.True(l.t, ok, "stub %s not found", ch)
+
+	var events []*peer.ChaincodeEvent
+	for _, event := range stub.Events {
+		if event.GetEventName() != core.BatchExecute && event.GetEventName() != core.ExecuteTasksEvent {
+			events = append(events, event)
+			continue
+		}
+
+		batchEvent := &proto.BatchEvent{}
+		require.NoError(l.t, pb.Unmarshal(event.GetPayload(), batchEvent))
+		for _, txEvent := range batchEvent.GetEvents() {
+			for _, e := range txEvent.GetEvents() {
+				events = append(events, &peer.ChaincodeEvent{EventName: e.GetName(), Payload: e.GetValue()})
+			}
+		}
+	}
+
+	return events
+}
+
+// EventsShouldContain checks that the chaincode emitted the event with the name.
+// If payload is specified, the event must also have the same payload.
+func (l *Ledger) EventsShouldContain(ch string, name string, payload ...[]byte) {
+	for _, event := range l.Events(ch) {
+		if event.GetEventName() != name {
+			continue
+		}
+
+		if len(payload) == 0 || bytes.Equal(event.GetPayload(), payload[0]) {
+			return
+		}
+	}
+
+	if len(payload) == 0 {
+		require.Fail(l.t, "event not found", "event '%s' is not emitted by %s", name, ch)
+		return
+	}
+
+	require.Fail(l.t, "event not found", "event '%s' with payload '%s' is not emitted by %s", name, payload[0], ch)
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/hyperledger/fabric-protos-go/peer"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type Ledger struct {
@@ -111,6 +112,7 @@ func (l *Ledger) NewCCArgsArr(
 	l.stubs[name].ChannelID = name
 
 	l.stubs[name].MockPeerChaincode("acl/acl", l.stubs["acl"])
+	l.peerChannels(name)
 
 	err = l.stubs[name].SetAdminCreatorCert("platformMSP")
 	require.NoError(l.t, err)
@@ -130,6 +132,19 @@ func (l *Ledger) NewCCArgsArr(
 	return ""
 }
 
+// peerChannels registers the contract of the channel name and the contracts of other channels
+// as peer chaincodes of each other, so the contracts can query other channels
+func (l *Ledger) peerChannels(name string) {
+	for other, otherStub := range l.stubs {
+		if other == name || other == "acl" {
+			continue
+		}
+
+		l.stubs[name].MockPeerChaincodeWithChannel(other, otherStub, other)
+		otherStub.MockPeerChaincodeWithChannel(name, l.stubs[name], name)
+	}
+}
+
 func (l *Ledger) NewCC(
 	name string,
 	bci core.BaseContractInterface,
@@ -150,6 +165,7 @@ func (l *Ledger) NewCC(
 	l.stubs[name].ChannelID = name
 
 	l.stubs[name].MockPeerChaincode("acl/acl", l.stubs["acl"])
+	l.peerChannels(name)
 
 	err = l.stubs[name].SetAdminCreatorCert("platformMSP")
 	require.NoError(l.t, err)
@@ -163,11 +179,44 @@ func (l *Ledger) NewCC(
 	return ""
 }
 
+// UpgradeCC replaces the contract of the deployed chaincode keeping its state
+// and initializes it with the new config, as the upgrade of the chaincode does
+func (l *Ledger) UpgradeCC(
+	name string,
+	bci core.BaseContractInterface,
+	config string,
+	opts ...core.ChaincodeOption,
+) string {
+	_, exists := l.stubs[name]
+	require.True(
+		l.t,
+		exists,
+		fmt.Sprintf("stub with name '%s' does not exist in ledger mock", name),
+	)
+
+	cc, err := core.NewCC(bci, opts...)
+	require.NoError(l.t, err)
+	l.stubs[name].SetChaincode(cc)
+
+	err = l.stubs[name].SetAdminCreatorCert("platformMSP")
+	require.NoError(l.t, err)
+	res := l.stubs[name].MockInit(txIDGen(), [][]byte{[]byte(config)})
+
+	return res.GetMessage()
+}
+
 // GetStub returns stub
 func (l *Ledger) GetStub(name string) *stub.Stub {
 	return l.stubs[name]
 }
 
+// SetTxTime sets a fixed timestamp of transactions for all chaincodes deployed on the ledger
+func (l *Ledger) SetTxTime(t time.Time) {
+	for _, s := range l.stubs {
+		s.FixedTxTimestamp = timestamppb.New(t)
+	}
+}
+
 // WaitMultiSwapAnswer waits for multi swap answer
 func (l *Ledger) WaitMultiSwapAnswer(name string, id string, timeout time.Duration) {
 	interval := time.Second / 2 //nolint:gomnd
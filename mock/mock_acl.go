@@ -17,7 +17,19 @@ import (
 	"golang.org/x/crypto/sha3"
 )
 
-const rightKey = "acl_access_matrix"
+const (
+	rightKey = "acl_access_matrix"
+	listKey  = "acl_list"
+
+	// GrayList is a name of the ACL gray list
+	GrayList = "gray"
+	// BlackList is a name of the ACL black list
+	BlackList = "black"
+
+	signaturePolicyCompositeType = "signature_policy"
+	rotatedKeyCompositeType      = "rotated_key"
+	unregisteredCompositeType    = "unregistered"
+)
 
 // mockACL emulates alc chaincode, rights are stored in state
 type mockACL struct{}
@@ -35,6 +47,10 @@ func (ma *mockACL) Invoke(stub shim.ChaincodeStubInterface) peer.Response { //no
 			return shim.Error(err.Error())
 		}
 
+		if isUnregistered(stub, args[0]) {
+			return shim.Error("address not found")
+		}
+
 		data, err := proto.Marshal((*pb.Address)(addr))
 		if err != nil {
 			return shim.Error(err.Error())
@@ -51,18 +67,33 @@ func (ma *mockACL) Invoke(stub shim.ChaincodeStubInterface) peer.Response { //no
 		})
 
 		hashed := sha3.Sum256(bytes.Join(binPubKeys, []byte("")))
-		keyType := getWalletKeyType(stub, base58.CheckEncode(hashed[1:], hashed[0]))
+		addr := base58.CheckEncode(hashed[1:], hashed[0])
+		if rotated := getRotatedAddress(stub, addr); rotated != nil {
+			copy(hashed[:], rotated)
+			addr = base58.CheckEncode(hashed[1:], hashed[0])
+		}
+		keyType := getWalletKeyType(stub, addr)
+		signaturePolicy := getSignaturePolicy(stub, addr)
+
+		grayListed, err := ma.isListed(stub, addr, GrayList)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+
+		blackListed, err := ma.isListed(stub, addr, BlackList)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
 
 		data, err := proto.Marshal(&pb.AclResponse{
 			Account: &pb.AccountInfo{
-				KycHash:    "123",
-				GrayListed: false,
+				KycHash:     "123",
+				GrayListed:  grayListed,
+				BlackListed: blackListed,
 			},
 			Address: &pb.SignedAddress{
-				Address: &pb.Address{Address: hashed[:]},
-				SignaturePolicy: &pb.SignaturePolicy{
-					N: 2, //nolint:gomnd
-				},
+				Address:         &pb.Address{Address: hashed[:]},
+				SignaturePolicy: signaturePolicy,
 			},
 			KeyTypes: []pb.KeyType{
 				keyType,
@@ -73,15 +104,45 @@ func (ma *mockACL) Invoke(stub shim.ChaincodeStubInterface) peer.Response { //no
 		}
 		return shim.Success(data)
 	case "getAccountInfo":
+		grayListed, err := ma.isListed(stub, args[0], GrayList)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+
+		blackListed, err := ma.isListed(stub, args[0], BlackList)
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+
 		data, err := json.Marshal(&pb.AccountInfo{
 			KycHash:     "123",
-			GrayListed:  false,
-			BlackListed: false,
+			GrayListed:  grayListed,
+			BlackListed: blackListed,
 		})
 		if err != nil {
 			return shim.Error(err.Error())
 		}
 		return shim.Success(data)
+	case "addToList", "delFromList":
+		if len(args) != 2 { //nolint:gomnd
+			return shim.Error(fmt.Sprintf(acl.WrongArgsCount, len(args), 2)) //nolint:gomnd
+		}
+
+		key, err := stub.CreateCompositeKey(listKey, []string{args[1], args[0]})
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+
+		if fn == "delFromList" {
+			err = stub.DelState(key)
+		} else {
+			err = stub.PutState(key, []byte("true"))
+		}
+		if err != nil {
+			return shim.Error(err.Error())
+		}
+
+		return shim.Success(nil)
 	case acl.GetAccOpRightFn:
 		if len(args) != acl.GetAccOpRightArgCount {
 			return shim.Error(fmt.Sprintf(acl.WrongArgsCount, len(args), acl.GetAccOpRightArgCount))
@@ -127,6 +188,20 @@ func (ma *mockACL) Invoke(stub shim.ChaincodeStubInterface) peer.Response { //no
 	}
 }
 
+func (ma *mockACL) isListed(stub shim.ChaincodeStubInterface, addr, list string) (bool, error) {
+	key, err := stub.CreateCompositeKey(listKey, []string{list, addr})
+	if err != nil {
+		return false, err
+	}
+
+	data, err := stub.GetState(key)
+	if err != nil {
+		return false, err
+	}
+
+	return len(data) != 0, nil
+}
+
 func (ma *mockACL) addRight(stub shim.ChaincodeStubInterface, channel, cc, role, addr, operation string) error {
 	key, err := stub.CreateCompositeKey(rightKey, []string{channel, cc, role, operation})
 	if err != nil {
@@ -250,3 +325,62 @@ func (ma *mockACL) getRight(stub shim.ChaincodeStubInterface, channel, cc, role,
 
 	return false, nil
 }
+
+// getSignaturePolicy returns the signature policy set for the address by Multisig.SetWeights
+// or the default policy without weights
+func getSignaturePolicy(stub shim.ChaincodeStubInterface, address string) *pb.SignaturePolicy {
+	policy := &pb.SignaturePolicy{N: 2} //nolint:gomnd
+
+	ck, err := stub.CreateCompositeKey(signaturePolicyCompositeType, []string{address})
+	if err != nil {
+		panic(err)
+	}
+
+	raw, err := stub.GetState(ck)
+	if err != nil {
+		panic(err)
+	}
+
+	if len(raw) != 0 {
+		if err = proto.Unmarshal(raw, policy); err != nil {
+			panic(err)
+		}
+	}
+
+	return policy
+}
+
+// getRotatedAddress returns the address bytes the key hashed to address is rotated to by
+// Wallet.RotateKey or nil if the key is not rotated
+func getRotatedAddress(stub shim.ChaincodeStubInterface, address string) []byte {
+	ck, err := stub.CreateCompositeKey(rotatedKeyCompositeType, []string{address})
+	if err != nil {
+		panic(err)
+	}
+
+	raw, err := stub.GetState(ck)
+	if err != nil {
+		panic(err)
+	}
+
+	if len(raw) == 0 {
+		return nil
+	}
+
+	return raw
+}
+
+// isUnregistered reports whether the address is removed from ACL by Wallet.Unregister
+func isUnregistered(stub shim.ChaincodeStubInterface, address string) bool {
+	ck, err := stub.CreateCompositeKey(unregisteredCompositeType, []string{address})
+	if err != nil {
+		panic(err)
+	}
+
+	raw, err := stub.GetState(ck)
+	if err != nil {
+		panic(err)
+	}
+
+	return len(raw) != 0
+}
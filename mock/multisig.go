@@ -38,6 +38,31 @@ func (w *Multisig) AddressType() *types.Address {
 	return &types.Address{Address: append([]byte{ver}, value...)[:32]}
 }
 
+// SetWeights sets weights of the multisig members in the order of their keys
+// and the weight threshold the sum of weights of the signers must meet
+func (w *Multisig) SetWeights(threshold uint32, weights ...uint32) {
+	pubKeys := make([][]byte, len(w.pKeys))
+	for i, k := range w.pKeys {
+		pubKeys[i] = k
+	}
+
+	data, err := pb.Marshal(&proto.SignaturePolicy{
+		N:               2, //nolint:gomnd
+		PubKeys:         pubKeys,
+		Weights:         weights,
+		WeightThreshold: threshold,
+	})
+	require.NoError(w.ledger.t, err)
+
+	stubACL := w.ledger.stubs["acl"]
+	txID := txIDGen()
+	stubACL.MockTransactionStart(txID)
+	compositeKey, err := stubACL.CreateCompositeKey(signaturePolicyCompositeType, []string{w.addr})
+	require.NoError(w.ledger.t, err)
+	require.NoError(w.ledger.t, stubACL.PutState(compositeKey, data))
+	stubACL.MockTransactionEnd(txID)
+}
+
 // ChangeKeysFor changes private and public keys for Multisig member with specific index
 func (w *Multisig) ChangeKeysFor(index int, sKey ed25519.PrivateKey) error {
 	w.sKeys[index] = sKey
@@ -115,6 +140,50 @@ func (w *Multisig) RawSignedInvoke(signCnt int, ch string, fn string, args ...st
 	return txID, TxResponse{}, out.GetCreatedSwaps()
 }
 
+// RawSignedInvokeWithErrorReturned invokes chaincode function with specific arguments signed
+// with multisig wallet and returns the error of the invocation or of the batch transaction
+func (w *Multisig) RawSignedInvokeWithErrorReturned(signCnt int, ch string, fn string, args ...string) error {
+	txID := txIDGen()
+	args, _ = w.sign(signCnt, fn, ch, args...)
+	if err := w.ledger.doInvokeWithErrorReturned(ch, txID, fn, args...); err != nil {
+		return err
+	}
+
+	id, err := hex.DecodeString(txID)
+	require.NoError(w.ledger.t, err)
+	data, err := pb.Marshal(&proto.Batch{TxIDs: [][]byte{id}})
+	require.NoError(w.ledger.t, err)
+
+	cert, err := hex.DecodeString(batchRobotCert)
+	require.NoError(w.ledger.t, err)
+	w.ledger.stubs[ch].SetCreator(cert)
+	w.Invoke(ch, core.BatchExecute, string(data))
+
+	e := <-w.ledger.stubs[ch].ChaincodeEventsChannel
+	if e.GetEventName() == core.BatchExecute {
+		events := &proto.BatchEvent{}
+		require.NoError(w.ledger.t, pb.Unmarshal(e.GetPayload(), events))
+		for _, ev := range events.GetEvents() {
+			if hex.EncodeToString(ev.GetId()) == txID {
+				if ev.GetError() != nil {
+					return errors.New(ev.GetError().GetError())
+				}
+				return nil
+			}
+		}
+	}
+	require.Fail(w.ledger.t, shouldNotBeHereMsg)
+	return nil
+}
+
+// SignPartially signs the call of fn with each key of the multisig wallet separately,
+// so the signatures can be submitted to the pending operation one by one.
+// Returns the arguments of the call without signatures and the signatures in the order of the keys
+func (w *Multisig) SignPartially(ch string, fn string, args ...string) ([]string, []string) {
+	signed, _ := w.sign(len(w.sKeys), fn, ch, args...)
+	return signed[:len(signed)-len(w.sKeys)], signed[len(signed)-len(w.sKeys):]
+}
+
 // SecretKeys returns private keys of multisig wallet
 func (w *Multisig) SecretKeys() []ed25519.PrivateKey {
 	return w.sKeys
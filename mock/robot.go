@@ -0,0 +1,112 @@
+package mock
+
+import (
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/anoideaopen/foundation/proto"
+)
+
+// robotPageSize is the page size of the transfers read by the robot from the channel From
+const robotPageSize = 100
+
+// Robot drives the transfers between the channels of the ledger the way the channel-transfer service does,
+// so the whole lifecycle of the transfers can be covered by unit tests.
+// The channel To of the transfer is the chaincode named by the lowercase symbol of the channel.
+type Robot struct {
+	ledger *Ledger
+	wallet *Wallet
+}
+
+// NewRobot creates the robot of the ledger
+func (l *Ledger) NewRobot() *Robot {
+	return &Robot{
+		ledger: l,
+		wallet: l.NewWallet(),
+	}
+}
+
+// Transfer delivers the transfer id created in the channel from to the channel To and completes it:
+// pickUpCCTransferFrom, createCCTransferTo, commitCCTransferFrom, deleteCCTransferTo and deleteCCTransferFrom
+// are executed in turn.
+// The transfer already committed in the channel From is only deleted from both channels.
+func (r *Robot) Transfer(from string, id string) error {
+	resp, err := r.ledger.doInvokeWithPeerResponse(from, txIDGen(), "channelTransferFrom", id)
+	if err != nil {
+		return err
+	}
+	if resp.GetStatus() != 200 { //nolint:gomnd
+		return errors.New(resp.GetMessage())
+	}
+
+	var tr proto.CCTransfer
+	if err = json.Unmarshal(resp.GetPayload(), &tr); err != nil {
+		return err
+	}
+
+	to := strings.ToLower(tr.GetTo())
+
+	if !tr.GetIsCommit() {
+		if _, _, err = r.wallet.RawChTransferInvoke(from, "pickUpCCTransferFrom", id); err != nil {
+			return err
+		}
+
+		if _, _, err = r.wallet.RawChTransferInvokeWithBatch(to, "createCCTransferTo", string(resp.GetPayload())); err != nil {
+			return err
+		}
+
+		if _, _, err = r.wallet.RawChTransferInvoke(from, "commitCCTransferFrom", id); err != nil {
+			return err
+		}
+	}
+
+	if _, _, err = r.wallet.RawChTransferInvoke(to, "deleteCCTransferTo", id); err != nil {
+		return err
+	}
+
+	_, _, err = r.wallet.RawChTransferInvoke(from, "deleteCCTransferFrom", id)
+
+	return err
+}
+
+// TransferAll delivers and completes all transfers created in the channel from by Transfer
+// and returns ids of the transfers
+func (r *Robot) TransferAll(from string) ([]string, error) {
+	var ids []string
+
+	bookmark := ""
+	for {
+		resp, err := r.ledger.doInvokeWithPeerResponse(from, txIDGen(), "channelTransfersFrom",
+			strconv.Itoa(robotPageSize), bookmark)
+		if err != nil {
+			return nil, err
+		}
+		if resp.GetStatus() != 200 { //nolint:gomnd
+			return nil, errors.New(resp.GetMessage())
+		}
+
+		var page proto.CCTransfers
+		if err = json.Unmarshal(resp.GetPayload(), &page); err != nil {
+			return nil, err
+		}
+
+		for _, tr := range page.GetCcts() {
+			ids = append(ids, tr.GetId())
+		}
+
+		bookmark = page.GetBookmark()
+		if bookmark == "" || len(page.GetCcts()) == 0 {
+			break
+		}
+	}
+
+	for _, id := range ids {
+		if err := r.Transfer(from, id); err != nil {
+			return nil, err
+		}
+	}
+
+	return ids, nil
+}
@@ -44,18 +44,21 @@ type Stub struct {
 	// A pointer back to the chaincode that will invoke this, set by constructor.
 	// If a peer calls this stub, the chaincode will be invoked from here.
 	cc                     shim.Chaincode
-	args                   [][]byte          // arguments the stub was called with
-	Name                   string            // A nice name that can be used for logging
-	State                  map[string][]byte // State keeps name value pairs
-	Keys                   *list.List        // Keys stores the list of mapped values in lexical order registered list of other Stub chaincodes that can be called from this Stub
+	args                   [][]byte                                  // arguments the stub was called with
+	Name                   string                                    // A nice name that can be used for logging
+	State                  map[string][]byte                         // State keeps name value pairs
+	History                map[string][]*queryresult.KeyModification // History keeps modifications of keys, the most recent first
+	Keys                   *list.List                                // Keys stores the list of mapped values in lexical order registered list of other Stub chaincodes that can be called from this Stub
 	Invokables             map[string]*Stub
 	TxID                   string // stores a transaction uuid while being Invoked / Deployed
 	TxTimestamp            *timestamp.Timestamp
-	signedProposal         *pb.SignedProposal // mocked signedProposal
-	ChannelID              string             // stores a channel ID of the proposal
+	FixedTxTimestamp       *timestamp.Timestamp // if set, it is used as a timestamp of every transaction instead of the current time
+	signedProposal         *pb.SignedProposal   // mocked signedProposal
+	ChannelID              string               // stores a channel ID of the proposal
 	PvtState               map[string]map[string][]byte
 	EndorsementPolicies    map[string]map[string][]byte // stores per-key endorsement policy, first map index is the collection, second map index is the key
 	ChaincodeEventsChannel chan *pb.ChaincodeEvent      // channel to store ChaincodeEvents
+	Events                 []*pb.ChaincodeEvent         // Events keeps all ChaincodeEvents, unlike ChaincodeEventsChannel they are not consumed by reading
 	Decorations            map[string][]byte
 	creator                []byte
 	logger                 *logging.Logger
@@ -68,6 +71,7 @@ func NewMockStub(name string, cc shim.Chaincode) *Stub {
 	s.Name = name
 	s.cc = cc
 	s.State = make(map[string][]byte)
+	s.History = make(map[string][]*queryresult.KeyModification)
 	s.PvtState = make(map[string]map[string][]byte)
 	s.EndorsementPolicies = make(map[string]map[string][]byte)
 	s.Invokables = make(map[string]*Stub)
@@ -123,7 +127,11 @@ func (stub *Stub) GetFunctionAndParameters() (function string, params []string)
 func (stub *Stub) MockTransactionStart(txID string) {
 	stub.TxID = txID
 	stub.setSignedProposal(&pb.SignedProposal{})
-	stub.setTxTimestamp(createUtcTimestamp())
+	if stub.FixedTxTimestamp != nil {
+		stub.setTxTimestamp(stub.FixedTxTimestamp)
+	} else {
+		stub.setTxTimestamp(createUtcTimestamp())
+	}
 }
 
 // MockTransactionEnd ends a mocked transaction, clearing the UUID.
@@ -289,6 +297,7 @@ func (stub *Stub) putState(key string, value []byte, checkTxID bool) error {
 
 	stub.logger.Debug("Stub", stub.Name, "Putting", key, value)
 	stub.State[key] = value
+	stub.addHistory(key, value, false)
 
 	// insert key into ordered list of keys
 OuterLoop:
@@ -336,6 +345,9 @@ func (stub *Stub) PutBalanceToState(key string, balance *big.Int) error {
 // DelState removes the specified `key` and its value from the Ledger.
 func (stub *Stub) DelState(key string) error {
 	stub.logger.Debug("Stub", stub.Name, "Deleting", key, stub.State[key])
+	if _, ok := stub.State[key]; ok {
+		stub.addHistory(key, nil, true)
+	}
 	delete(stub.State, key)
 
 	for elem := stub.Keys.Front(); elem != nil; elem = elem.Next() {
@@ -373,8 +385,28 @@ func (stub *Stub) GetQueryResult(_ string) (shim.StateQueryIteratorInterface, er
 
 // GetHistoryForKey function can be invoked by a chaincode to return a history of
 // key values across time. GetHistoryForKey is intended to be used for read-only queries.
-func (stub *Stub) GetHistoryForKey(_ string) (shim.HistoryQueryIteratorInterface, error) { // key
-	return nil, fmt.Errorf(ErrFuncNotImplemented, "GetHistoryForKey")
+// Modifications are returned the most recent first, as the peer does.
+func (stub *Stub) GetHistoryForKey(key string) (shim.HistoryQueryIteratorInterface, error) {
+	return NewMockHistoryQueryIterator(stub, stub.History[key]), nil
+}
+
+// addHistory records modification of the key, only the last modification
+// of the key within the transaction is kept.
+func (stub *Stub) addHistory(key string, value []byte, isDelete bool) {
+	modification := &queryresult.KeyModification{
+		TxId:      stub.TxID,
+		Value:     value,
+		Timestamp: stub.TxTimestamp,
+		IsDelete:  isDelete,
+	}
+
+	history := stub.History[key]
+	if len(history) != 0 && stub.TxID != "" && history[0].GetTxId() == stub.TxID {
+		history[0] = modification
+		return
+	}
+
+	stub.History[key] = append([]*queryresult.KeyModification{modification}, history...)
 }
 
 // GetStateByPartialCompositeKey function can be invoked by a chaincode to query the
@@ -520,7 +552,10 @@ func (stub *Stub) InvokeChaincode(chaincodeName string, args [][]byte, channel s
 		chaincodeName = chaincodeName + "/" + channel
 	}
 
-	otherStub := stub.Invokables[chaincodeName]
+	otherStub, ok := stub.Invokables[chaincodeName]
+	if !ok {
+		return shim.Error("chaincode " + chaincodeName + " is not found")
+	}
 	stub.logger.Debug("Stub", stub.Name, "Invoking peer chaincode", otherStub.Name, args)
 	//	function, strings := getFuncArgs(args)
 	res := otherStub.MockInvoke(stub.TxID, args)
@@ -533,6 +568,11 @@ func (stub *Stub) SetCreator(creator []byte) {
 	stub.creator = creator
 }
 
+// SetChaincode replaces the chaincode of the stub keeping its state, as it happens on upgrade
+func (stub *Stub) SetChaincode(cc shim.Chaincode) {
+	stub.cc = cc
+}
+
 // SetCreatorCert sets creator cert
 func (stub *Stub) SetCreatorCert(creatorMSP string, creatorCert []byte) error {
 	creator, err := BuildCreator(creatorMSP, creatorCert)
@@ -601,7 +641,9 @@ func (stub *Stub) GetTxTimestamp() (*timestamp.Timestamp, error) {
 
 // SetEvent allows the chaincode to set an event
 func (stub *Stub) SetEvent(name string, payload []byte) error {
-	stub.ChaincodeEventsChannel <- &pb.ChaincodeEvent{EventName: name, Payload: payload}
+	event := &pb.ChaincodeEvent{EventName: name, Payload: payload}
+	stub.Events = append(stub.Events, event)
+	stub.ChaincodeEventsChannel <- event
 	return nil
 }
 
@@ -842,6 +884,63 @@ func NewMockStateRangeQueryWithPaginationIterator(stub *Stub, elements []string)
 	return iter
 }
 
+/*****************************
+ History Query Iterator
+*****************************/
+
+// HistoryQueryIterator is an interface that is used to iterate over modifications of a key
+type HistoryQueryIterator struct {
+	Closed        bool
+	Stub          *Stub
+	Modifications []*queryresult.KeyModification
+}
+
+// HasNext returns true if the history query iterator contains additional modifications.
+func (iter *HistoryQueryIterator) HasNext() bool {
+	return !iter.Closed && len(iter.Modifications) != 0
+}
+
+// Next returns the next modification in the history query iterator.
+func (iter *HistoryQueryIterator) Next() (*queryresult.KeyModification, error) {
+	if iter.Closed {
+		err := errors.New("HistoryQueryIterator.Next() called after Close()")
+		iter.Stub.logger.Errorf("%+v", err)
+		return nil, err
+	}
+
+	if !iter.HasNext() {
+		err := errors.New("HistoryQueryIterator.Next() called when it does not HaveNext()")
+		iter.Stub.logger.Errorf("%+v", err)
+		return nil, err
+	}
+
+	modification := iter.Modifications[0]
+	iter.Modifications = iter.Modifications[1:]
+	return modification, nil
+}
+
+// Close closes the history query iterator.
+func (iter *HistoryQueryIterator) Close() error {
+	if iter.Closed {
+		err := errors.New("HistoryQueryIterator.Close() called after Close()")
+		iter.Stub.logger.Errorf("%+v", err)
+		return err
+	}
+
+	iter.Modifications = nil
+	iter.Closed = true
+	return nil
+}
+
+// NewMockHistoryQueryIterator - Constructor for a HistoryQueryIterator
+func NewMockHistoryQueryIterator(stub *Stub, modifications []*queryresult.KeyModification) *HistoryQueryIterator {
+	return &HistoryQueryIterator{
+		Closed:        false,
+		Stub:          stub,
+		Modifications: append([]*queryresult.KeyModification(nil), modifications...),
+	}
+}
+
 const (
 	minUnicodeRuneValue   = 0            // U+0000
 	maxUnicodeRuneValue   = utf8.MaxRune // U+10FFFF - maximum (and unallocated) code point
@@ -147,6 +147,18 @@ func (l *Ledger) NewWalletFromKey(key string) *Wallet {
 	}
 }
 
+// NewWalletFromSeed creates new wallet with ed25519 key derived from seed by derivation path
+func (l *Ledger) NewWalletFromSeed(seed []byte, path string) *Wallet {
+	keysStr, err := keys.DeriveKeysFromSeed(proto.KeyType_ed25519, seed, path)
+	require.NoError(l.t, err)
+	hash := sha3.Sum256(keysStr.PublicKeyEd25519)
+	return &Wallet{
+		ledger: l,
+		Keys:   keysStr,
+		addr:   base58.CheckEncode(hash[1:], hash[0]),
+	}
+}
+
 // NewWalletFromHexKey creates new wallet from hex key
 func (l *Ledger) NewWalletFromHexKey(key string) *Wallet {
 	keysStr, err := keys.GenerateEd25519FromHex(key)
@@ -220,6 +232,41 @@ func (w *Wallet) ChangeKeys(sKey ed25519.PrivateKey) error {
 	return nil
 }
 
+// RotateKey changes the ed25519 keys of the wallet like ChangeKeys and maps the new public key
+// to the address of the wallet in ACL, so signatures of the new key resolve to the same address
+func (w *Wallet) RotateKey(sKey ed25519.PrivateKey) error {
+	if err := w.ChangeKeys(sKey); err != nil {
+		return err
+	}
+
+	addr := w.AddressType()
+	hashed := sha3.Sum256(w.PublicKeyEd25519)
+
+	stubACL := w.ledger.stubs["acl"]
+	txID := txIDGen()
+	stubACL.MockTransactionStart(txID)
+	compositeKey, err := stubACL.CreateCompositeKey(
+		rotatedKeyCompositeType,
+		[]string{base58.CheckEncode(hashed[1:], hashed[0])},
+	)
+	require.NoError(w.ledger.t, err)
+	require.NoError(w.ledger.t, stubACL.PutState(compositeKey, addr.Address))
+	stubACL.MockTransactionEnd(txID)
+
+	return nil
+}
+
+// Unregister makes ACL respond to checkAddress of the wallet address as to an address not registered in ACL
+func (w *Wallet) Unregister() {
+	stubACL := w.ledger.stubs["acl"]
+	txID := txIDGen()
+	stubACL.MockTransactionStart(txID)
+	compositeKey, err := stubACL.CreateCompositeKey(unregisteredCompositeType, []string{w.Address()})
+	require.NoError(w.ledger.t, err)
+	require.NoError(w.ledger.t, stubACL.PutState(compositeKey, []byte("true")))
+	stubACL.MockTransactionEnd(txID)
+}
+
 // Address returns the address of the wallet
 func (w *Wallet) Address() string {
 	switch w.KeyType {
@@ -354,6 +401,38 @@ func (w *Wallet) GroupBalanceShouldBe(ch, group string, expected uint64) {
 	require.Fail(w.ledger.t, "group not found")
 }
 
+// Nonces returns the nonce list of the wallet stored in the chaincode state
+// followed by the nonce journal if there is one
+func (w *Wallet) Nonces(ch string) []uint64 {
+	st := w.ledger.stubs[ch]
+
+	var nonces []uint64
+	for _, prefix := range []string{hex.EncodeToString([]byte{core.StateKeyNonce}), core.NonceJournalCompositeType} {
+		key, err := st.CreateCompositeKey(prefix, []string{w.AddressType().String()})
+		require.NoError(w.ledger.t, err)
+
+		data, ok := st.State[key]
+		if !ok {
+			continue
+		}
+
+		nonce := new(proto.Nonce)
+		require.NoError(w.ledger.t, pb.Unmarshal(data, nonce))
+		nonces = append(nonces, nonce.GetNonce()...)
+	}
+
+	return nonces
+}
+
+// QueryShouldNotChangeNonce invokes the query and checks that the nonce list of the wallet is left unchanged
+func (w *Wallet) QueryShouldNotChangeNonce(ch, fn string, args ...string) string {
+	before := w.Nonces(ch)
+	result := w.Invoke(ch, fn, args...)
+	require.Equal(w.ledger.t, before, w.Nonces(ch), "query %s changed nonce", fn)
+
+	return result
+}
+
 // Invoke invokes a function on the ledger
 func (w *Wallet) Invoke(ch, fn string, args ...string) string {
 	return w.ledger.doInvoke(ch, txIDGen(), fn, args...)
@@ -381,6 +460,12 @@ func (w *Wallet) SignArgs(ch, fn string, args ...string) []string {
 	return resp
 }
 
+// SignArgsWithNonce signs the arguments of the function call like SignArgs with the given nonce
+func (w *Wallet) SignArgsWithNonce(ch, fn string, nonce uint64, args ...string) []string {
+	resp, _ := w.signWithNonce(fn, ch, nonce, args...)
+	return resp
+}
+
 // BatchedInvoke invokes a function on the ledger
 func (w *Wallet) BatchedInvoke(ch, fn string, args ...string) (string, TxResponse) {
 	if err := w.verifyIncoming(ch, fn); err != nil {
@@ -445,7 +530,11 @@ func (w *Wallet) sign(fn, ch string, args ...string) ([]string, string) {
 	time.Sleep(time.Millisecond * 5)
 
 	// Generation of nonce based on current time in milliseconds.
-	nonce := strconv.FormatInt(time.Now().UnixNano()/1000000, 10)
+	return w.signWithNonce(fn, ch, uint64(time.Now().UnixNano()/1000000), args...)
+}
+
+func (w *Wallet) signWithNonce(fn, ch string, nonceValue uint64, args ...string) ([]string, string) {
+	nonce := strconv.FormatUint(nonceValue, 10)
 
 	// Forming a message for signature, including function name,
 	// empty string (placeholder), channel name, arguments and nonce.
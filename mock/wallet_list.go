@@ -0,0 +1,36 @@
+package mock
+
+import (
+	"errors"
+
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+)
+
+// AddToList adds the wallet address to the ACL list (GrayList or BlackList)
+func (w *Wallet) AddToList(list string) error {
+	return w.modifyList("addToList", list)
+}
+
+// DelFromList removes the wallet address from the ACL list (GrayList or BlackList)
+func (w *Wallet) DelFromList(list string) error {
+	return w.modifyList("delFromList", list)
+}
+
+func (w *Wallet) modifyList(fn string, list string) error {
+	params := [][]byte{
+		[]byte(fn),
+		[]byte(w.Address()),
+		[]byte(list),
+	}
+	const acl = "acl"
+	aclstub := w.ledger.GetStub(acl)
+	aclstub.TxID = txIDGen()
+	aclstub.MockPeerChaincodeWithChannel(acl, aclstub, acl)
+
+	rsp := aclstub.InvokeChaincode(acl, params, acl)
+	if rsp.GetStatus() != shim.OK {
+		return errors.New(rsp.GetMessage())
+	}
+
+	return nil
+}
@@ -0,0 +1,36 @@
+package mock
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/stretchr/testify/require"
+)
+
+// SignedBatchInvoke signs calls with a single nonce and executes them atomically with signedBatch.
+// Returns the JSON encoded list of the call results or an error if any of the calls fails.
+func (w *Wallet) SignedBatchInvoke(ch string, calls ...core.SignedBatchCall) (string, error) {
+	if err := w.verifyIncoming(ch, core.SignedBatch); err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(calls)
+	require.NoError(w.ledger.t, err)
+
+	args, _ := w.sign(core.SignedBatch, ch, string(data))
+	cert, err := base64.StdEncoding.DecodeString(userCert)
+	require.NoError(w.ledger.t, err)
+	_ = w.ledger.stubs[ch].SetCreatorCert("platformMSP", cert)
+
+	resp, err := w.ledger.doInvokeWithPeerResponse(ch, txIDGen(), core.SignedBatch, args...)
+	if err != nil {
+		return "", err
+	}
+	if resp.GetStatus() != 200 { //nolint:gomnd
+		return "", errors.New(resp.GetMessage())
+	}
+
+	return string(resp.GetPayload()), nil
+}
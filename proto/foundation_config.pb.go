@@ -22,6 +22,56 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// AmountFormat is an output format of amounts returned by queries.
+type AmountFormat int32
+
+const (
+	AmountFormat_AMOUNT_FORMAT_DECIMAL AmountFormat = 0 // Decimal string of base units, e.g. "150000000"
+	AmountFormat_AMOUNT_FORMAT_HEX     AmountFormat = 1 // Hex string of base units with 0x prefix, e.g. "0x8f0d180"
+	AmountFormat_AMOUNT_FORMAT_DISPLAY AmountFormat = 2 // Decimal string of display units adjusted by token decimals, e.g. "1.50000000"
+)
+
+// Enum value maps for AmountFormat.
+var (
+	AmountFormat_name = map[int32]string{
+		0: "AMOUNT_FORMAT_DECIMAL",
+		1: "AMOUNT_FORMAT_HEX",
+		2: "AMOUNT_FORMAT_DISPLAY",
+	}
+	AmountFormat_value = map[string]int32{
+		"AMOUNT_FORMAT_DECIMAL": 0,
+		"AMOUNT_FORMAT_HEX":     1,
+		"AMOUNT_FORMAT_DISPLAY": 2,
+	}
+)
+
+func (x AmountFormat) Enum() *AmountFormat {
+	p := new(AmountFormat)
+	*p = x
+	return p
+}
+
+func (x AmountFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AmountFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_foundation_config_proto_enumTypes[0].Descriptor()
+}
+
+func (AmountFormat) Type() protoreflect.EnumType {
+	return &file_foundation_config_proto_enumTypes[0]
+}
+
+func (x AmountFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AmountFormat.Descriptor instead.
+func (AmountFormat) EnumDescriptor() ([]byte, []int) {
+	return file_foundation_config_proto_rawDescGZIP(), []int{0}
+}
+
 // Config stores all chaincode configuration parameters.
 type Config struct {
 	state         protoimpl.MessageState
@@ -102,6 +152,19 @@ type ContractConfig struct {
 	Admin *Wallet `protobuf:"bytes,4,opt,name=admin,proto3" json:"admin,omitempty"`
 	// tracingCollectorEndpoint - tracing collector endpoint host & port, e.g. "172.23.0.6:4318"
 	TracingCollectorEndpoint *CollectorEndpoint `protobuf:"bytes,5,opt,name=tracingCollectorEndpoint,proto3" json:"tracingCollectorEndpoint,omitempty"`
+	// channel_transfer_fee is a fee charged for the channel transfers created in the channel.
+	// It is independent of the fee of the transfers within the channel.
+	ChannelTransferFee *ChannelTransferFee `protobuf:"bytes,6,opt,name=channel_transfer_fee,json=channelTransferFee,proto3" json:"channel_transfer_fee,omitempty"`
+	// version is the version of the deployed contract, e.g. "1.2.0".
+	// It is set on instantiation and updated on upgrade together with the rest of the config.
+	Version string `protobuf:"bytes,7,opt,name=version,proto3" json:"version,omitempty"`
+	// trusted_addresses are the addresses whose transfers skip the optional guards:
+	// the denylist check and the min balance of the sender. The transfers are recorded as usual.
+	TrustedAddresses []*Wallet `protobuf:"bytes,8,rep,name=trusted_addresses,json=trustedAddresses,proto3" json:"trusted_addresses,omitempty"`
+	// symbol_aliases maps alternate token symbols to the canonical ones, e.g. {"CCX": "CC"}.
+	// Aliases are resolved in the token arguments of the allowed balances, channel transfers and swaps,
+	// so the balances of the alias are the balances of the canonical symbol.
+	SymbolAliases map[string]string `protobuf:"bytes,9,rep,name=symbol_aliases,json=symbolAliases,proto3" json:"symbol_aliases,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 }
 
 func (x *ContractConfig) Reset() {
@@ -171,6 +234,110 @@ func (x *ContractConfig) GetTracingCollectorEndpoint() *CollectorEndpoint {
 	return nil
 }
 
+func (x *ContractConfig) GetChannelTransferFee() *ChannelTransferFee {
+	if x != nil {
+		return x.ChannelTransferFee
+	}
+	return nil
+}
+
+func (x *ContractConfig) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *ContractConfig) GetTrustedAddresses() []*Wallet {
+	if x != nil {
+		return x.TrustedAddresses
+	}
+	return nil
+}
+
+func (x *ContractConfig) GetSymbolAliases() map[string]string {
+	if x != nil {
+		return x.SymbolAliases
+	}
+	return nil
+}
+
+// ChannelTransferFee is a fee charged in the transferred token in addition to the amount of the channel transfer.
+type ChannelTransferFee struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// rate is a decimal fee rate with 8 decimal places, e.g. "1000000" is 1%.
+	Rate string `protobuf:"bytes,1,opt,name=rate,proto3" json:"rate,omitempty"`
+	// floor is a decimal minimal fee amount.
+	Floor string `protobuf:"bytes,2,opt,name=floor,proto3" json:"floor,omitempty"`
+	// cap is a decimal maximal fee amount. Empty or zero value means no cap.
+	Cap string `protobuf:"bytes,3,opt,name=cap,proto3" json:"cap,omitempty"`
+	// address is the wallet the fee is transferred to.
+	Address *Wallet `protobuf:"bytes,4,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *ChannelTransferFee) Reset() {
+	*x = ChannelTransferFee{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_foundation_config_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ChannelTransferFee) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChannelTransferFee) ProtoMessage() {}
+
+func (x *ChannelTransferFee) ProtoReflect() protoreflect.Message {
+	mi := &file_foundation_config_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChannelTransferFee.ProtoReflect.Descriptor instead.
+func (*ChannelTransferFee) Descriptor() ([]byte, []int) {
+	return file_foundation_config_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ChannelTransferFee) GetRate() string {
+	if x != nil {
+		return x.Rate
+	}
+	return ""
+}
+
+func (x *ChannelTransferFee) GetFloor() string {
+	if x != nil {
+		return x.Floor
+	}
+	return ""
+}
+
+func (x *ChannelTransferFee) GetCap() string {
+	if x != nil {
+		return x.Cap
+	}
+	return ""
+}
+
+func (x *ChannelTransferFee) GetAddress() *Wallet {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
 type CollectorEndpoint struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -185,7 +352,7 @@ type CollectorEndpoint struct {
 func (x *CollectorEndpoint) Reset() {
 	*x = CollectorEndpoint{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_foundation_config_proto_msgTypes[2]
+		mi := &file_foundation_config_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -198,7 +365,7 @@ func (x *CollectorEndpoint) String() string {
 func (*CollectorEndpoint) ProtoMessage() {}
 
 func (x *CollectorEndpoint) ProtoReflect() protoreflect.Message {
-	mi := &file_foundation_config_proto_msgTypes[2]
+	mi := &file_foundation_config_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -211,7 +378,7 @@ func (x *CollectorEndpoint) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CollectorEndpoint.ProtoReflect.Descriptor instead.
 func (*CollectorEndpoint) Descriptor() ([]byte, []int) {
-	return file_foundation_config_proto_rawDescGZIP(), []int{2}
+	return file_foundation_config_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *CollectorEndpoint) GetEndpoint() string {
@@ -255,12 +422,133 @@ type ChaincodeOptions struct {
 	DisableSwaps bool `protobuf:"varint,2,opt,name=disable_swaps,json=disableSwaps,proto3" json:"disable_swaps,omitempty"`
 	// disable_multi_swaps determines whether multi-swap operations can be performed.
 	DisableMultiSwaps bool `protobuf:"varint,3,opt,name=disable_multi_swaps,json=disableMultiSwaps,proto3" json:"disable_multi_swaps,omitempty"`
+	// tracing_redacted_attributes stores list of span attribute keys (e.g. "sender", "amount")
+	// whose values are redacted. If the CHAINCODE_TRACING_REDACTION_KEY environment variable is set,
+	// the value is replaced by the hex encoded HMAC-SHA3-256 of the value keyed by the variable,
+	// so redacted values are searchable by the HMAC of the exact value only by the key holders.
+	// Otherwise the value is dropped.
+	TracingRedactedAttributes []string `protobuf:"bytes,4,rep,name=tracing_redacted_attributes,json=tracingRedactedAttributes,proto3" json:"tracing_redacted_attributes,omitempty"`
+	// max_args_size limits total size in bytes of all arguments of a method call.
+	// Zero value means no limit.
+	MaxArgsSize uint32 `protobuf:"varint,5,opt,name=max_args_size,json=maxArgsSize,proto3" json:"max_args_size,omitempty"`
+	// check_denylist determines whether transfer parties are checked against
+	// the ACL channel and transfers involving blacklisted addresses are rejected.
+	CheckDenylist bool `protobuf:"varint,6,opt,name=check_denylist,json=checkDenylist,proto3" json:"check_denylist,omitempty"`
+	// maintenance_allowed_functions stores list of transaction methods (e.g. "TxTransferBalance")
+	// which can be called while the contract is in maintenance mode.
+	// TxSetMaintenanceMode is always allowed.
+	MaintenanceAllowedFunctions []string `protobuf:"bytes,7,rep,name=maintenance_allowed_functions,json=maintenanceAllowedFunctions,proto3" json:"maintenance_allowed_functions,omitempty"`
+	// accepted_key_types stores list of signature key types accepted by the contract.
+	// Signed calls with keys of other types are rejected.
+	// Empty list means that all key types are accepted.
+	AcceptedKeyTypes []KeyType `protobuf:"varint,8,rep,packed,name=accepted_key_types,json=acceptedKeyTypes,proto3,enum=proto.KeyType" json:"accepted_key_types,omitempty"`
+	// event_name_prefix is prepended to names of events emitted by contract methods,
+	// so consumers of several chaincodes can tell their events apart.
+	// Framework events batchExecute and executeTasks are not prefixed.
+	// Empty value keeps event names unchanged.
+	EventNamePrefix string `protobuf:"bytes,9,opt,name=event_name_prefix,json=eventNamePrefix,proto3" json:"event_name_prefix,omitempty"`
+	// allow_zero_amount_channel_transfers determines how channel transfers of zero amount are handled.
+	// If false, they are rejected. If true, they are accepted as no-ops:
+	// balances are not changed and no transfer is created, only the memo of the transfer is recorded.
+	AllowZeroAmountChannelTransfers bool `protobuf:"varint,10,opt,name=allow_zero_amount_channel_transfers,json=allowZeroAmountChannelTransfers,proto3" json:"allow_zero_amount_channel_transfers,omitempty"`
+	// amount_format selects the output format of amounts returned by balance queries
+	// (balanceOf, allowedBalanceOf). Default format is a decimal string of base units.
+	AmountFormat AmountFormat `protobuf:"varint,11,opt,name=amount_format,json=amountFormat,proto3,enum=proto.AmountFormat" json:"amount_format,omitempty"`
+	// store_channel_transfers_as_proto determines whether channel transfer records are stored
+	// as binary protobuf instead of JSON. Records are read in both encodings,
+	// so the option can be switched without migration of existing records.
+	StoreChannelTransfersAsProto bool `protobuf:"varint,12,opt,name=store_channel_transfers_as_proto,json=storeChannelTransfersAsProto,proto3" json:"store_channel_transfers_as_proto,omitempty"`
+	// convert_allowed_balance_on_receipt determines whether tokens received by the forward
+	// channel transfer (createCCTransferTo) are credited to the native token balance of the contract
+	// instead of the allowed balance of the transferred token. The credited tokens are counted
+	// as the emission of the contract and recorded to the emission history, the backward transfer
+	// of the transferred token (channelTransferByCustomer) returns them from the native balance and burns them.
+	// The option requires the contract accounting the emission (token.BaseToken).
+	ConvertAllowedBalanceOnReceipt bool `protobuf:"varint,13,opt,name=convert_allowed_balance_on_receipt,json=convertAllowedBalanceOnReceipt,proto3" json:"convert_allowed_balance_on_receipt,omitempty"`
+	// derive_channel_transfer_ids determines whether channelTransferByCustomer called with an empty
+	// transfer id derives the id from the sender, the nonce and the arguments of the call.
+	// The calls of one signedBatch share the nonce, so the same transfer repeated in the batch
+	// derives the same id and fails the batch.
+	DeriveChannelTransferIds bool `protobuf:"varint,14,opt,name=derive_channel_transfer_ids,json=deriveChannelTransferIds,proto3" json:"derive_channel_transfer_ids,omitempty"`
+	// max_page_size limits the page size of paginated queries. Oversized page size requests
+	// are clamped to it and the response indicates the clamping. 0 means no limit.
+	MaxPageSize uint32 `protobuf:"varint,15,opt,name=max_page_size,json=maxPageSize,proto3" json:"max_page_size,omitempty"`
+	// amount_thousands_separator is a separator of digit groups of the integer part of amounts
+	// returned in AMOUNT_FORMAT_DISPLAY, e.g. "," or " ". Empty value disables grouping.
+	// Amount arguments are always parsed as decimal strings of base units.
+	AmountThousandsSeparator string `protobuf:"bytes,16,opt,name=amount_thousands_separator,json=amountThousandsSeparator,proto3" json:"amount_thousands_separator,omitempty"`
+	// amount_decimal_mark is a decimal mark of amounts returned in AMOUNT_FORMAT_DISPLAY, "." by default.
+	AmountDecimalMark string `protobuf:"bytes,17,opt,name=amount_decimal_mark,json=amountDecimalMark,proto3" json:"amount_decimal_mark,omitempty"`
+	// nonce_namespaces maps chaincode functions to nonce namespaces. Each namespace of an address
+	// has its own nonce sequence, so nonces of functions of different namespaces do not conflict.
+	// Functions not listed here share the default namespace.
+	NonceNamespaces map[string]string `protobuf:"bytes,18,rep,name=nonce_namespaces,json=nonceNamespaces,proto3" json:"nonce_namespaces,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// nonce_sub_millisecond_digits is the number of digits below milliseconds (up to 3) the nonces may carry
+	// in addition to the 13 digits of milliseconds. Clients with coarse clocks fill them with a counter
+	// to distinguish operations made in the same millisecond. Nonces without the extra digits are accepted
+	// as nonces with zero extra digits. Zero value means millisecond nonces only.
+	NonceSubMillisecondDigits uint32 `protobuf:"varint,19,opt,name=nonce_sub_millisecond_digits,json=nonceSubMillisecondDigits,proto3" json:"nonce_sub_millisecond_digits,omitempty"`
+	// nonce_tolerance_ms is how far in milliseconds a nonce may lag behind the greatest nonce of the sender.
+	// Zero value means the default tolerance of 50 seconds.
+	NonceToleranceMs uint32 `protobuf:"varint,20,opt,name=nonce_tolerance_ms,json=nonceToleranceMs,proto3" json:"nonce_tolerance_ms,omitempty"`
+	// check_channel_transfer_target_token rejects the channel transfer on creation if the contract
+	// of the channel To does not respond with the symbol of the channel To to the metadata query.
+	// Otherwise the transfer of the token unknown to the channel To fails on the delivery.
+	CheckChannelTransferTargetToken bool `protobuf:"varint,21,opt,name=check_channel_transfer_target_token,json=checkChannelTransferTargetToken,proto3" json:"check_channel_transfer_target_token,omitempty"`
+	// nonce_clock_correction_ms is how far in milliseconds beyond the tolerance a nonce may lag behind
+	// the greatest nonce of the sender, when the sender clock is corrected backward.
+	// Such a nonce restarts the nonce list of the sender, and strict ordering resumes from it.
+	// The used nonces are kept for the tolerance and the correction, so the repeated operations are rejected.
+	// No other correction is accepted until the nonces pass the greatest nonce before the correction
+	// by the tolerance and the correction. Zero value disables the corrections.
+	NonceClockCorrectionMs uint32 `protobuf:"varint,22,opt,name=nonce_clock_correction_ms,json=nonceClockCorrectionMs,proto3" json:"nonce_clock_correction_ms,omitempty"`
+	// max_active_transfers_per_address is the maximum number of the not committed transfers
+	// the address may have in the channel From. channelTransferByCustomer rejects new transfers
+	// of the address over the limit. Zero value means no limit.
+	MaxActiveTransfersPerAddress uint32 `protobuf:"varint,23,opt,name=max_active_transfers_per_address,json=maxActiveTransfersPerAddress,proto3" json:"max_active_transfers_per_address,omitempty"`
+	// protected_queries stores list of query methods (e.g. "QueryBalanceOf") which must be signed
+	// by the queried address, the first argument of the query. Arguments of a protected query are
+	// passed the same way as arguments of a signed method, unsigned calls are rejected.
+	ProtectedQueries []string `protobuf:"bytes,24,rep,name=protected_queries,json=protectedQueries,proto3" json:"protected_queries,omitempty"`
+	// allow_batch_shared_nonce determines how the batched transactions of the sender sharing the nonce
+	// within one batch are handled. If true, they are executed as one logical operation signed
+	// with that nonce, the nonce is checked only once, but the transaction repeating the method and the arguments
+	// of another one is rejected. If false (default), the nonce must be unique per operation and the repeated one
+	// is rejected. The nonce repeated in a later batch is always rejected.
+	AllowBatchSharedNonce bool `protobuf:"varint,25,opt,name=allow_batch_shared_nonce,json=allowBatchSharedNonce,proto3" json:"allow_batch_shared_nonce,omitempty"`
+	// require_reserved_transfer_ids determines whether channelTransferByCustomer accepts only the transfer ids
+	// reserved by the sender with reserveTransferID. If false (default), the not reserved ids are accepted as well,
+	// the reserved ids are accepted from the address which has reserved them only.
+	RequireReservedTransferIds bool `protobuf:"varint,26,opt,name=require_reserved_transfer_ids,json=requireReservedTransferIds,proto3" json:"require_reserved_transfer_ids,omitempty"`
+	// require_registered_transfer_tokens determines whether createCCTransferTo rejects the transfer
+	// crediting the allowed balance of a token not listed in registered_transfer_tokens,
+	// so the balance of an unknown token is not created. The own token of the channel is always accepted.
+	RequireRegisteredTransferTokens bool `protobuf:"varint,27,opt,name=require_registered_transfer_tokens,json=requireRegisteredTransferTokens,proto3" json:"require_registered_transfer_tokens,omitempty"`
+	// registered_transfer_tokens stores list of tokens (e.g. "VT") the allowed balances
+	// can be created for on the channel transfer receipt if require_registered_transfer_tokens is set.
+	RegisteredTransferTokens []string `protobuf:"bytes,28,rep,name=registered_transfer_tokens,json=registeredTransferTokens,proto3" json:"registered_transfer_tokens,omitempty"`
+	// validate_signature_size determines whether the signatures of the signed invocations are checked
+	// for the length the key type requires before the verification: 64 bytes for ed25519 and GOST,
+	// 64 or 65 (recoverable) bytes for secp256k1. Malformed signatures are rejected with a clear error
+	// instead of failing the verification.
+	ValidateSignatureSize bool `protobuf:"varint,29,opt,name=validate_signature_size,json=validateSignatureSize,proto3" json:"validate_signature_size,omitempty"`
+	// wallet_audit determines whether the audit trail of the wallets (walletAudit) is kept: the emissions,
+	// the transfers, the channel transfers, the swaps and the fees of each address. The trail adds
+	// two writes per recorded event, so it is disabled by default.
+	WalletAudit bool `protobuf:"varint,30,opt,name=wallet_audit,json=walletAudit,proto3" json:"wallet_audit,omitempty"`
+	// nonce_prune_threshold is the number of the recent nonces of the sender kept in a separate journal
+	// before they are merged into the nonce list of the sender and the nonces beyond the tolerance are pruned.
+	// The operations of the hot wallets write the short journal instead of the whole nonce list,
+	// the list is rewritten once per nonce_prune_threshold operations. The nonces are checked against
+	// the list and the journal, so the pruned nonces are rejected as too old either way.
+	// Zero value writes and prunes the list on every operation.
+	NoncePruneThreshold uint32 `protobuf:"varint,31,opt,name=nonce_prune_threshold,json=noncePruneThreshold,proto3" json:"nonce_prune_threshold,omitempty"`
 }
 
 func (x *ChaincodeOptions) Reset() {
 	*x = ChaincodeOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_foundation_config_proto_msgTypes[3]
+		mi := &file_foundation_config_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -273,7 +561,7 @@ func (x *ChaincodeOptions) String() string {
 func (*ChaincodeOptions) ProtoMessage() {}
 
 func (x *ChaincodeOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_foundation_config_proto_msgTypes[3]
+	mi := &file_foundation_config_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -286,7 +574,7 @@ func (x *ChaincodeOptions) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChaincodeOptions.ProtoReflect.Descriptor instead.
 func (*ChaincodeOptions) Descriptor() ([]byte, []int) {
-	return file_foundation_config_proto_rawDescGZIP(), []int{3}
+	return file_foundation_config_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *ChaincodeOptions) GetDisabledFunctions() []string {
@@ -310,6 +598,202 @@ func (x *ChaincodeOptions) GetDisableMultiSwaps() bool {
 	return false
 }
 
+func (x *ChaincodeOptions) GetTracingRedactedAttributes() []string {
+	if x != nil {
+		return x.TracingRedactedAttributes
+	}
+	return nil
+}
+
+func (x *ChaincodeOptions) GetMaxArgsSize() uint32 {
+	if x != nil {
+		return x.MaxArgsSize
+	}
+	return 0
+}
+
+func (x *ChaincodeOptions) GetCheckDenylist() bool {
+	if x != nil {
+		return x.CheckDenylist
+	}
+	return false
+}
+
+func (x *ChaincodeOptions) GetMaintenanceAllowedFunctions() []string {
+	if x != nil {
+		return x.MaintenanceAllowedFunctions
+	}
+	return nil
+}
+
+func (x *ChaincodeOptions) GetAcceptedKeyTypes() []KeyType {
+	if x != nil {
+		return x.AcceptedKeyTypes
+	}
+	return nil
+}
+
+func (x *ChaincodeOptions) GetEventNamePrefix() string {
+	if x != nil {
+		return x.EventNamePrefix
+	}
+	return ""
+}
+
+func (x *ChaincodeOptions) GetAllowZeroAmountChannelTransfers() bool {
+	if x != nil {
+		return x.AllowZeroAmountChannelTransfers
+	}
+	return false
+}
+
+func (x *ChaincodeOptions) GetAmountFormat() AmountFormat {
+	if x != nil {
+		return x.AmountFormat
+	}
+	return AmountFormat_AMOUNT_FORMAT_DECIMAL
+}
+
+func (x *ChaincodeOptions) GetStoreChannelTransfersAsProto() bool {
+	if x != nil {
+		return x.StoreChannelTransfersAsProto
+	}
+	return false
+}
+
+func (x *ChaincodeOptions) GetConvertAllowedBalanceOnReceipt() bool {
+	if x != nil {
+		return x.ConvertAllowedBalanceOnReceipt
+	}
+	return false
+}
+
+func (x *ChaincodeOptions) GetDeriveChannelTransferIds() bool {
+	if x != nil {
+		return x.DeriveChannelTransferIds
+	}
+	return false
+}
+
+func (x *ChaincodeOptions) GetMaxPageSize() uint32 {
+	if x != nil {
+		return x.MaxPageSize
+	}
+	return 0
+}
+
+func (x *ChaincodeOptions) GetAmountThousandsSeparator() string {
+	if x != nil {
+		return x.AmountThousandsSeparator
+	}
+	return ""
+}
+
+func (x *ChaincodeOptions) GetAmountDecimalMark() string {
+	if x != nil {
+		return x.AmountDecimalMark
+	}
+	return ""
+}
+
+func (x *ChaincodeOptions) GetNonceNamespaces() map[string]string {
+	if x != nil {
+		return x.NonceNamespaces
+	}
+	return nil
+}
+
+func (x *ChaincodeOptions) GetNonceSubMillisecondDigits() uint32 {
+	if x != nil {
+		return x.NonceSubMillisecondDigits
+	}
+	return 0
+}
+
+func (x *ChaincodeOptions) GetNonceToleranceMs() uint32 {
+	if x != nil {
+		return x.NonceToleranceMs
+	}
+	return 0
+}
+
+func (x *ChaincodeOptions) GetCheckChannelTransferTargetToken() bool {
+	if x != nil {
+		return x.CheckChannelTransferTargetToken
+	}
+	return false
+}
+
+func (x *ChaincodeOptions) GetNonceClockCorrectionMs() uint32 {
+	if x != nil {
+		return x.NonceClockCorrectionMs
+	}
+	return 0
+}
+
+func (x *ChaincodeOptions) GetMaxActiveTransfersPerAddress() uint32 {
+	if x != nil {
+		return x.MaxActiveTransfersPerAddress
+	}
+	return 0
+}
+
+func (x *ChaincodeOptions) GetProtectedQueries() []string {
+	if x != nil {
+		return x.ProtectedQueries
+	}
+	return nil
+}
+
+func (x *ChaincodeOptions) GetAllowBatchSharedNonce() bool {
+	if x != nil {
+		return x.AllowBatchSharedNonce
+	}
+	return false
+}
+
+func (x *ChaincodeOptions) GetRequireReservedTransferIds() bool {
+	if x != nil {
+		return x.RequireReservedTransferIds
+	}
+	return false
+}
+
+func (x *ChaincodeOptions) GetRequireRegisteredTransferTokens() bool {
+	if x != nil {
+		return x.RequireRegisteredTransferTokens
+	}
+	return false
+}
+
+func (x *ChaincodeOptions) GetRegisteredTransferTokens() []string {
+	if x != nil {
+		return x.RegisteredTransferTokens
+	}
+	return nil
+}
+
+func (x *ChaincodeOptions) GetValidateSignatureSize() bool {
+	if x != nil {
+		return x.ValidateSignatureSize
+	}
+	return false
+}
+
+func (x *ChaincodeOptions) GetWalletAudit() bool {
+	if x != nil {
+		return x.WalletAudit
+	}
+	return false
+}
+
+func (x *ChaincodeOptions) GetNoncePruneThreshold() uint32 {
+	if x != nil {
+		return x.NoncePruneThreshold
+	}
+	return 0
+}
+
 // Wallet stores user specific data.
 type Wallet struct {
 	state         protoimpl.MessageState
@@ -323,7 +807,7 @@ type Wallet struct {
 func (x *Wallet) Reset() {
 	*x = Wallet{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_foundation_config_proto_msgTypes[4]
+		mi := &file_foundation_config_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -336,7 +820,7 @@ func (x *Wallet) String() string {
 func (*Wallet) ProtoMessage() {}
 
 func (x *Wallet) ProtoReflect() protoreflect.Message {
-	mi := &file_foundation_config_proto_msgTypes[4]
+	mi := &file_foundation_config_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -349,7 +833,7 @@ func (x *Wallet) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Wallet.ProtoReflect.Descriptor instead.
 func (*Wallet) Descriptor() ([]byte, []int) {
-	return file_foundation_config_proto_rawDescGZIP(), []int{4}
+	return file_foundation_config_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Wallet) GetAddress() string {
@@ -379,12 +863,52 @@ type TokenConfig struct {
 	FeeAddressSetter *Wallet `protobuf:"bytes,6,opt,name=fee_address_setter,json=feeAddressSetter,proto3" json:"fee_address_setter,omitempty"`
 	// redeemer is the user who has permission to manage redemption process.
 	Redeemer *Wallet `protobuf:"bytes,7,opt,name=redeemer,proto3" json:"redeemer,omitempty"`
+	// max_emission_per_address is a decimal limit of the total amount emitted to a single address
+	// by EmissionAddTo. The emitted amounts are counted with no limit set as well. Empty value means no limit.
+	MaxEmissionPerAddress string `protobuf:"bytes,8,opt,name=max_emission_per_address,json=maxEmissionPerAddress,proto3" json:"max_emission_per_address,omitempty"`
+	// min_balance is a decimal threshold of the sender token balance left after a transfer:
+	// the balance must be either zero or not less than the threshold. Empty or zero value disables the check.
+	MinBalance string `protobuf:"bytes,9,opt,name=min_balance,json=minBalance,proto3" json:"min_balance,omitempty"`
+	// emission_approval_threshold is a decimal amount above which the emission proposed by the issuer
+	// must be approved by emission approvers before execution. The threshold applies to every emission
+	// of the token, e.g. by the token methods calling EmissionAddTo and EmissionAdd or the channel transfers
+	// converted on receipt, the approved proposals are the only emissions above it.
+	// Empty value disables the approval.
+	EmissionApprovalThreshold string `protobuf:"bytes,10,opt,name=emission_approval_threshold,json=emissionApprovalThreshold,proto3" json:"emission_approval_threshold,omitempty"`
+	// emission_approvers are the users who can approve the emission proposals.
+	EmissionApprovers []*Wallet `protobuf:"bytes,11,rep,name=emission_approvers,json=emissionApprovers,proto3" json:"emission_approvers,omitempty"`
+	// emission_required_approvals is the number of approvals required to execute the emission proposal.
+	// Zero value means one approval.
+	EmissionRequiredApprovals uint32 `protobuf:"varint,12,opt,name=emission_required_approvals,json=emissionRequiredApprovals,proto3" json:"emission_required_approvals,omitempty"`
+	// balance_swing_alert_percent enables BalanceSwing event emitted when a transfer or an emission
+	// changes a wallet balance by more than the percentage of its prior balance.
+	// Any change of zero prior balance counts as a swing. Zero value disables the event.
+	BalanceSwingAlertPercent uint32 `protobuf:"varint,13,opt,name=balance_swing_alert_percent,json=balanceSwingAlertPercent,proto3" json:"balance_swing_alert_percent,omitempty"`
+	// max_supply is a decimal limit of the total emission of the token. Empty value means no limit.
+	MaxSupply string `protobuf:"bytes,14,opt,name=max_supply,json=maxSupply,proto3" json:"max_supply,omitempty"`
+	// require_registered_emission_recipient rejects the emission to addresses not registered in ACL.
+	RequireRegisteredEmissionRecipient bool `protobuf:"varint,15,opt,name=require_registered_emission_recipient,json=requireRegisteredEmissionRecipient,proto3" json:"require_registered_emission_recipient,omitempty"`
+	// sequential_emission_keys requires emission keys to be strictly increasing integers starting from 1
+	// without gaps, so the emission keys make a clean audit sequence.
+	SequentialEmissionKeys bool `protobuf:"varint,16,opt,name=sequential_emission_keys,json=sequentialEmissionKeys,proto3" json:"sequential_emission_keys,omitempty"`
+	// accumulate_fee_remainders keeps the fractional remainders of the transfer fees rounded down
+	// per payer and collects them with the fee once they sum up to a whole base unit.
+	// The remainders of the fees raised to the floor or lowered to the cap are not kept.
+	AccumulateFeeRemainders bool `protobuf:"varint,17,opt,name=accumulate_fee_remainders,json=accumulateFeeRemainders,proto3" json:"accumulate_fee_remainders,omitempty"`
+	// deduct_fee_from_amount takes the transfer fee from the transferred amount: the recipient receives
+	// the amount minus the fee and the sender pays the amount. Otherwise (default) the fee is charged on top:
+	// the recipient receives the amount and the sender pays the amount plus the fee.
+	// Fees in a currency other than the token are always charged on top.
+	DeductFeeFromAmount bool `protobuf:"varint,18,opt,name=deduct_fee_from_amount,json=deductFeeFromAmount,proto3" json:"deduct_fee_from_amount,omitempty"`
+	// allow_scheduled_emission enables the emissions scheduled by the issuer for a later execution.
+	// The scheduled amount can not exceed emission_approval_threshold, as the larger emissions must be approved.
+	AllowScheduledEmission bool `protobuf:"varint,19,opt,name=allow_scheduled_emission,json=allowScheduledEmission,proto3" json:"allow_scheduled_emission,omitempty"`
 }
 
 func (x *TokenConfig) Reset() {
 	*x = TokenConfig{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_foundation_config_proto_msgTypes[5]
+		mi := &file_foundation_config_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -397,7 +921,7 @@ func (x *TokenConfig) String() string {
 func (*TokenConfig) ProtoMessage() {}
 
 func (x *TokenConfig) ProtoReflect() protoreflect.Message {
-	mi := &file_foundation_config_proto_msgTypes[5]
+	mi := &file_foundation_config_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -410,7 +934,7 @@ func (x *TokenConfig) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TokenConfig.ProtoReflect.Descriptor instead.
 func (*TokenConfig) Descriptor() ([]byte, []int) {
-	return file_foundation_config_proto_rawDescGZIP(), []int{5}
+	return file_foundation_config_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *TokenConfig) GetName() string {
@@ -462,6 +986,90 @@ func (x *TokenConfig) GetRedeemer() *Wallet {
 	return nil
 }
 
+func (x *TokenConfig) GetMaxEmissionPerAddress() string {
+	if x != nil {
+		return x.MaxEmissionPerAddress
+	}
+	return ""
+}
+
+func (x *TokenConfig) GetMinBalance() string {
+	if x != nil {
+		return x.MinBalance
+	}
+	return ""
+}
+
+func (x *TokenConfig) GetEmissionApprovalThreshold() string {
+	if x != nil {
+		return x.EmissionApprovalThreshold
+	}
+	return ""
+}
+
+func (x *TokenConfig) GetEmissionApprovers() []*Wallet {
+	if x != nil {
+		return x.EmissionApprovers
+	}
+	return nil
+}
+
+func (x *TokenConfig) GetEmissionRequiredApprovals() uint32 {
+	if x != nil {
+		return x.EmissionRequiredApprovals
+	}
+	return 0
+}
+
+func (x *TokenConfig) GetBalanceSwingAlertPercent() uint32 {
+	if x != nil {
+		return x.BalanceSwingAlertPercent
+	}
+	return 0
+}
+
+func (x *TokenConfig) GetMaxSupply() string {
+	if x != nil {
+		return x.MaxSupply
+	}
+	return ""
+}
+
+func (x *TokenConfig) GetRequireRegisteredEmissionRecipient() bool {
+	if x != nil {
+		return x.RequireRegisteredEmissionRecipient
+	}
+	return false
+}
+
+func (x *TokenConfig) GetSequentialEmissionKeys() bool {
+	if x != nil {
+		return x.SequentialEmissionKeys
+	}
+	return false
+}
+
+func (x *TokenConfig) GetAccumulateFeeRemainders() bool {
+	if x != nil {
+		return x.AccumulateFeeRemainders
+	}
+	return false
+}
+
+func (x *TokenConfig) GetDeductFeeFromAmount() bool {
+	if x != nil {
+		return x.DeductFeeFromAmount
+	}
+	return false
+}
+
+func (x *TokenConfig) GetAllowScheduledEmission() bool {
+	if x != nil {
+		return x.AllowScheduledEmission
+	}
+	return false
+}
+
 var File_foundation_config_proto protoreflect.FileDescriptor
 
 var file_foundation_config_proto_rawDesc = []byte{
@@ -470,84 +1078,271 @@ var file_foundation_config_proto_rawDesc = []byte{
 	0x1a, 0x17, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x2f, 0x76, 0x61, 0x6c, 0x69, 0x64,
 	0x61, 0x74, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x19, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
 	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x61, 0x6e, 0x79, 0x2e, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xa6, 0x01, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12,
-	0x3d, 0x0a, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x15, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61,
-	0x63, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x42, 0x0a, 0xfa, 0x42, 0x07, 0x8a, 0x01, 0x04,
-	0x08, 0x00, 0x10, 0x01, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x28,
-	0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69,
-	0x67, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x33, 0x0a, 0x0a, 0x65, 0x78, 0x74, 0x5f,
-	0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67,
-	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41,
-	0x6e, 0x79, 0x52, 0x09, 0x65, 0x78, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0xad, 0x02,
-	0x0a, 0x0e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67,
-	0x12, 0x3d, 0x0a, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
-	0x42, 0x25, 0xfa, 0x42, 0x22, 0x72, 0x20, 0x32, 0x1e, 0x5e, 0x5b, 0x41, 0x2d, 0x5a, 0x5d, 0x2b,
-	0x5b, 0x41, 0x2d, 0x5a, 0x30, 0x2d, 0x39, 0x5d, 0x2b, 0x28, 0x2d, 0x5b, 0x41, 0x2d, 0x5a, 0x30,
-	0x2d, 0x39, 0x5d, 0x2b, 0x29, 0x3f, 0x24, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12,
-	0x31, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x17, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x63, 0x6f,
-	0x64, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x12, 0x2e, 0x0a, 0x08, 0x72, 0x6f, 0x62, 0x6f, 0x74, 0x53, 0x4b, 0x49, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x09, 0x42, 0x12, 0xfa, 0x42, 0x0f, 0x72, 0x0d, 0x32, 0x0b, 0x5e, 0x5b, 0x30,
-	0x2d, 0x39, 0x61, 0x2d, 0x66, 0x5d, 0x2b, 0x24, 0x52, 0x08, 0x72, 0x6f, 0x62, 0x6f, 0x74, 0x53,
-	0x4b, 0x49, 0x12, 0x23, 0x0a, 0x05, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74,
-	0x52, 0x05, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x12, 0x54, 0x0a, 0x18, 0x74, 0x72, 0x61, 0x63, 0x69,
-	0x6e, 0x67, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x45, 0x6e, 0x64, 0x70, 0x6f,
-	0x69, 0x6e, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x2e, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x45, 0x6e, 0x64, 0x70, 0x6f,
-	0x69, 0x6e, 0x74, 0x52, 0x18, 0x74, 0x72, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6c, 0x6c,
-	0x65, 0x63, 0x74, 0x6f, 0x72, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x22, 0xbe, 0x01,
-	0x0a, 0x11, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x45, 0x6e, 0x64, 0x70, 0x6f,
-	0x69, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12,
-	0x38, 0x0a, 0x18, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x16, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4b, 0x65, 0x79, 0x12, 0x3c, 0x0a, 0x1a, 0x61, 0x75, 0x74,
-	0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x18, 0x61,
-	0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x61, 0x64,
-	0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x15, 0x0a, 0x06, 0x74, 0x6c, 0x73, 0x5f, 0x63,
-	0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6c, 0x73, 0x43, 0x61, 0x22, 0x96,
-	0x01, 0x0a, 0x10, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x4f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x5f,
-	0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x11, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f,
-	0x6e, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x73, 0x77,
-	0x61, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x61, 0x62,
-	0x6c, 0x65, 0x53, 0x77, 0x61, 0x70, 0x73, 0x12, 0x2e, 0x0a, 0x13, 0x64, 0x69, 0x73, 0x61, 0x62,
-	0x6c, 0x65, 0x5f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x03,
-	0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x75, 0x6c,
-	0x74, 0x69, 0x53, 0x77, 0x61, 0x70, 0x73, 0x22, 0x42, 0x0a, 0x06, 0x57, 0x61, 0x6c, 0x6c, 0x65,
-	0x74, 0x12, 0x38, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x42, 0x1e, 0xfa, 0x42, 0x1b, 0x72, 0x19, 0x32, 0x17, 0x5e, 0x5b, 0x31, 0x2d, 0x39,
-	0x41, 0x2d, 0x48, 0x4a, 0x2d, 0x4e, 0x50, 0x2d, 0x5a, 0x61, 0x2d, 0x6b, 0x6d, 0x2d, 0x7a, 0x5d,
-	0x2b, 0x24, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0xaf, 0x02, 0x0a, 0x0b,
-	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e,
-	0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12,
-	0x1a, 0x0a, 0x08, 0x64, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x0d, 0x52, 0x08, 0x64, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x75,
-	0x6e, 0x64, 0x65, 0x72, 0x6c, 0x79, 0x69, 0x6e, 0x67, 0x5f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x6c, 0x79, 0x69, 0x6e,
-	0x67, 0x41, 0x73, 0x73, 0x65, 0x74, 0x12, 0x2f, 0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57,
-	0x61, 0x6c, 0x6c, 0x65, 0x74, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52,
-	0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12, 0x2c, 0x0a, 0x0a, 0x66, 0x65, 0x65, 0x5f, 0x73,
-	0x65, 0x74, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x52, 0x09, 0x66, 0x65, 0x65, 0x53,
-	0x65, 0x74, 0x74, 0x65, 0x72, 0x12, 0x3b, 0x0a, 0x12, 0x66, 0x65, 0x65, 0x5f, 0x61, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x5f, 0x73, 0x65, 0x74, 0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28,
-	0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74,
-	0x52, 0x10, 0x66, 0x65, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x53, 0x65, 0x74, 0x74,
-	0x65, 0x72, 0x12, 0x29, 0x0a, 0x08, 0x72, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x65, 0x72, 0x18, 0x07,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x0b, 0x62, 0x61, 0x74, 0x63, 0x68, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x22, 0xa6, 0x01, 0x0a, 0x06, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3d, 0x0a, 0x08,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x42, 0x0a, 0xfa, 0x42, 0x07, 0x8a, 0x01, 0x04, 0x08, 0x00, 0x10,
+	0x01, 0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x12, 0x28, 0x0a, 0x05, 0x74,
+	0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x05,
+	0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x33, 0x0a, 0x0a, 0x65, 0x78, 0x74, 0x5f, 0x63, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x41, 0x6e, 0x79, 0x52,
+	0x09, 0x65, 0x78, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x22, 0xe3, 0x04, 0x0a, 0x0e, 0x43,
+	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x3d, 0x0a,
+	0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x25, 0xfa,
+	0x42, 0x22, 0x72, 0x20, 0x32, 0x1e, 0x5e, 0x5b, 0x41, 0x2d, 0x5a, 0x5d, 0x2b, 0x5b, 0x41, 0x2d,
+	0x5a, 0x30, 0x2d, 0x39, 0x5d, 0x2b, 0x28, 0x2d, 0x5b, 0x41, 0x2d, 0x5a, 0x30, 0x2d, 0x39, 0x5d,
+	0x2b, 0x29, 0x3f, 0x24, 0x52, 0x06, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x12, 0x31, 0x0a, 0x07,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x63, 0x6f, 0x64, 0x65, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x2e, 0x0a, 0x08, 0x72, 0x6f, 0x62, 0x6f, 0x74, 0x53, 0x4b, 0x49, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x42, 0x12, 0xfa, 0x42, 0x0f, 0x72, 0x0d, 0x32, 0x0b, 0x5e, 0x5b, 0x30, 0x2d, 0x39, 0x61,
+	0x2d, 0x66, 0x5d, 0x2b, 0x24, 0x52, 0x08, 0x72, 0x6f, 0x62, 0x6f, 0x74, 0x53, 0x4b, 0x49, 0x12,
+	0x23, 0x0a, 0x05, 0x61, 0x64, 0x6d, 0x69, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x52, 0x05, 0x61,
+	0x64, 0x6d, 0x69, 0x6e, 0x12, 0x54, 0x0a, 0x18, 0x74, 0x72, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43,
+	0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74, 0x6f, 0x72, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x52, 0x18, 0x74, 0x72, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x43, 0x6f, 0x6c, 0x6c, 0x65, 0x63, 0x74,
+	0x6f, 0x72, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x4b, 0x0a, 0x14, 0x63, 0x68,
+	0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x66,
+	0x65, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2e, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x46, 0x65, 0x65, 0x52, 0x12, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x46, 0x65, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f,
+	0x6e, 0x12, 0x3a, 0x0a, 0x11, 0x74, 0x72, 0x75, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x52, 0x10, 0x74, 0x72, 0x75,
+	0x73, 0x74, 0x65, 0x64, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x4f, 0x0a,
+	0x0e, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x5f, 0x61, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x18,
+	0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x28, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x6f,
+	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x53, 0x79, 0x6d,
+	0x62, 0x6f, 0x6c, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x0d, 0x73, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x1a, 0x40,
+	0x0a, 0x12, 0x53, 0x79, 0x6d, 0x62, 0x6f, 0x6c, 0x41, 0x6c, 0x69, 0x61, 0x73, 0x65, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x83, 0x01, 0x0a, 0x12, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x66, 0x65, 0x72, 0x46, 0x65, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x72, 0x61, 0x74, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x72, 0x61, 0x74, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x66,
+	0x6c, 0x6f, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x66, 0x6c, 0x6f, 0x6f,
+	0x72, 0x12, 0x10, 0x0a, 0x03, 0x63, 0x61, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x63, 0x61, 0x70, 0x12, 0x31, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x04,
 	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x6c,
-	0x6c, 0x65, 0x74, 0x52, 0x08, 0x72, 0x65, 0x64, 0x65, 0x65, 0x6d, 0x65, 0x72, 0x42, 0x29, 0x5a,
-	0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61, 0x6e, 0x6f, 0x69,
-	0x64, 0x65, 0x61, 0x6f, 0x70, 0x65, 0x6e, 0x2f, 0x66, 0x6f, 0x75, 0x6e, 0x64, 0x61, 0x74, 0x69,
-	0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x6c, 0x65, 0x74, 0x42, 0x08, 0xfa, 0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0xbe, 0x01, 0x0a, 0x11, 0x43, 0x6f, 0x6c, 0x6c, 0x65,
+	0x63, 0x74, 0x6f, 0x72, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x38, 0x0a, 0x18, 0x61, 0x75, 0x74, 0x68,
+	0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
+	0x5f, 0x6b, 0x65, 0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x16, 0x61, 0x75, 0x74, 0x68,
+	0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x4b,
+	0x65, 0x79, 0x12, 0x3c, 0x0a, 0x1a, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x18, 0x61, 0x75, 0x74, 0x68, 0x6f, 0x72, 0x69, 0x7a,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x12, 0x15, 0x0a, 0x06, 0x74, 0x6c, 0x73, 0x5f, 0x63, 0x61, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x74, 0x6c, 0x73, 0x43, 0x61, 0x22, 0xe5, 0x0e, 0x0a, 0x10, 0x43, 0x68, 0x61, 0x69,
+	0x6e, 0x63, 0x6f, 0x64, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2d, 0x0a, 0x12,
+	0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x5f, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c,
+	0x65, 0x64, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x23, 0x0a, 0x0d, 0x64,
+	0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x08, 0x52, 0x0c, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x77, 0x61, 0x70, 0x73,
+	0x12, 0x2e, 0x0a, 0x13, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x6d, 0x75, 0x6c, 0x74,
+	0x69, 0x5f, 0x73, 0x77, 0x61, 0x70, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x11, 0x64,
+	0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x53, 0x77, 0x61, 0x70, 0x73,
+	0x12, 0x3e, 0x0a, 0x1b, 0x74, 0x72, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x5f, 0x72, 0x65, 0x64, 0x61,
+	0x63, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73, 0x18,
+	0x04, 0x20, 0x03, 0x28, 0x09, 0x52, 0x19, 0x74, 0x72, 0x61, 0x63, 0x69, 0x6e, 0x67, 0x52, 0x65,
+	0x64, 0x61, 0x63, 0x74, 0x65, 0x64, 0x41, 0x74, 0x74, 0x72, 0x69, 0x62, 0x75, 0x74, 0x65, 0x73,
+	0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x72, 0x67, 0x73, 0x5f, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x61, 0x78, 0x41, 0x72, 0x67, 0x73,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x5f, 0x64, 0x65,
+	0x6e, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0d, 0x63, 0x68,
+	0x65, 0x63, 0x6b, 0x44, 0x65, 0x6e, 0x79, 0x6c, 0x69, 0x73, 0x74, 0x12, 0x42, 0x0a, 0x1d, 0x6d,
+	0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77,
+	0x65, 0x64, 0x5f, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x07, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x1b, 0x6d, 0x61, 0x69, 0x6e, 0x74, 0x65, 0x6e, 0x61, 0x6e, 0x63, 0x65, 0x41,
+	0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x46, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12,
+	0x3c, 0x0a, 0x12, 0x61, 0x63, 0x63, 0x65, 0x70, 0x74, 0x65, 0x64, 0x5f, 0x6b, 0x65, 0x79, 0x5f,
+	0x74, 0x79, 0x70, 0x65, 0x73, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x0e, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x4b, 0x65, 0x79, 0x54, 0x79, 0x70, 0x65, 0x52, 0x10, 0x61, 0x63, 0x63,
+	0x65, 0x70, 0x74, 0x65, 0x64, 0x4b, 0x65, 0x79, 0x54, 0x79, 0x70, 0x65, 0x73, 0x12, 0x2a, 0x0a,
+	0x11, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x5f, 0x70, 0x72, 0x65, 0x66,
+	0x69, 0x78, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x4e,
+	0x61, 0x6d, 0x65, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78, 0x12, 0x4c, 0x0a, 0x23, 0x61, 0x6c, 0x6c,
+	0x6f, 0x77, 0x5f, 0x7a, 0x65, 0x72, 0x6f, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x63,
+	0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73,
+	0x18, 0x0a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5a, 0x65, 0x72,
+	0x6f, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x12, 0x38, 0x0a, 0x0d, 0x61, 0x6d, 0x6f, 0x75, 0x6e,
+	0x74, 0x5f, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x13,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x46, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x52, 0x0c, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x46, 0x6f, 0x72, 0x6d, 0x61,
+	0x74, 0x12, 0x46, 0x0a, 0x20, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x5f, 0x61, 0x73, 0x5f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1c, 0x73, 0x74, 0x6f,
+	0x72, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x73, 0x41, 0x73, 0x50, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x4a, 0x0a, 0x22, 0x63, 0x6f, 0x6e,
+	0x76, 0x65, 0x72, 0x74, 0x5f, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x62, 0x61, 0x6c,
+	0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x63, 0x65, 0x69, 0x70, 0x74, 0x18,
+	0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1e, 0x63, 0x6f, 0x6e, 0x76, 0x65, 0x72, 0x74, 0x41, 0x6c,
+	0x6c, 0x6f, 0x77, 0x65, 0x64, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x4f, 0x6e, 0x52, 0x65,
+	0x63, 0x65, 0x69, 0x70, 0x74, 0x12, 0x3d, 0x0a, 0x1b, 0x64, 0x65, 0x72, 0x69, 0x76, 0x65, 0x5f,
+	0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x5f, 0x69, 0x64, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x18, 0x64, 0x65, 0x72, 0x69,
+	0x76, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x49, 0x64, 0x73, 0x12, 0x22, 0x0a, 0x0d, 0x6d, 0x61, 0x78, 0x5f, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x6d, 0x61, 0x78,
+	0x50, 0x61, 0x67, 0x65, 0x53, 0x69, 0x7a, 0x65, 0x12, 0x3c, 0x0a, 0x1a, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x5f, 0x74, 0x68, 0x6f, 0x75, 0x73, 0x61, 0x6e, 0x64, 0x73, 0x5f, 0x73, 0x65, 0x70,
+	0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x18, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x54, 0x68, 0x6f, 0x75, 0x73, 0x61, 0x6e, 0x64, 0x73, 0x53, 0x65, 0x70,
+	0x61, 0x72, 0x61, 0x74, 0x6f, 0x72, 0x12, 0x2e, 0x0a, 0x13, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x5f, 0x64, 0x65, 0x63, 0x69, 0x6d, 0x61, 0x6c, 0x5f, 0x6d, 0x61, 0x72, 0x6b, 0x18, 0x11, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x11, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x44, 0x65, 0x63, 0x69, 0x6d,
+	0x61, 0x6c, 0x4d, 0x61, 0x72, 0x6b, 0x12, 0x57, 0x0a, 0x10, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x5f,
+	0x6e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x18, 0x12, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2c, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x68, 0x61, 0x69, 0x6e, 0x63, 0x6f,
+	0x64, 0x65, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x4e,
+	0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0f,
+	0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x12,
+	0x48, 0x0a, 0x1c, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x5f, 0x73, 0x75, 0x62, 0x5f, 0x6d, 0x69, 0x6c,
+	0x6c, 0x69, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x5f, 0x64, 0x69, 0x67, 0x69, 0x74, 0x73, 0x18,
+	0x13, 0x20, 0x01, 0x28, 0x0d, 0x42, 0x07, 0xfa, 0x42, 0x04, 0x2a, 0x02, 0x18, 0x03, 0x52, 0x19,
+	0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x53, 0x75, 0x62, 0x4d, 0x69, 0x6c, 0x6c, 0x69, 0x73, 0x65, 0x63,
+	0x6f, 0x6e, 0x64, 0x44, 0x69, 0x67, 0x69, 0x74, 0x73, 0x12, 0x2c, 0x0a, 0x12, 0x6e, 0x6f, 0x6e,
+	0x63, 0x65, 0x5f, 0x74, 0x6f, 0x6c, 0x65, 0x72, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x6d, 0x73, 0x18,
+	0x14, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x10, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x54, 0x6f, 0x6c, 0x65,
+	0x72, 0x61, 0x6e, 0x63, 0x65, 0x4d, 0x73, 0x12, 0x4c, 0x0a, 0x23, 0x63, 0x68, 0x65, 0x63, 0x6b,
+	0x5f, 0x63, 0x68, 0x61, 0x6e, 0x6e, 0x65, 0x6c, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65,
+	0x72, 0x5f, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x15,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x1f, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x43, 0x68, 0x61, 0x6e, 0x6e,
+	0x65, 0x6c, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x54, 0x61, 0x72, 0x67, 0x65, 0x74,
+	0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x39, 0x0a, 0x19, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x5f, 0x63,
+	0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x63, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x6d, 0x73, 0x18, 0x16, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x16, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x43,
+	0x6c, 0x6f, 0x63, 0x6b, 0x43, 0x6f, 0x72, 0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x73,
+	0x12, 0x46, 0x0a, 0x20, 0x6d, 0x61, 0x78, 0x5f, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x5f, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x17, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x1c, 0x6d, 0x61, 0x78, 0x41,
+	0x63, 0x74, 0x69, 0x76, 0x65, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x73, 0x50, 0x65,
+	0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x2b, 0x0a, 0x11, 0x70, 0x72, 0x6f, 0x74,
+	0x65, 0x63, 0x74, 0x65, 0x64, 0x5f, 0x71, 0x75, 0x65, 0x72, 0x69, 0x65, 0x73, 0x18, 0x18, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x65, 0x63, 0x74, 0x65, 0x64, 0x51, 0x75,
+	0x65, 0x72, 0x69, 0x65, 0x73, 0x12, 0x37, 0x0a, 0x18, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x5f, 0x62,
+	0x61, 0x74, 0x63, 0x68, 0x5f, 0x73, 0x68, 0x61, 0x72, 0x65, 0x64, 0x5f, 0x6e, 0x6f, 0x6e, 0x63,
+	0x65, 0x18, 0x19, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x53, 0x68, 0x61, 0x72, 0x65, 0x64, 0x4e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x41,
+	0x0a, 0x1d, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x73, 0x18,
+	0x1a, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1a, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x52, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x49, 0x64,
+	0x73, 0x12, 0x4b, 0x0a, 0x22, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x67,
+	0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x1b, 0x20, 0x01, 0x28, 0x08, 0x52, 0x1f, 0x72,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x3c,
+	0x0a, 0x1a, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x5f, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x66, 0x65, 0x72, 0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x18, 0x1c, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x18, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x54, 0x72,
+	0x61, 0x6e, 0x73, 0x66, 0x65, 0x72, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x73, 0x12, 0x36, 0x0a, 0x17,
+	0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x5f, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x1d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x15, 0x76,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x65, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x53, 0x69, 0x7a, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x77, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x5f, 0x61,
+	0x75, 0x64, 0x69, 0x74, 0x18, 0x1e, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x77, 0x61, 0x6c, 0x6c,
+	0x65, 0x74, 0x41, 0x75, 0x64, 0x69, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x6e, 0x6f, 0x6e, 0x63, 0x65,
+	0x5f, 0x70, 0x72, 0x75, 0x6e, 0x65, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x18, 0x1f, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x13, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x50, 0x72, 0x75,
+	0x6e, 0x65, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x1a, 0x42, 0x0a, 0x14, 0x4e,
+	0x6f, 0x6e, 0x63, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x73, 0x70, 0x61, 0x63, 0x65, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x42, 0x0a, 0x06, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x12, 0x38, 0x0a, 0x07, 0x61, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x42, 0x1e, 0xfa, 0x42, 0x1b, 0x72,
+	0x19, 0x32, 0x17, 0x5e, 0x5b, 0x31, 0x2d, 0x39, 0x41, 0x2d, 0x48, 0x4a, 0x2d, 0x4e, 0x50, 0x2d,
+	0x5a, 0x61, 0x2d, 0x6b, 0x6d, 0x2d, 0x7a, 0x5d, 0x2b, 0x24, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x22, 0xdd, 0x07, 0x0a, 0x0b, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x65, 0x63, 0x69, 0x6d,
+	0x61, 0x6c, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x08, 0x64, 0x65, 0x63, 0x69, 0x6d,
+	0x61, 0x6c, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x75, 0x6e, 0x64, 0x65, 0x72, 0x6c, 0x79, 0x69, 0x6e,
+	0x67, 0x5f, 0x61, 0x73, 0x73, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x75,
+	0x6e, 0x64, 0x65, 0x72, 0x6c, 0x79, 0x69, 0x6e, 0x67, 0x41, 0x73, 0x73, 0x65, 0x74, 0x12, 0x2f,
+	0x0a, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x42, 0x08, 0xfa,
+	0x42, 0x05, 0x8a, 0x01, 0x02, 0x10, 0x01, 0x52, 0x06, 0x69, 0x73, 0x73, 0x75, 0x65, 0x72, 0x12,
+	0x2c, 0x0a, 0x0a, 0x66, 0x65, 0x65, 0x5f, 0x73, 0x65, 0x74, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x6c, 0x6c,
+	0x65, 0x74, 0x52, 0x09, 0x66, 0x65, 0x65, 0x53, 0x65, 0x74, 0x74, 0x65, 0x72, 0x12, 0x3b, 0x0a,
+	0x12, 0x66, 0x65, 0x65, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x73, 0x65, 0x74,
+	0x74, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x52, 0x10, 0x66, 0x65, 0x65, 0x41, 0x64, 0x64,
+	0x72, 0x65, 0x73, 0x73, 0x53, 0x65, 0x74, 0x74, 0x65, 0x72, 0x12, 0x29, 0x0a, 0x08, 0x72, 0x65,
+	0x64, 0x65, 0x65, 0x6d, 0x65, 0x72, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x52, 0x08, 0x72, 0x65, 0x64,
+	0x65, 0x65, 0x6d, 0x65, 0x72, 0x12, 0x37, 0x0a, 0x18, 0x6d, 0x61, 0x78, 0x5f, 0x65, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x15, 0x6d, 0x61, 0x78, 0x45, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x50, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1f,
+	0x0a, 0x0b, 0x6d, 0x69, 0x6e, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x6d, 0x69, 0x6e, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12,
+	0x3e, 0x0a, 0x1b, 0x65, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x61, 0x70, 0x70, 0x72,
+	0x6f, 0x76, 0x61, 0x6c, 0x5f, 0x74, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x19, 0x65, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x41, 0x70,
+	0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x12,
+	0x3c, 0x0a, 0x12, 0x65, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x61, 0x70, 0x70, 0x72,
+	0x6f, 0x76, 0x65, 0x72, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x57, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x52, 0x11, 0x65, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x65, 0x72, 0x73, 0x12, 0x3e, 0x0a,
+	0x1b, 0x65, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72,
+	0x65, 0x64, 0x5f, 0x61, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x73, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x19, 0x65, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75,
+	0x69, 0x72, 0x65, 0x64, 0x41, 0x70, 0x70, 0x72, 0x6f, 0x76, 0x61, 0x6c, 0x73, 0x12, 0x3d, 0x0a,
+	0x1b, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x73, 0x77, 0x69, 0x6e, 0x67, 0x5f, 0x61,
+	0x6c, 0x65, 0x72, 0x74, 0x5f, 0x70, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x0d, 0x20, 0x01,
+	0x28, 0x0d, 0x52, 0x18, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x53, 0x77, 0x69, 0x6e, 0x67,
+	0x41, 0x6c, 0x65, 0x72, 0x74, 0x50, 0x65, 0x72, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x6d, 0x61, 0x78, 0x5f, 0x73, 0x75, 0x70, 0x70, 0x6c, 0x79, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x6d, 0x61, 0x78, 0x53, 0x75, 0x70, 0x70, 0x6c, 0x79, 0x12, 0x51, 0x0a, 0x25, 0x72,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x5f, 0x72, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65,
+	0x64, 0x5f, 0x65, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x72, 0x65, 0x63, 0x69, 0x70,
+	0x69, 0x65, 0x6e, 0x74, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x08, 0x52, 0x22, 0x72, 0x65, 0x71, 0x75,
+	0x69, 0x72, 0x65, 0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x65, 0x64, 0x45, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x12, 0x38,
+	0x0a, 0x18, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x5f, 0x65, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x5f, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x10, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x16, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x74, 0x69, 0x61, 0x6c, 0x45, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x4b, 0x65, 0x79, 0x73, 0x12, 0x3a, 0x0a, 0x19, 0x61, 0x63, 0x63, 0x75,
+	0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x72, 0x65, 0x6d, 0x61, 0x69,
+	0x6e, 0x64, 0x65, 0x72, 0x73, 0x18, 0x11, 0x20, 0x01, 0x28, 0x08, 0x52, 0x17, 0x61, 0x63, 0x63,
+	0x75, 0x6d, 0x75, 0x6c, 0x61, 0x74, 0x65, 0x46, 0x65, 0x65, 0x52, 0x65, 0x6d, 0x61, 0x69, 0x6e,
+	0x64, 0x65, 0x72, 0x73, 0x12, 0x33, 0x0a, 0x16, 0x64, 0x65, 0x64, 0x75, 0x63, 0x74, 0x5f, 0x66,
+	0x65, 0x65, 0x5f, 0x66, 0x72, 0x6f, 0x6d, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x12,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x13, 0x64, 0x65, 0x64, 0x75, 0x63, 0x74, 0x46, 0x65, 0x65, 0x46,
+	0x72, 0x6f, 0x6d, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x38, 0x0a, 0x18, 0x61, 0x6c, 0x6c,
+	0x6f, 0x77, 0x5f, 0x73, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x5f, 0x65, 0x6d, 0x69,
+	0x73, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x13, 0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x61, 0x6c, 0x6c,
+	0x6f, 0x77, 0x53, 0x63, 0x68, 0x65, 0x64, 0x75, 0x6c, 0x65, 0x64, 0x45, 0x6d, 0x69, 0x73, 0x73,
+	0x69, 0x6f, 0x6e, 0x2a, 0x5b, 0x0a, 0x0c, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x46, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x4d, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x46, 0x4f,
+	0x52, 0x4d, 0x41, 0x54, 0x5f, 0x44, 0x45, 0x43, 0x49, 0x4d, 0x41, 0x4c, 0x10, 0x00, 0x12, 0x15,
+	0x0a, 0x11, 0x41, 0x4d, 0x4f, 0x55, 0x4e, 0x54, 0x5f, 0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f,
+	0x48, 0x45, 0x58, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x41, 0x4d, 0x4f, 0x55, 0x4e, 0x54, 0x5f,
+	0x46, 0x4f, 0x52, 0x4d, 0x41, 0x54, 0x5f, 0x44, 0x49, 0x53, 0x50, 0x4c, 0x41, 0x59, 0x10, 0x02,
+	0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61,
+	0x6e, 0x6f, 0x69, 0x64, 0x65, 0x61, 0x6f, 0x70, 0x65, 0x6e, 0x2f, 0x66, 0x6f, 0x75, 0x6e, 0x64,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
 }
 
 var (
@@ -562,32 +1357,46 @@ func file_foundation_config_proto_rawDescGZIP() []byte {
 	return file_foundation_config_proto_rawDescData
 }
 
-var file_foundation_config_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_foundation_config_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_foundation_config_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_foundation_config_proto_goTypes = []any{
-	(*Config)(nil),            // 0: proto.Config
-	(*ContractConfig)(nil),    // 1: proto.ContractConfig
-	(*CollectorEndpoint)(nil), // 2: proto.CollectorEndpoint
-	(*ChaincodeOptions)(nil),  // 3: proto.ChaincodeOptions
-	(*Wallet)(nil),            // 4: proto.Wallet
-	(*TokenConfig)(nil),       // 5: proto.TokenConfig
-	(*anypb.Any)(nil),         // 6: google.protobuf.Any
+	(AmountFormat)(0),          // 0: proto.AmountFormat
+	(*Config)(nil),             // 1: proto.Config
+	(*ContractConfig)(nil),     // 2: proto.ContractConfig
+	(*ChannelTransferFee)(nil), // 3: proto.ChannelTransferFee
+	(*CollectorEndpoint)(nil),  // 4: proto.CollectorEndpoint
+	(*ChaincodeOptions)(nil),   // 5: proto.ChaincodeOptions
+	(*Wallet)(nil),             // 6: proto.Wallet
+	(*TokenConfig)(nil),        // 7: proto.TokenConfig
+	nil,                        // 8: proto.ContractConfig.SymbolAliasesEntry
+	nil,                        // 9: proto.ChaincodeOptions.NonceNamespacesEntry
+	(*anypb.Any)(nil),          // 10: google.protobuf.Any
+	(KeyType)(0),               // 11: proto.KeyType
 }
 var file_foundation_config_proto_depIdxs = []int32{
-	1,  // 0: proto.Config.contract:type_name -> proto.ContractConfig
-	5,  // 1: proto.Config.token:type_name -> proto.TokenConfig
-	6,  // 2: proto.Config.ext_config:type_name -> google.protobuf.Any
-	3,  // 3: proto.ContractConfig.options:type_name -> proto.ChaincodeOptions
-	4,  // 4: proto.ContractConfig.admin:type_name -> proto.Wallet
-	2,  // 5: proto.ContractConfig.tracingCollectorEndpoint:type_name -> proto.CollectorEndpoint
-	4,  // 6: proto.TokenConfig.issuer:type_name -> proto.Wallet
-	4,  // 7: proto.TokenConfig.fee_setter:type_name -> proto.Wallet
-	4,  // 8: proto.TokenConfig.fee_address_setter:type_name -> proto.Wallet
-	4,  // 9: proto.TokenConfig.redeemer:type_name -> proto.Wallet
-	10, // [10:10] is the sub-list for method output_type
-	10, // [10:10] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	2,  // 0: proto.Config.contract:type_name -> proto.ContractConfig
+	7,  // 1: proto.Config.token:type_name -> proto.TokenConfig
+	10, // 2: proto.Config.ext_config:type_name -> google.protobuf.Any
+	5,  // 3: proto.ContractConfig.options:type_name -> proto.ChaincodeOptions
+	6,  // 4: proto.ContractConfig.admin:type_name -> proto.Wallet
+	4,  // 5: proto.ContractConfig.tracingCollectorEndpoint:type_name -> proto.CollectorEndpoint
+	3,  // 6: proto.ContractConfig.channel_transfer_fee:type_name -> proto.ChannelTransferFee
+	6,  // 7: proto.ContractConfig.trusted_addresses:type_name -> proto.Wallet
+	8,  // 8: proto.ContractConfig.symbol_aliases:type_name -> proto.ContractConfig.SymbolAliasesEntry
+	6,  // 9: proto.ChannelTransferFee.address:type_name -> proto.Wallet
+	11, // 10: proto.ChaincodeOptions.accepted_key_types:type_name -> proto.KeyType
+	0,  // 11: proto.ChaincodeOptions.amount_format:type_name -> proto.AmountFormat
+	9,  // 12: proto.ChaincodeOptions.nonce_namespaces:type_name -> proto.ChaincodeOptions.NonceNamespacesEntry
+	6,  // 13: proto.TokenConfig.issuer:type_name -> proto.Wallet
+	6,  // 14: proto.TokenConfig.fee_setter:type_name -> proto.Wallet
+	6,  // 15: proto.TokenConfig.fee_address_setter:type_name -> proto.Wallet
+	6,  // 16: proto.TokenConfig.redeemer:type_name -> proto.Wallet
+	6,  // 17: proto.TokenConfig.emission_approvers:type_name -> proto.Wallet
+	18, // [18:18] is the sub-list for method output_type
+	18, // [18:18] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_foundation_config_proto_init() }
@@ -595,6 +1404,7 @@ func file_foundation_config_proto_init() {
 	if File_foundation_config_proto != nil {
 		return
 	}
+	file_batch_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_foundation_config_proto_msgTypes[0].Exporter = func(v any, i int) any {
 			switch v := v.(*Config); i {
@@ -621,7 +1431,7 @@ func file_foundation_config_proto_init() {
 			}
 		}
 		file_foundation_config_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*CollectorEndpoint); i {
+			switch v := v.(*ChannelTransferFee); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -633,7 +1443,7 @@ func file_foundation_config_proto_init() {
 			}
 		}
 		file_foundation_config_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*ChaincodeOptions); i {
+			switch v := v.(*CollectorEndpoint); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -645,7 +1455,7 @@ func file_foundation_config_proto_init() {
 			}
 		}
 		file_foundation_config_proto_msgTypes[4].Exporter = func(v any, i int) any {
-			switch v := v.(*Wallet); i {
+			switch v := v.(*ChaincodeOptions); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -657,6 +1467,18 @@ func file_foundation_config_proto_init() {
 			}
 		}
 		file_foundation_config_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*Wallet); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_foundation_config_proto_msgTypes[6].Exporter = func(v any, i int) any {
 			switch v := v.(*TokenConfig); i {
 			case 0:
 				return &v.state
@@ -674,13 +1496,14 @@ func file_foundation_config_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_foundation_config_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      1,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_foundation_config_proto_goTypes,
 		DependencyIndexes: file_foundation_config_proto_depIdxs,
+		EnumInfos:         file_foundation_config_proto_enumTypes,
 		MessageInfos:      file_foundation_config_proto_msgTypes,
 	}.Build()
 	File_foundation_config_proto = out.File
@@ -362,6 +362,73 @@ func (m *ContractConfig) validate(all bool) error {
 		}
 	}
 
+	if all {
+		switch v := interface{}(m.GetChannelTransferFee()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ContractConfigValidationError{
+					field:  "ChannelTransferFee",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ContractConfigValidationError{
+					field:  "ChannelTransferFee",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetChannelTransferFee()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ContractConfigValidationError{
+				field:  "ChannelTransferFee",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Version
+
+	for idx, item := range m.GetTrustedAddresses() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, ContractConfigValidationError{
+						field:  fmt.Sprintf("TrustedAddresses[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, ContractConfigValidationError{
+						field:  fmt.Sprintf("TrustedAddresses[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return ContractConfigValidationError{
+					field:  fmt.Sprintf("TrustedAddresses[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for SymbolAliases
+
 	if len(errors) > 0 {
 		return ContractConfigMultiError(errors)
 	}
@@ -444,6 +511,154 @@ var _ContractConfig_Symbol_Pattern = regexp.MustCompile("^[A-Z]+[A-Z0-9]+(-[A-Z0
 
 var _ContractConfig_RobotSKI_Pattern = regexp.MustCompile("^[0-9a-f]+$")
 
+// Validate checks the field values on ChannelTransferFee with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *ChannelTransferFee) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on ChannelTransferFee with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// ChannelTransferFeeMultiError, or nil if none found.
+func (m *ChannelTransferFee) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *ChannelTransferFee) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Rate
+
+	// no validation rules for Floor
+
+	// no validation rules for Cap
+
+	if m.GetAddress() == nil {
+		err := ChannelTransferFeeValidationError{
+			field:  "Address",
+			reason: "value is required",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if all {
+		switch v := interface{}(m.GetAddress()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, ChannelTransferFeeValidationError{
+					field:  "Address",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, ChannelTransferFeeValidationError{
+					field:  "Address",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetAddress()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return ChannelTransferFeeValidationError{
+				field:  "Address",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return ChannelTransferFeeMultiError(errors)
+	}
+
+	return nil
+}
+
+// ChannelTransferFeeMultiError is an error wrapping multiple validation errors
+// returned by ChannelTransferFee.ValidateAll() if the designated constraints
+// aren't met.
+type ChannelTransferFeeMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m ChannelTransferFeeMultiError) Error() string {
+	var msgs []string
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m ChannelTransferFeeMultiError) AllErrors() []error { return m }
+
+// ChannelTransferFeeValidationError is the validation error returned by
+// ChannelTransferFee.Validate if the designated constraints aren't met.
+type ChannelTransferFeeValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e ChannelTransferFeeValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e ChannelTransferFeeValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e ChannelTransferFeeValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e ChannelTransferFeeValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e ChannelTransferFeeValidationError) ErrorName() string {
+	return "ChannelTransferFeeValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e ChannelTransferFeeValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sChannelTransferFee.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = ChannelTransferFeeValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = ChannelTransferFeeValidationError{}
+
 // Validate checks the field values on CollectorEndpoint with the rules defined
 // in the proto definition for this message. If any rules are violated, the
 // first error encountered is returned, or nil if there are no violations.
@@ -574,6 +789,61 @@ func (m *ChaincodeOptions) validate(all bool) error {
 
 	// no validation rules for DisableMultiSwaps
 
+	// no validation rules for MaxArgsSize
+
+	// no validation rules for CheckDenylist
+
+	// no validation rules for EventNamePrefix
+
+	// no validation rules for AllowZeroAmountChannelTransfers
+
+	// no validation rules for AmountFormat
+
+	// no validation rules for StoreChannelTransfersAsProto
+
+	// no validation rules for ConvertAllowedBalanceOnReceipt
+
+	// no validation rules for DeriveChannelTransferIds
+
+	// no validation rules for MaxPageSize
+
+	// no validation rules for AmountThousandsSeparator
+
+	// no validation rules for AmountDecimalMark
+
+	// no validation rules for NonceNamespaces
+
+	if m.GetNonceSubMillisecondDigits() > 3 {
+		err := ChaincodeOptionsValidationError{
+			field:  "NonceSubMillisecondDigits",
+			reason: "value must be less than or equal to 3",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	// no validation rules for NonceToleranceMs
+
+	// no validation rules for CheckChannelTransferTargetToken
+
+	// no validation rules for NonceClockCorrectionMs
+
+	// no validation rules for MaxActiveTransfersPerAddress
+
+	// no validation rules for AllowBatchSharedNonce
+
+	// no validation rules for RequireReservedTransferIds
+
+	// no validation rules for RequireRegisteredTransferTokens
+
+	// no validation rules for ValidateSignatureSize
+
+	// no validation rules for WalletAudit
+
+	// no validation rules for NoncePruneThreshold
+
 	if len(errors) > 0 {
 		return ChaincodeOptionsMultiError(errors)
 	}
@@ -918,6 +1188,62 @@ func (m *TokenConfig) validate(all bool) error {
 		}
 	}
 
+	// no validation rules for MaxEmissionPerAddress
+
+	// no validation rules for MinBalance
+
+	// no validation rules for EmissionApprovalThreshold
+
+	for idx, item := range m.GetEmissionApprovers() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, TokenConfigValidationError{
+						field:  fmt.Sprintf("EmissionApprovers[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, TokenConfigValidationError{
+						field:  fmt.Sprintf("EmissionApprovers[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return TokenConfigValidationError{
+					field:  fmt.Sprintf("EmissionApprovers[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	// no validation rules for EmissionRequiredApprovals
+
+	// no validation rules for BalanceSwingAlertPercent
+
+	// no validation rules for MaxSupply
+
+	// no validation rules for RequireRegisteredEmissionRecipient
+
+	// no validation rules for SequentialEmissionKeys
+
+	// no validation rules for AccumulateFeeRemainders
+
+	// no validation rules for DeductFeeFromAmount
+
+	// no validation rules for AllowScheduledEmission
+
 	if len(errors) > 0 {
 		return TokenConfigMultiError(errors)
 	}
@@ -0,0 +1,194 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.1
+// source: htlc.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// HTLC is a hash time locked transfer: tokens are locked on the sender balance
+// and can be claimed by the recipient with the secret before timeout,
+// or refunded to the sender after timeout.
+type HTLC struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id        string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`               // HTLC identifier (txID of the lock transaction)
+	Sender    []byte `protobuf:"bytes,2,opt,name=sender,proto3" json:"sender,omitempty"`       // The address of the tokens owner
+	Recipient []byte `protobuf:"bytes,3,opt,name=recipient,proto3" json:"recipient,omitempty"` // The address of the tokens recipient
+	Amount    []byte `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`       // big.Int number of locked tokens
+	Hashlock  []byte `protobuf:"bytes,5,opt,name=hashlock,proto3" json:"hashlock,omitempty"`   // SHA3-256 hash of the secret
+	Timeout   int64  `protobuf:"varint,6,opt,name=timeout,proto3" json:"timeout,omitempty"`    // unix time in seconds, after which tokens can be refunded
+}
+
+func (x *HTLC) Reset() {
+	*x = HTLC{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_htlc_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *HTLC) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HTLC) ProtoMessage() {}
+
+func (x *HTLC) ProtoReflect() protoreflect.Message {
+	mi := &file_htlc_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HTLC.ProtoReflect.Descriptor instead.
+func (*HTLC) Descriptor() ([]byte, []int) {
+	return file_htlc_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *HTLC) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *HTLC) GetSender() []byte {
+	if x != nil {
+		return x.Sender
+	}
+	return nil
+}
+
+func (x *HTLC) GetRecipient() []byte {
+	if x != nil {
+		return x.Recipient
+	}
+	return nil
+}
+
+func (x *HTLC) GetAmount() []byte {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *HTLC) GetHashlock() []byte {
+	if x != nil {
+		return x.Hashlock
+	}
+	return nil
+}
+
+func (x *HTLC) GetTimeout() int64 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+var File_htlc_proto protoreflect.FileDescriptor
+
+var file_htlc_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x68, 0x74, 0x6c, 0x63, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0x9a, 0x01, 0x0a, 0x04, 0x48, 0x54, 0x4c, 0x43, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x73, 0x65,
+	0x6e, 0x64, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e,
+	0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65,
+	0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0c, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08, 0x68, 0x61,
+	0x73, 0x68, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x08, 0x68, 0x61,
+	0x73, 0x68, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x18, 0x0a, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75,
+	0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x07, 0x74, 0x69, 0x6d, 0x65, 0x6f, 0x75, 0x74,
+	0x42, 0x29, 0x5a, 0x27, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x61,
+	0x6e, 0x6f, 0x69, 0x64, 0x65, 0x61, 0x6f, 0x70, 0x65, 0x6e, 0x2f, 0x66, 0x6f, 0x75, 0x6e, 0x64,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_htlc_proto_rawDescOnce sync.Once
+	file_htlc_proto_rawDescData = file_htlc_proto_rawDesc
+)
+
+func file_htlc_proto_rawDescGZIP() []byte {
+	file_htlc_proto_rawDescOnce.Do(func() {
+		file_htlc_proto_rawDescData = protoimpl.X.CompressGZIP(file_htlc_proto_rawDescData)
+	})
+	return file_htlc_proto_rawDescData
+}
+
+var file_htlc_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_htlc_proto_goTypes = []any{
+	(*HTLC)(nil), // 0: proto.HTLC
+}
+var file_htlc_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_htlc_proto_init() }
+func file_htlc_proto_init() {
+	if File_htlc_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_htlc_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*HTLC); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_htlc_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_htlc_proto_goTypes,
+		DependencyIndexes: file_htlc_proto_depIdxs,
+		MessageInfos:      file_htlc_proto_msgTypes,
+	}.Build()
+	File_htlc_proto = out.File
+	file_htlc_proto_rawDesc = nil
+	file_htlc_proto_goTypes = nil
+	file_htlc_proto_depIdxs = nil
+}
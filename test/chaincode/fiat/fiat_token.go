@@ -40,7 +40,7 @@ func (ft *FiatToken) TxEmit(sender *types.Sender, address *types.Address, amount
 	if err := ft.TokenBalanceAdd(address, amount, "txEmit"); err != nil {
 		return err
 	}
-	return ft.EmissionAdd(amount)
+	return ft.EmissionAddTo(address, amount)
 }
 
 func (ft *FiatToken) QueryAllowedBalanceAdd(token string, address *types.Address, amount *big.Int, reason string) (string, error) {
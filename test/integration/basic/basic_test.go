@@ -1,12 +1,14 @@
 package basic
 
 import (
+	"encoding/hex"
 	"encoding/json"
 	"os"
 	"path/filepath"
 	"syscall"
 	"time"
 
+	"github.com/anoideaopen/foundation/core"
 	pbfound "github.com/anoideaopen/foundation/proto"
 	"github.com/anoideaopen/foundation/test/integration/cmn"
 	"github.com/anoideaopen/foundation/test/integration/cmn/client"
@@ -23,6 +25,8 @@ import (
 	"github.com/onsi/gomega/gbytes"
 	"github.com/tedsuo/ifrit"
 	ginkgomon "github.com/tedsuo/ifrit/ginkgomon_v2"
+	"golang.org/x/crypto/sha3"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const fnMethodWithRights = "withRights"
@@ -331,6 +335,68 @@ var _ = Describe("Basic foundation Tests", func() {
 					"balanceOf", user2.AddressBase58Check)
 			})
 
+			It("transfer involving denylisted user", func() {
+				By("add users to acl")
+				client.AddUser(network, peer, network.Orderers[0], user1)
+				client.AddUser(network, peer, network.Orderers[0], user2)
+
+				By("emit tokens")
+				amount := "1"
+				client.TxInvokeWithSign(network, peer, network.Orderers[0],
+					cmn.ChannelFiat, cmn.ChannelFiat, admin,
+					"emit", "", client.NewNonceByTime().Get(), nil, user1.AddressBase58Check, amount)
+
+				By("lock tokens of user1 for user2")
+				preimage := "htlc secret"
+				hashlock := sha3.Sum256([]byte(preimage))
+				htlcID := client.TxInvokeWithSign(network, peer, network.Orderers[0],
+					cmn.ChannelFiat, cmn.ChannelFiat, user1, "lockHTLC", "",
+					client.NewNonceByTime().Get(), nil,
+					user2.AddressBase58Check, amount, hex.EncodeToString(hashlock[:]), "3600")
+
+				By("add user1 to black list")
+				client.AddToList(network, peer, network.Orderers[0], user1, "black")
+
+				fDenylistErr := func(out []byte) string {
+					Expect(gbytes.BufferWithBytes(out)).To(gbytes.Say(core.ErrAddressDenylisted.Error()))
+					return ""
+				}
+
+				// the arguments of claimHTLC are not addresses, so the denylisted owner of the tokens
+				// is rejected by the denylist check instead of the argument validation
+				By("NEGATIVE: user2 claims tokens locked by user1")
+				client.TxInvokeWithSign(network, peer, network.Orderers[0],
+					cmn.ChannelFiat, cmn.ChannelFiat, user2, "claimHTLC", "",
+					client.NewNonceByTime().Get(), fabricnetwork.CheckResult(nil, fDenylistErr),
+					htlcID, preimage)
+
+				By("NEGATIVE: admin transfer balance from user1 to user2")
+				transferRequest := &pbfound.TransferRequest{
+					Basis:           pbfound.TransferBasis_TRANSFER_BASIS_INHERITANCE,
+					AdministratorId: admin.AddressBase58Check,
+					DocumentType:    pbfound.DocumentType_DOCUMENT_TYPE_INHERITANCE,
+					DocumentNumber:  "1",
+					DocumentDate:    timestamppb.New(time.Now()),
+					DocumentHashes:  []string{"hash"},
+					FromAddress:     user1.AddressBase58Check,
+					ToAddress:       user2.AddressBase58Check,
+					Amount:          amount,
+					Reason:          "test transfer",
+					BalanceType:     pbfound.BalanceType_BALANCE_TYPE_TOKEN,
+				}
+				data, err := json.Marshal(transferRequest)
+				Expect(err).NotTo(HaveOccurred())
+
+				client.TxInvokeWithSign(network, peer, network.Orderers[0],
+					cmn.ChannelFiat, cmn.ChannelFiat, admin, "transferBalance", "",
+					client.NewNonceByTime().Get(), fabricnetwork.CheckResult(nil, fDenylistErr), string(data))
+
+				By("check balance user2")
+				client.Query(network, peer, cmn.ChannelFiat, cmn.ChannelFiat,
+					fabricnetwork.CheckResult(fabricnetwork.CheckBalance("0"), nil),
+					"balanceOf", user2.AddressBase58Check)
+			})
+
 			It("transfer with fee", func() {
 				By("add users to acl")
 				user1.UserID = "1111"
@@ -85,9 +85,11 @@ func DeployCC(network *nwo.Network, components *nwo.Components, peer *nwo.Peer,
 	Eventually(sess, network.EventuallyTimeout).Should(gbytes.Say(`{"name":"Currency Coin","symbol":"CC","decimals":8,"underlying_asset":"US Dollars"`))
 }
 
+// DeployFiat deploys fiat chaincode, acceptedKeyTypes limit key types of signatures accepted by the chaincode
 func DeployFiat(network *nwo.Network, components *nwo.Components, peer *nwo.Peer,
 	testDir string, skiRobot string, adminAddressBase58Check string,
-	feeSetterAddressBase58Check string, feeAddressSetterAddressBase58Check string) {
+	feeSetterAddressBase58Check string, feeAddressSetterAddressBase58Check string,
+	acceptedKeyTypes ...pb.KeyType) {
 	By("Deploying chaincode fiat")
 
 	cfgFiat := &pb.Config{
@@ -97,6 +99,8 @@ func DeployFiat(network *nwo.Network, components *nwo.Components, peer *nwo.Peer
 			Admin:    &pb.Wallet{Address: adminAddressBase58Check},
 			Options: &pb.ChaincodeOptions{
 				DisabledFunctions: []string{"TxBuyToken", "TxBuyBack"},
+				CheckDenylist:     true,
+				AcceptedKeyTypes:  acceptedKeyTypes,
 			},
 		},
 		Token: &pb.TokenConfig{
@@ -254,3 +254,22 @@ func CheckRights(network *nwo.Network, peer *nwo.Peer,
 		return ""
 	}, network.EventuallyTimeout, time.Second).Should(BeEmpty())
 }
+
+// AddToList adds user address to ACL list, listType is "gray" or "black"
+func AddToList(network *nwo.Network, peer *nwo.Peer, orderer *nwo.Orderer,
+	user *UserFoundation, listType string) {
+	sess, err := network.PeerUserSession(peer, "User1", commands.ChaincodeInvoke{
+		ChannelID: cmn.ChannelAcl,
+		Orderer:   network.OrdererAddress(orderer, nwo.ListenPort),
+		Name:      cmn.ChannelAcl,
+		Ctor:      cmn.CtorFromSlice([]string{"addToList", user.AddressBase58Check, listType}),
+		PeerAddresses: []string{
+			network.PeerAddress(network.Peer("Org1", "peer0"), nwo.ListenPort),
+			network.PeerAddress(network.Peer("Org2", "peer0"), nwo.ListenPort),
+		},
+		WaitForEvent: true,
+	})
+	Expect(err).NotTo(HaveOccurred())
+	Eventually(sess, network.EventuallyTimeout).Should(gexec.Exit(0))
+	Expect(sess.Err).To(gbytes.Say("Chaincode invoke successful. result: status:200"))
+}
@@ -239,10 +239,15 @@ func TxInvokeByRobot(network *nwo.Network, peer *nwo.Peer, orderer *nwo.Orderer,
 	return invokeTx(network, peer, orderer, "User2", channel, ccName, checkErr, args...)
 }
 
-// TxInvokeWithSign func for invoke with sign to foundation fabric
+// TxInvokeWithSign func for invoke with sign to foundation fabric.
+// If the user has keys of several types, the key type accepted by the chaincode is negotiated first.
 func TxInvokeWithSign(network *nwo.Network, peer *nwo.Peer, orderer *nwo.Orderer,
 	channel string, ccName string, user *UserFoundation,
 	fn string, requestID string, nonce string, checkErr CheckResultFunc, args ...string) (txId string) {
+	if len(user.KeyTypes()) > 1 {
+		NegotiateKeyType(network, peer, channel, ccName, user)
+	}
+
 	ctorArgs := append(append([]string{fn, requestID, channel, ccName}, args...), nonce)
 	pubKey, sMsg, err := user.Sign(ctorArgs...)
 	Expect(err).NotTo(HaveOccurred())
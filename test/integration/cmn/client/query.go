@@ -1,8 +1,11 @@
 package client
 
 import (
+	"encoding/json"
+	"fmt"
 	"time"
 
+	"github.com/anoideaopen/foundation/core"
 	"github.com/anoideaopen/foundation/test/integration/cmn"
 	"github.com/btcsuite/btcutil/base58"
 	"github.com/hyperledger/fabric/integration/nwo"
@@ -65,3 +68,26 @@ func QueryWithSign(network *nwo.Network, peer *nwo.Peer, channel string, ccName
 	ctorArgs = append(ctorArgs, pubKey, base58.Encode(sMsg))
 	Query(network, peer, channel, ccName, checkResultFunc, ctorArgs...)
 }
+
+// NegotiateKeyType queries key types accepted by the chaincode and switches
+// the user to the most preferred of them available to the user
+func NegotiateKeyType(network *nwo.Network, peer *nwo.Peer, channel string, ccName string, user *UserFoundation) {
+	Query(network, peer, channel, ccName, func(err error, exitCode int, sessError []byte, sessOut []byte) string {
+		if err != nil {
+			return fmt.Sprintf("error executing command: %v", err)
+		}
+
+		if exitCode != 0 {
+			return fmt.Sprintf("exit code is %d: %s, %v", exitCode, string(sessError), err)
+		}
+
+		capabilities := &core.Capabilities{}
+		if err = json.Unmarshal(sessOut, capabilities); err != nil {
+			return fmt.Sprintf("failed to unmarshal capabilities: %v", err)
+		}
+
+		Expect(user.SelectKeyType(capabilities.KeyTypes)).NotTo(HaveOccurred())
+
+		return ""
+	}, "capabilities")
+}
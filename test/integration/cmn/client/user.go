@@ -6,16 +6,23 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/anoideaopen/foundation/core/stringsx"
 	"github.com/anoideaopen/foundation/keys"
 	pbfound "github.com/anoideaopen/foundation/proto"
 	"github.com/btcsuite/btcutil/base58"
 	"golang.org/x/crypto/sha3"
 )
 
+var ErrNoMutualKeyType = errors.New("no key type supported by both user and chaincode")
+
 type UserFoundation struct {
 	*keys.Keys
 	AddressBase58Check string
 	UserID             string
+
+	// keySets stores all keys of the user created by NewUserFoundationWithKeyTypes,
+	// the user signs with one of them selected by UseKeyType or SelectKeyType
+	keySets []*UserFoundation
 }
 
 func NewUserFoundation(keyType pbfound.KeyType) (*UserFoundation, error) {
@@ -34,6 +41,89 @@ func NewUserFoundation(keyType pbfound.KeyType) (*UserFoundation, error) {
 	}, nil
 }
 
+// NewUserFoundationFromSeed creates user with keys derived from seed by derivation path,
+// so one seed manages many users, e.g. "m/44'/0'/0'" and "m/44'/0'/1'"
+func NewUserFoundationFromSeed(keyType pbfound.KeyType, seed []byte, path string) (*UserFoundation, error) {
+	keysStr, err := keys.DeriveKeysFromSeed(keyType, seed, path)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := sha3.Sum256(keysStr.PublicKeyBytes)
+	addressBase58Check := base58.CheckEncode(hash[1:], hash[0])
+
+	return &UserFoundation{
+		Keys:               keysStr,
+		AddressBase58Check: addressBase58Check,
+		UserID:             "testuser",
+	}, nil
+}
+
+// NewUserFoundationWithKeyTypes creates user with keys of several types in order of preference.
+// The first key type is used until other is selected by UseKeyType or SelectKeyType.
+func NewUserFoundationWithKeyTypes(keyTypes ...pbfound.KeyType) (*UserFoundation, error) {
+	if len(keyTypes) == 0 {
+		return nil, errors.New("no key types")
+	}
+
+	keySets := make([]*UserFoundation, 0, len(keyTypes))
+	for _, keyType := range keyTypes {
+		keySet, err := NewUserFoundation(keyType)
+		if err != nil {
+			return nil, err
+		}
+		keySets = append(keySets, keySet)
+	}
+
+	user := *keySets[0]
+	user.keySets = keySets
+
+	return &user, nil
+}
+
+// KeyTypes returns key types available to the user in order of preference
+func (u *UserFoundation) KeyTypes() []pbfound.KeyType {
+	if len(u.keySets) == 0 {
+		return []pbfound.KeyType{u.KeyType}
+	}
+
+	keyTypes := make([]pbfound.KeyType, 0, len(u.keySets))
+	for _, keySet := range u.keySets {
+		keyTypes = append(keyTypes, keySet.KeyType)
+	}
+
+	return keyTypes
+}
+
+// UseKeyType switches the user to the keys of the specified type
+func (u *UserFoundation) UseKeyType(keyType pbfound.KeyType) error {
+	for _, keySet := range u.keySets {
+		if keySet.KeyType == keyType {
+			u.Keys = keySet.Keys
+			u.AddressBase58Check = keySet.AddressBase58Check
+			return nil
+		}
+	}
+
+	if u.KeyType == keyType {
+		return nil
+	}
+
+	return fmt.Errorf("user has no %s key", keyType)
+}
+
+// SelectKeyType switches the user to the most preferred key type among the accepted ones.
+// Accepted key types are the names returned by the capabilities query of the chaincode.
+func (u *UserFoundation) SelectKeyType(accepted []string) error {
+	for _, keyType := range u.KeyTypes() {
+		if stringsx.OneOf(keyType.String(), accepted...) {
+			return u.UseKeyType(keyType)
+		}
+	}
+
+	return fmt.Errorf("%w: user %v, chaincode %v", ErrNoMutualKeyType, u.KeyTypes(), accepted)
+}
+
 func UserFoundationFromEd25519PrivateKey(privateKey ed25519.PrivateKey) (*UserFoundation, error) {
 	publicKey, ok := privateKey.Public().(ed25519.PublicKey)
 	if !ok {
@@ -0,0 +1,55 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	pb "github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddressKeyType(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC(testTokenCCName, &token.BaseToken{}, config)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance(testTokenCCName, 1000)
+
+	user2 := ledger.NewWallet()
+	user2.UseSecp256k1Key()
+	user2.AddBalance(testTokenCCName, 1000)
+
+	t.Run("not recorded before the first signature", func(t *testing.T) {
+		err := owner.InvokeWithError(testTokenCCName, "addressKeyType", user1.Address())
+		require.ErrorContains(t, err, core.ErrAddressKeyTypeNotFound.Error())
+	})
+
+	t.Run("recorded on the first signature", func(t *testing.T) {
+		user1.SignedInvoke(testTokenCCName, "transfer", owner.Address(), "100", "")
+		require.Equal(t, "\"ed25519\"", owner.Invoke(testTokenCCName, "addressKeyType", user1.Address()))
+
+		user2.SignedInvoke(testTokenCCName, "transfer", owner.Address(), "100", "")
+		require.Equal(t, "\"secp256k1\"", owner.Invoke(testTokenCCName, "addressKeyType", user2.Address()))
+	})
+
+	t.Run("signature of the other key type is rejected", func(t *testing.T) {
+		stub := ledger.GetStub(testTokenCCName)
+		key, err := stub.CreateCompositeKey(core.AddressKeyTypeCompositeType, []string{user1.Address()})
+		require.NoError(t, err)
+
+		stub.MockTransactionStart("record_key_type")
+		require.NoError(t, stub.PutState(key, []byte(pb.KeyType_gost.String())))
+		stub.MockTransactionEnd("record_key_type")
+
+		err = user1.RawSignedInvokeWithErrorReturned(testTokenCCName, "transfer", owner.Address(), "100", "")
+		require.ErrorContains(t, err, core.ErrKeyTypeMismatch.Error())
+		user1.BalanceShouldBe(testTokenCCName, 900)
+	})
+}
@@ -0,0 +1,62 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInvalidAddressArgument checks that malformed base58check addresses are rejected
+// on parsing of the method arguments with ErrInvalidAddress
+func TestInvalidAddressArgument(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+	user := ledgerMock.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		issuer.Address(), "", "", "", nil)
+	initMsg := ledgerMock.NewCC(testTokenCCName, &token.BaseToken{}, config)
+	require.Empty(t, initMsg)
+
+	issuer.AddBalance(testTokenCCName, 1000)
+
+	addrBytes := user.AddressType().Bytes()
+	valid := user.Address()
+
+	truncated := base58.CheckEncode(addrBytes[1:types.AddressLength/2], addrBytes[0])
+
+	wrongChecksum := []byte(valid)
+	if wrongChecksum[len(wrongChecksum)-1] == '1' {
+		wrongChecksum[len(wrongChecksum)-1] = '2'
+	} else {
+		wrongChecksum[len(wrongChecksum)-1] = '1'
+	}
+
+	for _, tc := range []struct {
+		name    string
+		address string
+	}{
+		{name: "truncated address", address: truncated},
+		{name: "truncated string", address: valid[:len(valid)-5]},
+		{name: "wrong checksum", address: string(wrongChecksum)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := issuer.RawSignedInvokeWithErrorReturned(testTokenCCName, "transfer", tc.address, "100", "")
+			require.ErrorContains(t, err, types.ErrInvalidAddress.Error())
+			require.ErrorContains(t, err, tc.address)
+
+			err = issuer.InvokeWithError(testTokenCCName, "balanceOf", tc.address)
+			require.ErrorContains(t, err, types.ErrInvalidAddress.Error())
+		})
+	}
+
+	t.Run("valid address", func(t *testing.T) {
+		err := issuer.RawSignedInvokeWithErrorReturned(testTokenCCName, "transfer", valid, "100", "")
+		require.NoError(t, err)
+		user.BalanceShouldBe(testTokenCCName, 100)
+	})
+}
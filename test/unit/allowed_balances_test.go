@@ -136,3 +136,26 @@ func TestQuery(t *testing.T) {
 		require.Equal(t, "{\"CC\":\"600\"}", balance)
 	})
 }
+
+func TestAllowedBalanceTransfer(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	issuer := ledger.NewWallet()
+	user1 := ledger.NewWallet()
+	user2 := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		issuer.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("cc", &TestToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	user1.AddAllowedBalance("cc", "FIAT", 1000)
+
+	user1.SignedInvoke("cc", "allowedBalanceTransfer", user2.Address(), "FIAT", "300")
+	user1.AllowedBalanceShouldBe("cc", "FIAT", 700)
+	user2.AllowedBalanceShouldBe("cc", "FIAT", 300)
+
+	err := user1.RawSignedInvokeWithErrorReturned("cc", "allowedBalanceTransfer", user2.Address(), "FIAT", "701")
+	require.ErrorContains(t, err, "insufficient balance")
+	user1.AllowedBalanceShouldBe("cc", "FIAT", 700)
+	user2.AllowedBalanceShouldBe("cc", "FIAT", 300)
+}
@@ -0,0 +1,223 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/anoideaopen/foundation/mock"
+	pb "github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestAmountFormat(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		format   pb.AmountFormat
+		decimals uint32
+		balance  string
+		allowed  string
+	}{
+		{
+			name:     "decimal",
+			format:   pb.AmountFormat_AMOUNT_FORMAT_DECIMAL,
+			decimals: 8,
+			balance:  `"150000000"`,
+			allowed:  `"25"`,
+		},
+		{
+			name:     "hex",
+			format:   pb.AmountFormat_AMOUNT_FORMAT_HEX,
+			decimals: 8,
+			balance:  `"0x8f0d180"`,
+			allowed:  `"0x19"`,
+		},
+		{
+			name:     "display",
+			format:   pb.AmountFormat_AMOUNT_FORMAT_DISPLAY,
+			decimals: 8,
+			balance:  `"1.50000000"`,
+			allowed:  `"0.00000025"`,
+		},
+		{
+			name:     "display without decimals",
+			format:   pb.AmountFormat_AMOUNT_FORMAT_DISPLAY,
+			decimals: 0,
+			balance:  `"150000000"`,
+			allowed:  `"25"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ledger := mock.NewLedger(t)
+			issuer := ledger.NewWallet()
+
+			cfg := &pb.Config{
+				Contract: &pb.ContractConfig{
+					Symbol:   "CC",
+					RobotSKI: fixtures_test.RobotHashedCert,
+					Options: &pb.ChaincodeOptions{
+						AmountFormat: tc.format,
+					},
+				},
+				Token: &pb.TokenConfig{
+					Name:     "CC Token",
+					Decimals: tc.decimals,
+					Issuer:   &pb.Wallet{Address: issuer.Address()},
+				},
+			}
+			cfgBytes, err := protojson.Marshal(cfg)
+			require.NoError(t, err)
+
+			initMsg := ledger.NewCC("cc", &token.BaseToken{}, string(cfgBytes))
+			require.Empty(t, initMsg)
+
+			user := ledger.NewWallet()
+			user.AddBalance("cc", 150000000)
+			user.AddAllowedBalance("cc", "VT", 25)
+
+			require.Equal(t, tc.balance, user.Invoke("cc", "balanceOf", user.Address()))
+			require.Equal(t, tc.allowed, user.Invoke("cc", "allowedBalanceOf", user.Address(), "VT"))
+		})
+	}
+
+	t.Run("zero balance", func(t *testing.T) {
+		ledger := mock.NewLedger(t)
+		issuer := ledger.NewWallet()
+
+		cfg := &pb.Config{
+			Contract: &pb.ContractConfig{
+				Symbol:   "CC",
+				RobotSKI: fixtures_test.RobotHashedCert,
+				Options: &pb.ChaincodeOptions{
+					AmountFormat: pb.AmountFormat_AMOUNT_FORMAT_DISPLAY,
+				},
+			},
+			Token: &pb.TokenConfig{
+				Name:     "CC Token",
+				Decimals: 2,
+				Issuer:   &pb.Wallet{Address: issuer.Address()},
+			},
+		}
+		cfgBytes, err := protojson.Marshal(cfg)
+		require.NoError(t, err)
+
+		initMsg := ledger.NewCC("cc", &token.BaseToken{}, string(cfgBytes))
+		require.Empty(t, initMsg)
+
+		user := ledger.NewWallet()
+		require.Equal(t, `"0.00"`, user.Invoke("cc", "balanceOf", user.Address()))
+	})
+}
+
+func TestAmountFormatLocale(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		separator   string
+		decimalMark string
+		balance     string
+		allowed     string
+		transferred string
+	}{
+		{
+			name:        "en",
+			separator:   ",",
+			decimalMark: ".",
+			balance:     `"1,234,567.50000000"`,
+			allowed:     `"0.00000025"`,
+			transferred: `"0.50000000"`,
+		},
+		{
+			name:        "de",
+			separator:   ".",
+			decimalMark: ",",
+			balance:     `"1.234.567,50000000"`,
+			allowed:     `"0,00000025"`,
+			transferred: `"0,50000000"`,
+		},
+		{
+			name:        "default decimal mark",
+			balance:     `"1234567.50000000"`,
+			allowed:     `"0.00000025"`,
+			transferred: `"0.50000000"`,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ledger := mock.NewLedger(t)
+			issuer := ledger.NewWallet()
+
+			cfg := &pb.Config{
+				Contract: &pb.ContractConfig{
+					Symbol:   "CC",
+					RobotSKI: fixtures_test.RobotHashedCert,
+					Options: &pb.ChaincodeOptions{
+						AmountFormat:             pb.AmountFormat_AMOUNT_FORMAT_DISPLAY,
+						AmountThousandsSeparator: tc.separator,
+						AmountDecimalMark:        tc.decimalMark,
+					},
+				},
+				Token: &pb.TokenConfig{
+					Name:     "CC Token",
+					Decimals: 8,
+					Issuer:   &pb.Wallet{Address: issuer.Address()},
+				},
+			}
+			cfgBytes, err := protojson.Marshal(cfg)
+			require.NoError(t, err)
+
+			initMsg := ledger.NewCC("cc", &token.BaseToken{}, string(cfgBytes))
+			require.Empty(t, initMsg)
+
+			user1 := ledger.NewWallet()
+			user1.AddBalance("cc", 123456800000000)
+			user1.AddAllowedBalance("cc", "VT", 25)
+
+			// amount arguments are parsed as canonical base units regardless of the display format
+			user2 := ledger.NewWallet()
+			user1.SignedInvoke("cc", "transfer", user2.Address(), "50000000", "")
+
+			require.Equal(t, tc.balance, user1.Invoke("cc", "balanceOf", user1.Address()))
+			require.Equal(t, tc.allowed, user1.Invoke("cc", "allowedBalanceOf", user1.Address(), "VT"))
+			require.Equal(t, tc.transferred, user2.Invoke("cc", "balanceOf", user2.Address()))
+		})
+	}
+}
+
+// TestBalanceDetails checks that the balance details include the base units, the decimals and the display value
+// regardless of the amount format.
+func TestBalanceDetails(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	issuer := ledger.NewWallet()
+
+	cfg := &pb.Config{
+		Contract: &pb.ContractConfig{
+			Symbol:   "CC",
+			RobotSKI: fixtures_test.RobotHashedCert,
+			Options: &pb.ChaincodeOptions{
+				AmountFormat:             pb.AmountFormat_AMOUNT_FORMAT_HEX,
+				AmountThousandsSeparator: ",",
+			},
+		},
+		Token: &pb.TokenConfig{
+			Name:     "CC Token",
+			Decimals: 8,
+			Issuer:   &pb.Wallet{Address: issuer.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	user := ledger.NewWallet()
+	user.AddBalance("cc", 123456750000000)
+
+	require.JSONEq(t,
+		`{"balance":"123456750000000","decimals":8,"display":"1,234,567.50000000"}`,
+		user.Invoke("cc", "balanceDetails", user.Address()),
+	)
+
+	// the balance query keeps the amount format
+	require.Equal(t, `"0x704883ba9780"`, user.Invoke("cc", "balanceOf", user.Address()))
+}
@@ -0,0 +1,90 @@
+package unit
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/core/types/big"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestNearMaxAmounts checks that balances and total emission keep amounts
+// far beyond fixed-width integers without truncation.
+func TestNearMaxAmounts(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+	user := ledgerMock.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8, issuer.Address(), "", "", "", nil)
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, config)
+	require.Empty(t, initMsg)
+
+	// 2^256 - 1
+	amount := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+	expected := new(big.Int).Mul(amount, big.NewInt(2))
+
+	issuer.SignedInvoke(testTokenCCName, "emissionAdd", user.Address(), amount.String())
+	issuer.SignedInvoke(testTokenCCName, "emissionAdd", user.Address(), amount.String())
+
+	balance := issuer.Invoke(testTokenCCName, "balanceOf", user.Address())
+	require.Equal(t, "\""+expected.String()+"\"", balance)
+
+	var metadata struct {
+		TotalEmission *big.Int `json:"total_emission"` //nolint:tagliatelle
+	}
+	require.NoError(t, json.Unmarshal([]byte(issuer.Invoke(testTokenCCName, "metadata")), &metadata))
+	require.Equal(t, 0, expected.Cmp(metadata.TotalEmission))
+}
+
+// TestOldNonceOverflow checks that the nonce stored in the old format
+// which does not fit into uint64 is reported instead of being truncated.
+func TestOldNonceOverflow(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+	user := ledgerMock.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8, issuer.Address(), "", "", "", nil)
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, config)
+	require.Empty(t, initMsg)
+
+	stub := ledgerMock.GetStub(testTokenCCName)
+	key, err := stub.CreateCompositeKey(hex.EncodeToString([]byte{core.StateKeyNonce}), []string{user.Address()})
+	require.NoError(t, err)
+	stub.State[key] = bytes.Repeat([]byte{0xff}, 9)
+
+	err = user.InvokeWithError(testTokenCCName, "getNonce", user.Address())
+	require.ErrorContains(t, err, big.ErrUint64Overflow.Error())
+}
+
+func TestCheckedConversions(t *testing.T) {
+	maxUint64 := new(big.Int).SetUint64(math.MaxUint64)
+
+	value, err := maxUint64.Uint64Checked()
+	require.NoError(t, err)
+	require.Equal(t, uint64(math.MaxUint64), value)
+
+	_, err = new(big.Int).Add(maxUint64, big.NewInt(1)).Uint64Checked()
+	require.ErrorIs(t, err, big.ErrUint64Overflow)
+
+	_, err = big.NewInt(-1).Uint64Checked()
+	require.ErrorIs(t, err, big.ErrUint64Overflow)
+
+	minInt64, err := big.NewInt(math.MinInt64).Int64Checked()
+	require.NoError(t, err)
+	require.Equal(t, int64(math.MinInt64), minInt64)
+
+	_, err = new(big.Int).Sub(big.NewInt(math.MinInt64), big.NewInt(1)).Int64Checked()
+	require.ErrorIs(t, err, big.ErrInt64Overflow)
+
+	data, err := maxUint64.BytesChecked()
+	require.NoError(t, err)
+	require.Equal(t, maxUint64.Bytes(), data)
+
+	_, err = big.NewInt(-1).BytesChecked()
+	require.ErrorIs(t, err, big.ErrNegativeBytes)
+}
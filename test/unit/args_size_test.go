@@ -0,0 +1,58 @@
+package unit
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// TestMaxArgsSize checks that calls with arguments exceeding the configured
+// total size are rejected before method dispatch.
+func TestMaxArgsSize(t *testing.T) {
+	const maxArgsSize = 128
+
+	ledgerMock := mock.NewLedger(t)
+	owner := ledgerMock.NewWallet()
+
+	cfg := &proto.Config{
+		Contract: &proto.ContractConfig{
+			Symbol:   testTokenSymbol,
+			RobotSKI: fixtures_test.RobotHashedCert,
+			Options: &proto.ChaincodeOptions{
+				MaxArgsSize: maxArgsSize,
+			},
+		},
+		Token: &proto.TokenConfig{
+			Name:     testTokenName,
+			Decimals: 8,
+			Issuer:   &proto.Wallet{Address: owner.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	t.Run("normal argument is accepted", func(t *testing.T) {
+		err := owner.InvokeWithError(testTokenCCName, "balanceOf", owner.Address())
+		require.NoError(t, err)
+	})
+
+	t.Run("oversized argument is rejected", func(t *testing.T) {
+		err := owner.InvokeWithError(testTokenCCName, "helloWorldSet", strings.Repeat("a", maxArgsSize+1))
+		require.ErrorContains(t, err, core.ErrArgsSizeExceeded.Error())
+	})
+
+	t.Run("arguments are summed", func(t *testing.T) {
+		half := strings.Repeat("1", maxArgsSize/2)
+		err := owner.InvokeWithError(testTokenCCName, "srcPartFile", half, half, "1")
+		require.ErrorContains(t, err, core.ErrArgsSizeExceeded.Error())
+	})
+}
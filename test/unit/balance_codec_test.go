@@ -0,0 +1,91 @@
+package unit
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/core/balance"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+)
+
+const prefixCodecVersion = 0x01
+
+// prefixCodec stores balances as big-endian bytes prefixed with the codec version
+type prefixCodec struct{}
+
+func (prefixCodec) Encode(value *big.Int) ([]byte, error) {
+	return append([]byte{prefixCodecVersion}, value.Bytes()...), nil
+}
+
+func (prefixCodec) Decode(data []byte) (*big.Int, error) {
+	if len(data) == 0 || data[0] != prefixCodecVersion {
+		return nil, errors.New("unknown balance encoding")
+	}
+
+	return new(big.Int).SetBytes(data[1:]), nil
+}
+
+func rawTokenBalance(t *testing.T, ledgerMock *mock.Ledger, ch string, address string) []byte {
+	stub := ledgerMock.GetStub(ch)
+	key, err := stub.CreateCompositeKey(balance.BalanceTypeToken.String(), []string{address})
+	require.NoError(t, err)
+
+	return stub.State[key]
+}
+
+// TestBalanceCodec checks that balances are stored with the codec set by WithBalanceCodec
+func TestBalanceCodec(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+	user := ledgerMock.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		issuer.Address(), "", "", "", nil)
+	initMsg := ledgerMock.NewCC(testTokenCCName, &token.BaseToken{}, config, core.WithBalanceCodec(prefixCodec{}))
+	require.Empty(t, initMsg)
+
+	issuer.SignedInvoke(testTokenCCName, "proposeEmission", issuer.Address(), "1000")
+	issuer.SignedInvoke(testTokenCCName, "transfer", user.Address(), "400", "")
+
+	issuer.BalanceShouldBe(testTokenCCName, 600)
+	user.BalanceShouldBe(testTokenCCName, 400)
+
+	raw := rawTokenBalance(t, ledgerMock, testTokenCCName, user.Address())
+	require.Equal(t, []byte{prefixCodecVersion, 0x01, 0x90}, raw)
+
+	value, err := prefixCodec{}.Decode(raw)
+	require.NoError(t, err)
+	require.Equal(t, int64(400), value.Int64())
+}
+
+// TestBalanceCodecMigration checks that balances stored with the default codec
+// are read by MigrationCodec and rewritten with the current codec
+func TestBalanceCodecMigration(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+	user1 := ledgerMock.NewWallet()
+	user2 := ledgerMock.NewWallet()
+
+	codec := balance.MigrationCodec{Current: prefixCodec{}, Legacy: balance.DefaultCodec{}}
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		issuer.Address(), "", "", "", nil)
+	initMsg := ledgerMock.NewCC(testTokenCCName, &token.BaseToken{}, config, core.WithBalanceCodec(codec))
+	require.Empty(t, initMsg)
+
+	// balances put by the mock are encoded with the default codec
+	user1.AddBalance(testTokenCCName, 1000)
+	require.Equal(t, []byte{0x03, 0xe8}, rawTokenBalance(t, ledgerMock, testTokenCCName, user1.Address()))
+	user1.BalanceShouldBe(testTokenCCName, 1000)
+
+	user1.SignedInvoke(testTokenCCName, "transfer", user2.Address(), "300", "")
+
+	user1.BalanceShouldBe(testTokenCCName, 700)
+	user2.BalanceShouldBe(testTokenCCName, 300)
+	require.Equal(t, []byte{prefixCodecVersion, 0x02, 0xbc}, rawTokenBalance(t, ledgerMock, testTokenCCName, user1.Address()))
+	require.Equal(t, []byte{prefixCodecVersion, 0x01, 0x2c}, rawTokenBalance(t, ledgerMock, testTokenCCName, user2.Address()))
+}
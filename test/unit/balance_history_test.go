@@ -0,0 +1,103 @@
+package unit
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/anoideaopen/foundation/core/balance"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBalanceHistoryPagination checks that the balance history retrieved
+// page by page reconstructs the full ordered history.
+func TestBalanceHistoryPagination(t *testing.T) {
+	const changes = 10
+
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+	user := ledgerMock.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		issuer.Address(), "", "", "", nil)
+
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, config)
+	require.Empty(t, initMsg)
+
+	for i := 0; i < changes; i++ {
+		issuer.SignedInvoke(testTokenCCName, "emissionAdd", user.Address(), "10")
+	}
+	user.BalanceShouldBe(testTokenCCName, 10*changes)
+
+	var (
+		entries  []token.BalanceHistoryEntry
+		bookmark string
+		pages    int
+	)
+	for {
+		resp := user.Invoke(testTokenCCName, "balanceHistory", user.Address(), "3", bookmark)
+
+		var history token.BalanceHistory
+		require.NoError(t, json.Unmarshal([]byte(resp), &history))
+		require.LessOrEqual(t, len(history.Entries), 3)
+
+		entries = append(entries, history.Entries...)
+		pages++
+
+		if history.Bookmark == "" {
+			break
+		}
+		bookmark = history.Bookmark
+	}
+
+	require.Equal(t, 4, pages)
+	require.Len(t, entries, changes)
+	for i, entry := range entries {
+		require.Equal(t, strconv.Itoa(10*(changes-i)), entry.Balance.String())
+		require.NotEmpty(t, entry.TxID)
+		require.Greater(t, entry.Timestamp, int64(0))
+	}
+
+	t.Run("unknown bookmark is rejected", func(t *testing.T) {
+		err := user.InvokeWithError(testTokenCCName, "balanceHistory", user.Address(), "3", "unknown")
+		require.ErrorContains(t, err, balance.ErrInvalidHistoryBookmark.Error())
+	})
+}
+
+// TestBalanceExists checks that a never funded address is distinguished
+// from an address with the balance spent to zero.
+func TestBalanceExists(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+	fresh := ledgerMock.NewWallet()
+	funded := ledgerMock.NewWallet()
+	spent := ledgerMock.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		issuer.Address(), "", "", "", nil)
+
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, config)
+	require.Empty(t, initMsg)
+
+	issuer.SignedInvoke(testTokenCCName, "emissionAdd", funded.Address(), "100")
+	issuer.SignedInvoke(testTokenCCName, "emissionAdd", spent.Address(), "100")
+	spent.SignedInvoke(testTokenCCName, "transfer", funded.Address(), "100", "")
+	spent.BalanceShouldBe(testTokenCCName, 0)
+
+	for _, test := range []struct {
+		name   string
+		wallet *mock.Wallet
+		exists string
+	}{
+		{name: "fresh address", wallet: fresh, exists: "false"},
+		{name: "funded address", wallet: funded, exists: "true"},
+		{name: "funded then spent address", wallet: spent, exists: "true"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			resp := test.wallet.Invoke(testTokenCCName, "balanceExists", test.wallet.Address())
+			require.Equal(t, test.exists, resp)
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/anoideaopen/foundation/mock"
+	pb "github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// TestBalanceSwingEvent checks that the balance swing event is emitted
+// on transfers and emissions moving more than the configured percentage of the balance only.
+func TestBalanceSwingEvent(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	issuer := ledger.NewWallet()
+	user1 := ledger.NewWallet()
+	user2 := ledger.NewWallet()
+
+	cfg := &pb.Config{
+		Contract: &pb.ContractConfig{
+			Symbol:   "FIAT",
+			RobotSKI: fixtures_test.RobotHashedCert,
+		},
+		Token: &pb.TokenConfig{
+			Name:                     "FIAT Token",
+			Decimals:                 8,
+			Issuer:                   &pb.Wallet{Address: issuer.Address()},
+			BalanceSwingAlertPercent: 50,
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledger.NewCC("fiat", NewFiatTestToken(token.BaseToken{}), string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	swingsOf := func(t *testing.T, resp mock.TxResponse) []token.BalanceSwing {
+		data, ok := resp.Events[token.BalanceSwingEvent]
+		require.True(t, ok)
+
+		event := token.BalanceSwingsEvent{}
+		require.NoError(t, json.Unmarshal(data, &event))
+
+		return event.Swings
+	}
+
+	t.Run("emission to empty wallet", func(t *testing.T) {
+		_, resp, _ := issuer.RawSignedInvoke("fiat", "emit", user1.Address(), "1000")
+		require.Empty(t, resp.Error)
+
+		swings := swingsOf(t, resp)
+		require.Len(t, swings, 1)
+		require.Equal(t, user1.Address(), swings[0].Address)
+		require.Equal(t, "0", swings[0].OldBalance.String())
+		require.Equal(t, "1000", swings[0].NewBalance.String())
+	})
+
+	t.Run("small emission", func(t *testing.T) {
+		_, resp, _ := issuer.RawSignedInvoke("fiat", "emit", user1.Address(), "100")
+		require.Empty(t, resp.Error)
+		require.NotContains(t, resp.Events, token.BalanceSwingEvent)
+	})
+
+	t.Run("small transfer", func(t *testing.T) {
+		user2.AddBalance("fiat", 1000)
+
+		_, resp, _ := user1.RawSignedInvoke("fiat", "transfer", user2.Address(), "100", "")
+		require.Empty(t, resp.Error)
+		require.NotContains(t, resp.Events, token.BalanceSwingEvent)
+	})
+
+	t.Run("large transfer", func(t *testing.T) {
+		_, resp, _ := user1.RawSignedInvoke("fiat", "transfer", user2.Address(), "600", "")
+		require.Empty(t, resp.Error)
+
+		swings := swingsOf(t, resp)
+		require.Len(t, swings, 2)
+		require.Equal(t, user1.Address(), swings[0].Address)
+		require.Equal(t, "1000", swings[0].OldBalance.String())
+		require.Equal(t, "400", swings[0].NewBalance.String())
+		require.Equal(t, user2.Address(), swings[1].Address)
+		require.Equal(t, "1100", swings[1].OldBalance.String())
+		require.Equal(t, "1700", swings[1].NewBalance.String())
+	})
+}
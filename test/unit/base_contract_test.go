@@ -4,10 +4,14 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/anoideaopen/foundation/core"
 	"github.com/anoideaopen/foundation/core/types"
 	"github.com/anoideaopen/foundation/core/types/big"
+	"github.com/anoideaopen/foundation/keys"
 	"github.com/anoideaopen/foundation/mock"
+	pb "github.com/anoideaopen/foundation/proto"
 	"github.com/anoideaopen/foundation/token"
+	"github.com/btcsuite/btcutil/base58"
 	"github.com/stretchr/testify/require"
 )
 
@@ -85,7 +89,25 @@ func (tt *TestToken) TxEmissionAdd(sender *types.Sender, address *types.Address,
 	if err := tt.TokenBalanceAdd(address, amount, "txEmit"); err != nil {
 		return err
 	}
-	return tt.EmissionAdd(amount)
+	return tt.EmissionAddTo(address, amount)
+}
+
+// TxEmissionAddDisplay emits the display amount, e.g. "10.5", converted to base units by the token decimals
+func (tt *TestToken) TxEmissionAddDisplay(sender *types.Sender, address *types.Address, amount string) error {
+	value, err := tt.ParseDisplayAmount(amount)
+	if err != nil {
+		return err
+	}
+
+	return tt.TxEmissionAdd(sender, address, value)
+}
+
+func (tt *TestToken) PrivilegedMethods() map[string][]string {
+	methods := tt.BaseToken.PrivilegedMethods()
+	methods["emissionAdd"] = []string{core.RoleIssuer}
+	methods["emissionAddDisplay"] = []string{core.RoleIssuer}
+
+	return methods
 }
 
 func TestBytesEncoder(t *testing.T) {
@@ -196,3 +218,44 @@ func TestTxHealthCheck(t *testing.T) {
 		require.NotEmpty(t, txID)
 	})
 }
+
+// TestQueryVerifySignature - Checking offline signature verification.
+func TestQueryVerifySignature(t *testing.T) {
+	const (
+		verifySignatureFnName = "verifySignature"
+		payload               = "payload to sign"
+	)
+
+	ledgerMock := mock.NewLedger(t)
+	owner := ledgerMock.NewWallet()
+
+	tt := &TestToken{}
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		owner.Address(), "", "", "", nil)
+
+	initMsg := ledgerMock.NewCC(testTokenCCName, tt, config)
+	require.Empty(t, initMsg)
+
+	_, signature, err := keys.SignMessageByKeyType(pb.KeyType_ed25519, owner.Keys, []byte(payload))
+	require.NoError(t, err)
+
+	publicKey := base58.Encode(owner.PubKey())
+
+	t.Run("Valid signature", func(t *testing.T) {
+		resp := owner.Invoke(testTokenCCName, verifySignatureFnName,
+			owner.Address(), publicKey, pb.KeyType_ed25519.String(), payload, base58.Encode(signature))
+		require.Equal(t, "true", resp)
+	})
+
+	t.Run("Tampered payload", func(t *testing.T) {
+		resp := owner.Invoke(testTokenCCName, verifySignatureFnName,
+			owner.Address(), publicKey, pb.KeyType_ed25519.String(), payload+"!", base58.Encode(signature))
+		require.Equal(t, "false", resp)
+	})
+
+	t.Run("Wrong key type", func(t *testing.T) {
+		resp := owner.Invoke(testTokenCCName, verifySignatureFnName,
+			owner.Address(), publicKey, pb.KeyType_secp256k1.String(), payload, base58.Encode(signature))
+		require.Equal(t, "false", resp)
+	})
+}
@@ -0,0 +1,113 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	pb "github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// TestBatchSharedNonce checks both policies of the batched transactions sharing the nonce within the batch.
+func TestBatchSharedNonce(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		shared bool
+	}{
+		{name: "nonce must be unique per operation"},
+		{name: "batch shared nonce is allowed", shared: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ledger := mock.NewLedger(t)
+			issuer := ledger.NewWallet()
+			user := ledger.NewWallet()
+
+			cfg := &pb.Config{
+				Contract: &pb.ContractConfig{
+					Symbol:   "CC",
+					RobotSKI: fixtures_test.RobotHashedCert,
+					Options:  &pb.ChaincodeOptions{AllowBatchSharedNonce: test.shared},
+				},
+				Token: &pb.TokenConfig{
+					Name:     "CC Token",
+					Decimals: 8,
+					Issuer:   &pb.Wallet{Address: issuer.Address()},
+				},
+			}
+			cfgBytes, err := protojson.Marshal(cfg)
+			require.NoError(t, err)
+
+			initMsg := ledger.NewCC("cc", &token.BaseToken{}, string(cfgBytes))
+			require.Empty(t, initMsg)
+
+			issuer.AddBalance("cc", 1000)
+
+			nonce := uint64(time.Now().UnixMilli())
+
+			first := issuer.InvokeReturnsTxID("cc", "transfer",
+				issuer.SignArgsWithNonce("cc", "transfer", nonce, user.Address(), "100", "")...)
+			second := issuer.InvokeReturnsTxID("cc", "transfer",
+				issuer.SignArgsWithNonce("cc", "transfer", nonce, user.Address(), "200", "")...)
+
+			resp := issuer.DoBatch("cc", first, second)
+			resp.TxHasNoError(t, first)
+
+			if test.shared {
+				resp.TxHasNoError(t, second)
+				user.BalanceShouldBe("cc", 300)
+			} else {
+				require.Contains(t, resp[second].GetError().GetError(), "already exists")
+				user.BalanceShouldBe("cc", 100)
+			}
+
+			// the nonce is not shared with the later batch
+			replay := issuer.InvokeReturnsTxID("cc", "transfer",
+				issuer.SignArgsWithNonce("cc", "transfer", nonce, user.Address(), "300", "")...)
+			resp = issuer.DoBatch("cc", replay)
+			require.Contains(t, resp[replay].GetError().GetError(), "already exists")
+		})
+	}
+}
+
+// TestBatchSharedNonceDuplicate checks that the copy of the signed transaction submitted again
+// is not executed twice within the batch sharing the nonce.
+func TestBatchSharedNonceDuplicate(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	issuer := ledger.NewWallet()
+	user := ledger.NewWallet()
+
+	cfg := &pb.Config{
+		Contract: &pb.ContractConfig{
+			Symbol:   "CC",
+			RobotSKI: fixtures_test.RobotHashedCert,
+			Options:  &pb.ChaincodeOptions{AllowBatchSharedNonce: true},
+		},
+		Token: &pb.TokenConfig{
+			Name:     "CC Token",
+			Decimals: 8,
+			Issuer:   &pb.Wallet{Address: issuer.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	issuer.AddBalance("cc", 1000)
+
+	signed := issuer.SignArgsWithNonce("cc", "transfer", uint64(time.Now().UnixMilli()), user.Address(), "100", "")
+
+	first := issuer.InvokeReturnsTxID("cc", "transfer", signed...)
+	copied := issuer.InvokeReturnsTxID("cc", "transfer", signed...)
+
+	resp := issuer.DoBatch("cc", first, copied)
+	resp.TxHasNoError(t, first)
+	require.Contains(t, resp[copied].GetError().GetError(), core.ErrBatchDuplicateTx.Error())
+	user.BalanceShouldBe("cc", 100)
+}
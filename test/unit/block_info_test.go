@@ -0,0 +1,29 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryBlockInfo(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	owner := ledgerMock.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, config)
+	require.Empty(t, initMsg)
+
+	txTime := time.Date(2024, time.March, 1, 12, 30, 0, 0, time.UTC)
+	ledgerMock.SetTxTime(txTime)
+
+	var info core.BlockInfo
+	require.NoError(t, json.Unmarshal([]byte(owner.Invoke(testTokenCCName, "blockInfo")), &info))
+	require.True(t, txTime.Equal(info.Timestamp))
+	require.NotEmpty(t, info.TxID)
+}
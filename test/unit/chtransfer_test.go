@@ -2,14 +2,18 @@ package unit
 
 import (
 	"encoding/json"
+	"strconv"
 	"testing"
 	"time"
 
+	"github.com/anoideaopen/foundation/core"
 	"github.com/anoideaopen/foundation/core/cctransfer"
+	"github.com/anoideaopen/foundation/core/types/big"
 	"github.com/anoideaopen/foundation/mock"
 	pb "github.com/anoideaopen/foundation/proto"
 	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
 	"github.com/anoideaopen/foundation/token"
+	"github.com/golang/protobuf/proto" //nolint:staticcheck
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -64,6 +68,143 @@ func TestByCustomerForwardSuccess(t *testing.T) {
 	user1.CheckGivenBalanceShouldBe("cc", "VT", 450)
 }
 
+func TestTransferStatus(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	vtConfig := makeBaseTokenConfig("VT Token", "VT", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg = ledger.NewCC("vt", &token.BaseToken{}, vtConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	statusShouldBe := func(ch, id string, expected core.TransferStatus) {
+		var status core.TransferStatus
+		require.NoError(t, json.Unmarshal([]byte(user1.Invoke(ch, "transferStatus", id)), &status))
+		require.Equal(t, expected, status)
+	}
+
+	t.Run("forward transfer", func(t *testing.T) {
+		id := uuid.NewString()
+
+		err := user1.InvokeWithError("cc", "transferStatus", id)
+		require.ErrorContains(t, err, cctransfer.ErrNotFound.Error())
+
+		_ = user1.SignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", "450")
+		statusShouldBe("cc", id, core.TransferStatusCreated)
+		cct := user1.Invoke("cc", "channelTransferFrom", id)
+
+		_, _, err = user1.RawChTransferInvokeWithBatch("vt", "createCCTransferTo", cct)
+		require.NoError(t, err)
+		ledger.WaitChTransferTo("vt", id, time.Second*5)
+		statusShouldBe("vt", id, core.TransferStatusToCreated)
+
+		_, _, err = user1.RawChTransferInvoke("cc", "commitCCTransferFrom", id)
+		require.NoError(t, err)
+		statusShouldBe("cc", id, core.TransferStatusCommitted)
+
+		_, _, err = user1.RawChTransferInvoke("vt", "deleteCCTransferTo", id)
+		require.NoError(t, err)
+		statusShouldBe("vt", id, core.TransferStatusCompleted)
+
+		_, _, err = user1.RawChTransferInvoke("cc", "deleteCCTransferFrom", id)
+		require.NoError(t, err)
+		statusShouldBe("cc", id, core.TransferStatusCompleted)
+	})
+
+	t.Run("cancelled transfer", func(t *testing.T) {
+		id := uuid.NewString()
+
+		_ = user1.SignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", "100")
+		statusShouldBe("cc", id, core.TransferStatusCreated)
+
+		_, _, err := user1.RawChTransferInvokeWithBatch("cc", "cancelCCTransferFrom", id)
+		require.NoError(t, err)
+		statusShouldBe("cc", id, core.TransferStatusCancelled)
+	})
+}
+
+func TestQueryTransfersByStatus(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	vtConfig := makeBaseTokenConfig("VT Token", "VT", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg = ledger.NewCC("vt", &token.BaseToken{}, vtConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	idsByStatus := func(ch string, status core.TransferStatus) []string {
+		ids := []string{}
+		bookmark := ""
+		for {
+			res := new(pb.CCTransfers)
+			resStr := user1.Invoke(ch, "transfersByStatus", string(status), "1", bookmark)
+			require.NoError(t, json.Unmarshal([]byte(resStr), res))
+			for _, tr := range res.GetCcts() {
+				ids = append(ids, tr.GetId())
+			}
+			if res.GetBookmark() == "" {
+				return ids
+			}
+			bookmark = res.GetBookmark()
+		}
+	}
+
+	committed := uuid.NewString()
+	created := uuid.NewString()
+	cancelled := uuid.NewString()
+	for _, id := range []string{committed, created, cancelled} {
+		_ = user1.SignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", "100")
+	}
+	require.ElementsMatch(t, []string{committed, created, cancelled}, idsByStatus("cc", core.TransferStatusCreated))
+
+	_, _, err := user1.RawChTransferInvokeWithBatch("cc", "cancelCCTransferFrom", cancelled)
+	require.NoError(t, err)
+
+	cct := user1.Invoke("cc", "channelTransferFrom", committed)
+	_, _, err = user1.RawChTransferInvokeWithBatch("vt", "createCCTransferTo", cct)
+	require.NoError(t, err)
+	ledger.WaitChTransferTo("vt", committed, time.Second*5)
+
+	_, _, err = user1.RawChTransferInvoke("cc", "commitCCTransferFrom", committed)
+	require.NoError(t, err)
+
+	require.Equal(t, []string{created}, idsByStatus("cc", core.TransferStatusCreated))
+	require.Equal(t, []string{committed}, idsByStatus("cc", core.TransferStatusCommitted))
+	require.Equal(t, []string{committed}, idsByStatus("vt", core.TransferStatusToCreated))
+	require.Empty(t, idsByStatus("cc", core.TransferStatusToCreated))
+
+	_, _, err = user1.RawChTransferInvoke("vt", "deleteCCTransferTo", committed)
+	require.NoError(t, err)
+	_, _, err = user1.RawChTransferInvoke("cc", "deleteCCTransferFrom", committed)
+	require.NoError(t, err)
+
+	require.Empty(t, idsByStatus("cc", core.TransferStatusCommitted))
+	require.Empty(t, idsByStatus("vt", core.TransferStatusToCreated))
+	require.Equal(t, []string{created}, idsByStatus("cc", core.TransferStatusCreated))
+
+	err = user1.InvokeWithError("cc", "transfersByStatus", string(core.TransferStatusCancelled), "1", "")
+	require.ErrorContains(t, err, cctransfer.ErrStatusNotIndexed.Error())
+
+	err = user1.InvokeWithError("cc", "transfersByStatus", string(core.TransferStatusCreated), "0", "")
+	require.ErrorContains(t, err, cctransfer.ErrPageSizeLessOrEqZero.Error())
+}
+
 func TestByAdminForwardSuccess(t *testing.T) {
 	ledger := mock.NewLedger(t)
 	owner := ledger.NewWallet()
@@ -351,6 +492,42 @@ func TestQueryAllTransfersFrom(t *testing.T) {
 	}
 }
 
+func TestQueryAllTransfersFromDeterministic(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", owner.Address(), nil)
+
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	for i := 0; i < 3; i++ {
+		_ = user1.SignedInvoke("cc", "channelTransferByCustomer", uuid.NewString(), "VT", "CC", "100")
+	}
+
+	resStr := user1.Invoke("cc", "channelTransfersFrom", "10", "")
+	for i := 0; i < 10; i++ {
+		require.Equal(t, resStr, user1.Invoke("cc", "channelTransfersFrom", "10", ""))
+	}
+
+	res := new(pb.CCTransfers)
+	require.NoError(t, json.Unmarshal([]byte(resStr), &res))
+	require.Len(t, res.Ccts, 3)
+
+	expected, err := json.Marshal(res)
+	require.NoError(t, err)
+	require.Equal(t, resStr, string(expected))
+	for i := 0; i < 10; i++ {
+		data, err := json.Marshal(res)
+		require.NoError(t, err)
+		require.Equal(t, expected, data)
+	}
+}
+
 func TestFailBeginTransfer(t *testing.T) {
 	// preparation
 	ledger := mock.NewLedger(t)
@@ -409,6 +586,42 @@ func TestFailBeginTransfer(t *testing.T) {
 	require.EqualError(t, err, cctransfer.ErrIDTransferExist.Error())
 }
 
+// TestByAdminRetry checks that channelTransferByAdmin is idempotent on identical resubmission
+// and fails on resubmission with the same id and different arguments.
+func TestByAdminRetry(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", owner.Address(), nil)
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	id := uuid.NewString()
+
+	err := owner.RawSignedInvokeWithErrorReturned("cc", "channelTransferByAdmin",
+		id, "VT", user1.Address(), "CC", "450")
+	require.NoError(t, err)
+	user1.BalanceShouldBe("cc", 550)
+
+	t.Run("identical resubmission", func(t *testing.T) {
+		err := owner.RawSignedInvokeWithErrorReturned("cc", "channelTransferByAdmin",
+			id, "VT", user1.Address(), "CC", "450")
+		require.NoError(t, err)
+		user1.BalanceShouldBe("cc", 550)
+	})
+
+	t.Run("conflicting resubmission", func(t *testing.T) {
+		err := owner.RawSignedInvokeWithErrorReturned("cc", "channelTransferByAdmin",
+			id, "VT", user1.Address(), "CC", "100")
+		require.EqualError(t, err, cctransfer.ErrIDTransferConflict.Error())
+		user1.BalanceShouldBe("cc", 550)
+	})
+}
+
 func TestFailCreateTransferTo(t *testing.T) {
 	// preparation
 	ledger := mock.NewLedger(t)
@@ -678,3 +891,1777 @@ func TestFailForwardByAdmin(t *testing.T) {
 		id, "VT", fixtures_test.AdminAddr, "CC", "450")
 	require.EqualError(t, err, cctransfer.ErrAdminNotSet.Error())
 }
+
+func TestChannelStats(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	vtConfig := makeBaseTokenConfig("VT Token", "VT", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg = ledger.NewCC("vt", &token.BaseToken{}, vtConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+	user2 := ledger.NewWallet()
+	user2.AddBalance("cc", 1000)
+
+	statsShouldBe := func(ch string, token string, expected core.ChannelStats) {
+		var stats core.ChannelStats
+		require.NoError(t, json.Unmarshal([]byte(user1.Invoke(ch, "channelStats", token)), &stats))
+		require.Equal(t, expected.Transfers, stats.Transfers)
+		require.Equal(t, expected.Committed, stats.Committed)
+		require.Equal(t, expected.Cancelled, stats.Cancelled)
+		require.Equal(t, expected.Received, stats.Received)
+		require.Equal(t, expected.LockedAmount.String(), stats.LockedAmount.String())
+		require.Equal(t, expected.Participants, stats.Participants)
+	}
+
+	statsShouldBe("cc", "CC", core.ChannelStats{LockedAmount: big.NewInt(0)})
+
+	id1 := uuid.NewString()
+	_ = user1.SignedInvoke("cc", "channelTransferByCustomer", id1, "VT", "CC", "450")
+	id2 := uuid.NewString()
+	_ = user1.SignedInvoke("cc", "channelTransferByCustomer", id2, "VT", "CC", "100")
+	id3 := uuid.NewString()
+	_ = user2.SignedInvoke("cc", "channelTransferByCustomer", id3, "VT", "CC", "200")
+
+	statsShouldBe("cc", "CC", core.ChannelStats{
+		Transfers:    3,
+		LockedAmount: big.NewInt(750),
+		Participants: 2,
+	})
+
+	cct := user1.Invoke("cc", "channelTransferFrom", id1)
+	_, _, err := user1.RawChTransferInvokeWithBatch("vt", "createCCTransferTo", cct)
+	require.NoError(t, err)
+	ledger.WaitChTransferTo("vt", id1, time.Second*5)
+
+	_, _, err = user1.RawChTransferInvoke("cc", "commitCCTransferFrom", id1)
+	require.NoError(t, err)
+
+	_, _, err = user1.RawChTransferInvokeWithBatch("cc", "cancelCCTransferFrom", id2)
+	require.NoError(t, err)
+
+	statsShouldBe("cc", "CC", core.ChannelStats{
+		Transfers:    3,
+		Committed:    1,
+		Cancelled:    1,
+		LockedAmount: big.NewInt(200),
+		Participants: 2,
+	})
+	statsShouldBe("vt", "CC", core.ChannelStats{
+		Received:     1,
+		LockedAmount: big.NewInt(0),
+		Participants: 1,
+	})
+	statsShouldBe("vt", "VT", core.ChannelStats{LockedAmount: big.NewInt(0)})
+
+	_, _, err = user1.RawChTransferInvoke("vt", "deleteCCTransferTo", id1)
+	require.NoError(t, err)
+	_, _, err = user1.RawChTransferInvoke("cc", "deleteCCTransferFrom", id1)
+	require.NoError(t, err)
+
+	statsShouldBe("cc", "CC", core.ChannelStats{
+		Transfers:    3,
+		Committed:    1,
+		Cancelled:    1,
+		LockedAmount: big.NewInt(200),
+		Participants: 2,
+	})
+
+	t.Run("statistics are kept per token", func(t *testing.T) {
+		user1.AddAllowedBalance("cc", "VT", 100)
+		_ = user1.SignedInvoke("cc", "channelTransferByCustomer", uuid.NewString(), "VT", "VT", "100")
+
+		statsShouldBe("cc", "VT", core.ChannelStats{
+			Transfers:    1,
+			LockedAmount: big.NewInt(100),
+			Participants: 1,
+		})
+		statsShouldBe("cc", "CC", core.ChannelStats{
+			Transfers:    3,
+			Committed:    1,
+			Cancelled:    1,
+			LockedAmount: big.NewInt(200),
+			Participants: 2,
+		})
+	})
+}
+
+// TestZeroAmountChannelTransfer checks zero amount channel transfers are rejected in strict mode
+// and recorded as memos without balance changes in permissive mode.
+func TestZeroAmountChannelTransfer(t *testing.T) {
+	newCC := func(ledger *mock.Ledger, owner *mock.Wallet, allowZero bool) {
+		cfg := &pb.Config{
+			Contract: &pb.ContractConfig{
+				Symbol:   "CC",
+				RobotSKI: fixtures_test.RobotHashedCert,
+				Options: &pb.ChaincodeOptions{
+					AllowZeroAmountChannelTransfers: allowZero,
+				},
+			},
+			Token: &pb.TokenConfig{
+				Name:     "CC Token",
+				Decimals: 8,
+				Issuer:   &pb.Wallet{Address: owner.Address()},
+			},
+		}
+		cfgBytes, err := protojson.Marshal(cfg)
+		require.NoError(t, err)
+
+		initMsg := ledger.NewCC("cc", &token.BaseToken{}, string(cfgBytes))
+		require.Empty(t, initMsg)
+	}
+
+	t.Run("strict", func(t *testing.T) {
+		ledger := mock.NewLedger(t)
+		owner := ledger.NewWallet()
+		newCC(ledger, owner, false)
+
+		user1 := ledger.NewWallet()
+		user1.AddBalance("cc", 1000)
+
+		err := user1.RawSignedInvokeWithErrorReturned("cc", "channelTransferByCustomer",
+			uuid.NewString(), "VT", "CC", "0")
+		require.EqualError(t, err, cctransfer.ErrZeroAmount.Error())
+
+		user1.BalanceShouldBe("cc", 1000)
+	})
+
+	t.Run("permissive", func(t *testing.T) {
+		ledger := mock.NewLedger(t)
+		owner := ledger.NewWallet()
+		newCC(ledger, owner, true)
+
+		user1 := ledger.NewWallet()
+		user1.AddBalance("cc", 1000)
+
+		id := uuid.NewString()
+		err := user1.RawSignedInvokeWithErrorReturned("cc", "channelTransferByCustomer",
+			id, "VT", "CC", "0")
+		require.NoError(t, err)
+
+		user1.BalanceShouldBe("cc", 1000)
+
+		err = user1.InvokeWithError("cc", "channelTransferFrom", id)
+		require.Error(t, err)
+
+		var memo core.ChannelTransferMemo
+		require.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "channelTransferMemo", id)), &memo))
+		require.Equal(t, id, memo.ID)
+		require.Equal(t, "CC", memo.From)
+		require.Equal(t, "VT", memo.To)
+		require.Equal(t, "CC", memo.Token)
+		require.Equal(t, user1.Address(), memo.User)
+
+		var details core.ChannelTransferDetails
+		require.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "channelTransfer", id)), &details))
+		require.True(t, details.Memo)
+		require.Equal(t, "0", details.Amount.String())
+		require.Equal(t, core.TransferStatusCompleted, details.Status)
+		require.Equal(t, memo.TimeAsNanos, details.CreatedAt.UnixNano())
+
+		var stats core.ChannelStats
+		require.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "channelStats", "CC")), &stats))
+		require.Zero(t, stats.Transfers)
+
+		err = user1.RawSignedInvokeWithErrorReturned("cc", "channelTransferByCustomer",
+			id, "VT", "CC", "0")
+		require.EqualError(t, err, cctransfer.ErrIDTransferExist.Error())
+	})
+}
+
+func TestQueryChannelTransfer(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	id := uuid.NewString()
+
+	err := user1.InvokeWithError("cc", "channelTransfer", id)
+	require.ErrorContains(t, err, cctransfer.ErrNotFound.Error())
+
+	_ = user1.SignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", "450")
+
+	cct := new(pb.CCTransfer)
+	require.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "channelTransferFrom", id)), cct))
+
+	var details core.ChannelTransferDetails
+	require.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "channelTransfer", id)), &details))
+	require.Equal(t, id, details.ID)
+	require.Equal(t, "CC", details.From)
+	require.Equal(t, "VT", details.To)
+	require.Equal(t, "CC", details.Token)
+	require.Equal(t, user1.Address(), details.User)
+	require.Equal(t, "450", details.Amount.String())
+	require.True(t, details.ForwardDirection)
+	require.Equal(t, core.TransferStatusCreated, details.Status)
+	require.False(t, details.Memo)
+	require.NotZero(t, cct.GetTimeAsNanos())
+	require.Equal(t, cct.GetTimeAsNanos(), details.CreatedAt.UnixNano())
+
+	_, _, err = user1.RawChTransferInvoke("cc", "commitCCTransferFrom", id)
+	require.NoError(t, err)
+
+	details = core.ChannelTransferDetails{}
+	require.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "channelTransfer", id)), &details))
+	require.Equal(t, core.TransferStatusCommitted, details.Status)
+}
+
+func TestCancelByCustomer(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	vtConfig := makeBaseTokenConfig("VT Token", "VT", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg = ledger.NewCC("vt", &token.BaseToken{}, vtConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+	user2 := ledger.NewWallet()
+
+	t.Run("self-cancel", func(t *testing.T) {
+		id := uuid.NewString()
+		_ = user1.SignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", "450")
+		user1.BalanceShouldBe("cc", 550)
+
+		err := user2.RawSignedInvokeWithErrorReturned("cc", "channelTransferCancelByCustomer", id)
+		require.EqualError(t, err, cctransfer.ErrUnauthorizedOperation.Error())
+
+		err = user1.RawSignedInvokeWithErrorReturned("cc", "channelTransferCancelByCustomer", id)
+		require.NoError(t, err)
+		user1.BalanceShouldBe("cc", 1000)
+
+		var status core.TransferStatus
+		require.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "transferStatus", id)), &status))
+		require.Equal(t, core.TransferStatusCancelled, status)
+
+		err = user1.RawSignedInvokeWithErrorReturned("cc", "channelTransferCancelByCustomer", id)
+		require.EqualError(t, err, cctransfer.ErrNotFound.Error())
+	})
+
+	t.Run("too late", func(t *testing.T) {
+		id := uuid.NewString()
+		_ = user1.SignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", "450")
+
+		cct := user1.Invoke("cc", "channelTransferFrom", id)
+		_, _, err := user1.RawChTransferInvokeWithBatch("vt", "createCCTransferTo", cct)
+		require.NoError(t, err)
+		ledger.WaitChTransferTo("vt", id, time.Second*5)
+
+		_, _, err = user1.RawChTransferInvoke("cc", "commitCCTransferFrom", id)
+		require.NoError(t, err)
+
+		err = user1.RawSignedInvokeWithErrorReturned("cc", "channelTransferCancelByCustomer", id)
+		require.EqualError(t, err, cctransfer.ErrTransferCommit.Error())
+		user1.BalanceShouldBe("cc", 550)
+		user1.AllowedBalanceShouldBe("vt", "CC", 450)
+	})
+
+	t.Run("picked up before the commit", func(t *testing.T) {
+		id := uuid.NewString()
+		_ = user1.SignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", "450")
+		user1.BalanceShouldBe("cc", 100)
+
+		_, _, err := user1.RawChTransferInvoke("cc", "pickUpCCTransferFrom", id)
+		require.NoError(t, err)
+		_, _, err = user1.RawChTransferInvoke("cc", "pickUpCCTransferFrom", id)
+		require.NoError(t, err)
+
+		cct := user1.Invoke("cc", "channelTransferFrom", id)
+		_, _, err = user1.RawChTransferInvokeWithBatch("vt", "createCCTransferTo", cct)
+		require.NoError(t, err)
+		ledger.WaitChTransferTo("vt", id, time.Second*5)
+
+		// the tokens are credited in the channel To, so the refund would double them
+		err = user1.RawSignedInvokeWithErrorReturned("cc", "channelTransferCancelByCustomer", id)
+		require.EqualError(t, err, cctransfer.ErrTransferPickedUp.Error())
+		user1.BalanceShouldBe("cc", 100)
+		user1.AllowedBalanceShouldBe("vt", "CC", 900)
+
+		_, _, err = user1.RawChTransferInvoke("cc", "commitCCTransferFrom", id)
+		require.NoError(t, err)
+	})
+}
+
+// TestChannelTransferFee checks that channel transfers are charged with the channel transfer fee
+// and transfers within the channel are charged with the token fee
+func TestChannelTransferFee(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+	feeSetter := ledger.NewWallet()
+	feeAddressSetter := ledger.NewWallet()
+	feeAggregator := ledger.NewWallet()
+	chFeeAggregator := ledger.NewWallet()
+
+	cfg := &pb.Config{
+		Contract: &pb.ContractConfig{
+			Symbol:   "CC",
+			RobotSKI: fixtures_test.RobotHashedCert,
+			ChannelTransferFee: &pb.ChannelTransferFee{
+				Rate:    "5000000",
+				Floor:   "3",
+				Address: &pb.Wallet{Address: chFeeAggregator.Address()},
+			},
+		},
+		Token: &pb.TokenConfig{
+			Name:             "CC Token",
+			Decimals:         8,
+			Issuer:           &pb.Wallet{Address: owner.Address()},
+			FeeSetter:        &pb.Wallet{Address: feeSetter.Address()},
+			FeeAddressSetter: &pb.Wallet{Address: feeAddressSetter.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	err = feeAddressSetter.RawSignedInvokeWithErrorReturned("cc", "setFeeAddress", feeAggregator.Address())
+	require.NoError(t, err)
+	err = feeSetter.RawSignedInvokeWithErrorReturned("cc", "setFee", "CC", "1000000", "0", "0")
+	require.NoError(t, err)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+	user2 := ledger.NewWallet()
+
+	t.Run("transfer within the channel", func(t *testing.T) {
+		err := user1.RawSignedInvokeWithErrorReturned("cc", "transfer", user2.Address(), "100", "")
+		require.NoError(t, err)
+
+		user1.BalanceShouldBe("cc", 899)
+		user2.BalanceShouldBe("cc", 100)
+		feeAggregator.BalanceShouldBe("cc", 1)
+		chFeeAggregator.BalanceShouldBe("cc", 0)
+	})
+
+	t.Run("channel transfer", func(t *testing.T) {
+		require.Equal(t, "\"5\"", user1.Invoke("cc", "predictChannelTransferFee", "100"))
+
+		_ = user1.SignedInvoke("cc", "channelTransferByCustomer", uuid.NewString(), "VT", "CC", "100")
+
+		user1.BalanceShouldBe("cc", 794)
+		feeAggregator.BalanceShouldBe("cc", 1)
+		chFeeAggregator.BalanceShouldBe("cc", 5)
+	})
+
+	t.Run("channel transfer fee floor", func(t *testing.T) {
+		require.Equal(t, "\"3\"", user1.Invoke("cc", "predictChannelTransferFee", "20"))
+
+		_ = user1.SignedInvoke("cc", "channelTransferByCustomer", uuid.NewString(), "VT", "CC", "20")
+
+		user1.BalanceShouldBe("cc", 771)
+		feeAggregator.BalanceShouldBe("cc", 1)
+		chFeeAggregator.BalanceShouldBe("cc", 8)
+	})
+}
+
+func TestChannelTransferProtoStorage(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	cfg := &pb.Config{
+		Contract: &pb.ContractConfig{
+			Symbol:   "CC",
+			RobotSKI: fixtures_test.RobotHashedCert,
+			Options: &pb.ChaincodeOptions{
+				StoreChannelTransfersAsProto: true,
+			},
+		},
+		Token: &pb.TokenConfig{
+			Name:     "CC Token",
+			Decimals: 8,
+			Issuer:   &pb.Wallet{Address: owner.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	vtConfig := makeBaseTokenConfig("VT Token", "VT", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg = ledger.NewCC("vt", &token.BaseToken{}, vtConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	id := uuid.NewString()
+	_ = user1.SignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", "450")
+
+	raw := ledger.GetStub("cc").State[cctransfer.CCFromTransfer(id)]
+	stored := new(pb.CCTransfer)
+	require.Error(t, protojson.Unmarshal(raw, stored))
+	require.NoError(t, proto.Unmarshal(raw, stored))
+	require.Equal(t, id, stored.GetId())
+	require.Equal(t, "VT", stored.GetTo())
+	require.Equal(t, int64(450), new(big.Int).SetBytes(stored.GetAmount()).Int64())
+
+	cct := user1.Invoke("cc", "channelTransferFrom", id)
+	_, _, err = user1.RawChTransferInvokeWithBatch("vt", "createCCTransferTo", cct)
+	require.NoError(t, err)
+	ledger.WaitChTransferTo("vt", id, time.Second*5)
+
+	_, _, err = user1.RawChTransferInvoke("cc", "commitCCTransferFrom", id)
+	require.NoError(t, err)
+
+	res := new(pb.CCTransfers)
+	require.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "channelTransfersFrom", "10", "")), res))
+	require.Len(t, res.GetCcts(), 1)
+	require.True(t, res.GetCcts()[0].GetIsCommit())
+
+	_, _, err = user1.RawChTransferInvoke("vt", "deleteCCTransferTo", id)
+	require.NoError(t, err)
+	_, _, err = user1.RawChTransferInvoke("cc", "deleteCCTransferFrom", id)
+	require.NoError(t, err)
+
+	user1.BalanceShouldBe("cc", 550)
+	user1.AllowedBalanceShouldBe("vt", "CC", 450)
+}
+
+func TestConvertAllowedBalanceOnReceipt(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		convert bool
+	}{
+		{name: "flag off", convert: false},
+		{name: "flag on", convert: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ledger := mock.NewLedger(t)
+			owner := ledger.NewWallet()
+
+			ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+				owner.Address(), "", "", "", nil)
+			initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+			require.Empty(t, initMsg)
+
+			cfg := &pb.Config{
+				Contract: &pb.ContractConfig{
+					Symbol:   "VT",
+					RobotSKI: fixtures_test.RobotHashedCert,
+					Options: &pb.ChaincodeOptions{
+						ConvertAllowedBalanceOnReceipt: tc.convert,
+					},
+				},
+				Token: &pb.TokenConfig{
+					Name:     "VT Token",
+					Decimals: 8,
+					Issuer:   &pb.Wallet{Address: owner.Address()},
+				},
+			}
+			cfgBytes, err := protojson.Marshal(cfg)
+			require.NoError(t, err)
+
+			initMsg = ledger.NewCC("vt", &token.BaseToken{}, string(cfgBytes))
+			require.Empty(t, initMsg)
+
+			user1 := ledger.NewWallet()
+			user1.AddBalance("cc", 1000)
+
+			id := uuid.NewString()
+			_ = user1.SignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", "450")
+			cct := user1.Invoke("cc", "channelTransferFrom", id)
+
+			_, _, err = user1.RawChTransferInvokeWithBatch("vt", "createCCTransferTo", cct)
+			require.NoError(t, err)
+			ledger.WaitChTransferTo("vt", id, time.Second*5)
+
+			user1.BalanceShouldBe("cc", 550)
+			if !tc.convert {
+				user1.AllowedBalanceShouldBe("vt", "CC", 450)
+				user1.BalanceShouldBe("vt", 0)
+				return
+			}
+
+			user1.AllowedBalanceShouldBe("vt", "CC", 0)
+			user1.BalanceShouldBe("vt", 450)
+			require.Equal(t, "450", ledger.Metadata("vt").TotalEmission.String())
+
+			history := &token.EmissionHistory{}
+			require.NoError(t, json.Unmarshal([]byte(owner.Invoke("vt", "emissionHistory", "10", "")), history))
+			require.Len(t, history.Records, 1)
+			require.Equal(t, user1.Address(), history.Records[0].Recipient)
+			require.Equal(t, "450", history.Records[0].Amount.String())
+
+			t.Run("cancelled return", func(t *testing.T) {
+				id := uuid.NewString()
+				_ = user1.SignedInvoke("vt", "channelTransferByCustomer", id, "CC", "CC", "100")
+				user1.BalanceShouldBe("vt", 350)
+				require.Equal(t, "350", ledger.Metadata("vt").TotalEmission.String())
+
+				_, _, err := user1.RawChTransferInvokeWithBatch("vt", "cancelCCTransferFrom", id)
+				require.NoError(t, err)
+				user1.BalanceShouldBe("vt", 450)
+				require.Equal(t, "450", ledger.Metadata("vt").TotalEmission.String())
+			})
+
+			t.Run("return", func(t *testing.T) {
+				id := uuid.NewString()
+				_ = user1.SignedInvoke("vt", "channelTransferByCustomer", id, "CC", "CC", "200")
+				cct := user1.Invoke("vt", "channelTransferFrom", id)
+
+				_, _, err := user1.RawChTransferInvokeWithBatch("cc", "createCCTransferTo", cct)
+				require.NoError(t, err)
+				ledger.WaitChTransferTo("cc", id, time.Second*5)
+
+				user1.BalanceShouldBe("vt", 250)
+				user1.AllowedBalanceShouldBe("vt", "CC", 0)
+				require.Equal(t, "250", ledger.Metadata("vt").TotalEmission.String())
+				user1.BalanceShouldBe("cc", 750)
+			})
+		})
+	}
+}
+
+func TestDeriveChannelTransferIDs(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	cfg := &pb.Config{
+		Contract: &pb.ContractConfig{
+			Symbol:   "CC",
+			RobotSKI: fixtures_test.RobotHashedCert,
+			Options: &pb.ChaincodeOptions{
+				DeriveChannelTransferIds: true,
+			},
+		},
+		Token: &pb.TokenConfig{
+			Name:     "CC Token",
+			Decimals: 8,
+			Issuer:   &pb.Wallet{Address: owner.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	transferID := func(t *testing.T, resp mock.TxResponse) string {
+		require.Empty(t, resp.Error)
+
+		var id string
+		require.NoError(t, json.Unmarshal([]byte(resp.Result), &id))
+		require.Len(t, id, 64)
+
+		return id
+	}
+
+	_, resp, _ := user1.RawSignedInvoke("cc", "channelTransferByCustomer", "", "VT", "CC", "100")
+	id1 := transferID(t, resp)
+
+	_, resp, _ = user1.RawSignedInvoke("cc", "channelTransferByCustomer", "", "VT", "CC", "100")
+	id2 := transferID(t, resp)
+	require.NotEqual(t, id1, id2)
+
+	for _, id := range []string{id1, id2} {
+		cct := new(pb.CCTransfer)
+		require.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "channelTransferFrom", id)), cct))
+		require.Equal(t, id, cct.GetId())
+		require.Equal(t, int64(100), new(big.Int).SetBytes(cct.GetAmount()).Int64())
+	}
+
+	id := uuid.NewString()
+	_ = user1.SignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", "100")
+	cct := new(pb.CCTransfer)
+	require.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "channelTransferFrom", id)), cct))
+	require.Equal(t, id, cct.GetId())
+
+	user1.BalanceShouldBe("cc", 700)
+
+	t.Run("signed batch", func(t *testing.T) {
+		result, err := user1.SignedBatchInvoke("cc",
+			core.SignedBatchCall{Method: "channelTransferByCustomer", Args: []string{"", "VT", "CC", "100"}},
+			core.SignedBatchCall{Method: "channelTransferByCustomer", Args: []string{"", "VT", "CC", "50"}},
+		)
+		require.NoError(t, err)
+
+		var ids []string
+		require.NoError(t, json.Unmarshal([]byte(result), &ids))
+		require.Len(t, ids, 2)
+		require.NotEqual(t, ids[0], ids[1])
+
+		for i, amount := range []int64{100, 50} {
+			batchCCT := new(pb.CCTransfer)
+			require.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "channelTransferFrom", ids[i])), batchCCT))
+			require.Equal(t, amount, new(big.Int).SetBytes(batchCCT.GetAmount()).Int64())
+		}
+
+		user1.BalanceShouldBe("cc", 550)
+	})
+
+	t.Run("same transfer repeated in signed batch", func(t *testing.T) {
+		_, err := user1.SignedBatchInvoke("cc",
+			core.SignedBatchCall{Method: "channelTransferByCustomer", Args: []string{"", "VT", "CC", "100"}},
+			core.SignedBatchCall{Method: "channelTransferByCustomer", Args: []string{"", "VT", "CC", "100"}},
+		)
+		require.ErrorContains(t, err, cctransfer.ErrIDTransferExist.Error())
+
+		user1.BalanceShouldBe("cc", 550)
+	})
+}
+
+func TestMaxPageSize(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	cfg := &pb.Config{
+		Contract: &pb.ContractConfig{
+			Symbol:   "CC",
+			RobotSKI: fixtures_test.RobotHashedCert,
+			Options: &pb.ChaincodeOptions{
+				MaxPageSize: 2,
+			},
+		},
+		Token: &pb.TokenConfig{
+			Name:     "CC Token",
+			Decimals: 8,
+			Issuer:   &pb.Wallet{Address: owner.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	ids := make(map[string]struct{})
+	for i := 0; i < 5; i++ {
+		id := uuid.NewString()
+		ids[id] = struct{}{}
+		_ = user1.SignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", "100")
+	}
+
+	t.Run("oversized page is clamped", func(t *testing.T) {
+		pages := 0
+		b := ""
+		for {
+			res := new(pb.CCTransfers)
+			require.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "channelTransfersFrom", "1000", b)), res))
+			require.True(t, res.GetPageSizeClamped())
+			require.LessOrEqual(t, len(res.GetCcts()), 2)
+			pages++
+
+			for _, tr := range res.GetCcts() {
+				_, ok := ids[tr.GetId()]
+				require.True(t, ok)
+				delete(ids, tr.GetId())
+			}
+			if res.GetBookmark() == "" {
+				break
+			}
+			b = res.GetBookmark()
+		}
+
+		require.Empty(t, ids)
+		require.GreaterOrEqual(t, pages, 3)
+	})
+
+	t.Run("page within limit is not clamped", func(t *testing.T) {
+		res := new(pb.CCTransfers)
+		require.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "channelTransfersFrom", "2", "")), res))
+		require.False(t, res.GetPageSizeClamped())
+		require.Len(t, res.GetCcts(), 2)
+	})
+
+	t.Run("holders page is clamped", func(t *testing.T) {
+		user2 := ledger.NewWallet()
+		user2.AddBalance("cc", 10)
+		user3 := ledger.NewWallet()
+		user3.AddBalance("cc", 10)
+
+		res := new(token.Holders)
+		require.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "holders", "1000", "")), res))
+		require.True(t, res.PageSizeClamped)
+		require.Len(t, res.Addresses, 2)
+		require.NotEmpty(t, res.Bookmark)
+	})
+}
+
+func TestMultiTransferByAdmin(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", owner.Address(), nil)
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	users := []*mock.Wallet{ledger.NewWallet(), ledger.NewWallet(), ledger.NewWallet()}
+	for _, user := range users {
+		user.AddBalance("cc", 1000)
+	}
+
+	makeTransfers := func(amounts ...int64) ([]core.AdminChannelTransfer, string) {
+		transfers := make([]core.AdminChannelTransfer, 0, len(users))
+		for i, user := range users {
+			transfers = append(transfers, core.AdminChannelTransfer{
+				ID:     uuid.NewString(),
+				User:   user.AddressType(),
+				Token:  "CC",
+				Amount: big.NewInt(amounts[i]),
+			})
+		}
+
+		data, err := json.Marshal(transfers)
+		require.NoError(t, err)
+
+		return transfers, string(data)
+	}
+
+	t.Run("transfers for all users", func(t *testing.T) {
+		transfers, arg := makeTransfers(100, 200, 300)
+		err := owner.RawSignedInvokeWithErrorReturned("cc", "channelMultiTransferByAdmin", "VT", arg)
+		require.NoError(t, err)
+
+		for i, tr := range transfers {
+			cct := new(pb.CCTransfer)
+			require.NoError(t, json.Unmarshal([]byte(users[i].Invoke("cc", "channelTransferFrom", tr.ID)), cct))
+			require.Equal(t, tr.ID, cct.GetId())
+			require.Equal(t, "VT", cct.GetTo())
+			require.Equal(t, users[i].AddressType().Bytes(), cct.GetUser())
+			require.Equal(t, tr.Amount.Int64(), new(big.Int).SetBytes(cct.GetAmount()).Int64())
+		}
+
+		users[0].BalanceShouldBe("cc", 900)
+		users[1].BalanceShouldBe("cc", 800)
+		users[2].BalanceShouldBe("cc", 700)
+	})
+
+	t.Run("failed transfer aborts all transfers", func(t *testing.T) {
+		transfers, arg := makeTransfers(100, 100, 5000)
+		err := owner.RawSignedInvokeWithErrorReturned("cc", "channelMultiTransferByAdmin", "VT", arg)
+		require.ErrorContains(t, err, "transfer 2")
+
+		for i, tr := range transfers {
+			require.Error(t, users[i].InvokeWithError("cc", "channelTransferFrom", tr.ID))
+		}
+
+		users[0].BalanceShouldBe("cc", 900)
+		users[1].BalanceShouldBe("cc", 800)
+		users[2].BalanceShouldBe("cc", 700)
+	})
+
+	t.Run("not admin", func(t *testing.T) {
+		_, arg := makeTransfers(1, 1, 1)
+		err := users[0].RawSignedInvokeWithErrorReturned("cc", "channelMultiTransferByAdmin", "VT", arg)
+		require.ErrorContains(t, err, cctransfer.ErrUnauthorisedNotAdmin.Error())
+	})
+}
+
+func TestPruneTransfers(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", owner.Address(), nil)
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	vtConfig := makeBaseTokenConfig("VT Token", "VT", 8,
+		owner.Address(), "", "", owner.Address(), nil)
+	initMsg = ledger.NewCC("vt", &token.BaseToken{}, vtConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	commitTransfer := func(id string) {
+		cct := user1.Invoke("cc", "channelTransferFrom", id)
+		_, _, err := user1.RawChTransferInvokeWithBatch("vt", "createCCTransferTo", cct)
+		require.NoError(t, err)
+		ledger.WaitChTransferTo("vt", id, time.Second*5)
+		_, _, err = user1.RawChTransferInvoke("cc", "commitCCTransferFrom", id)
+		require.NoError(t, err)
+	}
+
+	ledger.SetTxTime(time.Now().Add(-48 * time.Hour))
+
+	oldCommitted := uuid.NewString()
+	_ = user1.SignedInvoke("cc", "channelTransferByCustomer", oldCommitted, "VT", "CC", "100")
+	commitTransfer(oldCommitted)
+
+	oldCreated := uuid.NewString()
+	_ = user1.SignedInvoke("cc", "channelTransferByCustomer", oldCreated, "VT", "CC", "100")
+
+	ledger.SetTxTime(time.Now())
+
+	recentCommitted := uuid.NewString()
+	_ = user1.SignedInvoke("cc", "channelTransferByCustomer", recentCommitted, "VT", "CC", "100")
+	commitTransfer(recentCommitted)
+
+	prune := func(t *testing.T, ch string) uint64 {
+		pruned := uint64(0)
+		bookmark := ""
+		for {
+			_, resp, _ := owner.RawSignedInvoke(ch, "pruneTransfers", "24h", "1", bookmark)
+			require.Empty(t, resp.Error)
+
+			res := new(core.PrunedTransfers)
+			require.NoError(t, json.Unmarshal([]byte(resp.Result), res))
+			pruned += res.Pruned
+
+			if res.Bookmark == "" {
+				return pruned
+			}
+			bookmark = res.Bookmark
+		}
+	}
+
+	t.Run("not admin", func(t *testing.T) {
+		err := user1.RawSignedInvokeWithErrorReturned("cc", "pruneTransfers", "24h", "10", "")
+		require.ErrorContains(t, err, cctransfer.ErrUnauthorisedNotAdmin.Error())
+	})
+
+	t.Run("invalid age", func(t *testing.T) {
+		err := owner.RawSignedInvokeWithErrorReturned("cc", "pruneTransfers", "-1h", "10", "")
+		require.ErrorContains(t, err, cctransfer.ErrInvalidPruneAge.Error())
+	})
+
+	t.Run("old terminal transfers are pruned", func(t *testing.T) {
+		require.Equal(t, uint64(1), prune(t, "cc"))
+		require.Error(t, user1.InvokeWithError("cc", "channelTransferFrom", oldCommitted))
+		require.NoError(t, user1.InvokeWithError("cc", "channelTransferFrom", oldCreated))
+		require.NoError(t, user1.InvokeWithError("cc", "channelTransferFrom", recentCommitted))
+
+		require.Equal(t, uint64(1), prune(t, "vt"))
+		require.Error(t, user1.InvokeWithError("vt", "channelTransferTo", oldCommitted))
+		require.NoError(t, user1.InvokeWithError("vt", "channelTransferTo", recentCommitted))
+
+		res := new(pb.CCTransfers)
+		require.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "transfersByStatus", "committed", "10", "")), res))
+		require.Len(t, res.GetCcts(), 1)
+		require.Equal(t, recentCommitted, res.GetCcts()[0].GetId())
+	})
+
+	t.Run("repeated prune", func(t *testing.T) {
+		require.Equal(t, uint64(0), prune(t, "cc"))
+		require.Equal(t, uint64(0), prune(t, "vt"))
+		user1.BalanceShouldBe("cc", 700)
+	})
+}
+
+func TestChannelTransferReceipt(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	vtConfig := makeBaseTokenConfig("VT Token", "VT", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg = ledger.NewCC("vt", &token.BaseToken{}, vtConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	id := uuid.NewString()
+	_ = user1.SignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", "450")
+	cctRaw := user1.Invoke("cc", "channelTransferFrom", id)
+
+	cct := new(pb.CCTransfer)
+	require.NoError(t, json.Unmarshal([]byte(cctRaw), cct))
+
+	err := user1.InvokeWithError("cc", "channelTransferReceipt", id)
+	require.ErrorContains(t, err, cctransfer.ErrTransferNotCompleted.Error())
+
+	_, _, err = user1.RawChTransferInvokeWithBatch("vt", "createCCTransferTo", cctRaw)
+	require.NoError(t, err)
+	ledger.WaitChTransferTo("vt", id, time.Second*5)
+
+	_, _, err = user1.RawChTransferInvoke("cc", "commitCCTransferFrom", id)
+	require.NoError(t, err)
+	_, _, err = user1.RawChTransferInvoke("vt", "deleteCCTransferTo", id)
+	require.NoError(t, err)
+	_, _, err = user1.RawChTransferInvoke("cc", "deleteCCTransferFrom", id)
+	require.NoError(t, err)
+
+	expected := cctransfer.ReceiptHash(id, "CC", "VT", "CC", user1.Address(), "450",
+		string(core.TransferStatusCompleted), cct.GetTimeAsNanos())
+
+	var receipt string
+	require.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "channelTransferReceipt", id)), &receipt))
+	require.Equal(t, expected, receipt)
+
+	receipt = ""
+	require.NoError(t, json.Unmarshal([]byte(user1.Invoke("vt", "channelTransferReceipt", id)), &receipt))
+	require.Equal(t, expected, receipt)
+}
+
+func TestExpiringTransfers(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	vtConfig := makeBaseTokenConfig("VT Token", "VT", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg = ledger.NewCC("vt", &token.BaseToken{}, vtConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	reap := func(t *testing.T) {
+		_, _, err := user1.RawChTransferInvoke("cc", "reapExpiredTransfers", "10")
+		require.NoError(t, err)
+	}
+
+	statusShouldBe := func(t *testing.T, id string, expected core.TransferStatus) {
+		var status core.TransferStatus
+		require.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "transferStatus", id)), &status))
+		require.Equal(t, expected, status)
+	}
+
+	t.Run("invalid ttl", func(t *testing.T) {
+		err := user1.RawSignedInvokeWithErrorReturned("cc", "channelTransferByCustomerWithExpiry",
+			uuid.NewString(), "VT", "CC", "100", "0s")
+		require.ErrorContains(t, err, cctransfer.ErrInvalidTransferTTL.Error())
+	})
+
+	start := time.Now()
+	ledger.SetTxTime(start)
+
+	expiring := uuid.NewString()
+	_ = user1.SignedInvoke("cc", "channelTransferByCustomerWithExpiry", expiring, "VT", "CC", "100", "1h")
+
+	lasting := uuid.NewString()
+	_ = user1.SignedInvoke("cc", "channelTransferByCustomer", lasting, "VT", "CC", "200")
+	user1.BalanceShouldBe("cc", 700)
+
+	cct := new(pb.CCTransfer)
+	require.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "channelTransferFrom", expiring)), cct))
+	require.Equal(t, start.Add(time.Hour).UnixNano(), cct.GetExpiresAtNanos())
+
+	t.Run("not expired transfers are not reaped", func(t *testing.T) {
+		reap(t)
+		user1.BalanceShouldBe("cc", 700)
+		statusShouldBe(t, expiring, core.TransferStatusCreated)
+	})
+
+	ledger.SetTxTime(start.Add(2 * time.Hour))
+
+	t.Run("expired transfer is rejected by the channel To", func(t *testing.T) {
+		cctRaw := user1.Invoke("cc", "channelTransferFrom", expiring)
+		_, _, err := user1.RawChTransferInvokeWithBatch("vt", "createCCTransferTo", cctRaw)
+		require.ErrorContains(t, err, cctransfer.ErrTransferExpired.Error())
+	})
+
+	t.Run("expired transfer is reaped with the refund", func(t *testing.T) {
+		reap(t)
+		user1.BalanceShouldBe("cc", 800)
+		statusShouldBe(t, expiring, core.TransferStatusCancelled)
+		statusShouldBe(t, lasting, core.TransferStatusCreated)
+
+		reap(t)
+		user1.BalanceShouldBe("cc", 800)
+	})
+}
+
+func TestAllowedBalancesBatch(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	vtConfig := makeBaseTokenConfig("VT Token", "VT", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg = ledger.NewCC("vt", &token.BaseToken{}, vtConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+	user2 := ledger.NewWallet()
+	user2.AddBalance("cc", 1000)
+	unknown := ledger.NewWallet()
+
+	for _, transfer := range []struct {
+		user   *mock.Wallet
+		amount string
+	}{
+		{user: user1, amount: "100"},
+		{user: user2, amount: "250"},
+		{user: user1, amount: "50"},
+	} {
+		id := uuid.NewString()
+		_ = transfer.user.SignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", transfer.amount)
+		cct := transfer.user.Invoke("cc", "channelTransferFrom", id)
+		_, _, err := transfer.user.RawChTransferInvokeWithBatch("vt", "createCCTransferTo", cct)
+		require.NoError(t, err)
+		ledger.WaitChTransferTo("vt", id, time.Second*5)
+	}
+
+	addresses, err := json.Marshal([]string{user1.Address(), user2.Address(), unknown.Address()})
+	require.NoError(t, err)
+
+	balances := make(map[string]string)
+	require.NoError(t, json.Unmarshal([]byte(
+		owner.QueryShouldNotChangeNonce("vt", "allowedBalancesBatch", string(addresses), "CC"),
+	), &balances))
+	require.Len(t, balances, 3)
+
+	for _, user := range []*mock.Wallet{user1, user2, unknown} {
+		var expected string
+		require.NoError(t, json.Unmarshal([]byte(owner.Invoke("vt", "allowedBalanceOf", user.Address(), "CC")), &expected))
+		require.Equal(t, expected, balances[user.Address()])
+	}
+
+	require.Equal(t, map[string]string{
+		user1.Address():   "150",
+		user2.Address():   "250",
+		unknown.Address(): "0",
+	}, balances)
+}
+
+func TestCancelAllTransfersForAddress(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", owner.Address(), nil)
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	vtConfig := makeBaseTokenConfig("VT Token", "VT", 8,
+		owner.Address(), "", "", owner.Address(), nil)
+	initMsg = ledger.NewCC("vt", &token.BaseToken{}, vtConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+	user2 := ledger.NewWallet()
+	user2.AddBalance("cc", 1000)
+
+	statusShouldBe := func(t *testing.T, id string, expected core.TransferStatus) {
+		var status core.TransferStatus
+		require.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "transferStatus", id)), &status))
+		require.Equal(t, expected, status)
+	}
+
+	committed := uuid.NewString()
+	_ = user1.SignedInvoke("cc", "channelTransferByCustomer", committed, "VT", "CC", "100")
+	cct := user1.Invoke("cc", "channelTransferFrom", committed)
+	_, _, err := user1.RawChTransferInvokeWithBatch("vt", "createCCTransferTo", cct)
+	require.NoError(t, err)
+	ledger.WaitChTransferTo("vt", committed, time.Second*5)
+	_, _, err = user1.RawChTransferInvoke("cc", "commitCCTransferFrom", committed)
+	require.NoError(t, err)
+
+	// the delivered transfer is not committed yet
+	delivered := uuid.NewString()
+	_ = user1.SignedInvoke("cc", "channelTransferByCustomer", delivered, "VT", "CC", "100")
+	_, _, err = user1.RawChTransferInvoke("cc", "pickUpCCTransferFrom", delivered)
+	require.NoError(t, err)
+	cct = user1.Invoke("cc", "channelTransferFrom", delivered)
+	_, _, err = user1.RawChTransferInvokeWithBatch("vt", "createCCTransferTo", cct)
+	require.NoError(t, err)
+	ledger.WaitChTransferTo("vt", delivered, time.Second*5)
+
+	pending := make([]string, 0, 3)
+	for _, amount := range []string{"50", "150", "200"} {
+		id := uuid.NewString()
+		_ = user1.SignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", amount)
+		pending = append(pending, id)
+	}
+	user1.BalanceShouldBe("cc", 400)
+
+	other := uuid.NewString()
+	_ = user2.SignedInvoke("cc", "channelTransferByCustomer", other, "VT", "CC", "300")
+	user2.BalanceShouldBe("cc", 700)
+
+	t.Run("not admin", func(t *testing.T) {
+		err := user1.RawSignedInvokeWithErrorReturned("cc", "cancelAllTransfersForAddress", user1.Address())
+		require.ErrorContains(t, err, cctransfer.ErrUnauthorisedNotAdmin.Error())
+	})
+
+	t.Run("pending transfers are cancelled with refunds", func(t *testing.T) {
+		_, resp, _ := owner.RawSignedInvoke("cc", "cancelAllTransfersForAddress", user1.Address())
+		require.Empty(t, resp.Error)
+
+		res := new(core.CancelledTransfers)
+		require.NoError(t, json.Unmarshal([]byte(resp.Result), res))
+		require.ElementsMatch(t, pending, res.Cancelled)
+
+		user1.BalanceShouldBe("cc", 800)
+		for _, id := range pending {
+			statusShouldBe(t, id, core.TransferStatusCancelled)
+		}
+		statusShouldBe(t, committed, core.TransferStatusCommitted)
+		statusShouldBe(t, delivered, core.TransferStatusCreated)
+		user1.AllowedBalanceShouldBe("vt", "CC", 200)
+
+		user2.BalanceShouldBe("cc", 700)
+		statusShouldBe(t, other, core.TransferStatusCreated)
+	})
+
+	t.Run("re-run cancels nothing", func(t *testing.T) {
+		_, resp, _ := owner.RawSignedInvoke("cc", "cancelAllTransfersForAddress", user1.Address())
+		require.Empty(t, resp.Error)
+
+		res := new(core.CancelledTransfers)
+		require.NoError(t, json.Unmarshal([]byte(resp.Result), res))
+		require.Empty(t, res.Cancelled)
+
+		user1.BalanceShouldBe("cc", 800)
+	})
+}
+
+func TestCheckChannelTransferTargetToken(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		check bool
+	}{
+		{name: "unknown target token fails late with the check off"},
+		{name: "unknown target token is rejected early with the check on", check: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ledger := mock.NewLedger(t)
+			owner := ledger.NewWallet()
+
+			cfg := &pb.Config{
+				Contract: &pb.ContractConfig{
+					Symbol:   "CC",
+					RobotSKI: fixtures_test.RobotHashedCert,
+					Options: &pb.ChaincodeOptions{
+						CheckChannelTransferTargetToken: test.check,
+					},
+				},
+				Token: &pb.TokenConfig{
+					Name:     "CC Token",
+					Decimals: 8,
+					Issuer:   &pb.Wallet{Address: owner.Address()},
+				},
+			}
+			cfgBytes, err := protojson.Marshal(cfg)
+			require.NoError(t, err)
+
+			initMsg := ledger.NewCC("cc", &token.BaseToken{}, string(cfgBytes))
+			require.Empty(t, initMsg)
+
+			vtConfig := makeBaseTokenConfig("VT Token", "VT", 8,
+				owner.Address(), "", "", "", nil)
+			initMsg = ledger.NewCC("vt", &token.BaseToken{}, vtConfig)
+			require.Empty(t, initMsg)
+
+			// the channel wt hosts the contract of another token
+			wtConfig := makeBaseTokenConfig("WX Token", "WX", 8,
+				owner.Address(), "", "", "", nil)
+			initMsg = ledger.NewCC("wt", &token.BaseToken{}, wtConfig)
+			require.Empty(t, initMsg)
+
+			user1 := ledger.NewWallet()
+			user1.AddBalance("cc", 1000)
+
+			_, resp := user1.BatchedInvoke("cc", "channelTransferByCustomer",
+				user1.SignArgs("cc", "channelTransferByCustomer", uuid.NewString(), "VT", "CC", "100")...)
+			require.Empty(t, resp.Error)
+
+			for _, to := range []string{"XT", "WT"} {
+				_, resp = user1.BatchedInvoke("cc", "channelTransferByCustomer",
+					user1.SignArgs("cc", "channelTransferByCustomer", uuid.NewString(), to, "CC", "100")...)
+				if !test.check {
+					require.Empty(t, resp.Error)
+					continue
+				}
+
+				require.Contains(t, resp.Error, cctransfer.ErrUnknownTargetToken.Error())
+			}
+
+			expected := uint64(700)
+			if test.check {
+				expected = 900
+			}
+			user1.BalanceShouldBe("cc", expected)
+		})
+	}
+}
+
+func TestRobotForwardTransfer(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	vtConfig := makeBaseTokenConfig("VT Token", "VT", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg = ledger.NewCC("vt", &token.BaseToken{}, vtConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	robot := ledger.NewRobot()
+
+	id := uuid.NewString()
+	user1.SignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", "450")
+
+	require.NoError(t, robot.Transfer("cc", id))
+
+	user1.BalanceShouldBe("cc", 550)
+	user1.AllowedBalanceShouldBe("vt", "CC", 450)
+	user1.CheckGivenBalanceShouldBe("cc", "VT", 450)
+
+	require.Error(t, user1.InvokeWithError("cc", "channelTransferFrom", id))
+	require.Error(t, user1.InvokeWithError("vt", "channelTransferTo", id))
+
+	ids := []string{uuid.NewString(), uuid.NewString()}
+	for _, id := range ids {
+		user1.SignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", "100")
+	}
+
+	transferred, err := robot.TransferAll("cc")
+	require.NoError(t, err)
+	require.ElementsMatch(t, ids, transferred)
+
+	user1.BalanceShouldBe("cc", 350)
+	user1.AllowedBalanceShouldBe("vt", "CC", 650)
+
+	transferred, err = robot.TransferAll("cc")
+	require.NoError(t, err)
+	require.Empty(t, transferred)
+}
+
+func TestQueryTransfersByTimeRange(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	vtConfig := makeBaseTokenConfig("VT Token", "VT", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg = ledger.NewCC("vt", &token.BaseToken{}, vtConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	start := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	ids := make([]string, 3)
+	for i := range ids {
+		ledger.SetTxTime(start.Add(time.Duration(i) * time.Hour))
+		ids[i] = uuid.NewString()
+		user1.SignedInvoke("cc", "channelTransferByCustomer", ids[i], "VT", "CC", "100")
+	}
+
+	ledger.SetTxTime(start.Add(3 * time.Hour))
+	require.NoError(t, ledger.NewRobot().Transfer("cc", ids[0]))
+
+	cancelled := uuid.NewString()
+	user1.SignedInvoke("cc", "channelTransferByCustomer", cancelled, "VT", "CC", "100")
+	user1.SignedInvoke("cc", "channelTransferCancelByCustomer", cancelled)
+
+	query := func(ch string, from, to time.Time, pageSize int, bookmark string) *pb.CCTransfers {
+		res := new(pb.CCTransfers)
+		require.NoError(t, json.Unmarshal([]byte(user1.Invoke(ch, "transfersByTimeRange",
+			from.Format(time.RFC3339), to.Format(time.RFC3339), strconv.Itoa(pageSize), bookmark)), res))
+		return res
+	}
+
+	transferIDs := func(res *pb.CCTransfers) []string {
+		var ids []string
+		for _, tr := range res.GetCcts() {
+			ids = append(ids, tr.GetId())
+		}
+		return ids
+	}
+
+	t.Run("start is inclusive, end is exclusive", func(t *testing.T) {
+		res := query("cc", start, start.Add(2*time.Hour), 10, "")
+		require.Equal(t, ids[:2], transferIDs(res))
+
+		res = query("cc", start.Add(time.Hour), start.Add(3*time.Hour), 10, "")
+		require.Equal(t, ids[1:], transferIDs(res))
+
+		res = query("cc", start.Add(time.Second), start.Add(time.Hour), 10, "")
+		require.Empty(t, res.GetCcts())
+	})
+
+	t.Run("completed transfers are restored from history", func(t *testing.T) {
+		res := query("cc", start, start.Add(time.Hour), 10, "")
+		require.Len(t, res.GetCcts(), 1)
+		require.Equal(t, ids[0], res.GetCcts()[0].GetId())
+		require.True(t, res.GetCcts()[0].GetIsCommit())
+
+		res = query("vt", start, start.Add(24*time.Hour), 10, "")
+		require.Equal(t, ids[:1], transferIDs(res))
+	})
+
+	t.Run("cancelled transfers are not indexed", func(t *testing.T) {
+		res := query("cc", start.Add(3*time.Hour), start.Add(4*time.Hour), 10, "")
+		require.Empty(t, res.GetCcts())
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		var got []string
+		bookmark := ""
+		for {
+			res := query("cc", start, start.Add(24*time.Hour), 1, bookmark)
+			got = append(got, transferIDs(res)...)
+			if res.GetBookmark() == "" {
+				break
+			}
+			bookmark = res.GetBookmark()
+		}
+		require.Equal(t, ids, got)
+	})
+
+	t.Run("invalid range", func(t *testing.T) {
+		err := user1.InvokeWithError("cc", "transfersByTimeRange",
+			start.Format(time.RFC3339), start.Format(time.RFC3339), "10", "")
+		require.ErrorContains(t, err, cctransfer.ErrInvalidTimeRange.Error())
+
+		err = user1.InvokeWithError("cc", "transfersByTimeRange",
+			start.Format(time.RFC3339), start.Add(time.Hour).Format(time.RFC3339), "10", "bad")
+		require.ErrorContains(t, err, cctransfer.ErrInvalidBookmark.Error())
+	})
+}
+
+func TestMaxActiveTransfersPerAddress(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	cfg := &pb.Config{
+		Contract: &pb.ContractConfig{
+			Symbol:   "CC",
+			RobotSKI: fixtures_test.RobotHashedCert,
+			Options: &pb.ChaincodeOptions{
+				MaxActiveTransfersPerAddress: 2,
+			},
+		},
+		Token: &pb.TokenConfig{
+			Name:     "CC Token",
+			Decimals: 8,
+			Issuer:   &pb.Wallet{Address: owner.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	vtConfig := makeBaseTokenConfig("VT Token", "VT", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg = ledger.NewCC("vt", &token.BaseToken{}, vtConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+	user2 := ledger.NewWallet()
+	user2.AddBalance("cc", 1000)
+
+	transfer := func(user *mock.Wallet) (string, string) {
+		id := uuid.NewString()
+		_, resp := user.BatchedInvoke("cc", "channelTransferByCustomer",
+			user.SignArgs("cc", "channelTransferByCustomer", id, "VT", "CC", "100")...)
+		return id, resp.Error
+	}
+
+	first, errMsg := transfer(user1)
+	require.Empty(t, errMsg)
+	_, errMsg = transfer(user1)
+	require.Empty(t, errMsg)
+
+	_, errMsg = transfer(user1)
+	require.Contains(t, errMsg, cctransfer.ErrActiveTransfersLimit.Error())
+
+	// the limit is per address
+	_, errMsg = transfer(user2)
+	require.Empty(t, errMsg)
+
+	require.NoError(t, ledger.NewRobot().Transfer("cc", first))
+
+	_, errMsg = transfer(user1)
+	require.Empty(t, errMsg)
+
+	_, errMsg = transfer(user1)
+	require.Contains(t, errMsg, cctransfer.ErrActiveTransfersLimit.Error())
+
+	user1.BalanceShouldBe("cc", 700)
+	user2.BalanceShouldBe("cc", 900)
+}
+
+func TestLinkedBackwardTransfer(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	vtConfig := makeBaseTokenConfig("VT Token", "VT", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg = ledger.NewCC("vt", &token.BaseToken{}, vtConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+	user2 := ledger.NewWallet()
+
+	robot := ledger.NewRobot()
+
+	forwardID := uuid.NewString()
+	user1.SignedInvoke("cc", "channelTransferByCustomer", forwardID, "VT", "CC", "400")
+	require.NoError(t, robot.Transfer("cc", forwardID))
+
+	netTransfer := func(ch string) *core.NetTransfer {
+		net := new(core.NetTransfer)
+		require.NoError(t, json.Unmarshal([]byte(user1.Invoke(ch, "netTransfer", forwardID)), net))
+		return net
+	}
+
+	linked := func(user *mock.Wallet, id, amount string) string {
+		_, resp := user.BatchedInvoke("vt", "channelTransferLinkedByCustomer",
+			user.SignArgs("vt", "channelTransferLinkedByCustomer", id, forwardID, "CC", "CC", amount)...)
+		return resp.Error
+	}
+
+	net := netTransfer("vt")
+	require.Empty(t, net.BackwardID)
+	require.Equal(t, "400", net.NetAmount.String())
+
+	t.Run("mismatching backward transfers are rejected", func(t *testing.T) {
+		require.Contains(t, linked(user1, uuid.NewString(), "401"), cctransfer.ErrInvalidLinkedTransfer.Error())
+		require.Contains(t, linked(user2, uuid.NewString(), "100"), cctransfer.ErrInvalidLinkedTransfer.Error())
+	})
+
+	t.Run("cancelled backward transfer unlinks", func(t *testing.T) {
+		id := uuid.NewString()
+		require.Empty(t, linked(user1, id, "100"))
+		require.Equal(t, id, netTransfer("vt").BackwardID)
+
+		user1.SignedInvoke("vt", "channelTransferCancelByCustomer", id)
+		require.Empty(t, netTransfer("vt").BackwardID)
+	})
+
+	backwardID := uuid.NewString()
+
+	t.Run("linked backward transfer", func(t *testing.T) {
+		require.Empty(t, linked(user1, backwardID, "400"))
+		require.Contains(t, linked(user1, uuid.NewString(), "100"), cctransfer.ErrTransferAlreadyLinked.Error())
+
+		require.NoError(t, robot.Transfer("vt", backwardID))
+
+		user1.BalanceShouldBe("cc", 1000)
+		user1.AllowedBalanceShouldBe("vt", "CC", 0)
+	})
+
+	t.Run("net is zero in both channels", func(t *testing.T) {
+		for _, ch := range []string{"cc", "vt"} {
+			net := netTransfer(ch)
+			require.Equal(t, forwardID, net.ForwardID)
+			require.Equal(t, backwardID, net.BackwardID)
+			require.Equal(t, user1.Address(), net.User)
+			require.Equal(t, "400", net.ForwardAmount.String())
+			require.Equal(t, "400", net.BackwardAmount.String())
+			require.Equal(t, "0", net.NetAmount.String())
+		}
+	})
+}
+
+// TestChannelTransferFromByCustomer checks that the debited address must be the signer.
+func TestChannelTransferFromByCustomer(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	vtConfig := makeBaseTokenConfig("VT Token", "VT", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg = ledger.NewCC("vt", &token.BaseToken{}, vtConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+	user2 := ledger.NewWallet()
+	user2.AddBalance("cc", 1000)
+
+	t.Run("mismatched from is rejected", func(t *testing.T) {
+		_, resp := user1.BatchedInvoke("cc", "channelTransferFromByCustomer",
+			user1.SignArgs("cc", "channelTransferFromByCustomer", uuid.NewString(), user2.Address(), "VT", "CC", "100")...)
+		require.Contains(t, resp.Error, cctransfer.ErrSourceNotSigner.Error())
+
+		user1.BalanceShouldBe("cc", 1000)
+		user2.BalanceShouldBe("cc", 1000)
+	})
+
+	t.Run("matching from is accepted", func(t *testing.T) {
+		id := uuid.NewString()
+		_, resp := user1.BatchedInvoke("cc", "channelTransferFromByCustomer",
+			user1.SignArgs("cc", "channelTransferFromByCustomer", id, user1.Address(), "VT", "CC", "100")...)
+		require.Empty(t, resp.Error)
+		require.NoError(t, ledger.NewRobot().Transfer("cc", id))
+
+		user1.BalanceShouldBe("cc", 900)
+		user2.BalanceShouldBe("cc", 1000)
+		user1.AllowedBalanceShouldBe("vt", "CC", 100)
+	})
+}
+
+func TestReserveTransferID(t *testing.T) {
+	newLedger := func(t *testing.T, required bool) (*mock.Ledger, *mock.Wallet, *mock.Wallet) {
+		ledger := mock.NewLedger(t)
+		owner := ledger.NewWallet()
+
+		cfg := &pb.Config{
+			Contract: &pb.ContractConfig{
+				Symbol:   "CC",
+				RobotSKI: fixtures_test.RobotHashedCert,
+				Options: &pb.ChaincodeOptions{
+					RequireReservedTransferIds: required,
+				},
+			},
+			Token: &pb.TokenConfig{
+				Name:     "CC Token",
+				Decimals: 8,
+				Issuer:   &pb.Wallet{Address: owner.Address()},
+			},
+		}
+		cfgBytes, err := protojson.Marshal(cfg)
+		require.NoError(t, err)
+
+		initMsg := ledger.NewCC("cc", &token.BaseToken{}, string(cfgBytes))
+		require.Empty(t, initMsg)
+
+		user1 := ledger.NewWallet()
+		user1.AddBalance("cc", 1000)
+		user2 := ledger.NewWallet()
+		user2.AddBalance("cc", 1000)
+
+		return ledger, user1, user2
+	}
+
+	reserve := func(t *testing.T, user *mock.Wallet) string {
+		_, resp, _ := user.RawSignedInvoke("cc", "reserveTransferID")
+		require.Empty(t, resp.Error)
+
+		var id string
+		require.NoError(t, json.Unmarshal([]byte(resp.Result), &id))
+		require.NotEmpty(t, id)
+
+		return id
+	}
+
+	t.Run("reserved id is used once", func(t *testing.T) {
+		_, user1, _ := newLedger(t, false)
+
+		id := reserve(t, user1)
+		require.NotEqual(t, id, reserve(t, user1))
+
+		_, resp, _ := user1.RawSignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", "100")
+		require.Empty(t, resp.Error)
+
+		_, resp, _ = user1.RawSignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", "100")
+		require.Contains(t, resp.Error, cctransfer.ErrIDReservationUsed.Error())
+
+		user1.BalanceShouldBe("cc", 900)
+	})
+
+	t.Run("not reserved id is accepted in legacy mode", func(t *testing.T) {
+		_, user1, _ := newLedger(t, false)
+
+		_, resp, _ := user1.RawSignedInvoke("cc", "channelTransferByCustomer", uuid.NewString(), "VT", "CC", "100")
+		require.Empty(t, resp.Error)
+
+		user1.BalanceShouldBe("cc", 900)
+	})
+
+	t.Run("not reserved id is rejected if reservation is required", func(t *testing.T) {
+		_, user1, _ := newLedger(t, true)
+
+		_, resp, _ := user1.RawSignedInvoke("cc", "channelTransferByCustomer", uuid.NewString(), "VT", "CC", "100")
+		require.Contains(t, resp.Error, cctransfer.ErrIDTransferNotReserved.Error())
+
+		id := reserve(t, user1)
+		_, resp, _ = user1.RawSignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", "100")
+		require.Empty(t, resp.Error)
+
+		user1.BalanceShouldBe("cc", 900)
+	})
+
+	t.Run("id reserved by another address is rejected", func(t *testing.T) {
+		_, user1, user2 := newLedger(t, false)
+
+		id := reserve(t, user1)
+		_, resp, _ := user2.RawSignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", "100")
+		require.Contains(t, resp.Error, cctransfer.ErrIDReservedByAnother.Error())
+
+		user2.BalanceShouldBe("cc", 1000)
+	})
+
+	t.Run("expired reservation is rejected", func(t *testing.T) {
+		ledger, user1, _ := newLedger(t, false)
+
+		ledger.SetTxTime(time.Now().Add(-2 * core.TransferIDReservationTTL))
+		id := reserve(t, user1)
+		ledger.SetTxTime(time.Now())
+
+		_, resp, _ := user1.RawSignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", "100")
+		require.Contains(t, resp.Error, cctransfer.ErrIDReservationExpired.Error())
+
+		user1.BalanceShouldBe("cc", 1000)
+	})
+}
+
+func TestRequireRegisteredTransferTokens(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	newConfig := func(t *testing.T, symbol string, registered ...string) string {
+		cfg := &pb.Config{
+			Contract: &pb.ContractConfig{
+				Symbol:   symbol,
+				RobotSKI: fixtures_test.RobotHashedCert,
+				Options: &pb.ChaincodeOptions{
+					RequireRegisteredTransferTokens: true,
+					RegisteredTransferTokens:        registered,
+				},
+			},
+			Token: &pb.TokenConfig{
+				Name:     symbol + " Token",
+				Decimals: 8,
+				Issuer:   &pb.Wallet{Address: owner.Address()},
+			},
+		}
+		cfgBytes, err := protojson.Marshal(cfg)
+		require.NoError(t, err)
+
+		return string(cfgBytes)
+	}
+
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, newConfig(t, "CC", "VT"))
+	require.Empty(t, initMsg)
+	initMsg = ledger.NewCC("vt", &token.BaseToken{}, newConfig(t, "VT"))
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+	user1.AddBalance("vt", 1000)
+
+	t.Run("unregistered token is rejected", func(t *testing.T) {
+		id := uuid.NewString()
+		_, resp, _ := user1.RawSignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", "100")
+		require.Empty(t, resp.Error)
+
+		err := ledger.NewRobot().Transfer("cc", id)
+		require.ErrorContains(t, err, cctransfer.ErrTokenNotRegistered.Error())
+
+		user1.AllowedBalanceShouldBe("vt", "CC", 0)
+	})
+
+	t.Run("registered token is accepted", func(t *testing.T) {
+		id := uuid.NewString()
+		_, resp, _ := user1.RawSignedInvoke("vt", "channelTransferByCustomer", id, "CC", "VT", "100")
+		require.Empty(t, resp.Error)
+		require.NoError(t, ledger.NewRobot().Transfer("vt", id))
+
+		user1.BalanceShouldBe("vt", 900)
+		user1.AllowedBalanceShouldBe("cc", "VT", 100)
+	})
+
+	t.Run("own token is accepted", func(t *testing.T) {
+		id := uuid.NewString()
+		_, resp, _ := user1.RawSignedInvoke("cc", "channelTransferByCustomer", id, "VT", "VT", "100")
+		require.Empty(t, resp.Error)
+		require.NoError(t, ledger.NewRobot().Transfer("cc", id))
+
+		user1.AllowedBalanceShouldBe("cc", "VT", 0)
+		user1.BalanceShouldBe("vt", 1000)
+	})
+}
+
+func TestQueryTotalLocked(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	vtConfig := makeBaseTokenConfig("VT Token", "VT", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg = ledger.NewCC("vt", &token.BaseToken{}, vtConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+
+	require.Equal(t, `"0"`, user1.Invoke("cc", "totalLocked", "CC"))
+
+	created := uuid.NewString()
+	committed := uuid.NewString()
+	completed := uuid.NewString()
+	cancelled := uuid.NewString()
+	for i, id := range []string{created, committed, completed, cancelled} {
+		_ = user1.SignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CC", strconv.Itoa(100*(i+1)))
+	}
+	require.Equal(t, `"1000"`, user1.Invoke("cc", "totalLocked", "CC"))
+
+	_, _, err := user1.RawChTransferInvokeWithBatch("cc", "cancelCCTransferFrom", cancelled)
+	require.NoError(t, err)
+
+	require.NoError(t, ledger.NewRobot().Transfer("cc", completed))
+
+	cct := user1.Invoke("cc", "channelTransferFrom", committed)
+	_, _, err = user1.RawChTransferInvokeWithBatch("vt", "createCCTransferTo", cct)
+	require.NoError(t, err)
+	ledger.WaitChTransferTo("vt", committed, time.Second*5)
+	_, _, err = user1.RawChTransferInvoke("cc", "commitCCTransferFrom", committed)
+	require.NoError(t, err)
+
+	// the created and the committed transfers are outstanding
+	require.Equal(t, `"300"`, user1.Invoke("cc", "totalLocked", "CC"))
+	require.Equal(t, `"0"`, user1.Invoke("cc", "totalLocked", "VT"))
+	require.Equal(t, `"0"`, user1.Invoke("vt", "totalLocked", "CC"))
+}
@@ -256,6 +256,26 @@ func TestWithConfigMapperFuncFromArgs(t *testing.T) {
 	})
 }
 
+func TestValidatingConfigMapper(t *testing.T) {
+	t.Parallel()
+
+	ledgerMock := mock.NewLedger(t)
+
+	ttSymbol := "tt"
+	initArgs := []string{
+		"",                            // PlatformSKI (backend) - deprecated
+		fixtures_test.RobotHashedCert, // RobotSKI
+		"0OIl",                        // IssuerAddress, not a base58 string
+		fixtures_test.AdminAddr,       // AdminAddress
+	}
+	message := ledgerMock.NewCCArgsArr(ttSymbol, &TestConfigToken{}, initArgs, core.WithConfigMapper(
+		contract.NewValidatingConfigMapper(contract.ConfigMapperFunc(func(args []string) (*proto.Config, error) {
+			return config.FromArgsWithIssuerAndAdmin(ttSymbol, args)
+		}), config.ArgsWithIssuerAndAdmin...),
+	))
+	require.Contains(t, message, "init arg 2 'issuerAddress' (Token.Issuer.Address)")
+}
+
 func TestBaseTokenTx(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,42 @@
+package unit
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+)
+
+// TestQueryConfigHash checks that the hash of the applied config is stable and changes with the config.
+func TestQueryConfigHash(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	config := makeBaseTokenConfig("CC Token", "CC", 8, owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, config)
+	require.Empty(t, initMsg)
+
+	hash := owner.Invoke("cc", "configHash")
+	require.Len(t, hash, 2+64) // JSON string of the hex encoded hash
+
+	t.Run("same config yields the same hash", func(t *testing.T) {
+		require.Empty(t, ledger.UpgradeCC("cc", &token.BaseToken{}, config))
+		require.Equal(t, hash, owner.Invoke("cc", "configHash"))
+	})
+
+	t.Run("formatting of the config does not change the hash", func(t *testing.T) {
+		var indented bytes.Buffer
+		require.NoError(t, json.Indent(&indented, []byte(config), "", "  "))
+		require.Empty(t, ledger.UpgradeCC("cc", &token.BaseToken{}, indented.String()))
+		require.Equal(t, hash, owner.Invoke("cc", "configHash"))
+	})
+
+	t.Run("other config yields other hash", func(t *testing.T) {
+		other := makeBaseTokenConfig("CC Token", "CC", 6, owner.Address(), "", "", "", nil)
+		require.Empty(t, ledger.UpgradeCC("cc", &token.BaseToken{}, other))
+		require.NotEqual(t, hash, owner.Invoke("cc", "configHash"))
+	})
+}
@@ -0,0 +1,138 @@
+package unit
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TestDenylist checks that transfers involving addresses blacklisted in ACL are rejected
+// when check_denylist option is enabled.
+func TestDenylist(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	owner := ledgerMock.NewWallet()
+	user1 := ledgerMock.NewWallet()
+	user2 := ledgerMock.NewWallet()
+
+	cfg := &proto.Config{
+		Contract: &proto.ContractConfig{
+			Symbol:   testTokenSymbol,
+			RobotSKI: fixtures_test.RobotHashedCert,
+			Admin:    &proto.Wallet{Address: owner.Address()},
+			Options: &proto.ChaincodeOptions{
+				CheckDenylist: true,
+			},
+		},
+		Token: &proto.TokenConfig{
+			Name:     testTokenName,
+			Decimals: 8,
+			Issuer:   &proto.Wallet{Address: owner.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	user1.AddBalance(testTokenCCName, 1000)
+
+	data, err := json.Marshal(&proto.TransferRequest{
+		Basis:           proto.TransferBasis_TRANSFER_BASIS_INHERITANCE,
+		AdministratorId: owner.Address(),
+		DocumentType:    proto.DocumentType_DOCUMENT_TYPE_INHERITANCE,
+		DocumentNumber:  "1",
+		DocumentDate:    timestamppb.New(time.Now()),
+		DocumentHashes:  []string{"hash1"},
+		FromAddress:     user1.Address(),
+		ToAddress:       user2.Address(),
+		Amount:          "100",
+		Reason:          "test transfer",
+		BalanceType:     proto.BalanceType_BALANCE_TYPE_TOKEN,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, user2.AddToList(mock.BlackList))
+
+	t.Run("transfer to denylisted address is rejected", func(t *testing.T) {
+		err := user1.RawSignedInvokeWithErrorReturned(testTokenCCName, "transfer", user2.Address(), "100", "")
+		require.ErrorContains(t, err, "is blacklisted")
+	})
+
+	t.Run("admin transfer involving denylisted address is rejected", func(t *testing.T) {
+		err := owner.RawSignedInvokeWithErrorReturned(testTokenCCName, "transferBalance", string(data))
+		require.ErrorContains(t, err, core.ErrAddressDenylisted.Error())
+	})
+
+	require.NoError(t, user2.DelFromList(mock.BlackList))
+
+	t.Run("admin transfer is allowed after removing from denylist", func(t *testing.T) {
+		err := owner.RawSignedInvokeWithErrorReturned(testTokenCCName, "transferBalance", string(data))
+		require.NoError(t, err)
+
+		user1.BalanceShouldBe(testTokenCCName, 900)
+		user2.BalanceShouldBe(testTokenCCName, 100)
+	})
+}
+
+// TestDenylistSwapDone checks that the swap is not completed to the owner blacklisted in ACL
+// after the swap is begun when check_denylist option is enabled.
+func TestDenylistSwapDone(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	owner := ledgerMock.NewWallet()
+	user1 := ledgerMock.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledgerMock.NewCC("cc", &TestToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	cfg := &proto.Config{
+		Contract: &proto.ContractConfig{
+			Symbol:   "VT",
+			RobotSKI: fixtures_test.RobotHashedCert,
+			Options: &proto.ChaincodeOptions{
+				CheckDenylist: true,
+			},
+		},
+		Token: &proto.TokenConfig{
+			Name:     "VT Token",
+			Decimals: 8,
+			Issuer:   &proto.Wallet{Address: owner.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg = ledgerMock.NewCC("vt", &TestToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	user1.AddBalance("cc", 1000)
+
+	swapKey := "123"
+	hashed := sha3.Sum256([]byte(swapKey))
+
+	txID := user1.SignedInvoke("cc", "swapBegin", "CC", "VT", "450", hex.EncodeToString(hashed[:]))
+	ledgerMock.WaitSwapAnswer("vt", txID, time.Second*5)
+
+	require.NoError(t, user1.AddToList(mock.BlackList))
+
+	err = user1.InvokeWithError("vt", "swapDone", txID, swapKey)
+	require.ErrorContains(t, err, core.ErrAddressDenylisted.Error())
+
+	require.NoError(t, user1.DelFromList(mock.BlackList))
+	user1.AllowedBalanceShouldBe("vt", "CC", 0)
+
+	require.NoError(t, user1.InvokeWithError("vt", "swapDone", txID, swapKey))
+	user1.AllowedBalanceShouldBe("vt", "CC", 450)
+}
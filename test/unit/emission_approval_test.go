@@ -0,0 +1,217 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// TestEmissionApproval checks that emissions above the threshold are executed only after approvals
+func TestEmissionApproval(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+	approver1 := ledgerMock.NewWallet()
+	approver2 := ledgerMock.NewWallet()
+	user := ledgerMock.NewWallet()
+
+	cfg := &proto.Config{
+		Contract: &proto.ContractConfig{
+			Symbol:   testTokenSymbol,
+			RobotSKI: fixtures_test.RobotHashedCert,
+		},
+		Token: &proto.TokenConfig{
+			Name:                      testTokenName,
+			Decimals:                  8,
+			Issuer:                    &proto.Wallet{Address: issuer.Address()},
+			EmissionApprovalThreshold: "1000",
+			EmissionApprovers: []*proto.Wallet{
+				{Address: issuer.Address()},
+				{Address: approver1.Address()},
+				{Address: approver2.Address()},
+			},
+			EmissionRequiredApprovals: 2,
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledgerMock.NewCC(testTokenCCName, &token.BaseToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	t.Run("small emission is executed immediately", func(t *testing.T) {
+		issuer.SignedInvoke(testTokenCCName, "proposeEmission", user.Address(), "1000")
+		user.BalanceShouldBe(testTokenCCName, 1000)
+	})
+
+	t.Run("large emission requires approvals", func(t *testing.T) {
+		proposalID := issuer.SignedInvoke(testTokenCCName, "proposeEmission", user.Address(), "5000")
+		user.BalanceShouldBe(testTokenCCName, 1000)
+
+		proposalShouldBe := func(approvals int, executed bool) {
+			var proposal token.EmissionProposal
+			require.NoError(t, json.Unmarshal([]byte(user.Invoke(testTokenCCName, "emissionProposal", proposalID)), &proposal))
+			require.Equal(t, user.Address(), proposal.Address)
+			require.Equal(t, "5000", proposal.Amount.String())
+			require.Len(t, proposal.Approvals, approvals)
+			require.Equal(t, executed, proposal.Executed)
+		}
+		proposalShouldBe(0, false)
+
+		err := issuer.RawSignedInvokeWithErrorReturned(testTokenCCName, "approveEmission", proposalID)
+		require.ErrorContains(t, err, token.ErrNotEmissionApprover.Error())
+
+		err = user.RawSignedInvokeWithErrorReturned(testTokenCCName, "approveEmission", proposalID)
+		require.ErrorContains(t, err, token.ErrNotEmissionApprover.Error())
+
+		approver1.SignedInvoke(testTokenCCName, "approveEmission", proposalID)
+		proposalShouldBe(1, false)
+		user.BalanceShouldBe(testTokenCCName, 1000)
+
+		err = approver1.RawSignedInvokeWithErrorReturned(testTokenCCName, "approveEmission", proposalID)
+		require.ErrorContains(t, err, token.ErrEmissionAlreadyApproved.Error())
+
+		approver2.SignedInvoke(testTokenCCName, "approveEmission", proposalID)
+		proposalShouldBe(2, true)
+		user.BalanceShouldBe(testTokenCCName, 6000)
+
+		var metadata token.Metadata
+		require.NoError(t, json.Unmarshal([]byte(user.Invoke(testTokenCCName, "metadata")), &metadata))
+		require.Equal(t, "6000", metadata.TotalEmission.String())
+
+		err = approver1.RawSignedInvokeWithErrorReturned(testTokenCCName, "approveEmission", proposalID)
+		require.ErrorContains(t, err, token.ErrEmissionProposalExecuted.Error())
+	})
+
+	t.Run("proposals of one transaction get distinct ids", func(t *testing.T) {
+		propose := core.SignedBatchCall{Method: "proposeEmission", Args: []string{user.Address(), "2000"}}
+		result, err := issuer.SignedBatchInvoke(testTokenCCName, propose, propose)
+		require.NoError(t, err)
+
+		var ids []string
+		require.NoError(t, json.Unmarshal([]byte(result), &ids))
+		require.Len(t, ids, 2)
+		require.NotEqual(t, ids[0], ids[1])
+
+		for _, id := range ids {
+			approver1.SignedInvoke(testTokenCCName, "approveEmission", id)
+			approver2.SignedInvoke(testTokenCCName, "approveEmission", id)
+		}
+		user.BalanceShouldBe(testTokenCCName, 10000)
+	})
+}
+
+// TestEmissionApprovalThreshold checks that the emissions of the token methods other than
+// the emission proposal can not exceed the threshold
+func TestEmissionApprovalThreshold(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+	approver := ledgerMock.NewWallet()
+	user := ledgerMock.NewWallet()
+
+	cfg := &proto.Config{
+		Contract: &proto.ContractConfig{
+			Symbol:   testTokenSymbol,
+			RobotSKI: fixtures_test.RobotHashedCert,
+		},
+		Token: &proto.TokenConfig{
+			Name:                      testTokenName,
+			Decimals:                  8,
+			Issuer:                    &proto.Wallet{Address: issuer.Address()},
+			EmissionApprovalThreshold: "1000",
+			EmissionApprovers:         []*proto.Wallet{{Address: approver.Address()}},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	issuer.SignedInvoke(testTokenCCName, "emissionAdd", user.Address(), "1000")
+	user.BalanceShouldBe(testTokenCCName, 1000)
+
+	err = issuer.RawSignedInvokeWithErrorReturned(testTokenCCName, "emissionAdd", user.Address(), "1001")
+	require.ErrorContains(t, err, token.ErrEmissionApprovalRequired.Error())
+	user.BalanceShouldBe(testTokenCCName, 1000)
+
+	// the approved proposal is the only emission above the threshold
+	proposalID := issuer.SignedInvoke(testTokenCCName, "proposeEmission", user.Address(), "1001")
+	approver.SignedInvoke(testTokenCCName, "approveEmission", proposalID)
+	user.BalanceShouldBe(testTokenCCName, 2001)
+}
+
+// TestEmissionApprovalConfig checks that the config with the emission approval
+// which can not collect the required approvals is rejected
+func TestEmissionApprovalConfig(t *testing.T) {
+	for _, tc := range []struct {
+		name      string
+		approvers int
+		// issuerApprover lists the issuer among the approvers
+		issuerApprover bool
+		required       uint32
+		err            error
+	}{
+		{
+			name: "empty approvers",
+			err:  token.ErrEmptyEmissionApprovers,
+		},
+		{
+			name:           "issuer is the only approver",
+			issuerApprover: true,
+			err:            token.ErrEmptyEmissionApprovers,
+		},
+		{
+			name:      "required approvals above approvers",
+			approvers: 2,
+			required:  3,
+			err:       token.ErrUnreachableEmissionApprovals,
+		},
+		{
+			name:           "issuer is not counted",
+			approvers:      1,
+			issuerApprover: true,
+			required:       2,
+			err:            token.ErrUnreachableEmissionApprovals,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ledgerMock := mock.NewLedger(t)
+			issuer := ledgerMock.NewWallet()
+
+			approvers := make([]*proto.Wallet, 0, tc.approvers)
+			for i := 0; i < tc.approvers; i++ {
+				approvers = append(approvers, &proto.Wallet{Address: ledgerMock.NewWallet().Address()})
+			}
+			if tc.issuerApprover {
+				approvers = append(approvers, &proto.Wallet{Address: issuer.Address()})
+			}
+
+			cfg := &proto.Config{
+				Contract: &proto.ContractConfig{
+					Symbol:   testTokenSymbol,
+					RobotSKI: fixtures_test.RobotHashedCert,
+				},
+				Token: &proto.TokenConfig{
+					Name:                      testTokenName,
+					Decimals:                  8,
+					Issuer:                    &proto.Wallet{Address: issuer.Address()},
+					EmissionApprovalThreshold: "1000",
+					EmissionApprovers:         approvers,
+					EmissionRequiredApprovals: tc.required,
+				},
+			}
+			cfgBytes, err := protojson.Marshal(cfg)
+			require.NoError(t, err)
+
+			initMsg := ledgerMock.NewCC(testTokenCCName, &token.BaseToken{}, string(cfgBytes))
+			require.Contains(t, initMsg, tc.err.Error())
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// TestEmissionDisplayAmount checks that the display amount is emitted in base units
+// according to the token decimals and the over-precise amount is rejected.
+func TestEmissionDisplayAmount(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+	user := ledgerMock.NewWallet()
+
+	cfg := &proto.Config{
+		Contract: &proto.ContractConfig{
+			Symbol:   testTokenSymbol,
+			RobotSKI: fixtures_test.RobotHashedCert,
+		},
+		Token: &proto.TokenConfig{
+			Name:     testTokenName,
+			Decimals: 8,
+			Issuer:   &proto.Wallet{Address: issuer.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	t.Run("fractional amount", func(t *testing.T) {
+		issuer.SignedInvoke(testTokenCCName, "emissionAddDisplay", user.Address(), "10.5")
+		user.BalanceShouldBe(testTokenCCName, 1050000000)
+	})
+
+	t.Run("integer amount", func(t *testing.T) {
+		issuer.SignedInvoke(testTokenCCName, "emissionAddDisplay", user.Address(), "2")
+		user.BalanceShouldBe(testTokenCCName, 1250000000)
+	})
+
+	t.Run("over-precise amount", func(t *testing.T) {
+		err := issuer.RawSignedInvokeWithErrorReturned(testTokenCCName, "emissionAddDisplay", user.Address(), "10.123456789")
+		require.ErrorContains(t, err, token.ErrDisplayAmountTooPrecise.Error())
+		user.BalanceShouldBe(testTokenCCName, 1250000000)
+	})
+
+	t.Run("invalid amount", func(t *testing.T) {
+		for _, amount := range []string{"", "-1", "1.", ".5", "1,5", "1.2.3"} {
+			err := issuer.RawSignedInvokeWithErrorReturned(testTokenCCName, "emissionAddDisplay", user.Address(), amount)
+			require.ErrorContains(t, err, token.ErrInvalidDisplayAmount.Error(), amount)
+		}
+		user.BalanceShouldBe(testTokenCCName, 1250000000)
+	})
+}
@@ -0,0 +1,62 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+)
+
+// TestEmissionHistory checks that the emissions are listed in the order they are made.
+func TestEmissionHistory(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	fiatConfig := makeBaseTokenConfig("fiat", "FIAT", 8, owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("fiat", NewFiatTestToken(token.BaseToken{}), fiatConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user2 := ledger.NewWallet()
+
+	emissions := []struct {
+		recipient *mock.Wallet
+		amount    string
+	}{
+		{user1, "100"},
+		{user2, "200"},
+		{user1, "300"},
+	}
+	for _, emission := range emissions {
+		require.NoError(t, owner.RawSignedInvokeWithErrorReturned("fiat", "emit", emission.recipient.Address(), emission.amount))
+	}
+
+	history := &token.EmissionHistory{}
+	require.NoError(t, json.Unmarshal([]byte(owner.Invoke("fiat", "emissionHistory", "10", "")), history))
+	require.Len(t, history.Records, len(emissions))
+	require.Empty(t, history.Bookmark)
+
+	txIDs := map[string]struct{}{}
+	for i, emission := range emissions {
+		record := history.Records[i]
+		require.Equal(t, emission.recipient.Address(), record.Recipient)
+		require.Equal(t, emission.amount, record.Amount.String())
+		require.NotZero(t, record.Timestamp)
+		require.NotEmpty(t, record.TxID)
+		txIDs[record.TxID] = struct{}{}
+	}
+	require.Len(t, txIDs, len(emissions))
+
+	t.Run("pages", func(t *testing.T) {
+		first := &token.EmissionHistory{}
+		require.NoError(t, json.Unmarshal([]byte(owner.Invoke("fiat", "emissionHistory", "2", "")), first))
+		require.Equal(t, history.Records[:2], first.Records)
+		require.NotEmpty(t, first.Bookmark)
+
+		second := &token.EmissionHistory{}
+		require.NoError(t, json.Unmarshal([]byte(owner.Invoke("fiat", "emissionHistory", "2", first.Bookmark)), second))
+		require.Equal(t, history.Records[2:], second.Records)
+	})
+}
@@ -0,0 +1,97 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/anoideaopen/foundation/mock"
+	pb "github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func newEmissionKeyLedger(t *testing.T, sequential bool) (*mock.Ledger, *mock.Wallet) {
+	ledger := mock.NewLedger(t)
+	issuer := ledger.NewWallet()
+
+	cfg := &pb.Config{
+		Contract: &pb.ContractConfig{
+			Symbol:   "FIAT",
+			RobotSKI: fixtures_test.RobotHashedCert,
+		},
+		Token: &pb.TokenConfig{
+			Name:                   "FIAT",
+			Decimals:               8,
+			Issuer:                 &pb.Wallet{Address: issuer.Address()},
+			SequentialEmissionKeys: sequential,
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledger.NewCC("fiat", NewFiatTestToken(token.BaseToken{}), string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	return ledger, issuer
+}
+
+func TestEmissionKeyIsUsedOnce(t *testing.T) {
+	ledger, issuer := newEmissionKeyLedger(t, false)
+	user := ledger.NewWallet()
+
+	issuer.SignedInvoke("fiat", "emitWithKey", "order-b", user.Address(), "100")
+	issuer.SignedInvoke("fiat", "emitWithKey", "order-a", user.Address(), "100")
+
+	err := issuer.RawSignedInvokeWithErrorReturned("fiat", "emitWithKey", "order-a", user.Address(), "100")
+	require.ErrorContains(t, err, token.ErrEmissionKeyUsed.Error())
+
+	err = issuer.RawSignedInvokeWithErrorReturned("fiat", "emitWithKey", "", user.Address(), "100")
+	require.ErrorContains(t, err, token.ErrEmptyEmissionKey.Error())
+
+	user.BalanceShouldBe("fiat", 200)
+}
+
+func TestSequentialEmissionKeys(t *testing.T) {
+	t.Run("in-order sequence is accepted", func(t *testing.T) {
+		ledger, issuer := newEmissionKeyLedger(t, true)
+		user := ledger.NewWallet()
+
+		for _, key := range []string{"1", "2", "3"} {
+			issuer.SignedInvoke("fiat", "emitWithKey", key, user.Address(), "100")
+		}
+
+		user.BalanceShouldBe("fiat", 300)
+	})
+
+	for _, test := range []struct {
+		name string
+		keys []string
+		bad  string
+	}{
+		{name: "sequence not starting from 1 is rejected", bad: "2"},
+		{name: "gap is rejected", keys: []string{"1", "2"}, bad: "4"},
+		{name: "repeat is rejected", keys: []string{"1", "2"}, bad: "2"},
+		{name: "decrease is rejected", keys: []string{"1", "2"}, bad: "1"},
+		{name: "leading zero is rejected", keys: []string{"1"}, bad: "02"},
+		{name: "not integer is rejected", keys: []string{"1"}, bad: "order-2"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ledger, issuer := newEmissionKeyLedger(t, true)
+			user := ledger.NewWallet()
+
+			for _, key := range test.keys {
+				issuer.SignedInvoke("fiat", "emitWithKey", key, user.Address(), "100")
+			}
+
+			err := issuer.RawSignedInvokeWithErrorReturned("fiat", "emitWithKey", test.bad, user.Address(), "100")
+			require.ErrorContains(t, err, token.ErrEmissionKeyNotSequential.Error())
+
+			user.BalanceShouldBe("fiat", uint64(100*len(test.keys)))
+
+			next := []string{"1", "2", "3"}[len(test.keys)]
+			issuer.SignedInvoke("fiat", "emitWithKey", next, user.Address(), "100")
+			user.BalanceShouldBe("fiat", uint64(100*(len(test.keys)+1)))
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// TestMaxEmissionPerAddress checks that the total amount emitted to an address
+// is limited across transactions while other addresses keep their own limit.
+func TestMaxEmissionPerAddress(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+	user1 := ledgerMock.NewWallet()
+	user2 := ledgerMock.NewWallet()
+
+	cfg := &proto.Config{
+		Contract: &proto.ContractConfig{
+			Symbol:   testTokenSymbol,
+			RobotSKI: fixtures_test.RobotHashedCert,
+		},
+		Token: &proto.TokenConfig{
+			Name:                  testTokenName,
+			Decimals:              8,
+			Issuer:                &proto.Wallet{Address: issuer.Address()},
+			MaxEmissionPerAddress: "1000",
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	t.Run("emission up to the limit", func(t *testing.T) {
+		issuer.SignedInvoke(testTokenCCName, "emissionAdd", user1.Address(), "600")
+		issuer.SignedInvoke(testTokenCCName, "emissionAdd", user1.Address(), "400")
+		user1.BalanceShouldBe(testTokenCCName, 1000)
+	})
+
+	t.Run("emission beyond the limit", func(t *testing.T) {
+		err := issuer.RawSignedInvokeWithErrorReturned(testTokenCCName, "emissionAdd", user1.Address(), "1")
+		require.ErrorContains(t, err, token.ErrEmissionPerAddressExceeded.Error())
+		user1.BalanceShouldBe(testTokenCCName, 1000)
+	})
+
+	t.Run("counter is not reduced by transfer", func(t *testing.T) {
+		user1.SignedInvoke(testTokenCCName, "transfer", user2.Address(), "500", "")
+		user1.BalanceShouldBe(testTokenCCName, 500)
+
+		err := issuer.RawSignedInvokeWithErrorReturned(testTokenCCName, "emissionAdd", user1.Address(), "100")
+		require.ErrorContains(t, err, token.ErrEmissionPerAddressExceeded.Error())
+	})
+
+	t.Run("limit is per address", func(t *testing.T) {
+		issuer.SignedInvoke(testTokenCCName, "emissionAdd", user2.Address(), "500")
+		user2.BalanceShouldBe(testTokenCCName, 1000)
+
+		err := issuer.RawSignedInvokeWithErrorReturned(testTokenCCName, "emissionAdd", user2.Address(), "501")
+		require.ErrorContains(t, err, token.ErrEmissionPerAddressExceeded.Error())
+	})
+}
+
+// TestMaxEmissionPerAddressSetLater checks that the limit set by the upgrade of the chaincode
+// counts the amounts emitted to the address before the limit is set.
+func TestMaxEmissionPerAddressSetLater(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+	user1 := ledgerMock.NewWallet()
+
+	makeConfig := func(limit string) string {
+		cfg := &proto.Config{
+			Contract: &proto.ContractConfig{
+				Symbol:   testTokenSymbol,
+				RobotSKI: fixtures_test.RobotHashedCert,
+			},
+			Token: &proto.TokenConfig{
+				Name:                  testTokenName,
+				Decimals:              8,
+				Issuer:                &proto.Wallet{Address: issuer.Address()},
+				MaxEmissionPerAddress: limit,
+			},
+		}
+		cfgBytes, err := protojson.Marshal(cfg)
+		require.NoError(t, err)
+
+		return string(cfgBytes)
+	}
+
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, makeConfig(""))
+	require.Empty(t, initMsg)
+
+	issuer.SignedInvoke(testTokenCCName, "emissionAdd", user1.Address(), "600")
+
+	require.Empty(t, ledgerMock.UpgradeCC(testTokenCCName, &TestToken{}, makeConfig("1000")))
+
+	err := issuer.RawSignedInvokeWithErrorReturned(testTokenCCName, "emissionAdd", user1.Address(), "401")
+	require.ErrorContains(t, err, token.ErrEmissionPerAddressExceeded.Error())
+
+	issuer.SignedInvoke(testTokenCCName, "emissionAdd", user1.Address(), "400")
+	user1.BalanceShouldBe(testTokenCCName, 1000)
+}
+
+func TestInvalidMaxEmissionPerAddress(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+
+	cfg := &proto.Config{
+		Contract: &proto.ContractConfig{
+			Symbol:   testTokenSymbol,
+			RobotSKI: fixtures_test.RobotHashedCert,
+		},
+		Token: &proto.TokenConfig{
+			Name:                  testTokenName,
+			Decimals:              8,
+			Issuer:                &proto.Wallet{Address: issuer.Address()},
+			MaxEmissionPerAddress: "-1",
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, string(cfgBytes))
+	require.Contains(t, initMsg, token.ErrInvalidMaxEmissionPerAddress.Error())
+}
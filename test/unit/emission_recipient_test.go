@@ -0,0 +1,62 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/anoideaopen/foundation/mock"
+	pb "github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestRequireRegisteredEmissionRecipient(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		require bool
+	}{
+		{name: "emission to unregistered address is allowed with the flag off"},
+		{name: "emission to unregistered address is rejected with the flag on", require: true},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ledger := mock.NewLedger(t)
+			issuer := ledger.NewWallet()
+
+			cfg := &pb.Config{
+				Contract: &pb.ContractConfig{
+					Symbol:   "FIAT",
+					RobotSKI: fixtures_test.RobotHashedCert,
+				},
+				Token: &pb.TokenConfig{
+					Name:                               "FIAT",
+					Decimals:                           8,
+					Issuer:                             &pb.Wallet{Address: issuer.Address()},
+					RequireRegisteredEmissionRecipient: test.require,
+				},
+			}
+			cfgBytes, err := protojson.Marshal(cfg)
+			require.NoError(t, err)
+
+			initMsg := ledger.NewCC("fiat", NewFiatTestToken(token.BaseToken{}), string(cfgBytes))
+			require.Empty(t, initMsg)
+
+			registered := ledger.NewWallet()
+			issuer.SignedInvoke("fiat", "emit", registered.Address(), "1000")
+			registered.BalanceShouldBe("fiat", 1000)
+
+			unregistered := ledger.NewWallet()
+			unregistered.Unregister()
+
+			err = issuer.RawSignedInvokeWithErrorReturned("fiat", "emit", unregistered.Address(), "1000")
+			if !test.require {
+				require.NoError(t, err)
+				unregistered.BalanceShouldBe("fiat", 1000)
+				return
+			}
+
+			require.ErrorContains(t, err, token.ErrRecipientNotRegistered.Error())
+			unregistered.BalanceShouldBe("fiat", 0)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestEventNamePrefix(t *testing.T) {
+	const prefix = "tt."
+
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+	user1 := ledgerMock.NewWallet()
+	user2 := ledgerMock.NewWallet()
+
+	cfg := &proto.Config{
+		Contract: &proto.ContractConfig{
+			Symbol:   testTokenSymbol,
+			RobotSKI: fixtures_test.RobotHashedCert,
+			Options: &proto.ChaincodeOptions{
+				EventNamePrefix: prefix,
+			},
+		},
+		Token: &proto.TokenConfig{
+			Name:     testTokenName,
+			Decimals: 8,
+			Issuer:   &proto.Wallet{Address: issuer.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	user1.AddBalance(testTokenCCName, 1000)
+
+	_, resp, _ := user1.RawSignedInvoke(testTokenCCName, "transfer", user2.Address(), "400", "")
+	require.Empty(t, resp.Error)
+	require.NotContains(t, resp.Events, token.TransferEvent)
+	require.Contains(t, resp.Events, prefix+token.TransferEvent)
+
+	var event token.TransferredEvent
+	require.NoError(t, json.Unmarshal(resp.Events[prefix+token.TransferEvent], &event))
+	require.Equal(t, user1.Address(), event.From)
+	require.Equal(t, user2.Address(), event.To)
+	require.Equal(t, "400", event.Amount.String())
+}
+
+func TestEventNameWithoutPrefix(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+	user1 := ledgerMock.NewWallet()
+	user2 := ledgerMock.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8, issuer.Address(), "", "", "", nil)
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, config)
+	require.Empty(t, initMsg)
+
+	user1.AddBalance(testTokenCCName, 1000)
+
+	_, resp, _ := user1.RawSignedInvoke(testTokenCCName, "transfer", user2.Address(), "400", "")
+	require.Empty(t, resp.Error)
+	require.Contains(t, resp.Events, token.TransferEvent)
+}
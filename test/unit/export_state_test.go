@@ -0,0 +1,225 @@
+package unit
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/core/balance"
+	"github.com/anoideaopen/foundation/core/types/big"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testExportStateFnName = "exportState"
+	testImportStateFnName = "importState"
+)
+
+// TestExportImportState exports the state of a populated ledger page by page
+// and imports it into a fresh ledger.
+func TestExportImportState(t *testing.T) {
+	ledgerFrom := mock.NewLedger(t)
+	issuer := ledgerFrom.NewWallet()
+	admin := ledgerFrom.NewWallet()
+	user1 := ledgerFrom.NewWallet()
+	user2 := ledgerFrom.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		issuer.Address(), "", "", admin.Address(), nil)
+
+	initMsg := ledgerFrom.NewCC(testTokenCCName, &TestToken{}, config)
+	require.Empty(t, initMsg)
+
+	issuer.SignedInvoke(testTokenCCName, "emissionAdd", user1.Address(), "1000")
+	issuer.SignedInvoke(testTokenCCName, "emissionAdd", user2.Address(), "500")
+	user1.AddAllowedBalance(testTokenCCName, "USD", 300)
+	user2.AddAllowedBalance(testTokenCCName, "EUR", 200)
+	_ = user1.SignedInvoke(testTokenCCName, "channelTransferByCustomer", uuid.NewString(), "VT", testTokenSymbol, "100")
+
+	t.Run("export is allowed to admin only", func(t *testing.T) {
+		err := user1.InvokeWithError(testTokenCCName, testExportStateFnName,
+			user1.SignArgs(testTokenCCName, testExportStateFnName, "10", "")...)
+		require.ErrorContains(t, err, core.ErrUnauthorisedNotAdmin.Error())
+	})
+
+	var pages []string
+	bookmark := ""
+	for {
+		resp := admin.Invoke(testTokenCCName, testExportStateFnName,
+			admin.SignArgs(testTokenCCName, testExportStateFnName, "3", bookmark)...)
+
+		var page token.State
+		require.NoError(t, json.Unmarshal([]byte(resp), &page))
+		require.LessOrEqual(t, len(page.Balances), 3)
+		require.Equal(t, "1500", page.TotalEmission.String())
+
+		pages = append(pages, resp)
+		if page.Bookmark == "" {
+			break
+		}
+		bookmark = page.Bookmark
+	}
+	require.Len(t, pages, 2)
+
+	ledgerTo := mock.NewLedger(t)
+	adminTo := ledgerTo.NewWalletFromHexKey(hex.EncodeToString(admin.SecretKey()))
+	require.Equal(t, admin.Address(), adminTo.Address())
+
+	initMsg = ledgerTo.NewCC(testTokenCCName, &TestToken{}, config)
+	require.Empty(t, initMsg)
+
+	t.Run("page with wrong total is rejected", func(t *testing.T) {
+		var page token.State
+		require.NoError(t, json.Unmarshal([]byte(pages[0]), &page))
+		page.Total.SetInt64(1)
+		data, err := json.Marshal(page)
+		require.NoError(t, err)
+
+		err = adminTo.RawSignedInvokeWithErrorReturned(testTokenCCName, testImportStateFnName, string(data))
+		require.ErrorContains(t, err, token.ErrStateTotalMismatch.Error())
+	})
+
+	// import twice to check that import is idempotent
+	for i := 0; i < 2; i++ {
+		for _, page := range pages {
+			err := adminTo.RawSignedInvokeWithErrorReturned(testTokenCCName, testImportStateFnName, page)
+			require.NoError(t, err)
+		}
+	}
+
+	require.Equal(t, "1500", ledgerTo.Metadata(testTokenCCName).TotalEmission.String())
+	require.Equal(t, "\"900\"", adminTo.Invoke(testTokenCCName, "balanceOf", user1.Address()))
+	require.Equal(t, "\"500\"", adminTo.Invoke(testTokenCCName, "balanceOf", user2.Address()))
+	require.Equal(t, "\"300\"", adminTo.Invoke(testTokenCCName, "allowedBalanceOf", user1.Address(), "USD"))
+	require.Equal(t, "\"200\"", adminTo.Invoke(testTokenCCName, "allowedBalanceOf", user2.Address(), "EUR"))
+
+	t.Run("page is rejected after the import is completed", func(t *testing.T) {
+		data, err := json.Marshal(token.State{
+			TotalEmission: big.NewInt(1500),
+			Balances: []token.StateBalance{{
+				Type:    balance.BalanceTypeAllowed.String(),
+				Address: user1.Address(),
+				Token:   "GBP",
+				Amount:  big.NewInt(1),
+			}},
+			Total: big.NewInt(1),
+		})
+		require.NoError(t, err)
+
+		err = adminTo.RawSignedInvokeWithErrorReturned(testTokenCCName, testImportStateFnName, string(data))
+		require.ErrorContains(t, err, token.ErrStateImportCompleted.Error())
+	})
+
+	var imported token.State
+	require.NoError(t, json.Unmarshal([]byte(adminTo.Invoke(testTokenCCName, testExportStateFnName,
+		adminTo.SignArgs(testTokenCCName, testExportStateFnName, "10", "")...)), &imported))
+	require.Contains(t, imported.Balances, token.StateBalance{
+		Type:    balance.BalanceTypeGiven.String(),
+		Address: "VT",
+		Amount:  big.NewInt(100),
+	})
+
+	t.Run("balance with invalid key is rejected", func(t *testing.T) {
+		for balanceType, key := range map[balance.BalanceType]string{
+			balance.BalanceTypeToken: "VT",
+			balance.BalanceTypeGiven: "vt",
+		} {
+			data, err := json.Marshal(token.State{
+				TotalEmission: big.NewInt(1500),
+				Balances: []token.StateBalance{{
+					Type:    balanceType.String(),
+					Address: key,
+					Amount:  big.NewInt(1),
+				}},
+				Total: big.NewInt(1),
+			})
+			require.NoError(t, err)
+
+			err = adminTo.RawSignedInvokeWithErrorReturned(testTokenCCName, testImportStateFnName, string(data))
+			require.ErrorContains(t, err, token.ErrInvalidStateBalanceKey.Error())
+		}
+	})
+}
+
+// TestImportStateFreshLedger checks that the state is imported into the fresh ledger only
+// and the imported balances match the total emission.
+func TestImportStateFreshLedger(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+	admin := ledgerMock.NewWallet()
+	user1 := ledgerMock.NewWallet()
+	user2 := ledgerMock.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		issuer.Address(), "", "", admin.Address(), nil)
+
+	statePage := func(totalEmission int64, bookmark string, balances ...token.StateBalance) string {
+		total := big.NewInt(0)
+		for _, b := range balances {
+			total.Add(total, b.Amount)
+		}
+
+		data, err := json.Marshal(token.State{
+			TotalEmission: big.NewInt(totalEmission),
+			Balances:      balances,
+			Total:         total,
+			Bookmark:      bookmark,
+		})
+		require.NoError(t, err)
+
+		return string(data)
+	}
+
+	tokenBalance := func(user *mock.Wallet, amount int64) token.StateBalance {
+		return token.StateBalance{
+			Type:    balance.BalanceTypeToken.String(),
+			Address: user.Address(),
+			Amount:  big.NewInt(amount),
+		}
+	}
+
+	t.Run("ledger with balances is rejected", func(t *testing.T) {
+		initMsg := ledgerMock.NewCC("used", &TestToken{}, config)
+		require.Empty(t, initMsg)
+		user2.AddBalance("used", 10)
+
+		err := admin.RawSignedInvokeWithErrorReturned("used", testImportStateFnName,
+			statePage(100, "", tokenBalance(user1, 100)))
+		require.ErrorContains(t, err, token.ErrStateNotFresh.Error())
+	})
+
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, config)
+	require.Empty(t, initMsg)
+
+	first := statePage(1000, "2b|next", tokenBalance(user1, 600))
+	require.NoError(t, admin.RawSignedInvokeWithErrorReturned(testTokenCCName, testImportStateFnName, first))
+	require.NoError(t, admin.RawSignedInvokeWithErrorReturned(testTokenCCName, testImportStateFnName, first))
+
+	t.Run("page of another emission is rejected", func(t *testing.T) {
+		err := admin.RawSignedInvokeWithErrorReturned(testTokenCCName, testImportStateFnName,
+			statePage(2000, "", tokenBalance(user2, 400)))
+		require.ErrorContains(t, err, token.ErrStateEmissionMismatch.Error())
+	})
+
+	t.Run("imported balance is not overwritten", func(t *testing.T) {
+		err := admin.RawSignedInvokeWithErrorReturned(testTokenCCName, testImportStateFnName,
+			statePage(1000, "2b|other", tokenBalance(user1, 700)))
+		require.ErrorContains(t, err, token.ErrStateBalanceExists.Error())
+	})
+
+	t.Run("last page not matching the emission is rejected", func(t *testing.T) {
+		err := admin.RawSignedInvokeWithErrorReturned(testTokenCCName, testImportStateFnName,
+			statePage(1000, "", tokenBalance(user2, 300)))
+		require.ErrorContains(t, err, token.ErrStateEmissionSum.Error())
+	})
+
+	require.NoError(t, admin.RawSignedInvokeWithErrorReturned(testTokenCCName, testImportStateFnName,
+		statePage(1000, "", tokenBalance(user2, 400))))
+	user1.BalanceShouldBe(testTokenCCName, 600)
+	user2.BalanceShouldBe(testTokenCCName, 400)
+	require.Equal(t, "1000", ledgerMock.Metadata(testTokenCCName).TotalEmission.String())
+}
@@ -0,0 +1,107 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/anoideaopen/foundation/mock"
+	pb "github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestDeductFeeFromAmount(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		deduct    bool
+		sender    uint64
+		recipient uint64
+	}{
+		{name: "fee is charged on top of the amount", sender: 1000 - 100 - 10, recipient: 100},
+		{name: "fee is deducted from the amount", deduct: true, sender: 1000 - 100, recipient: 100 - 10},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ledger := mock.NewLedger(t)
+			owner := ledger.NewWallet()
+			feeSetter := ledger.NewWallet()
+			feeAddressSetter := ledger.NewWallet()
+			feeAggregator := ledger.NewWallet()
+
+			cfg := &pb.Config{
+				Contract: &pb.ContractConfig{
+					Symbol:   "FIAT",
+					RobotSKI: fixtures_test.RobotHashedCert,
+				},
+				Token: &pb.TokenConfig{
+					Name:                "FIAT",
+					Decimals:            8,
+					Issuer:              &pb.Wallet{Address: owner.Address()},
+					FeeSetter:           &pb.Wallet{Address: feeSetter.Address()},
+					FeeAddressSetter:    &pb.Wallet{Address: feeAddressSetter.Address()},
+					DeductFeeFromAmount: test.deduct,
+				},
+			}
+			cfgBytes, err := protojson.Marshal(cfg)
+			require.NoError(t, err)
+
+			initMsg := ledger.NewCC("fiat", NewFiatTestToken(token.BaseToken{}), string(cfgBytes))
+			require.Empty(t, initMsg)
+
+			user1 := ledger.NewWallet()
+			user2 := ledger.NewWallet()
+
+			owner.SignedInvoke("fiat", "emit", user1.Address(), "1000")
+
+			feeAddressSetter.SignedInvoke("fiat", "setFeeAddress", feeAggregator.Address())
+			// 10% fee without floor and cap
+			feeSetter.SignedInvoke("fiat", "setFee", "FIAT", "10000000", "0", "0")
+
+			user1.SignedInvoke("fiat", "transfer", user2.Address(), "100", "")
+			user1.BalanceShouldBe("fiat", test.sender)
+			user2.BalanceShouldBe("fiat", test.recipient)
+			feeAggregator.BalanceShouldBe("fiat", 10)
+		})
+	}
+
+	t.Run("fee not less than the amount is rejected", func(t *testing.T) {
+		ledger := mock.NewLedger(t)
+		owner := ledger.NewWallet()
+		feeAggregator := ledger.NewWallet()
+
+		cfg := &pb.Config{
+			Contract: &pb.ContractConfig{
+				Symbol:   "FIAT",
+				RobotSKI: fixtures_test.RobotHashedCert,
+			},
+			Token: &pb.TokenConfig{
+				Name:                "FIAT",
+				Decimals:            8,
+				Issuer:              &pb.Wallet{Address: owner.Address()},
+				FeeSetter:           &pb.Wallet{Address: owner.Address()},
+				FeeAddressSetter:    &pb.Wallet{Address: owner.Address()},
+				DeductFeeFromAmount: true,
+			},
+		}
+		cfgBytes, err := protojson.Marshal(cfg)
+		require.NoError(t, err)
+
+		initMsg := ledger.NewCC("fiat", NewFiatTestToken(token.BaseToken{}), string(cfgBytes))
+		require.Empty(t, initMsg)
+
+		user1 := ledger.NewWallet()
+		user2 := ledger.NewWallet()
+
+		owner.SignedInvoke("fiat", "emit", user1.Address(), "1000")
+		owner.SignedInvoke("fiat", "setFeeAddress", feeAggregator.Address())
+		// 10% fee with the floor of 100
+		owner.SignedInvoke("fiat", "setFee", "FIAT", "10000000", "100", "0")
+
+		err = user1.RawSignedInvokeWithErrorReturned("fiat", "transfer", user2.Address(), "100", "")
+		require.ErrorContains(t, err, token.ErrFeeExceedsAmount.Error())
+
+		user1.BalanceShouldBe("fiat", 1000)
+		user2.BalanceShouldBe("fiat", 0)
+		feeAggregator.BalanceShouldBe("fiat", 0)
+	})
+}
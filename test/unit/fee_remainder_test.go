@@ -0,0 +1,70 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/anoideaopen/foundation/mock"
+	pb "github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestAccumulateFeeRemainders(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		accumulate bool
+		collected  uint64
+	}{
+		{name: "fees rounded down to zero are not collected", collected: 0},
+		{name: "fee remainders are collected once they sum up to a base unit", accumulate: true, collected: 1},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ledger := mock.NewLedger(t)
+			owner := ledger.NewWallet()
+			feeSetter := ledger.NewWallet()
+			feeAddressSetter := ledger.NewWallet()
+			feeAggregator := ledger.NewWallet()
+
+			cfg := &pb.Config{
+				Contract: &pb.ContractConfig{
+					Symbol:   "FIAT",
+					RobotSKI: fixtures_test.RobotHashedCert,
+				},
+				Token: &pb.TokenConfig{
+					Name:                    "FIAT",
+					Decimals:                8,
+					Issuer:                  &pb.Wallet{Address: owner.Address()},
+					FeeSetter:               &pb.Wallet{Address: feeSetter.Address()},
+					FeeAddressSetter:        &pb.Wallet{Address: feeAddressSetter.Address()},
+					AccumulateFeeRemainders: test.accumulate,
+				},
+			}
+			cfgBytes, err := protojson.Marshal(cfg)
+			require.NoError(t, err)
+
+			initMsg := ledger.NewCC("fiat", NewFiatTestToken(token.BaseToken{}), string(cfgBytes))
+			require.Empty(t, initMsg)
+
+			user1 := ledger.NewWallet()
+			user2 := ledger.NewWallet()
+
+			owner.SignedInvoke("fiat", "emit", user1.Address(), "1000")
+
+			feeAddressSetter.SignedInvoke("fiat", "setFeeAddress", feeAggregator.Address())
+			// 1% fee without floor and cap, the fee of 15 is 0.15 of the base unit
+			feeSetter.SignedInvoke("fiat", "setFee", "FIAT", "1000000", "0", "0")
+
+			for i := 0; i < 6; i++ {
+				user1.SignedInvoke("fiat", "transfer", user2.Address(), "15", "")
+			}
+			feeAggregator.BalanceShouldBe("fiat", 0)
+
+			user1.SignedInvoke("fiat", "transfer", user2.Address(), "15", "")
+			feeAggregator.BalanceShouldBe("fiat", test.collected)
+			user1.BalanceShouldBe("fiat", 1000-7*15-test.collected)
+			user2.BalanceShouldBe("fiat", 7*15)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFrozenAddresses(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	admin := ledgerMock.NewWallet()
+	user1 := ledgerMock.NewWallet()
+	user2 := ledgerMock.NewWallet()
+	user3 := ledgerMock.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		admin.Address(), "", "", admin.Address(), nil)
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, config)
+	require.Empty(t, initMsg)
+
+	user1.AddBalance(testTokenCCName, 1000)
+
+	t.Run("addresses can be frozen by admin only", func(t *testing.T) {
+		err := user1.RawSignedInvokeWithErrorReturned(testTokenCCName, "freezeAddress", user2.Address())
+		require.ErrorContains(t, err, core.ErrUnauthorisedNotAdmin.Error())
+	})
+
+	for _, user := range []*mock.Wallet{user1, user2, user3} {
+		admin.SignedInvoke(testTokenCCName, "freezeAddress", user.Address())
+	}
+
+	t.Run("frozen address can not transfer", func(t *testing.T) {
+		err := user1.RawSignedInvokeWithErrorReturned(testTokenCCName, "transfer", user2.Address(), "100", "")
+		require.ErrorContains(t, err, core.ErrAddressFrozen.Error())
+		user1.BalanceShouldBe(testTokenCCName, 1000)
+	})
+
+	admin.SignedInvoke(testTokenCCName, "unfreezeAddress", user2.Address())
+
+	t.Run("unfrozen address is not listed", func(t *testing.T) {
+		var frozen core.FrozenAddresses
+		require.NoError(t, json.Unmarshal([]byte(admin.Invoke(testTokenCCName, "frozenAddresses", "10", "")), &frozen))
+		require.ElementsMatch(t, []string{user1.Address(), user3.Address()}, frozen.Addresses)
+		require.Empty(t, frozen.Bookmark)
+	})
+
+	t.Run("listing is paginated", func(t *testing.T) {
+		var (
+			addresses []string
+			bookmark  string
+		)
+		for {
+			var frozen core.FrozenAddresses
+			require.NoError(t, json.Unmarshal([]byte(admin.Invoke(testTokenCCName, "frozenAddresses", "1", bookmark)), &frozen))
+			addresses = append(addresses, frozen.Addresses...)
+			if frozen.Bookmark == "" {
+				break
+			}
+			bookmark = frozen.Bookmark
+		}
+		require.ElementsMatch(t, []string{user1.Address(), user3.Address()}, addresses)
+	})
+}
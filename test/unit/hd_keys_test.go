@@ -0,0 +1,84 @@
+package unit
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/anoideaopen/foundation/keys"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/stretchr/testify/require"
+)
+
+// SLIP-0010 test vector 1
+const hdTestSeed = "000102030405060708090a0b0c0d0e0f"
+
+func TestDeriveKeysFromSeed(t *testing.T) {
+	seed, err := hex.DecodeString(hdTestSeed)
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		keyType    proto.KeyType
+		path       string
+		privateKey string
+	}{
+		{proto.KeyType_ed25519, "m", "2b4be7f19ee27bbf30c667b642d5f4aa69fd169872f8fc3059c08ebae2eb19e7"},
+		{proto.KeyType_ed25519, "m/0'", "68e0fe46dfb67e368c75379acec591dad19df3cde26e63b93a8e704f1dade7a3"},
+		{proto.KeyType_ed25519, "m/0'/1'/2'/2'/1000000000'", "8f94d394a8e8fd6b1bc2f3f49f5c47e385281d5c17e65324b0f62483e37e8793"},
+		{proto.KeyType_secp256k1, "m", "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35"},
+		{proto.KeyType_secp256k1, "m/0h", "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea"},
+		{proto.KeyType_secp256k1, "m/0h/1", "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368"},
+	} {
+		t.Run(tc.keyType.String()+" "+tc.path, func(t *testing.T) {
+			k, err := keys.DeriveKeysFromSeed(tc.keyType, seed, tc.path)
+			require.NoError(t, err)
+
+			privateKey := k.PrivateKeyBytes
+			if tc.keyType == proto.KeyType_ed25519 {
+				privateKey = k.PrivateKeyEd25519.Seed()
+			}
+			require.Equal(t, tc.privateKey, hex.EncodeToString(privateKey))
+		})
+	}
+}
+
+func TestDeriveKeysFromSeedErrors(t *testing.T) {
+	seed, err := hex.DecodeString(hdTestSeed)
+	require.NoError(t, err)
+
+	_, err = keys.DeriveKeysFromSeed(proto.KeyType_ed25519, seed, "m/0")
+	require.ErrorIs(t, err, keys.ErrNotHardenedIndex)
+
+	_, err = keys.DeriveKeysFromSeed(proto.KeyType_gost, seed, "m/0'")
+	require.ErrorIs(t, err, keys.ErrHDKeyTypeNotSupported)
+
+	for _, path := range []string{"", "0'", "m/", "m/x'", "m/2147483648"} {
+		_, err = keys.DeriveKeysFromSeed(proto.KeyType_ed25519, seed, path)
+		require.ErrorIs(t, err, keys.ErrInvalidDerivationPath, path)
+	}
+}
+
+// TestHDWallets checks that child keys derived from one seed have different addresses
+// and both sign valid transactions.
+func TestHDWallets(t *testing.T) {
+	seed, err := hex.DecodeString(hdTestSeed)
+	require.NoError(t, err)
+
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+	user1 := ledgerMock.NewWalletFromSeed(seed, "m/44'/0'/0'")
+	user2 := ledgerMock.NewWalletFromSeed(seed, "m/44'/0'/1'")
+	require.NotEqual(t, user1.Address(), user2.Address())
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8, issuer.Address(), "", "", "", nil)
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, config)
+	require.Empty(t, initMsg)
+
+	issuer.SignedInvoke(testTokenCCName, "emissionAdd", user1.Address(), "1000")
+
+	user1.SignedInvoke(testTokenCCName, "transfer", user2.Address(), "400", "")
+	user2.SignedInvoke(testTokenCCName, "transfer", user1.Address(), "100", "")
+
+	user1.BalanceShouldBe(testTokenCCName, 700)
+	user2.BalanceShouldBe(testTokenCCName, 300)
+}
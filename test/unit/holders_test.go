@@ -0,0 +1,58 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryHolders(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	fiat := NewFiatTestToken(token.BaseToken{})
+	fiatConfig := makeBaseTokenConfig("fiat token", "FIAT", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("fiat", fiat, fiatConfig)
+	require.Empty(t, initMsg)
+
+	holders := make([]*mock.Wallet, 0, 4)
+	for i := 0; i < 4; i++ {
+		w := ledger.NewWallet()
+		owner.SignedInvoke("fiat", "emit", w.Address(), "100")
+		holders = append(holders, w)
+	}
+
+	// the first holder sends everything to the second one
+	holders[0].SignedInvoke("fiat", "transfer", holders[1].Address(), "100", "")
+	holders[0].BalanceShouldBe("fiat", 0)
+
+	// zero balance stored explicitly
+	empty := ledger.NewWallet()
+	empty.AddBalance("fiat", 0)
+
+	listHolders := func(pageSize string) []string {
+		addresses := []string{}
+		bookmark := ""
+		for {
+			res := new(token.Holders)
+			require.NoError(t, json.Unmarshal([]byte(owner.Invoke("fiat", "holders", pageSize, bookmark)), res))
+			addresses = append(addresses, res.Addresses...)
+			if res.Bookmark == "" {
+				return addresses
+			}
+			bookmark = res.Bookmark
+		}
+	}
+
+	expected := []string{holders[1].Address(), holders[2].Address(), holders[3].Address()}
+	require.ElementsMatch(t, expected, listHolders("1"))
+	require.ElementsMatch(t, expected, listHolders("2"))
+	require.ElementsMatch(t, expected, listHolders("100"))
+
+	err := owner.InvokeWithError("fiat", "holders", "0", "")
+	require.ErrorContains(t, err, token.ErrInvalidHoldersPageSize.Error())
+}
@@ -0,0 +1,118 @@
+package unit
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	testLockHTLCFnName   = "lockHTLC"
+	testClaimHTLCFnName  = "claimHTLC"
+	testRefundHTLCFnName = "refundHTLC"
+	testHTLCPreimage     = "htlc secret"
+)
+
+func prepareHTLC(t *testing.T) (*mock.Wallet, *mock.Wallet) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+	sender := ledger.NewWallet()
+	recipient := ledger.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		owner.Address(), "", "", "", nil)
+
+	initMsg := ledger.NewCC(testTokenCCName, &TestToken{}, config)
+	require.Empty(t, initMsg)
+
+	sender.AddBalance(testTokenCCName, 1000)
+
+	return sender, recipient
+}
+
+func htlcHashlock() string {
+	hash := sha3.Sum256([]byte(testHTLCPreimage))
+	return hex.EncodeToString(hash[:])
+}
+
+// TestHTLCClaim checks that the recipient claims locked tokens with the valid preimage
+func TestHTLCClaim(t *testing.T) {
+	sender, recipient := prepareHTLC(t)
+
+	id := sender.SignedInvoke(testTokenCCName, testLockHTLCFnName,
+		recipient.Address(), "400", htlcHashlock(), "3600")
+	sender.BalanceShouldBe(testTokenCCName, 600)
+
+	err := sender.RawSignedInvokeWithErrorReturned(testTokenCCName, testClaimHTLCFnName, id, testHTLCPreimage)
+	require.ErrorContains(t, err, core.ErrHTLCNotRecipient.Error())
+
+	recipient.SignedInvoke(testTokenCCName, testClaimHTLCFnName, id, testHTLCPreimage)
+	recipient.BalanceShouldBe(testTokenCCName, 400)
+	sender.BalanceShouldBe(testTokenCCName, 600)
+
+	err = recipient.RawSignedInvokeWithErrorReturned(testTokenCCName, testClaimHTLCFnName, id, testHTLCPreimage)
+	require.ErrorContains(t, err, core.ErrHTLCNotFound.Error())
+}
+
+// TestHTLCWrongPreimage checks that the claim with a wrong preimage is rejected
+func TestHTLCWrongPreimage(t *testing.T) {
+	sender, recipient := prepareHTLC(t)
+
+	id := sender.SignedInvoke(testTokenCCName, testLockHTLCFnName,
+		recipient.Address(), "400", htlcHashlock(), "3600")
+
+	err := recipient.RawSignedInvokeWithErrorReturned(testTokenCCName, testClaimHTLCFnName, id, "wrong secret")
+	require.ErrorContains(t, err, core.ErrHTLCWrongPreimage.Error())
+
+	recipient.BalanceShouldBe(testTokenCCName, 0)
+	sender.BalanceShouldBe(testTokenCCName, 600)
+}
+
+// TestHTLCRefund checks that the sender gets locked tokens back after the timeout only
+func TestHTLCRefund(t *testing.T) {
+	sender, recipient := prepareHTLC(t)
+
+	id := sender.SignedInvoke(testTokenCCName, testLockHTLCFnName,
+		recipient.Address(), "400", htlcHashlock(), "1")
+
+	err := sender.RawSignedInvokeWithErrorReturned(testTokenCCName, testRefundHTLCFnName, id)
+	require.ErrorContains(t, err, core.ErrHTLCNotExpired.Error())
+
+	time.Sleep(2 * time.Second)
+
+	err = recipient.RawSignedInvokeWithErrorReturned(testTokenCCName, testClaimHTLCFnName, id, testHTLCPreimage)
+	require.ErrorContains(t, err, core.ErrHTLCExpired.Error())
+
+	sender.SignedInvoke(testTokenCCName, testRefundHTLCFnName, id)
+	sender.BalanceShouldBe(testTokenCCName, 1000)
+	recipient.BalanceShouldBe(testTokenCCName, 0)
+}
+
+// TestHTLCSignedBatch checks that the HTLCs locked by one transaction get distinct ids
+func TestHTLCSignedBatch(t *testing.T) {
+	sender, recipient := prepareHTLC(t)
+
+	lock := core.SignedBatchCall{
+		Method: testLockHTLCFnName,
+		Args:   []string{recipient.Address(), "400", htlcHashlock(), "3600"},
+	}
+	result, err := sender.SignedBatchInvoke(testTokenCCName, lock, lock)
+	require.NoError(t, err)
+	sender.BalanceShouldBe(testTokenCCName, 200)
+
+	var ids []string
+	require.NoError(t, json.Unmarshal([]byte(result), &ids))
+	require.Len(t, ids, 2)
+	require.NotEqual(t, ids[0], ids[1])
+
+	for _, id := range ids {
+		recipient.SignedInvoke(testTokenCCName, testClaimHTLCFnName, id, testHTLCPreimage)
+	}
+	recipient.BalanceShouldBe(testTokenCCName, 800)
+}
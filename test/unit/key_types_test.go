@@ -0,0 +1,76 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// TestAcceptedKeyTypes checks that the contract rejects signatures of not accepted key types
+// and accepts the key type advertised by the capabilities query.
+func TestAcceptedKeyTypes(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+	user := ledgerMock.NewWallet()
+
+	issuer.UseSecp256k1Key()
+
+	cfg := &proto.Config{
+		Contract: &proto.ContractConfig{
+			Symbol:   testTokenSymbol,
+			RobotSKI: fixtures_test.RobotHashedCert,
+			Options: &proto.ChaincodeOptions{
+				AcceptedKeyTypes: []proto.KeyType{proto.KeyType_secp256k1},
+			},
+		},
+		Token: &proto.TokenConfig{
+			Name:     testTokenName,
+			Decimals: 8,
+			Issuer:   &proto.Wallet{Address: issuer.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	t.Run("capabilities", func(t *testing.T) {
+		var capabilities core.Capabilities
+		require.NoError(t, json.Unmarshal([]byte(issuer.Invoke(testTokenCCName, "capabilities")), &capabilities))
+		require.Equal(t, []string{proto.KeyType_secp256k1.String()}, capabilities.KeyTypes)
+	})
+
+	t.Run("not accepted key type", func(t *testing.T) {
+		err := user.RawSignedInvokeWithErrorReturned(testTokenCCName, "transfer", issuer.Address(), "1", "")
+		require.ErrorContains(t, err, core.ErrKeyTypeNotAccepted.Error())
+	})
+
+	t.Run("accepted key type", func(t *testing.T) {
+		issuer.SignedInvoke(testTokenCCName, "emissionAdd", user.Address(), "100")
+		user.BalanceShouldBe(testTokenCCName, 100)
+	})
+}
+
+func TestCapabilitiesAllKeyTypes(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8, issuer.Address(), "", "", "", nil)
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, config)
+	require.Empty(t, initMsg)
+
+	var capabilities core.Capabilities
+	require.NoError(t, json.Unmarshal([]byte(issuer.Invoke(testTokenCCName, "capabilities")), &capabilities))
+	require.Equal(t, []string{
+		proto.KeyType_ed25519.String(),
+		proto.KeyType_secp256k1.String(),
+		proto.KeyType_gost.String(),
+	}, capabilities.KeyTypes)
+}
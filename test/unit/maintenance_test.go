@@ -0,0 +1,93 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const testSetMaintenanceModeFnName = "setMaintenanceMode"
+
+// TestMaintenanceMode checks that transactions are blocked in maintenance mode
+// except allowed admin calls, while queries remain available.
+func TestMaintenanceMode(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	admin := ledgerMock.NewWallet()
+	user1 := ledgerMock.NewWallet()
+	user2 := ledgerMock.NewWallet()
+
+	cfg := &proto.Config{
+		Contract: &proto.ContractConfig{
+			Symbol:   testTokenSymbol,
+			RobotSKI: fixtures_test.RobotHashedCert,
+			Admin:    &proto.Wallet{Address: admin.Address()},
+			Options: &proto.ChaincodeOptions{
+				MaintenanceAllowedFunctions: []string{"TxTransferBalance"},
+			},
+		},
+		Token: &proto.TokenConfig{
+			Name:     testTokenName,
+			Decimals: 8,
+			Issuer:   &proto.Wallet{Address: admin.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	user1.AddBalance(testTokenCCName, 1000)
+
+	t.Run("maintenance mode can be set by admin only", func(t *testing.T) {
+		err := user1.RawSignedInvokeWithErrorReturned(testTokenCCName, testSetMaintenanceModeFnName, "true")
+		require.ErrorContains(t, err, core.ErrUnauthorisedNotAdmin.Error())
+	})
+
+	admin.SignedInvoke(testTokenCCName, testSetMaintenanceModeFnName, "true")
+	require.Equal(t, "true", user1.Invoke(testTokenCCName, "maintenanceMode"))
+
+	t.Run("transfer is blocked", func(t *testing.T) {
+		err := user1.RawSignedInvokeWithErrorReturned(testTokenCCName, "transfer", user2.Address(), "100", "")
+		require.ErrorContains(t, err, core.ErrMaintenanceMode.Error())
+	})
+
+	t.Run("query is available", func(t *testing.T) {
+		user1.BalanceShouldBe(testTokenCCName, 1000)
+	})
+
+	t.Run("allowed admin call succeeds", func(t *testing.T) {
+		data, err := json.Marshal(&proto.TransferRequest{
+			Basis:           proto.TransferBasis_TRANSFER_BASIS_INHERITANCE,
+			AdministratorId: admin.Address(),
+			DocumentType:    proto.DocumentType_DOCUMENT_TYPE_INHERITANCE,
+			DocumentNumber:  "1",
+			DocumentDate:    timestamppb.New(time.Now()),
+			DocumentHashes:  []string{"hash1"},
+			FromAddress:     user1.Address(),
+			ToAddress:       user2.Address(),
+			Amount:          "100",
+			Reason:          "recovery",
+			BalanceType:     proto.BalanceType_BALANCE_TYPE_TOKEN,
+		})
+		require.NoError(t, err)
+
+		err = admin.RawSignedInvokeWithErrorReturned(testTokenCCName, "transferBalance", string(data))
+		require.NoError(t, err)
+		user2.BalanceShouldBe(testTokenCCName, 100)
+	})
+
+	admin.SignedInvoke(testTokenCCName, testSetMaintenanceModeFnName, "false")
+	require.Equal(t, "false", user1.Invoke(testTokenCCName, "maintenanceMode"))
+
+	user1.SignedInvoke(testTokenCCName, "transfer", user2.Address(), "100", "")
+	user2.BalanceShouldBe(testTokenCCName, 200)
+}
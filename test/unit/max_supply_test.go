@@ -0,0 +1,83 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/anoideaopen/foundation/mock"
+	pb "github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestRemainingSupply(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	cfg := &pb.Config{
+		Contract: &pb.ContractConfig{
+			Symbol:   "CC",
+			RobotSKI: fixtures_test.RobotHashedCert,
+		},
+		Token: &pb.TokenConfig{
+			Name:      "CC Token",
+			Decimals:  8,
+			Issuer:    &pb.Wallet{Address: owner.Address()},
+			MaxSupply: "1000",
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledger.NewCC("cc", NewMintableTestToken(token.BaseToken{}), string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	remainingSupply := func(t *testing.T) *token.RemainingSupply {
+		res := new(token.RemainingSupply)
+		require.NoError(t, json.Unmarshal([]byte(owner.Invoke("cc", "remainingSupply")), res))
+		require.False(t, res.Unlimited)
+		require.Equal(t, "1000", res.MaxSupply.String())
+
+		return res
+	}
+
+	user := ledger.NewWallet()
+	user.AddAllowedBalance("cc", "FIAT", 2000)
+
+	owner.SignedInvoke("cc", "setRate", "buyToken", "FIAT", "100000000")
+	owner.SignedInvoke("cc", "setRate", "buyBack", "FIAT", "100000000")
+
+	require.Equal(t, "1000", remainingSupply(t).Remaining.String())
+
+	t.Run("partial emission", func(t *testing.T) {
+		user.SignedInvoke("cc", "buyToken", "600", "FIAT")
+		require.Equal(t, "400", remainingSupply(t).Remaining.String())
+	})
+
+	t.Run("emission over max supply", func(t *testing.T) {
+		err := user.RawSignedInvokeWithErrorReturned("cc", "buyToken", "401", "FIAT")
+		require.ErrorContains(t, err, token.ErrMaxSupplyExceeded.Error())
+		require.Equal(t, "400", remainingSupply(t).Remaining.String())
+		user.BalanceShouldBe("cc", 600)
+	})
+
+	t.Run("burn", func(t *testing.T) {
+		user.SignedInvoke("cc", "buyBack", "200", "FIAT")
+		require.Equal(t, "600", remainingSupply(t).Remaining.String())
+		user.BalanceShouldBe("cc", 400)
+	})
+
+	t.Run("unlimited", func(t *testing.T) {
+		config := makeBaseTokenConfig("VT Token", "VT", 8,
+			owner.Address(), "", "", "", nil)
+		initMsg := ledger.NewCC("vt", &token.BaseToken{}, config)
+		require.Empty(t, initMsg)
+
+		res := new(token.RemainingSupply)
+		require.NoError(t, json.Unmarshal([]byte(owner.Invoke("vt", "remainingSupply")), res))
+		require.True(t, res.Unlimited)
+		require.Nil(t, res.Remaining)
+	})
+}
@@ -30,3 +30,23 @@ func TestMetadataMethods(t *testing.T) {
 
 	// require.ElementsMatch(t, tokenMethods, meta.Methods)
 }
+
+func TestTokenMetadata(t *testing.T) {
+	t.Parallel()
+
+	ledger := ma.NewLedger(t)
+	issuer := ledger.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		issuer.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC(testTokenCCName, &token.BaseToken{}, config)
+	require.Empty(t, initMsg)
+
+	rsp := issuer.Invoke(testTokenCCName, "tokenMetadata")
+
+	var meta token.TokenMetadata
+	require.NoError(t, json.Unmarshal([]byte(rsp), &meta))
+	require.Equal(t, testTokenName, meta.Name)
+	require.Equal(t, testTokenSymbol, meta.Symbol)
+	require.Equal(t, uint(8), meta.Decimals)
+}
@@ -0,0 +1,57 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// TestMinBalance checks that a transfer can not leave a non-zero sender balance below min_balance
+func TestMinBalance(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+	user1 := ledgerMock.NewWallet()
+	user2 := ledgerMock.NewWallet()
+
+	cfg := &proto.Config{
+		Contract: &proto.ContractConfig{
+			Symbol:   testTokenSymbol,
+			RobotSKI: fixtures_test.RobotHashedCert,
+		},
+		Token: &proto.TokenConfig{
+			Name:       testTokenName,
+			Decimals:   8,
+			Issuer:     &proto.Wallet{Address: issuer.Address()},
+			MinBalance: "100",
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	user1.AddBalance(testTokenCCName, 1000)
+
+	t.Run("transfer leaving dust is rejected", func(t *testing.T) {
+		err := user1.RawSignedInvokeWithErrorReturned(testTokenCCName, "transfer", user2.Address(), "950", "")
+		require.ErrorContains(t, err, token.ErrBalanceBelowMinimum.Error())
+		user1.BalanceShouldBe(testTokenCCName, 1000)
+	})
+
+	t.Run("transfer leaving the threshold succeeds", func(t *testing.T) {
+		user1.SignedInvoke(testTokenCCName, "transfer", user2.Address(), "900", "")
+		user1.BalanceShouldBe(testTokenCCName, 100)
+	})
+
+	t.Run("full balance transfer succeeds", func(t *testing.T) {
+		user1.SignedInvoke(testTokenCCName, "transfer", user2.Address(), "100", "")
+		user1.BalanceShouldBe(testTokenCCName, 0)
+		user2.BalanceShouldBe(testTokenCCName, 1000)
+	})
+}
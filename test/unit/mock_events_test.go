@@ -0,0 +1,41 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/anoideaopen/foundation/core/types/big"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMockEventsShouldContain(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+	user1 := ledgerMock.NewWallet()
+	user2 := ledgerMock.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8, issuer.Address(), "", "", "", nil)
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, config)
+	require.Empty(t, initMsg)
+
+	require.Empty(t, ledgerMock.Events(testTokenCCName))
+
+	user1.AddBalance(testTokenCCName, 1000)
+	user1.SignedInvoke(testTokenCCName, "transfer", user2.Address(), "400", "")
+
+	payload, err := json.Marshal(token.TransferredEvent{
+		From:   user1.Address(),
+		To:     user2.Address(),
+		Amount: big.NewInt(400),
+	})
+	require.NoError(t, err)
+
+	events := ledgerMock.Events(testTokenCCName)
+	require.Len(t, events, 1)
+	require.Equal(t, token.TransferEvent, events[0].GetEventName())
+
+	ledgerMock.EventsShouldContain(testTokenCCName, token.TransferEvent)
+	ledgerMock.EventsShouldContain(testTokenCCName, token.TransferEvent, payload)
+}
@@ -0,0 +1,56 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMultisigWeightedThreshold checks that the sum of weights of the present signers
+// must meet the weight threshold of the signature policy.
+func TestMultisigWeightedThreshold(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewMultisigWallet(3)
+
+	fiat := NewFiatTestToken(token.BaseToken{})
+	fiatConfig := makeBaseTokenConfig("fiat token", "FIAT", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("fiat", fiat, fiatConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+
+	// the first signer counts as 2, the threshold is 2
+	owner.SetWeights(2, 2, 1, 1)
+
+	t.Run("single high-weight signer meets threshold", func(t *testing.T) {
+		err := owner.RawSignedInvokeWithErrorReturned(1, "fiat", "emit", user1.Address(), "1000")
+		require.NoError(t, err)
+		user1.BalanceShouldBe("fiat", 1000)
+	})
+
+	// the first signer counts as 1, the threshold is 3
+	owner.SetWeights(3, 1, 1, 1)
+
+	t.Run("low-weight signers below threshold are rejected", func(t *testing.T) {
+		err := owner.RawSignedInvokeWithErrorReturned(2, "fiat", "emit", user1.Address(), "1000")
+		require.ErrorContains(t, err, core.ErrWeightThresholdNotMet.Error())
+		user1.BalanceShouldBe("fiat", 1000)
+	})
+
+	t.Run("low-weight signers collectively meet threshold", func(t *testing.T) {
+		err := owner.RawSignedInvokeWithErrorReturned(3, "fiat", "emit", user1.Address(), "1000")
+		require.NoError(t, err)
+		user1.BalanceShouldBe("fiat", 2000)
+	})
+
+	t.Run("weights must match keys", func(t *testing.T) {
+		owner.SetWeights(1, 1, 1)
+		err := owner.RawSignedInvokeWithErrorReturned(3, "fiat", "emit", user1.Address(), "1000")
+		require.ErrorContains(t, err, core.ErrInvalidSignaturePolicy.Error())
+		user1.BalanceShouldBe("fiat", 2000)
+	})
+}
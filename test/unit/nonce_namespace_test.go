@@ -0,0 +1,80 @@
+package unit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/anoideaopen/foundation/mock"
+	pb "github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestNonceNamespaces(t *testing.T) {
+	for _, test := range []struct {
+		name       string
+		namespaces map[string]string
+	}{
+		{name: "admin and transfer nonces conflict in the default namespace"},
+		{name: "admin and transfer nonces do not conflict in separate namespaces", namespaces: map[string]string{
+			"setRate": "admin",
+		}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			ledger := mock.NewLedger(t)
+			issuer := ledger.NewWallet()
+			user := ledger.NewWallet()
+
+			cfg := &pb.Config{
+				Contract: &pb.ContractConfig{
+					Symbol:   "CC",
+					RobotSKI: fixtures_test.RobotHashedCert,
+					Options:  &pb.ChaincodeOptions{NonceNamespaces: test.namespaces},
+				},
+				Token: &pb.TokenConfig{
+					Name:     "CC Token",
+					Decimals: 8,
+					Issuer:   &pb.Wallet{Address: issuer.Address()},
+				},
+			}
+			cfgBytes, err := protojson.Marshal(cfg)
+			require.NoError(t, err)
+
+			initMsg := ledger.NewCC("cc", NewMintableTestToken(token.BaseToken{}), string(cfgBytes))
+			require.Empty(t, initMsg)
+
+			issuer.AddBalance("cc", 1000)
+
+			nonce := uint64(time.Now().UnixMilli())
+
+			_, resp := issuer.BatchedInvoke("cc", "transfer",
+				issuer.SignArgsWithNonce("cc", "transfer", nonce, user.Address(), "100", "")...)
+			require.Empty(t, resp.Error)
+
+			// the admin call signed a minute before the transfer
+			_, slowResp := issuer.BatchedInvoke("cc", "setRate",
+				issuer.SignArgsWithNonce("cc", "setRate", nonce-60000, "buyToken", "FIAT", "100000000")...)
+
+			// the admin call signed with the same nonce as the transfer
+			_, sameResp := issuer.BatchedInvoke("cc", "setRate",
+				issuer.SignArgsWithNonce("cc", "setRate", nonce, "buyBack", "FIAT", "100000000")...)
+
+			if test.namespaces == nil {
+				require.Contains(t, slowResp.Error, "incorrect nonce")
+				require.Contains(t, sameResp.Error, "already exists")
+			} else {
+				require.Empty(t, slowResp.Error)
+				require.Empty(t, sameResp.Error)
+			}
+
+			// the replay is rejected within the namespace
+			_, replayResp := issuer.BatchedInvoke("cc", "setRate",
+				issuer.SignArgsWithNonce("cc", "setRate", nonce, "buyBack", "FIAT", "100000000")...)
+			require.Contains(t, replayResp.Error, "already exists")
+
+			user.BalanceShouldBe("cc", 100)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/anoideaopen/foundation/keys"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSignInvocationOffline checks that channelTransferByCustomer payload signed
+// outside of the mock wallet passes on-chain.
+func TestSignInvocationOffline(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", owner.Address(), nil)
+
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	user := ledger.NewWallet()
+	user.AddBalance("cc", 1000)
+
+	id := uuid.NewString()
+	signed, err := keys.SignInvocation(user.Keys, "", "channelTransferByCustomer", "", "cc", "cc",
+		id, "VT", "CC", "450")
+	require.NoError(t, err)
+	require.NotEmpty(t, signed.Nonce)
+	require.Equal(t, signed.Nonce, signed.Args[len(signed.Args)-3])
+	require.Equal(t, signed.Signature, signed.Args[len(signed.Args)-1])
+
+	txID := user.InvokeReturnsTxID("cc", "channelTransferByCustomer", signed.Args...)
+	user.DoBatch("cc", txID).TxHasNoError(t, txID)
+
+	user.BalanceShouldBe("cc", 550)
+	require.NoError(t, user.InvokeWithError("cc", "channelTransferFrom", id))
+}
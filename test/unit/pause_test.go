@@ -0,0 +1,119 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestPause(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	admin := ledgerMock.NewWallet()
+	issuer := ledgerMock.NewWallet()
+	user1 := ledgerMock.NewWallet()
+	user2 := ledgerMock.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		issuer.Address(), "", "", admin.Address(), nil)
+	initMsg := ledgerMock.NewCC(testTokenCCName, &token.BaseToken{}, config)
+	require.Empty(t, initMsg)
+
+	user1.AddBalance(testTokenCCName, 1000)
+	htlcID := user1.SignedInvoke(testTokenCCName, testLockHTLCFnName,
+		user2.Address(), "100", htlcHashlock(), "3600")
+
+	transferRequest, err := json.Marshal(&proto.TransferRequest{
+		Basis:           proto.TransferBasis_TRANSFER_BASIS_INHERITANCE,
+		AdministratorId: admin.Address(),
+		DocumentType:    proto.DocumentType_DOCUMENT_TYPE_INHERITANCE,
+		DocumentNumber:  "1",
+		DocumentDate:    timestamppb.New(time.Now()),
+		DocumentHashes:  []string{"hash1"},
+		FromAddress:     user1.Address(),
+		ToAddress:       user2.Address(),
+		Amount:          "100",
+		Reason:          "test transfer",
+		BalanceType:     proto.BalanceType_BALANCE_TYPE_TOKEN,
+	})
+	require.NoError(t, err)
+
+	require.Equal(t, "false", user1.Invoke(testTokenCCName, "isPaused"))
+
+	err = user1.RawSignedInvokeWithErrorReturned(testTokenCCName, "pause")
+	require.ErrorContains(t, err, core.ErrUnauthorisedNotAdmin.Error())
+
+	err = admin.RawSignedInvokeWithErrorReturned(testTokenCCName, "unpause")
+	require.ErrorContains(t, err, core.ErrNotPaused.Error())
+
+	admin.SignedInvoke(testTokenCCName, "pause")
+	require.Equal(t, "true", user1.Invoke(testTokenCCName, "isPaused"))
+
+	err = admin.RawSignedInvokeWithErrorReturned(testTokenCCName, "pause")
+	require.ErrorContains(t, err, core.ErrAlreadyPaused.Error())
+
+	t.Run("transfers, emissions and value moving calls fail while paused", func(t *testing.T) {
+		err := user1.RawSignedInvokeWithErrorReturned(testTokenCCName, "transfer", user2.Address(), "100", "")
+		require.ErrorContains(t, err, core.ErrPaused.Error())
+
+		err = user1.RawSignedInvokeWithErrorReturned(testTokenCCName, "channelTransferByCustomer",
+			uuid.NewString(), "VT", testTokenSymbol, "100")
+		require.ErrorContains(t, err, core.ErrPaused.Error())
+
+		err = issuer.RawSignedInvokeWithErrorReturned(testTokenCCName, "proposeEmission", user2.Address(), "100")
+		require.ErrorContains(t, err, core.ErrPaused.Error())
+
+		err = user1.RawSignedInvokeWithErrorReturned(testTokenCCName, "buyToken", "100", "FIAT")
+		require.ErrorContains(t, err, core.ErrPaused.Error())
+
+		err = user1.RawSignedInvokeWithErrorReturned(testTokenCCName, "buyBack", "100", "FIAT")
+		require.ErrorContains(t, err, core.ErrPaused.Error())
+
+		err = user1.RawSignedInvokeWithErrorReturned(testTokenCCName, testLockHTLCFnName,
+			user2.Address(), "100", htlcHashlock(), "3600")
+		require.ErrorContains(t, err, core.ErrPaused.Error())
+
+		err = user2.RawSignedInvokeWithErrorReturned(testTokenCCName, testClaimHTLCFnName, htlcID, testHTLCPreimage)
+		require.ErrorContains(t, err, core.ErrPaused.Error())
+
+		err = admin.RawSignedInvokeWithErrorReturned(testTokenCCName, "transferBalance", string(transferRequest))
+		require.ErrorContains(t, err, core.ErrPaused.Error())
+
+		user1.BalanceShouldBe(testTokenCCName, 900)
+		user2.BalanceShouldBe(testTokenCCName, 0)
+	})
+
+	t.Run("admin calls remain available while paused", func(t *testing.T) {
+		err := admin.RawSignedInvokeWithErrorReturned(testTokenCCName, "freezeAddress", user2.Address())
+		require.NoError(t, err)
+		err = admin.RawSignedInvokeWithErrorReturned(testTokenCCName, "unfreezeAddress", user2.Address())
+		require.NoError(t, err)
+	})
+
+	admin.SignedInvoke(testTokenCCName, "unpause")
+	require.Equal(t, "false", user1.Invoke(testTokenCCName, "isPaused"))
+
+	t.Run("transfers and emissions succeed after unpause", func(t *testing.T) {
+		err := user1.RawSignedInvokeWithErrorReturned(testTokenCCName, "transfer", user2.Address(), "100", "")
+		require.NoError(t, err)
+
+		err = issuer.RawSignedInvokeWithErrorReturned(testTokenCCName, "proposeEmission", user2.Address(), "100")
+		require.NoError(t, err)
+
+		err = user2.RawSignedInvokeWithErrorReturned(testTokenCCName, testClaimHTLCFnName, htlcID, testHTLCPreimage)
+		require.NoError(t, err)
+
+		err = admin.RawSignedInvokeWithErrorReturned(testTokenCCName, "transferBalance", string(transferRequest))
+		require.NoError(t, err)
+
+		user1.BalanceShouldBe(testTokenCCName, 700)
+		user2.BalanceShouldBe(testTokenCCName, 400)
+	})
+}
@@ -0,0 +1,98 @@
+package unit
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPendingOperationSignatures checks that the multisig operation collects
+// signatures in separate transactions and is executed by the last one.
+func TestPendingOperationSignatures(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewMultisigWallet(3)
+
+	fiat := NewFiatTestToken(token.BaseToken{})
+	fiatConfig := makeBaseTokenConfig("fiat token", "FIAT", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("fiat", fiat, fiatConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+
+	call, signatures := owner.SignPartially("fiat", "emit", user1.Address(), "1000")
+
+	opID := user1.Invoke("fiat", core.ProposeOperation, append([]string{"emit"}, call...)...)
+	require.NotEmpty(t, opID)
+
+	submit := func(index int, signature string) (*core.SubmittedSignature, error) {
+		resp, err := user1.InvokeWithPeerResponse("fiat", core.SubmitSignature,
+			opID, base58.Encode(owner.PubKeys()[index]), signature)
+		require.NoError(t, err)
+		if resp.GetStatus() != 200 { //nolint:gomnd
+			return nil, errors.New(resp.GetMessage())
+		}
+
+		result := new(core.SubmittedSignature)
+		require.NoError(t, json.Unmarshal(resp.GetPayload(), result))
+		return result, nil
+	}
+
+	t.Run("operation is proposed once", func(t *testing.T) {
+		err := user1.InvokeWithError("fiat", core.ProposeOperation, append([]string{"emit"}, call...)...)
+		require.ErrorContains(t, err, core.ErrPendingOperationExists.Error())
+	})
+
+	t.Run("invalid signature is rejected", func(t *testing.T) {
+		_, otherSignatures := owner.SignPartially("fiat", "emit", user1.Address(), "1")
+		_, err := submit(0, otherSignatures[0])
+		require.Error(t, err)
+	})
+
+	for i := 0; i < 2; i++ {
+		result, err := submit(i, signatures[i])
+		require.NoError(t, err)
+		require.False(t, result.Executed)
+		user1.BalanceShouldBe("fiat", 0)
+	}
+
+	t.Run("signature is submitted once", func(t *testing.T) {
+		_, err := submit(1, signatures[1])
+		require.ErrorContains(t, err, core.ErrOperationAlreadySigned.Error())
+	})
+
+	t.Run("pending operation", func(t *testing.T) {
+		op := new(core.PendingOperation)
+		require.NoError(t, json.Unmarshal([]byte(user1.Invoke("fiat", "pendingOperation", opID)), op))
+		require.Equal(t, "emit", op.Method)
+		require.Equal(t, signatures[:2], op.Signatures[:2])
+		require.Empty(t, op.Signatures[2])
+	})
+
+	result, err := submit(2, signatures[2])
+	require.NoError(t, err)
+	require.True(t, result.Executed)
+	user1.BalanceShouldBe("fiat", 1000)
+
+	t.Run("executed operation is deleted", func(t *testing.T) {
+		err := user1.InvokeWithError("fiat", "pendingOperation", opID)
+		require.ErrorContains(t, err, core.ErrPendingOperationNotFound.Error())
+	})
+
+	t.Run("executed operation can not be replayed", func(t *testing.T) {
+		user1.Invoke("fiat", core.ProposeOperation, append([]string{"emit"}, call...)...)
+		for i := 0; i < 2; i++ {
+			_, err := submit(i, signatures[i])
+			require.NoError(t, err)
+		}
+		_, err := submit(2, signatures[2])
+		require.Error(t, err)
+		user1.BalanceShouldBe("fiat", 1000)
+	})
+}
@@ -0,0 +1,107 @@
+package unit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/anoideaopen/foundation/core/balance"
+	"github.com/anoideaopen/foundation/core/grpc"
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+)
+
+const testBonusToken = "BONUS"
+
+// TestPostTransferHook checks that the hook runs in the transfer transaction
+// and its error aborts the transfer.
+func TestPostTransferHook(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	owner := ledgerMock.NewWallet()
+	user1 := ledgerMock.NewWallet()
+	user2 := ledgerMock.NewWallet()
+
+	errHook := errors.New("bonus is not available")
+
+	tt := &TestToken{}
+	tt.SetPostTransferHook(func(ctx context.Context, from *types.Address, to *types.Address, amount *big.Int) error {
+		if amount.Cmp(big.NewInt(500)) > 0 {
+			return errHook
+		}
+
+		bonus := new(big.Int).Div(amount, big.NewInt(10))
+		return balance.Add(grpc.StubFromContext(ctx), balance.BalanceTypeAllowed, from.String(), testBonusToken, &bonus.Int)
+	})
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledgerMock.NewCC(testTokenCCName, tt, config)
+	require.Empty(t, initMsg)
+
+	user1.AddBalance(testTokenCCName, 1000)
+
+	t.Run("hook credits bonus", func(t *testing.T) {
+		user1.SignedInvoke(testTokenCCName, "transfer", user2.Address(), "300", "")
+		user1.BalanceShouldBe(testTokenCCName, 700)
+		user2.BalanceShouldBe(testTokenCCName, 300)
+		user1.AllowedBalanceShouldBe(testTokenCCName, testBonusToken, 30)
+	})
+
+	t.Run("hook error aborts transfer", func(t *testing.T) {
+		err := user1.RawSignedInvokeWithErrorReturned(testTokenCCName, "transfer", user2.Address(), "600", "")
+		require.ErrorContains(t, err, errHook.Error())
+		user1.BalanceShouldBe(testTokenCCName, 700)
+		user2.BalanceShouldBe(testTokenCCName, 300)
+		user1.AllowedBalanceShouldBe(testTokenCCName, testBonusToken, 30)
+	})
+}
+
+// TestPostTransferHookReentrancy checks that a transfer called from within the hook
+// is rejected and balances stay consistent.
+func TestPostTransferHookReentrancy(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	owner := ledgerMock.NewWallet()
+	user1 := ledgerMock.NewWallet()
+	user2 := ledgerMock.NewWallet()
+
+	var nestedErr error
+	tt := &TestToken{}
+	tt.SetPostTransferHook(func(ctx context.Context, from *types.Address, to *types.Address, amount *big.Int) error {
+		// send the transferred amount back to the sender once more
+		nestedErr = tt.TxTransfer(types.NewSenderFromAddr(to), from, amount, "")
+		if amount.Cmp(big.NewInt(500)) > 0 {
+			return nestedErr
+		}
+		return nil
+	})
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledgerMock.NewCC(testTokenCCName, tt, config)
+	require.Empty(t, initMsg)
+
+	user1.AddBalance(testTokenCCName, 1000)
+
+	t.Run("nested transfer error aborts transfer", func(t *testing.T) {
+		err := user1.RawSignedInvokeWithErrorReturned(testTokenCCName, "transfer", user2.Address(), "600", "")
+		require.ErrorContains(t, err, token.ErrReentrantTransfer.Error())
+		user1.BalanceShouldBe(testTokenCCName, 1000)
+		user2.BalanceShouldBe(testTokenCCName, 0)
+	})
+
+	t.Run("ignored nested transfer does not change balances", func(t *testing.T) {
+		user1.SignedInvoke(testTokenCCName, "transfer", user2.Address(), "300", "")
+		require.ErrorIs(t, nestedErr, token.ErrReentrantTransfer)
+		user1.BalanceShouldBe(testTokenCCName, 700)
+		user2.BalanceShouldBe(testTokenCCName, 300)
+	})
+
+	t.Run("transfer after hook is not blocked", func(t *testing.T) {
+		user2.SignedInvoke(testTokenCCName, "transfer", user1.Address(), "100", "")
+		user1.BalanceShouldBe(testTokenCCName, 800)
+		user2.BalanceShouldBe(testTokenCCName, 200)
+	})
+}
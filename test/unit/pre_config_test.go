@@ -0,0 +1,62 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPreConfigFunctions checks that only the pre-config functions are invoked before the config is applied.
+func TestPreConfigFunctions(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+	user := ledger.NewWallet()
+
+	// the chaincode is deployed, but the config is not applied
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, "{}")
+	require.NotEmpty(t, initMsg)
+
+	t.Run("health check is allowed", func(t *testing.T) {
+		require.NoError(t, owner.InvokeWithError("cc", "healthCheckNb",
+			owner.SignArgs("cc", "healthCheckNb")...))
+		require.NoError(t, owner.InvokeWithError("cc", "healthCheck",
+			owner.SignArgs("cc", "healthCheck")...))
+	})
+
+	t.Run("transfer is rejected", func(t *testing.T) {
+		err := owner.InvokeWithError("cc", "transfer",
+			owner.SignArgs("cc", "transfer", user.Address(), "1", "")...)
+		require.ErrorContains(t, err, core.ErrNotConfigured.Error())
+	})
+
+	t.Run("query is rejected", func(t *testing.T) {
+		err := owner.InvokeWithError("cc", "balanceOf", user.Address())
+		require.ErrorContains(t, err, core.ErrNotConfigured.Error())
+	})
+
+	t.Run("all functions are allowed after the config is applied", func(t *testing.T) {
+		config := makeBaseTokenConfig("CC Token", "CC", 8, owner.Address(), "", "", "", nil)
+		require.Empty(t, ledger.UpgradeCC("cc", &token.BaseToken{}, config))
+
+		owner.AddBalance("cc", 10)
+		owner.SignedInvoke("cc", "transfer", user.Address(), "1", "")
+		user.BalanceShouldBe("cc", 1)
+	})
+}
+
+// TestWithPreConfigFunctions checks that the pre-config functions are replaced by the chaincode option.
+func TestWithPreConfigFunctions(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, "{}", core.WithPreConfigFunctions("buildInfo"))
+	require.NotEmpty(t, initMsg)
+
+	require.NoError(t, owner.InvokeWithError("cc", "buildInfo"))
+
+	err := owner.InvokeWithError("cc", "healthCheckNb", owner.SignArgs("cc", "healthCheckNb")...)
+	require.ErrorContains(t, err, core.ErrNotConfigured.Error())
+}
@@ -0,0 +1,34 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrivilegedMethods(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	issuer := ledger.NewWallet()
+	user := ledger.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		issuer.Address(), "", "", "", nil)
+
+	initMsg := ledger.NewCC(testTokenCCName, &TestToken{}, config)
+	require.Empty(t, initMsg)
+
+	var methods map[string][]string
+	require.NoError(t, json.Unmarshal([]byte(user.Invoke(testTokenCCName, "privilegedMethods")), &methods))
+
+	require.Equal(t, []string{core.RoleIssuer}, methods["emissionAdd"])
+	require.Equal(t, []string{core.RoleIssuer}, methods["proposeEmission"])
+	require.Equal(t, []string{core.RoleEmissionApprover}, methods["approveEmission"])
+	require.Equal(t, []string{core.RoleAdmin}, methods["pause"])
+	require.Equal(t, []string{core.RoleRobot}, methods["createCCTransferTo"])
+
+	require.NotContains(t, methods, "transfer")
+	require.NotContains(t, methods, "balanceOf")
+}
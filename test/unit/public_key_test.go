@@ -0,0 +1,54 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/keys/eth"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryPublicKey(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC(testTokenCCName, &token.BaseToken{}, config)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance(testTokenCCName, 1000)
+
+	user2 := ledger.NewWallet()
+	user2.UseSecp256k1Key()
+	user2.AddBalance(testTokenCCName, 1000)
+
+	publicKey := func(t *testing.T, user *mock.Wallet) core.AddressPublicKey {
+		var result core.AddressPublicKey
+		require.NoError(t, json.Unmarshal([]byte(owner.Invoke(testTokenCCName, "publicKey", user.Address())), &result))
+		return result
+	}
+
+	t.Run("unknown before the first signature", func(t *testing.T) {
+		require.Equal(t, "{}", owner.Invoke(testTokenCCName, "publicKey", user1.Address()))
+	})
+
+	t.Run("recorded on the signature", func(t *testing.T) {
+		user1.SignedInvoke(testTokenCCName, "transfer", owner.Address(), "100", "")
+		require.Equal(t, core.AddressPublicKey{
+			PublicKey: base58.Encode(user1.PubKey()),
+			KeyType:   "ed25519",
+		}, publicKey(t, user1))
+
+		user2.SignedInvoke(testTokenCCName, "transfer", owner.Address(), "100", "")
+		require.Equal(t, core.AddressPublicKey{
+			PublicKey: base58.Encode(eth.PublicKeyBytes(user2.PublicKeySecp256k1)),
+			KeyType:   "secp256k1",
+		}, publicKey(t, user2))
+	})
+}
@@ -0,0 +1,147 @@
+package unit
+
+import (
+	"encoding/json"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	pb "github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// TestQueryDoesNotChangeNonce checks that queries leave the stored nonce list untouched.
+func TestQueryDoesNotChangeNonce(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		owner.Address(), "", "", owner.Address(), nil)
+	initMsg := ledger.NewCC(testTokenCCName, &token.BaseToken{}, config)
+	require.Empty(t, initMsg)
+
+	user := ledger.NewWallet()
+	user.AddBalance(testTokenCCName, 1000)
+
+	require.Empty(t, user.Nonces(testTokenCCName))
+	require.Equal(t, "\"0\"", user.QueryShouldNotChangeNonce(testTokenCCName, "getNonce", user.Address()))
+	require.Empty(t, user.Nonces(testTokenCCName))
+
+	user.SignedInvoke(testTokenCCName, "transfer", owner.Address(), "100", "")
+	user.SignedInvoke(testTokenCCName, "transfer", owner.Address(), "100", "")
+
+	nonces := user.Nonces(testTokenCCName)
+	require.Len(t, nonces, 2)
+
+	for i := 0; i < 5; i++ {
+		user.QueryShouldNotChangeNonce(testTokenCCName, "getNonce", user.Address())
+	}
+	user.QueryShouldNotChangeNonce(testTokenCCName, "balanceOf", user.Address())
+	owner.QueryShouldNotChangeNonce(testTokenCCName, "exportState", owner.SignArgs(testTokenCCName, "exportState", "10", "")...)
+
+	require.Equal(t, nonces, user.Nonces(testTokenCCName))
+}
+
+// TestQueryWouldAcceptNonce checks that the query reports the acceptance of the nonce
+// without changing the nonce list.
+func TestQueryWouldAcceptNonce(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		owner.Address(), "", "", owner.Address(), nil)
+	initMsg := ledger.NewCC(testTokenCCName, &token.BaseToken{}, config)
+	require.Empty(t, initMsg)
+
+	user := ledger.NewWallet()
+	user.AddBalance(testTokenCCName, 1000)
+	user.SignedInvoke(testTokenCCName, "transfer", owner.Address(), "100", "")
+
+	nonces := user.Nonces(testTokenCCName)
+	require.Len(t, nonces, 1)
+	last := nonces[0]
+
+	wouldAccept := func(nonce uint64) core.NonceAcceptance {
+		var acceptance core.NonceAcceptance
+		result := user.QueryShouldNotChangeNonce(testTokenCCName, "wouldAcceptNonce",
+			user.Address(), "transfer", strconv.FormatUint(nonce, 10))
+		require.NoError(t, json.Unmarshal([]byte(result), &acceptance))
+		return acceptance
+	}
+
+	t.Run("fresh nonce", func(t *testing.T) {
+		require.Equal(t, core.NonceAcceptance{Accepted: true}, wouldAccept(last+1))
+	})
+
+	t.Run("replayed nonce", func(t *testing.T) {
+		acceptance := wouldAccept(last)
+		require.False(t, acceptance.Accepted)
+		require.Contains(t, acceptance.Reason, "already exists")
+	})
+
+	t.Run("stale nonce", func(t *testing.T) {
+		acceptance := wouldAccept(last - 60000)
+		require.False(t, acceptance.Accepted)
+		require.Contains(t, acceptance.Reason, "incorrect nonce")
+	})
+
+	require.Equal(t, nonces, user.Nonces(testTokenCCName))
+
+	user.SignedInvoke(testTokenCCName, "transfer", owner.Address(), "100", "")
+	require.Len(t, user.Nonces(testTokenCCName), 2)
+}
+
+// TestQueryWouldAcceptNonceNamespaces checks that the nonce is checked against the namespace of the method
+func TestQueryWouldAcceptNonceNamespaces(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	issuer := ledger.NewWallet()
+	user := ledger.NewWallet()
+
+	cfg := &pb.Config{
+		Contract: &pb.ContractConfig{
+			Symbol:   "CC",
+			RobotSKI: fixtures_test.RobotHashedCert,
+			Options: &pb.ChaincodeOptions{NonceNamespaces: map[string]string{
+				"setRate": "admin",
+			}},
+		},
+		Token: &pb.TokenConfig{
+			Name:     "CC Token",
+			Decimals: 8,
+			Issuer:   &pb.Wallet{Address: issuer.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledger.NewCC("cc", NewMintableTestToken(token.BaseToken{}), string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	issuer.AddBalance("cc", 1000)
+
+	nonce := uint64(time.Now().UnixMilli())
+	_, resp := issuer.BatchedInvoke("cc", "transfer",
+		issuer.SignArgsWithNonce("cc", "transfer", nonce, user.Address(), "100", "")...)
+	require.Empty(t, resp.Error)
+
+	wouldAccept := func(method string, nonce uint64) core.NonceAcceptance {
+		var acceptance core.NonceAcceptance
+		result := issuer.QueryShouldNotChangeNonce("cc", "wouldAcceptNonce",
+			issuer.Address(), method, strconv.FormatUint(nonce, 10))
+		require.NoError(t, json.Unmarshal([]byte(result), &acceptance))
+		return acceptance
+	}
+
+	// the default namespace of the transfer has the nonce already
+	require.Contains(t, wouldAccept("transfer", nonce).Reason, "already exists")
+	require.Contains(t, wouldAccept("transfer", nonce-60000).Reason, "incorrect nonce")
+
+	// the admin namespace has no nonces yet
+	require.Equal(t, core.NonceAcceptance{Accepted: true}, wouldAccept("setRate", nonce))
+	require.Equal(t, core.NonceAcceptance{Accepted: true}, wouldAccept("setRate", nonce-60000))
+}
@@ -0,0 +1,49 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/anoideaopen/foundation/mock"
+	pb "github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestQueryVersion(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	issuer := ledger.NewWallet()
+	user := ledger.NewWallet()
+
+	makeConfig := func(version string) string {
+		cfg := &pb.Config{
+			Contract: &pb.ContractConfig{
+				Symbol:   "CC",
+				RobotSKI: fixtures_test.RobotHashedCert,
+				Version:  version,
+			},
+			Token: &pb.TokenConfig{
+				Name:     "CC Token",
+				Decimals: 8,
+				Issuer:   &pb.Wallet{Address: issuer.Address()},
+			},
+		}
+		cfgBytes, err := protojson.Marshal(cfg)
+		require.NoError(t, err)
+
+		return string(cfgBytes)
+	}
+
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, makeConfig("1.0.0"))
+	require.Empty(t, initMsg)
+
+	user.AddBalance("cc", 1000)
+	require.Equal(t, `"1.0.0"`, user.Invoke("cc", "version"))
+
+	initMsg = ledger.UpgradeCC("cc", &token.BaseToken{}, makeConfig("2.0.0"))
+	require.Empty(t, initMsg)
+
+	require.Equal(t, `"2.0.0"`, user.Invoke("cc", "version"))
+	user.BalanceShouldBe("cc", 1000)
+}
@@ -0,0 +1,63 @@
+package unit
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/core/balance"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testRawStateFnName = "rawState"
+
+func TestRawState(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	issuer := ledger.NewWallet()
+	admin := ledger.NewWallet()
+	user := ledger.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		issuer.Address(), "", "", admin.Address(), nil)
+
+	initMsg := ledger.NewCC(testTokenCCName, &TestToken{}, config)
+	require.Empty(t, initMsg)
+
+	issuer.SignedInvoke(testTokenCCName, "emissionAdd", user.Address(), "1000")
+
+	balanceAttributes := `["` + user.Address() + `"]`
+
+	t.Run("raw balance decodes to the balance", func(t *testing.T) {
+		resp := admin.Invoke(testTokenCCName, testRawStateFnName,
+			admin.SignArgs(testTokenCCName, testRawStateFnName, balance.BalanceTypeToken.String(), balanceAttributes)...)
+
+		var state core.RawState
+		require.NoError(t, json.Unmarshal([]byte(resp), &state))
+		require.True(t, state.Exists)
+		require.Equal(t, "1000", new(big.Int).SetBytes(state.Value).String())
+	})
+
+	t.Run("absent key", func(t *testing.T) {
+		resp := admin.Invoke(testTokenCCName, testRawStateFnName,
+			admin.SignArgs(testTokenCCName, testRawStateFnName, balance.BalanceTypeToken.String(), `["unknown"]`)...)
+
+		var state core.RawState
+		require.NoError(t, json.Unmarshal([]byte(resp), &state))
+		require.False(t, state.Exists)
+		require.Empty(t, state.Value)
+	})
+
+	t.Run("object type not in allowlist", func(t *testing.T) {
+		err := admin.InvokeWithError(testTokenCCName, testRawStateFnName,
+			admin.SignArgs(testTokenCCName, testRawStateFnName, "nonce", balanceAttributes)...)
+		require.ErrorContains(t, err, core.ErrRawStateNotAllowed.Error())
+	})
+
+	t.Run("allowed to admin only", func(t *testing.T) {
+		err := user.InvokeWithError(testTokenCCName, testRawStateFnName,
+			user.SignArgs(testTokenCCName, testRawStateFnName, balance.BalanceTypeToken.String(), balanceAttributes)...)
+		require.ErrorContains(t, err, core.ErrUnauthorisedNotAdmin.Error())
+	})
+}
@@ -0,0 +1,59 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type GetNonceOverrideToken struct {
+	TestToken
+}
+
+func (t *GetNonceOverrideToken) QueryGetNonce(_ *types.Address) (string, error) {
+	return "0", nil
+}
+
+type BatchExecuteToken struct {
+	TestToken
+}
+
+func (t *BatchExecuteToken) NBTxBatchExecute() error {
+	return nil
+}
+
+// TestReservedMethodNames checks that init fails if the contract defines a method reserved by the framework
+func TestReservedMethodNames(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		contract core.BaseContractInterface
+		method   string
+	}{
+		{name: "base contract method", contract: &GetNonceOverrideToken{}, method: "getNonce"},
+		{name: "framework function", contract: &BatchExecuteToken{}, method: core.BatchExecute},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ledgerMock := mock.NewLedger(t)
+			owner := ledgerMock.NewWallet()
+
+			config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+				owner.Address(), "", "", "", nil)
+			initMsg := ledgerMock.NewCC(testTokenCCName, tc.contract, config)
+			require.Contains(t, initMsg, core.ErrReservedMethodName.Error())
+			require.Contains(t, initMsg, tc.method)
+		})
+	}
+
+	t.Run("embedded base contract methods are allowed", func(t *testing.T) {
+		ledgerMock := mock.NewLedger(t)
+		owner := ledgerMock.NewWallet()
+
+		config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+			owner.Address(), "", "", "", nil)
+		initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, config)
+		require.Empty(t, initMsg)
+	})
+}
@@ -0,0 +1,58 @@
+package unit
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotateKey(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC(testTokenCCName, &token.BaseToken{}, config)
+	require.Empty(t, initMsg)
+
+	user := ledger.NewWallet()
+	user.AddBalance(testTokenCCName, 1000)
+	user.SignedInvoke(testTokenCCName, "transfer", owner.Address(), "100", "")
+
+	newPublicKey, newPrivateKey, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	t.Run("invalid key is rejected", func(t *testing.T) {
+		err := user.RawSignedInvokeWithErrorReturned(testTokenCCName, "rotateKey",
+			base58.Encode(newPublicKey[:16]), "ed25519")
+		require.ErrorContains(t, err, core.ErrInvalidPublicKey.Error())
+
+		err = user.RawSignedInvokeWithErrorReturned(testTokenCCName, "rotateKey",
+			base58.Encode(newPublicKey), "rsa")
+		require.ErrorContains(t, err, core.ErrUnknownKeyType.Error())
+	})
+
+	t.Run("old key signatures fail after rotation", func(t *testing.T) {
+		user.SignedInvoke(testTokenCCName, "rotateKey", base58.Encode(newPublicKey), "ed25519")
+
+		err := user.RawSignedInvokeWithErrorReturned(testTokenCCName, "transfer", owner.Address(), "100", "")
+		require.ErrorContains(t, err, core.ErrPublicKeyMismatch.Error())
+		user.BalanceShouldBe(testTokenCCName, 900)
+	})
+
+	t.Run("new key signatures pass after rotation", func(t *testing.T) {
+		address := user.Address()
+		require.NoError(t, user.RotateKey(newPrivateKey))
+		require.Equal(t, address, user.Address())
+
+		user.SignedInvoke(testTokenCCName, "transfer", owner.Address(), "100", "")
+		user.BalanceShouldBe(testTokenCCName, 800)
+		require.Equal(t, "\"ed25519\"", owner.Invoke(testTokenCCName, "addressKeyType", user.Address()))
+	})
+}
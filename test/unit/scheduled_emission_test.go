@@ -0,0 +1,194 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func makeScheduledEmissionConfig(t *testing.T, issuer string, approver string, allow bool) string {
+	cfg := &proto.Config{
+		Contract: &proto.ContractConfig{
+			Symbol:   "CC",
+			RobotSKI: fixtures_test.RobotHashedCert,
+		},
+		Token: &proto.TokenConfig{
+			Name:                      "CC Token",
+			Decimals:                  8,
+			Issuer:                    &proto.Wallet{Address: issuer},
+			MaxSupply:                 "400",
+			EmissionApprovalThreshold: "500",
+			EmissionApprovers:         []*proto.Wallet{{Address: approver}},
+			AllowScheduledEmission:    allow,
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	return string(cfgBytes)
+}
+
+// TestScheduledEmission checks that the scheduled emissions are executed by the robot once they are due.
+func TestScheduledEmission(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, makeScheduledEmissionConfig(t, owner.Address(), ledger.NewWallet().Address(), true))
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user2 := ledger.NewWallet()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	ledger.SetTxTime(now)
+
+	execute := func(t *testing.T) {
+		_, _, err := owner.RawChTransferInvoke("cc", "executeScheduledEmissions", "10")
+		require.NoError(t, err)
+	}
+
+	t.Run("only the robot executes emissions", func(t *testing.T) {
+		err := owner.InvokeWithError("cc", "executeScheduledEmissions", "10")
+		require.ErrorContains(t, err, core.ErrNotRobotCreator.Error())
+	})
+
+	t.Run("only the issuer schedules emissions", func(t *testing.T) {
+		err := user1.RawSignedInvokeWithErrorReturned("cc", "scheduleEmission",
+			user1.Address(), "100", now.Add(time.Hour).Format(time.RFC3339))
+		require.ErrorContains(t, err, "unauthorized")
+	})
+
+	require.NoError(t, owner.RawSignedInvokeWithErrorReturned("cc", "scheduleEmission",
+		user1.Address(), "100", now.Add(time.Hour).Format(time.RFC3339)))
+	require.NoError(t, owner.RawSignedInvokeWithErrorReturned("cc", "scheduleEmission",
+		user2.Address(), "200", now.Add(2*time.Hour).Format(time.RFC3339)))
+
+	t.Run("not due emissions are skipped", func(t *testing.T) {
+		execute(t)
+		user1.BalanceShouldBe("cc", 0)
+		user2.BalanceShouldBe("cc", 0)
+	})
+
+	t.Run("due emission is executed", func(t *testing.T) {
+		ledger.SetTxTime(now.Add(time.Hour))
+		execute(t)
+		user1.BalanceShouldBe("cc", 100)
+		user2.BalanceShouldBe("cc", 0)
+
+		// the executed emission is not repeated
+		execute(t)
+		user1.BalanceShouldBe("cc", 100)
+	})
+
+	t.Run("all due emissions are executed", func(t *testing.T) {
+		ledger.SetTxTime(now.Add(3 * time.Hour))
+		execute(t)
+		user1.BalanceShouldBe("cc", 100)
+		user2.BalanceShouldBe("cc", 200)
+	})
+
+	t.Run("emissions of one transaction get distinct ids", func(t *testing.T) {
+		schedule := core.SignedBatchCall{
+			Method: "scheduleEmission",
+			Args:   []string{user1.Address(), "50", now.Add(4 * time.Hour).Format(time.RFC3339)},
+		}
+		result, err := owner.SignedBatchInvoke("cc", schedule, schedule)
+		require.NoError(t, err)
+
+		var ids []string
+		require.NoError(t, json.Unmarshal([]byte(result), &ids))
+		require.Len(t, ids, 2)
+		require.NotEqual(t, ids[0], ids[1])
+
+		ledger.SetTxTime(now.Add(5 * time.Hour))
+		execute(t)
+		user1.BalanceShouldBe("cc", 200)
+	})
+}
+
+// TestScheduledEmissionFailure checks that the failed emission does not block the later ones
+// and the emissions can be cancelled.
+func TestScheduledEmissionFailure(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, makeScheduledEmissionConfig(t, owner.Address(), ledger.NewWallet().Address(), true))
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user2 := ledger.NewWallet()
+
+	now := time.Now().UTC().Truncate(time.Second)
+	ledger.SetTxTime(now)
+
+	schedule := func(t *testing.T, address string, amount string, at time.Time) string {
+		_, resp, _ := owner.RawSignedInvoke("cc", "scheduleEmission", address, amount, at.Format(time.RFC3339))
+		require.Empty(t, resp.Error)
+
+		var id string
+		require.NoError(t, json.Unmarshal([]byte(resp.Result), &id))
+		return id
+	}
+
+	t.Run("emission above the approval threshold is rejected", func(t *testing.T) {
+		err := owner.RawSignedInvokeWithErrorReturned("cc", "scheduleEmission",
+			user1.Address(), "600", now.Add(time.Hour).Format(time.RFC3339))
+		require.ErrorContains(t, err, token.ErrEmissionApprovalRequired.Error())
+	})
+
+	schedule(t, user1.Address(), "300", now.Add(time.Hour))
+	// exceeds max supply after the first emission
+	failed := schedule(t, user2.Address(), "200", now.Add(2*time.Hour))
+	schedule(t, user2.Address(), "50", now.Add(3*time.Hour))
+	cancelled := schedule(t, user1.Address(), "10", now.Add(4*time.Hour))
+
+	t.Run("pending emission is cancelled", func(t *testing.T) {
+		owner.SignedInvoke("cc", "cancelScheduledEmission", cancelled)
+
+		err := owner.RawSignedInvokeWithErrorReturned("cc", "cancelScheduledEmission", cancelled)
+		require.ErrorContains(t, err, token.ErrScheduledEmissionNotFound.Error())
+	})
+
+	t.Run("failed emission is skipped", func(t *testing.T) {
+		ledger.SetTxTime(now.Add(5 * time.Hour))
+		_, _, err := owner.RawChTransferInvoke("cc", "executeScheduledEmissions", "10")
+		require.NoError(t, err)
+
+		user1.BalanceShouldBe("cc", 300)
+		user2.BalanceShouldBe("cc", 50)
+
+		var history token.EmissionHistory
+		require.NoError(t, json.Unmarshal([]byte(owner.Invoke("cc", "emissionHistory", "10", "")), &history))
+		require.Len(t, history.Records, 2)
+	})
+
+	t.Run("failed emission is cancelled", func(t *testing.T) {
+		err := user1.RawSignedInvokeWithErrorReturned("cc", "cancelScheduledEmission", failed)
+		require.ErrorContains(t, err, "unauthorized")
+
+		owner.SignedInvoke("cc", "cancelScheduledEmission", failed)
+
+		err = owner.RawSignedInvokeWithErrorReturned("cc", "cancelScheduledEmission", failed)
+		require.ErrorContains(t, err, token.ErrScheduledEmissionNotFound.Error())
+	})
+}
+
+func TestScheduledEmissionDisabled(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, makeScheduledEmissionConfig(t, owner.Address(), ledger.NewWallet().Address(), false))
+	require.Empty(t, initMsg)
+
+	err := owner.RawSignedInvokeWithErrorReturned("cc", "scheduleEmission",
+		owner.Address(), "100", time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+	require.ErrorContains(t, err, token.ErrScheduledEmissionDisabled.Error())
+}
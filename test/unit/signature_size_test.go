@@ -0,0 +1,72 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/anoideaopen/foundation/keys"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// TestValidateSignatureSize checks that the signatures of the wrong length for the key type
+// are rejected with keys.ErrMalformedSignature if validate_signature_size option is set.
+func TestValidateSignatureSize(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	issuer := ledgerMock.NewWallet()
+
+	cfg := &proto.Config{
+		Contract: &proto.ContractConfig{
+			Symbol:   testTokenSymbol,
+			RobotSKI: fixtures_test.RobotHashedCert,
+			Options: &proto.ChaincodeOptions{
+				ValidateSignatureSize: true,
+			},
+		},
+		Token: &proto.TokenConfig{
+			Name:     testTokenName,
+			Decimals: 8,
+			Issuer:   &proto.Wallet{Address: issuer.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	for _, test := range []struct {
+		keyType proto.KeyType
+		lengths []int
+	}{
+		{keyType: proto.KeyType_ed25519, lengths: []int{1, 63, 65}},
+		{keyType: proto.KeyType_secp256k1, lengths: []int{63, 66}},
+		{keyType: proto.KeyType_gost, lengths: []int{32, 63, 128}},
+	} {
+		t.Run(test.keyType.String(), func(t *testing.T) {
+			user := ledgerMock.NewWallet()
+			switch test.keyType {
+			case proto.KeyType_secp256k1:
+				user.UseSecp256k1Key()
+			case proto.KeyType_gost:
+				user.UseGOSTKey()
+			}
+
+			for _, length := range test.lengths {
+				args := user.SignArgs(testTokenCCName, "transfer", issuer.Address(), "1", "")
+				args[len(args)-1] = base58.Encode(make([]byte, length))
+
+				err := user.InvokeWithError(testTokenCCName, "transfer", args...)
+				require.ErrorContains(t, err, keys.ErrMalformedSignature.Error(), "length %d", length)
+			}
+
+			// the signature of the valid length is verified
+			issuer.SignedInvoke(testTokenCCName, "emissionAdd", user.Address(), "1")
+			user.SignedInvoke(testTokenCCName, "transfer", issuer.Address(), "1", "")
+			user.BalanceShouldBe(testTokenCCName, 0)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSignedBatch checks that calls of the signed batch are executed atomically under one nonce.
+func TestSignedBatch(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	owner := ledgerMock.NewWallet()
+	user1 := ledgerMock.NewWallet()
+	user2 := ledgerMock.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, config)
+	require.Empty(t, initMsg)
+
+	user1.AddBalance(testTokenCCName, 1000)
+
+	t.Run("all calls succeed", func(t *testing.T) {
+		result, err := user1.SignedBatchInvoke(testTokenCCName,
+			core.SignedBatchCall{Method: "transfer", Args: []string{user2.Address(), "100", ""}},
+			core.SignedBatchCall{Method: "transfer", Args: []string{owner.Address(), "200", ""}},
+		)
+		require.NoError(t, err)
+		require.Equal(t, "[null,null]", result)
+
+		user1.BalanceShouldBe(testTokenCCName, 700)
+		user2.BalanceShouldBe(testTokenCCName, 100)
+		owner.BalanceShouldBe(testTokenCCName, 200)
+	})
+
+	t.Run("failed call rolls back the batch", func(t *testing.T) {
+		_, err := user1.SignedBatchInvoke(testTokenCCName,
+			core.SignedBatchCall{Method: "transfer", Args: []string{user2.Address(), "100", ""}},
+			core.SignedBatchCall{Method: "transfer", Args: []string{owner.Address(), "10000", ""}},
+		)
+		require.ErrorContains(t, err, "call 1 'transfer'")
+
+		user1.BalanceShouldBe(testTokenCCName, 700)
+		user2.BalanceShouldBe(testTokenCCName, 100)
+		owner.BalanceShouldBe(testTokenCCName, 200)
+	})
+
+	t.Run("query methods are not allowed", func(t *testing.T) {
+		_, err := user1.SignedBatchInvoke(testTokenCCName,
+			core.SignedBatchCall{Method: "balanceOf", Args: []string{user1.Address()}},
+		)
+		require.ErrorContains(t, err, core.ErrSignedBatchQueryMethod.Error())
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		_, err := user1.SignedBatchInvoke(testTokenCCName)
+		require.ErrorContains(t, err, core.ErrSignedBatchEmpty.Error())
+	})
+}
@@ -0,0 +1,72 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// TestProtectedQueries checks that protected queries are answered
+// only when signed by the queried address.
+func TestProtectedQueries(t *testing.T) {
+	const fn = "balanceOf"
+
+	ledgerMock := mock.NewLedger(t)
+	owner := ledgerMock.NewWallet()
+	user := ledgerMock.NewWallet()
+	other := ledgerMock.NewWallet()
+
+	cfg := &proto.Config{
+		Contract: &proto.ContractConfig{
+			Symbol:   testTokenSymbol,
+			RobotSKI: fixtures_test.RobotHashedCert,
+			Options: &proto.ChaincodeOptions{
+				ProtectedQueries: []string{"QueryBalanceOf"},
+			},
+		},
+		Token: &proto.TokenConfig{
+			Name:     testTokenName,
+			Decimals: 8,
+			Issuer:   &proto.Wallet{Address: owner.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	owner.SignedInvoke(testTokenCCName, "emissionAdd", user.Address(), "1000")
+
+	t.Run("signed by the queried address", func(t *testing.T) {
+		resp := user.Invoke(testTokenCCName, fn, user.SignArgs(testTokenCCName, fn, user.Address())...)
+		require.Equal(t, "\"1000\"", resp)
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		err := user.InvokeWithError(testTokenCCName, fn, user.Address())
+		require.ErrorContains(t, err, core.ErrQueryNotAuthenticated.Error())
+	})
+
+	t.Run("signed by another address", func(t *testing.T) {
+		err := other.InvokeWithError(testTokenCCName, fn, other.SignArgs(testTokenCCName, fn, user.Address())...)
+		require.ErrorContains(t, err, core.ErrQueryNotAuthenticated.Error())
+	})
+
+	t.Run("signature of other arguments", func(t *testing.T) {
+		args := user.SignArgs(testTokenCCName, fn, user.Address())
+		args[3] = other.Address()
+		err := user.InvokeWithError(testTokenCCName, fn, args...)
+		require.ErrorContains(t, err, core.ErrQueryNotAuthenticated.Error())
+	})
+
+	t.Run("not protected query", func(t *testing.T) {
+		err := user.InvokeWithError(testTokenCCName, "allowedBalanceOf", user.Address(), "CC")
+		require.NoError(t, err)
+	})
+}
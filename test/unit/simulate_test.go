@@ -0,0 +1,77 @@
+package unit
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/core/balance"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/hyperledger/fabric-chaincode-go/shim"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSimulate checks that the simulated emission predicts the balance and does not change the state.
+func TestSimulate(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	fiatConfig := makeBaseTokenConfig("fiat", "FIAT", 8, owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("fiat", NewFiatTestToken(token.BaseToken{}), fiatConfig)
+	require.Empty(t, initMsg)
+
+	user := ledger.NewWallet()
+	user.AddBalance("fiat", 100)
+
+	simulate := func(w *mock.Wallet, fn string, args ...string) *core.Simulation {
+		signed := w.SignArgs("fiat", fn, args...)
+		simulation := &core.Simulation{}
+		require.NoError(t, json.Unmarshal([]byte(w.Invoke("fiat", core.Simulate, append([]string{fn}, signed...)...)), simulation))
+		return simulation
+	}
+
+	t.Run("emission predicts the balance", func(t *testing.T) {
+		simulation := simulate(owner, "emit", user.Address(), "1000")
+		require.Empty(t, simulation.Error)
+		require.Equal(t, "emit", simulation.Method)
+
+		key, err := shim.CreateCompositeKey(balance.BalanceTypeToken.String(), []string{user.Address()})
+		require.NoError(t, err)
+
+		var predicted *big.Int
+		for _, write := range simulation.Writes {
+			if write.GetKey() == key {
+				predicted = new(big.Int).SetBytes(write.GetValue())
+			}
+		}
+		require.NotNil(t, predicted)
+		require.Equal(t, "1100", predicted.String())
+
+		require.Len(t, simulation.Accounting, 1)
+		require.Equal(t, "1000", new(big.Int).SetBytes(simulation.Accounting[0].GetAmount()).String())
+
+		user.BalanceShouldBe("fiat", 100)
+	})
+
+	t.Run("failure is predicted", func(t *testing.T) {
+		simulation := simulate(user, "emit", user.Address(), "1000")
+		require.Equal(t, "unauthorized", simulation.Error)
+		require.Empty(t, simulation.Writes)
+
+		user.BalanceShouldBe("fiat", 100)
+	})
+
+	t.Run("emission is executed after the simulation", func(t *testing.T) {
+		require.NoError(t, owner.RawSignedInvokeWithErrorReturned("fiat", "emit", user.Address(), "1000"))
+		user.BalanceShouldBe("fiat", 1100)
+	})
+
+	t.Run("query methods are not simulated", func(t *testing.T) {
+		simulation := &core.Simulation{}
+		require.NoError(t, json.Unmarshal([]byte(owner.Invoke("fiat", core.Simulate, "balanceOf", user.Address())), simulation))
+		require.ErrorContains(t, errors.New(simulation.Error), core.ErrSimulateQueryMethod.Error())
+	})
+}
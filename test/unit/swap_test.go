@@ -202,3 +202,57 @@ func TestAtomicSwapDisableSwaps(t *testing.T) {
 	err = user1.RawSignedInvokeWithErrorReturned(baCC, "swapDone", "", "")
 	require.ErrorContains(t, err, core.ErrSwapDisabled.Error())
 }
+
+func TestQuerySwapsByToken(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	ccConfig := makeBaseTokenConfig("CC Token", "CC", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, ccConfig)
+	require.Empty(t, initMsg)
+
+	vtConfig := makeBaseTokenConfig("VT Token", "VT", 8,
+		owner.Address(), "", "", "", nil)
+	initMsg = ledger.NewCC("vt", &token.BaseToken{}, vtConfig)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+	user1.AddAllowedBalance("cc", "VT", 1000)
+	user1.AddAllowedBalance("cc", "VT_testGroup", 1000)
+
+	hashed := sha3.Sum256([]byte("123"))
+	swapHash := hex.EncodeToString(hashed[:])
+
+	ccSwap := user1.SignedInvoke("cc", "swapBegin", "CC", "VT", "100", swapHash)
+	vtSwap := user1.SignedInvoke("cc", "swapBegin", "VT", "VT", "200", swapHash)
+	vtGroupSwap := user1.SignedInvoke("cc", "swapBegin", "VT_testGroup", "VT", "300", swapHash)
+
+	swapIDs := func(t *testing.T, symbol string) []string {
+		ids := []string{}
+		bookmark := ""
+		for {
+			page := new(core.SwapsPage)
+			require.NoError(t, json.Unmarshal([]byte(user1.Invoke("cc", "swapsByToken", symbol, "1", bookmark)), page))
+			for _, s := range page.Swaps {
+				ids = append(ids, hex.EncodeToString(s.GetId()))
+			}
+			if page.Bookmark == "" {
+				return ids
+			}
+			bookmark = page.Bookmark
+		}
+	}
+
+	require.Equal(t, []string{ccSwap}, swapIDs(t, "CC"))
+	require.ElementsMatch(t, []string{vtSwap, vtGroupSwap}, swapIDs(t, "VT"))
+	require.ElementsMatch(t, []string{vtSwap, vtGroupSwap}, swapIDs(t, "VT_testGroup"))
+	require.Empty(t, swapIDs(t, "FIAT"))
+
+	user1.SignedInvoke("cc", "swapCancel", vtSwap)
+	require.Equal(t, []string{vtGroupSwap}, swapIDs(t, "VT"))
+
+	err := user1.InvokeWithError("cc", "swapsByToken", "VT", "0", "")
+	require.ErrorContains(t, err, core.ErrInvalidSwapsPageSize.Error())
+}
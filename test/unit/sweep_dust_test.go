@@ -0,0 +1,100 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSweepDust(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	admin := ledgerMock.NewWallet()
+	issuer := ledgerMock.NewWallet()
+	treasury := ledgerMock.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		issuer.Address(), "", "", admin.Address(), nil)
+	initMsg := ledgerMock.NewCC(testTokenCCName, &token.BaseToken{}, config)
+	require.Empty(t, initMsg)
+
+	dust := []uint64{1, 5, 9, 3}
+	dustWallets := make([]*mock.Wallet, 0, len(dust))
+	for _, amount := range dust {
+		w := ledgerMock.NewWallet()
+		w.AddBalance(testTokenCCName, amount)
+		dustWallets = append(dustWallets, w)
+	}
+
+	rich := ledgerMock.NewWallet()
+	rich.AddBalance(testTokenCCName, 10)
+
+	empty := ledgerMock.NewWallet()
+	empty.AddBalance(testTokenCCName, 0)
+
+	sweep := func() (int, uint64, uint64) {
+		pages, wallets, amount := 0, uint64(0), uint64(0)
+		bookmark := ""
+		for {
+			var holders token.Holders
+			require.NoError(t, json.Unmarshal([]byte(admin.Invoke(testTokenCCName, "holders", "2", bookmark)), &holders))
+
+			addresses, err := json.Marshal(holders.Addresses)
+			require.NoError(t, err)
+
+			_, resp, _ := admin.RawSignedInvoke(testTokenCCName, "sweepDust",
+				"10", treasury.Address(), string(addresses))
+			require.Empty(t, resp.Error)
+			pages++
+
+			var event token.SweptDustEvent
+			require.NoError(t, json.Unmarshal(resp.Events[token.SweepDustEvent], &event))
+			require.Equal(t, treasury.Address(), event.Treasury)
+			wallets += event.Wallets
+			amount += event.Amount.Uint64()
+
+			bookmark = holders.Bookmark
+			if bookmark == "" {
+				break
+			}
+		}
+		return pages, wallets, amount
+	}
+
+	t.Run("non admin can not sweep", func(t *testing.T) {
+		err := issuer.RawSignedInvokeWithErrorReturned(testTokenCCName, "sweepDust",
+			"10", treasury.Address(), `["`+rich.Address()+`"]`)
+		require.ErrorContains(t, err, core.ErrUnauthorisedNotAdmin.Error())
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		err := admin.RawSignedInvokeWithErrorReturned(testTokenCCName, "sweepDust",
+			"0", treasury.Address(), `["`+rich.Address()+`"]`)
+		require.ErrorContains(t, err, token.ErrInvalidDustThreshold.Error())
+	})
+
+	t.Run("dust balances are swept to treasury", func(t *testing.T) {
+		pages, wallets, amount := sweep()
+		require.Greater(t, pages, 1)
+		require.Equal(t, uint64(len(dust)), wallets)
+		require.Equal(t, uint64(18), amount)
+
+		treasury.BalanceShouldBe(testTokenCCName, 18)
+		for _, w := range dustWallets {
+			w.BalanceShouldBe(testTokenCCName, 0)
+		}
+		rich.BalanceShouldBe(testTokenCCName, 10)
+	})
+
+	t.Run("repeated sweep moves nothing", func(t *testing.T) {
+		_, wallets, amount := sweep()
+		require.Zero(t, wallets)
+		require.Zero(t, amount)
+
+		treasury.BalanceShouldBe(testTokenCCName, 18)
+		rich.BalanceShouldBe(testTokenCCName, 10)
+	})
+}
@@ -0,0 +1,79 @@
+package unit
+
+import (
+	"testing"
+
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// TestSymbolAliases checks that the alias symbols resolve to the balances of the canonical symbol.
+func TestSymbolAliases(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	aliasedConfig := func(name, symbol string) string {
+		cfg := &proto.Config{
+			Contract: &proto.ContractConfig{
+				Symbol:        symbol,
+				RobotSKI:      fixtures_test.RobotHashedCert,
+				Admin:         &proto.Wallet{Address: owner.Address()},
+				SymbolAliases: map[string]string{"CCX": "CC"},
+			},
+			Token: &proto.TokenConfig{
+				Name:     name,
+				Decimals: 8,
+				Issuer:   &proto.Wallet{Address: owner.Address()},
+			},
+		}
+		cfgBytes, err := protojson.Marshal(cfg)
+		require.NoError(t, err)
+		return string(cfgBytes)
+	}
+
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, aliasedConfig("CC Token", "CC"))
+	require.Empty(t, initMsg)
+	initMsg = ledger.NewCC("vt", &token.BaseToken{}, aliasedConfig("VT Token", "VT"))
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user1.AddBalance("cc", 1000)
+	user2 := ledger.NewWallet()
+
+	t.Run("channel transfer of the alias", func(t *testing.T) {
+		id := uuid.NewString()
+		user1.SignedInvoke("cc", "channelTransferByCustomer", id, "VT", "CCX", "100")
+		require.NoError(t, ledger.NewRobot().Transfer("cc", id))
+
+		user1.BalanceShouldBe("cc", 900)
+		user1.CheckGivenBalanceShouldBe("cc", "VT", 100)
+		user1.AllowedBalanceShouldBe("vt", "CC", 100)
+		user1.AllowedBalanceShouldBe("vt", "CCX", 100)
+	})
+
+	t.Run("admin channel transfer retry with the alias", func(t *testing.T) {
+		id := uuid.NewString()
+		owner.SignedInvoke("cc", "channelTransferByAdmin", id, "VT", user1.Address(), "CCX", "100")
+		owner.SignedInvoke("cc", "channelTransferByAdmin", id, "VT", user1.Address(), "CCX", "100")
+		owner.SignedInvoke("cc", "channelTransferByAdmin", id, "VT", user1.Address(), "CC", "100")
+
+		user1.BalanceShouldBe("cc", 800)
+		user1.CheckGivenBalanceShouldBe("cc", "VT", 200)
+	})
+
+	t.Run("allowed balance transfer of the alias", func(t *testing.T) {
+		user1.SignedInvoke("vt", "allowedBalanceTransfer", user2.Address(), "CCX", "40")
+
+		user1.AllowedBalanceShouldBe("vt", "CC", 60)
+		user2.AllowedBalanceShouldBe("vt", "CC", 40)
+	})
+
+	t.Run("unknown symbol is not resolved", func(t *testing.T) {
+		user1.AllowedBalanceShouldBe("vt", "CCY", 0)
+	})
+}
@@ -2,15 +2,23 @@ package unit
 
 import (
 	"context"
+	"crypto/hmac"
+	"encoding/hex"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/anoideaopen/foundation/core/telemetry"
 	"github.com/anoideaopen/foundation/mock"
 	"github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/crypto/sha3"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 const (
@@ -151,3 +159,86 @@ func TestTelemetryWithoutInit(t *testing.T) {
 		require.NotEmpty(t, txID)
 	})
 }
+
+// TestTelemetrySpanAttributes checks that the method call span of a transfer
+// carries the standard attributes and that configured attributes are redacted.
+func TestTelemetrySpanAttributes(t *testing.T) {
+	t.Run("redaction key set", func(t *testing.T) {
+		const redactionKey = "secret"
+		t.Setenv(telemetry.TracingRedactionKeyEnv, redactionKey)
+
+		attrs, sender := transferSpanAttributes(t)
+		require.Equal(t, testTokenSymbol, attrs[telemetry.AttributeToken])
+		require.Equal(t, "100", attrs[telemetry.AttributeAmount])
+
+		mac := hmac.New(sha3.New256, []byte(redactionKey))
+		_, _ = mac.Write([]byte(sender))
+		require.Equal(t, hex.EncodeToString(mac.Sum(nil)), attrs[telemetry.AttributeSender])
+	})
+
+	t.Run("redaction key not set", func(t *testing.T) {
+		t.Setenv(telemetry.TracingRedactionKeyEnv, "")
+
+		attrs, _ := transferSpanAttributes(t)
+		require.Equal(t, "100", attrs[telemetry.AttributeAmount])
+		require.Equal(t, telemetry.RedactedValue, attrs[telemetry.AttributeSender])
+	})
+}
+
+// transferSpanAttributes returns the attributes of the method call span of a transfer
+// with the sender attribute redacted and the address of the sender
+func transferSpanAttributes(t *testing.T) (map[attribute.Key]string, string) {
+	ledgerMock := mock.NewLedger(t)
+	owner := ledgerMock.NewWallet()
+	user := ledgerMock.NewWallet()
+
+	cfg := &proto.Config{
+		Contract: &proto.ContractConfig{
+			Symbol:   testTokenSymbol,
+			RobotSKI: fixtures_test.RobotHashedCert,
+			Options: &proto.ChaincodeOptions{
+				TracingRedactedAttributes: []string{telemetry.AttributeSender},
+			},
+		},
+		Token: &proto.TokenConfig{
+			Name:     testTokenName,
+			Decimals: 8,
+			Issuer:   &proto.Wallet{Address: owner.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	tt := &TestToken{}
+	initMsg := ledgerMock.NewCC(testTokenCCName, tt, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	// the first call sets up tracing of the contract
+	owner.Invoke(testTokenCCName, "systemEnv")
+
+	recorder := tracetest.NewSpanRecorder()
+	tt.TracingHandler().Tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	owner.AddBalance(testTokenCCName, 1000)
+	owner.SignedInvoke(testTokenCCName, "transfer", user.Address(), "100", "")
+	user.BalanceShouldBe(testTokenCCName, 100)
+
+	var attrs map[attribute.Key]string
+	for _, span := range recorder.Ended() {
+		if span.Name() != "chaincode.CallMethod" {
+			continue
+		}
+
+		spanAttrs := make(map[attribute.Key]string)
+		for _, kv := range span.Attributes() {
+			spanAttrs[kv.Key] = kv.Value.Emit()
+		}
+
+		if spanAttrs[telemetry.AttributeMethod] == "transfer" {
+			attrs = spanAttrs
+		}
+	}
+	require.NotNil(t, attrs)
+
+	return attrs, owner.Address()
+}
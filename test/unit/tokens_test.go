@@ -56,7 +56,27 @@ func (ft *FiatTestToken) TxEmit(sender *types.Sender, address *types.Address, am
 	if err := ft.TokenBalanceAdd(address, amount, "txEmit"); err != nil {
 		return err
 	}
-	return ft.EmissionAdd(amount)
+	return ft.EmissionAddTo(address, amount)
+}
+
+// TxEmitWithKey - emits fiat token once for the emission key
+func (ft *FiatTestToken) TxEmitWithKey(sender *types.Sender, key string, address *types.Address, amount *big.Int) error {
+	if !sender.Equal(ft.Issuer()) {
+		return errors.New("unauthorized")
+	}
+
+	if amount.Cmp(big.NewInt(0)) == 0 {
+		return errors.New("amount should be more than zero")
+	}
+
+	if err := ft.UseEmissionKey(key); err != nil {
+		return err
+	}
+
+	if err := ft.TokenBalanceAdd(address, amount, "txEmitWithKey"); err != nil {
+		return err
+	}
+	return ft.EmissionAddTo(address, amount)
 }
 
 // TxEmit - emits fiat token
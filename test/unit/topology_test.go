@@ -0,0 +1,54 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/core/acl"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// TestQueryTopology checks that the topology reports the ACL chaincode the contract consults.
+func TestQueryTopology(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	cfg := &proto.Config{
+		Contract: &proto.ContractConfig{
+			Symbol:   "CC",
+			RobotSKI: fixtures_test.RobotHashedCert,
+			Options: &proto.ChaincodeOptions{
+				CheckChannelTransferTargetToken: true,
+			},
+		},
+		Token: &proto.TokenConfig{
+			Name:     "CC Token",
+			Decimals: 8,
+			Issuer:   &proto.Wallet{Address: owner.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledger.NewCC("cc", &token.BaseToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	topology := &core.Topology{}
+	require.NoError(t, json.Unmarshal([]byte(owner.Invoke("cc", "topology")), topology))
+
+	require.Equal(t, "cc", topology.Channel)
+	require.Equal(t, core.ChaincodeCoordinates{Channel: acl.ChACL, Chaincode: acl.CcACL}, topology.ACL)
+	require.True(t, topology.ChannelTransferTargets)
+
+	// the signed methods are checked by the ACL at the reported coordinates
+	user := ledger.NewWallet()
+	user.AddBalance("cc", 10)
+	user.SignedInvoke("cc", "transfer", owner.Address(), "5", "")
+	owner.BalanceShouldBe("cc", 5)
+}
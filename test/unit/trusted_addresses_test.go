@@ -0,0 +1,116 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	"github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TestTrustedAddresses checks that transfers from trusted addresses skip the optional guards
+// which still apply to other addresses
+func TestTrustedAddresses(t *testing.T) {
+	ledgerMock := mock.NewLedger(t)
+	owner := ledgerMock.NewWallet()
+	treasury := ledgerMock.NewWallet()
+	user1 := ledgerMock.NewWallet()
+	user2 := ledgerMock.NewWallet()
+
+	cfg := &proto.Config{
+		Contract: &proto.ContractConfig{
+			Symbol:   testTokenSymbol,
+			RobotSKI: fixtures_test.RobotHashedCert,
+			Admin:    &proto.Wallet{Address: owner.Address()},
+			Options: &proto.ChaincodeOptions{
+				CheckDenylist: true,
+			},
+			TrustedAddresses: []*proto.Wallet{{Address: treasury.Address()}},
+		},
+		Token: &proto.TokenConfig{
+			Name:       testTokenName,
+			Decimals:   8,
+			Issuer:     &proto.Wallet{Address: owner.Address()},
+			MinBalance: "100",
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledgerMock.NewCC(testTokenCCName, &TestToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	treasury.AddBalance(testTokenCCName, 1000)
+	user1.AddBalance(testTokenCCName, 1000)
+
+	t.Run("transfer below min balance", func(t *testing.T) {
+		err := user1.RawSignedInvokeWithErrorReturned(testTokenCCName, "transfer", user2.Address(), "950", "")
+		require.ErrorContains(t, err, token.ErrBalanceBelowMinimum.Error())
+		user1.BalanceShouldBe(testTokenCCName, 1000)
+
+		treasury.SignedInvoke(testTokenCCName, "transfer", user2.Address(), "950", "")
+		treasury.BalanceShouldBe(testTokenCCName, 50)
+		user2.BalanceShouldBe(testTokenCCName, 950)
+	})
+
+	require.NoError(t, user2.AddToList(mock.BlackList))
+
+	transferRequest := func(from *mock.Wallet, number string) string {
+		data, err := json.Marshal(&proto.TransferRequest{
+			Basis:           proto.TransferBasis_TRANSFER_BASIS_INHERITANCE,
+			AdministratorId: owner.Address(),
+			DocumentType:    proto.DocumentType_DOCUMENT_TYPE_INHERITANCE,
+			DocumentNumber:  number,
+			DocumentDate:    timestamppb.New(time.Now()),
+			DocumentHashes:  []string{"hash" + number},
+			FromAddress:     from.Address(),
+			ToAddress:       user2.Address(),
+			Amount:          "50",
+			Reason:          "test transfer",
+			BalanceType:     proto.BalanceType_BALANCE_TYPE_TOKEN,
+		})
+		require.NoError(t, err)
+
+		return string(data)
+	}
+
+	t.Run("admin transfer to denylisted address", func(t *testing.T) {
+		err := owner.RawSignedInvokeWithErrorReturned(testTokenCCName, "transferBalance", transferRequest(user1, "1"))
+		require.ErrorContains(t, err, core.ErrAddressDenylisted.Error())
+		user1.BalanceShouldBe(testTokenCCName, 1000)
+
+		err = owner.RawSignedInvokeWithErrorReturned(testTokenCCName, "transferBalance", transferRequest(treasury, "2"))
+		require.NoError(t, err)
+		treasury.BalanceShouldBe(testTokenCCName, 0)
+
+		require.NoError(t, user2.DelFromList(mock.BlackList))
+		user2.BalanceShouldBe(testTokenCCName, 1000)
+	})
+
+	t.Run("transfer to address denylisted before the batch", func(t *testing.T) {
+		treasury.AddBalance(testTokenCCName, 10)
+
+		// the recipient is denylisted after the validation of the arguments, so the transfer is checked by the denylist
+		fromUser := user1.InvokeReturnsTxID(testTokenCCName, "transfer",
+			user1.SignArgs(testTokenCCName, "transfer", user2.Address(), "10", "")...)
+		fromTreasury := treasury.InvokeReturnsTxID(testTokenCCName, "transfer",
+			treasury.SignArgs(testTokenCCName, "transfer", user2.Address(), "10", "")...)
+		require.NoError(t, user2.AddToList(mock.BlackList))
+
+		resp := owner.DoBatch(testTokenCCName, fromUser, fromTreasury)
+		require.Contains(t, resp[fromUser].GetError().GetError(), core.ErrAddressDenylisted.Error())
+		resp.TxHasNoError(t, fromTreasury)
+
+		require.NoError(t, user2.DelFromList(mock.BlackList))
+		user1.BalanceShouldBe(testTokenCCName, 1000)
+		treasury.BalanceShouldBe(testTokenCCName, 0)
+		user2.BalanceShouldBe(testTokenCCName, 1010)
+	})
+}
@@ -0,0 +1,95 @@
+package unit
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/mock"
+	pb "github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestQueryValidateConfig(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		owner.Address(), "", "", "", nil)
+	initMsg := ledger.NewCC(testTokenCCName, &token.BaseToken{}, config)
+	require.Empty(t, initMsg)
+
+	validate := func(t *testing.T, cfg *pb.Config) *core.ConfigValidation {
+		cfgBytes, err := protojson.Marshal(cfg)
+		require.NoError(t, err)
+
+		result := new(core.ConfigValidation)
+		require.NoError(t, json.Unmarshal([]byte(
+			owner.QueryShouldNotChangeNonce(testTokenCCName, "validateConfig", string(cfgBytes)),
+		), result))
+
+		return result
+	}
+
+	t.Run("valid config", func(t *testing.T) {
+		result := validate(t, &pb.Config{
+			Contract: &pb.ContractConfig{Symbol: "CC", RobotSKI: fixtures_test.RobotHashedCert},
+			Token:    &pb.TokenConfig{Name: "CC Token", Issuer: &pb.Wallet{Address: owner.Address()}},
+		})
+		require.True(t, result.Valid)
+		require.Empty(t, result.Errors)
+	})
+
+	t.Run("invalid contract config", func(t *testing.T) {
+		result := validate(t, &pb.Config{
+			Contract: &pb.ContractConfig{
+				Symbol:   "cc",
+				RobotSKI: "robot",
+				Admin:    &pb.Wallet{Address: "0OIl"},
+			},
+			Token: &pb.TokenConfig{Name: "CC Token", Issuer: &pb.Wallet{Address: owner.Address()}},
+		})
+		require.False(t, result.Valid)
+
+		fields := make(map[string]bool)
+		for _, e := range result.Errors {
+			if e.Section == core.ConfigSectionContract {
+				require.NotEmpty(t, e.Reason)
+				fields[e.Field] = true
+			}
+		}
+		require.Equal(t, map[string]bool{"Symbol": true, "RobotSKI": true, "Admin.Address": true}, fields)
+	})
+
+	t.Run("invalid token config", func(t *testing.T) {
+		result := validate(t, &pb.Config{
+			Contract: &pb.ContractConfig{Symbol: "CC", RobotSKI: fixtures_test.RobotHashedCert},
+			Token:    &pb.TokenConfig{Name: "CC Token"},
+		})
+		require.False(t, result.Valid)
+		require.Equal(t, []core.ConfigValidationError{{
+			Section: core.ConfigSectionToken,
+			Field:   "Token.Issuer",
+			Reason:  "value is required",
+		}}, result.Errors)
+
+		result = validate(t, &pb.Config{
+			Contract: &pb.ContractConfig{Symbol: "CC", RobotSKI: fixtures_test.RobotHashedCert},
+			Token:    &pb.TokenConfig{Name: "CC Token", Issuer: &pb.Wallet{Address: owner.Address()}, MaxSupply: "-1"},
+		})
+		require.False(t, result.Valid)
+		require.Len(t, result.Errors, 1)
+		require.Equal(t, core.ConfigSectionToken, result.Errors[0].Section)
+		require.Contains(t, result.Errors[0].Reason, token.ErrInvalidMaxSupply.Error())
+	})
+
+	t.Run("malformed config", func(t *testing.T) {
+		result := new(core.ConfigValidation)
+		require.NoError(t, json.Unmarshal([]byte(owner.Invoke(testTokenCCName, "validateConfig", "{")), result))
+		require.False(t, result.Valid)
+		require.NotEmpty(t, result.Errors)
+	})
+}
@@ -0,0 +1,246 @@
+package unit
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/anoideaopen/foundation/core"
+	"github.com/anoideaopen/foundation/core/walletaudit"
+	"github.com/anoideaopen/foundation/mock"
+	pb "github.com/anoideaopen/foundation/proto"
+	"github.com/anoideaopen/foundation/test/unit/fixtures_test"
+	"github.com/anoideaopen/foundation/token"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/sha3"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type walletAuditEvent struct {
+	Type         string
+	Token        string
+	Amount       string
+	Counterparty string
+}
+
+func walletAuditEvents(t *testing.T, page walletaudit.Page) []walletAuditEvent {
+	result := make([]walletAuditEvent, 0, len(page.Events))
+	for _, e := range page.Events {
+		require.NotEmpty(t, e.TxID)
+		result = append(result, walletAuditEvent{e.Type, e.Token, e.Amount.String(), e.Counterparty})
+	}
+	return result
+}
+
+func walletAudit(t *testing.T, w *mock.Wallet, ch string, address string, pageSize string, bookmark string) walletaudit.Page {
+	var result walletaudit.Page
+	require.NoError(t, json.Unmarshal([]byte(w.Invoke(ch, "walletAudit", address, pageSize, bookmark)), &result))
+	return result
+}
+
+// TestQueryWalletAudit checks that the audit trail of the wallet combines the emission,
+// the transfers in and out and the fees paid and received in the order they are made.
+func TestQueryWalletAudit(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+	feeSetter := ledger.NewWallet()
+	feeAddressSetter := ledger.NewWallet()
+	feeAggregator := ledger.NewWallet()
+
+	cfg := &pb.Config{
+		Contract: &pb.ContractConfig{
+			Symbol:   "FIAT",
+			RobotSKI: fixtures_test.RobotHashedCert,
+			Options:  &pb.ChaincodeOptions{WalletAudit: true},
+		},
+		Token: &pb.TokenConfig{
+			Name:             "FIAT",
+			Decimals:         8,
+			Issuer:           &pb.Wallet{Address: owner.Address()},
+			FeeSetter:        &pb.Wallet{Address: feeSetter.Address()},
+			FeeAddressSetter: &pb.Wallet{Address: feeAddressSetter.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledger.NewCC("fiat", NewFiatTestToken(token.BaseToken{}), string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user2 := ledger.NewWallet()
+
+	owner.SignedInvoke("fiat", "emit", user1.Address(), "1000")
+	owner.SignedInvoke("fiat", "emit", user2.Address(), "500")
+
+	feeAddressSetter.SignedInvoke("fiat", "setFeeAddress", feeAggregator.Address())
+	// 10% fee without floor and cap
+	feeSetter.SignedInvoke("fiat", "setFee", "FIAT", "10000000", "0", "0")
+
+	user1.SignedInvoke("fiat", "transfer", user2.Address(), "100", "")
+	user2.SignedInvoke("fiat", "transfer", user1.Address(), "50", "")
+
+	audit := func(address string, pageSize string, bookmark string) walletaudit.Page {
+		return walletAudit(t, owner, "fiat", address, pageSize, bookmark)
+	}
+	events := func(page walletaudit.Page) []walletAuditEvent {
+		return walletAuditEvents(t, page)
+	}
+	type event = walletAuditEvent
+
+	t.Run("combined trail", func(t *testing.T) {
+		trail := audit(user1.Address(), "10", "")
+		require.Equal(t, []event{
+			{walletaudit.Emission, "FIAT", "1000", ""},
+			{walletaudit.TransferOut, "FIAT", "100", user2.Address()},
+			{walletaudit.Fee, "FIAT", "10", feeAggregator.Address()},
+			{walletaudit.TransferIn, "FIAT", "50", user2.Address()},
+		}, events(trail))
+		require.Empty(t, trail.Bookmark)
+
+		require.Equal(t, []event{
+			{walletaudit.Emission, "FIAT", "500", ""},
+			{walletaudit.TransferIn, "FIAT", "100", user1.Address()},
+			{walletaudit.TransferOut, "FIAT", "50", user1.Address()},
+			{walletaudit.Fee, "FIAT", "5", feeAggregator.Address()},
+		}, events(audit(user2.Address(), "10", "")))
+
+		require.Equal(t, []event{
+			{walletaudit.FeeIn, "FIAT", "10", user1.Address()},
+			{walletaudit.FeeIn, "FIAT", "5", user2.Address()},
+		}, events(audit(feeAggregator.Address(), "10", "")))
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		first := audit(user1.Address(), "3", "")
+		require.Len(t, first.Events, 3)
+		require.NotEmpty(t, first.Bookmark)
+
+		second := audit(user1.Address(), "3", first.Bookmark)
+		require.Equal(t, []event{
+			{walletaudit.TransferIn, "FIAT", "50", user2.Address()},
+		}, events(second))
+	})
+
+	t.Run("unknown wallet", func(t *testing.T) {
+		require.Empty(t, audit(ledger.NewWallet().Address(), "10", "").Events)
+	})
+
+	t.Run("invalid page size", func(t *testing.T) {
+		err := owner.InvokeWithError("fiat", "walletAudit", user1.Address(), "0", "")
+		require.ErrorContains(t, err, core.ErrInvalidWalletAuditPageSize.Error())
+	})
+}
+
+// TestQueryWalletAuditPaths checks that the allowed balance transfers, the admin balance transfers,
+// the HTLC claims, the channel transfers and the swaps on both sides are recorded to the audit trail of the wallet.
+func TestQueryWalletAuditPaths(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	cfg := &pb.Config{
+		Contract: &pb.ContractConfig{
+			Symbol:   "FIAT",
+			RobotSKI: fixtures_test.RobotHashedCert,
+			Admin:    &pb.Wallet{Address: owner.Address()},
+			Options:  &pb.ChaincodeOptions{WalletAudit: true},
+		},
+		Token: &pb.TokenConfig{
+			Name:     "FIAT",
+			Decimals: 8,
+			Issuer:   &pb.Wallet{Address: owner.Address()},
+		},
+	}
+	cfgBytes, err := protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg := ledger.NewCC("fiat", NewFiatTestToken(token.BaseToken{}), string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	cfg.Contract.Symbol = "CC"
+	cfg.Token.Name = "CC"
+	cfgBytes, err = protojson.Marshal(cfg)
+	require.NoError(t, err)
+
+	initMsg = ledger.NewCC("cc", &token.BaseToken{}, string(cfgBytes))
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user2 := ledger.NewWallet()
+
+	owner.SignedInvoke("fiat", "emit", user1.Address(), "1000")
+
+	user1.AddAllowedBalance("fiat", "VT", 100)
+	user1.SignedInvoke("fiat", "allowedBalanceTransfer", user2.Address(), "VT", "30")
+
+	data, err := json.Marshal(&pb.TransferRequest{
+		Basis:           pb.TransferBasis_TRANSFER_BASIS_INHERITANCE,
+		AdministratorId: owner.Address(),
+		DocumentType:    pb.DocumentType_DOCUMENT_TYPE_INHERITANCE,
+		DocumentNumber:  "1",
+		DocumentDate:    timestamppb.New(time.Now()),
+		DocumentHashes:  []string{"hash1"},
+		FromAddress:     user1.Address(),
+		ToAddress:       user2.Address(),
+		Amount:          "100",
+		Reason:          "test transfer",
+		BalanceType:     pb.BalanceType_BALANCE_TYPE_TOKEN,
+	})
+	require.NoError(t, err)
+	owner.SignedInvoke("fiat", "transferBalance", string(data))
+
+	htlcID := user1.SignedInvoke("fiat", testLockHTLCFnName, user2.Address(), "200", htlcHashlock(), "3600")
+	user2.SignedInvoke("fiat", testClaimHTLCFnName, htlcID, testHTLCPreimage)
+
+	transferID := "audit-transfer"
+	user1.SignedInvoke("fiat", "channelTransferByCustomer", transferID, "CC", "FIAT", "50")
+	user1.SignedInvoke("fiat", "channelTransferCancelByCustomer", transferID)
+
+	const swapKey = "swap secret"
+	swapHash := sha3.Sum256([]byte(swapKey))
+	swapID := user1.SignedInvoke("fiat", "swapBegin", "FIAT", "CC", "20", hex.EncodeToString(swapHash[:]))
+	ledger.WaitSwapAnswer("cc", swapID, time.Second*5)
+	user1.Invoke("cc", "swapDone", swapID, swapKey)
+
+	require.Equal(t, []walletAuditEvent{
+		{walletaudit.Emission, "FIAT", "1000", ""},
+		{walletaudit.TransferOut, "VT", "30", user2.Address()},
+		{walletaudit.TransferOut, "FIAT", "100", user2.Address()},
+		{walletaudit.TransferOut, "FIAT", "200", user2.Address()},
+		{walletaudit.ChannelTransferOut, "FIAT", "50", "CC"},
+		{walletaudit.ChannelTransferCancel, "FIAT", "50", "CC"},
+		{walletaudit.SwapOut, "FIAT", "20", "CC"},
+	}, walletAuditEvents(t, walletAudit(t, owner, "fiat", user1.Address(), "20", "")))
+
+	require.Equal(t, []walletAuditEvent{
+		{walletaudit.TransferIn, "VT", "30", user1.Address()},
+		{walletaudit.TransferIn, "FIAT", "100", user1.Address()},
+		{walletaudit.TransferIn, "FIAT", "200", user1.Address()},
+	}, walletAuditEvents(t, walletAudit(t, owner, "fiat", user2.Address(), "20", "")))
+
+	require.Equal(t, []walletAuditEvent{
+		{walletaudit.SwapIn, "FIAT", "20", "FIAT"},
+	}, walletAuditEvents(t, walletAudit(t, owner, "cc", user1.Address(), "20", "")))
+}
+
+// TestQueryWalletAuditDisabled checks that the audit trail is not kept unless the wallet_audit option is set
+func TestQueryWalletAuditDisabled(t *testing.T) {
+	ledger := mock.NewLedger(t)
+	owner := ledger.NewWallet()
+
+	config := makeBaseTokenConfig(testTokenName, testTokenSymbol, 8,
+		owner.Address(), "", "", "", nil)
+
+	initMsg := ledger.NewCC(testTokenCCName, &TestToken{}, config)
+	require.Empty(t, initMsg)
+
+	user1 := ledger.NewWallet()
+	user2 := ledger.NewWallet()
+	user1.AddBalance(testTokenCCName, 1000)
+	user1.SignedInvoke(testTokenCCName, "transfer", user2.Address(), "100", "")
+
+	err := owner.InvokeWithError(testTokenCCName, "walletAudit", user1.Address(), "10", "")
+	require.ErrorContains(t, err, core.ErrWalletAuditDisabled.Error())
+}
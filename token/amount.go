@@ -0,0 +1,157 @@
+package token
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/anoideaopen/foundation/core/types/big"
+	"github.com/anoideaopen/foundation/proto"
+)
+
+var (
+	ErrInvalidDisplayAmount    = errors.New("invalid display amount")
+	ErrDisplayAmountTooPrecise = errors.New("display amount has more fractional digits than the token decimals")
+)
+
+// Amount is an amount returned by balance queries.
+// It is marshaled to JSON according to the amount_format option of the chaincode.
+type Amount struct {
+	*big.Int
+	format      proto.AmountFormat
+	decimals    uint32
+	separator   string
+	decimalMark string
+}
+
+// formatAmount binds the amount to the output format configured for the token
+func (bt *BaseToken) formatAmount(value *big.Int) *Amount {
+	options := bt.ContractConfig().GetOptions()
+
+	return &Amount{
+		Int:         value,
+		format:      options.GetAmountFormat(),
+		decimals:    bt.TokenConfig().GetDecimals(),
+		separator:   options.GetAmountThousandsSeparator(),
+		decimalMark: options.GetAmountDecimalMark(),
+	}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (a *Amount) MarshalJSON() ([]byte, error) {
+	if a.Int == nil {
+		return json.Marshal(nil)
+	}
+
+	return json.Marshal(a.String())
+}
+
+// String returns the amount formatted according to the output format
+func (a *Amount) String() string {
+	switch a.format {
+	case proto.AmountFormat_AMOUNT_FORMAT_HEX:
+		if a.Sign() < 0 {
+			return "-0x" + new(big.Int).Neg(a.Int).Text(16)
+		}
+		return "0x" + a.Text(16)
+	case proto.AmountFormat_AMOUNT_FORMAT_DISPLAY:
+		return displayAmount(a.Int, a.decimals, a.separator, a.decimalMark)
+	default:
+		return a.Int.String()
+	}
+}
+
+// displayAmount formats base units as display units with the given number of decimals.
+// Digit groups of the integer part are separated by separator, if it is not empty,
+// and the fractional part is separated by decimalMark, "." if it is empty.
+func displayAmount(value *big.Int, decimals uint32, separator string, decimalMark string) string {
+	if decimalMark == "" {
+		decimalMark = "."
+	}
+
+	digits := new(big.Int).Abs(value).String()
+	if pad := int(decimals) + 1 - len(digits); decimals > 0 && pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+
+	point := len(digits) - int(decimals)
+	result := groupDigits(digits[:point], separator)
+	if decimals > 0 {
+		result += decimalMark + digits[point:]
+	}
+
+	if value.Sign() < 0 {
+		result = "-" + result
+	}
+
+	return result
+}
+
+// groupDigits separates groups of three digits by separator starting from the right
+func groupDigits(digits string, separator string) string {
+	const groupSize = 3
+
+	if separator == "" || len(digits) <= groupSize {
+		return digits
+	}
+
+	var sb strings.Builder
+	head := len(digits) % groupSize
+	if head > 0 {
+		sb.WriteString(digits[:head])
+	}
+	for i := head; i < len(digits); i += groupSize {
+		if sb.Len() > 0 {
+			sb.WriteString(separator)
+		}
+		sb.WriteString(digits[i : i+groupSize])
+	}
+
+	return sb.String()
+}
+
+// ParseDisplayAmount converts the display amount (e.g. "10.5") to base units according to the token decimals.
+// The fractional part is separated by amount_decimal_mark of the chaincode options, "." if it is not set.
+// The amount with more fractional digits than the decimals is rejected with ErrDisplayAmountTooPrecise.
+func (bt *BaseToken) ParseDisplayAmount(value string) (*big.Int, error) {
+	return parseDisplayAmount(
+		value,
+		bt.TokenConfig().GetDecimals(),
+		bt.ContractConfig().GetOptions().GetAmountDecimalMark(),
+	)
+}
+
+// parseDisplayAmount converts the non-negative display amount to base units, it is the inverse of displayAmount
+// without the digit groups separators
+func parseDisplayAmount(value string, decimals uint32, decimalMark string) (*big.Int, error) {
+	if decimalMark == "" {
+		decimalMark = "."
+	}
+
+	integer, fraction, found := strings.Cut(value, decimalMark)
+	if integer == "" || !isDigits(integer) || (found && (fraction == "" || !isDigits(fraction))) {
+		return nil, fmt.Errorf("%w: '%s'", ErrInvalidDisplayAmount, value)
+	}
+
+	if len(fraction) > int(decimals) {
+		return nil, fmt.Errorf("%w: '%s', decimals %d", ErrDisplayAmountTooPrecise, value, decimals)
+	}
+
+	amount, ok := new(big.Int).SetString(integer+fraction+strings.Repeat("0", int(decimals)-len(fraction)), 10) //nolint:gomnd
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrInvalidDisplayAmount, value)
+	}
+
+	return amount, nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,100 @@
+package token
+
+import (
+	"encoding/json"
+
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
+)
+
+// BalanceSwingEvent - event on balances changed by more than balance_swing_alert_percent of the token config
+const BalanceSwingEvent = "BalanceSwing"
+
+// BalanceSwing is a balance change of the wallet reported by BalanceSwingEvent
+type BalanceSwing struct {
+	Address    string   `json:"address"`
+	OldBalance *big.Int `json:"oldBalance"`
+	NewBalance *big.Int `json:"newBalance"`
+}
+
+// BalanceSwingsEvent is the payload of BalanceSwingEvent
+type BalanceSwingsEvent struct {
+	Swings []BalanceSwing `json:"swings"`
+}
+
+// balanceSnapshot is the token balances of wallets taken before the operation
+type balanceSnapshot struct {
+	addresses []*types.Address
+	balances  []*big.Int
+}
+
+// snapshotBalances takes token balances of the addresses if balance swing alerts are enabled,
+// otherwise it returns nil
+func (bt *BaseToken) snapshotBalances(addresses ...*types.Address) (*balanceSnapshot, error) {
+	if bt.TokenConfig().GetBalanceSwingAlertPercent() == 0 {
+		return nil, nil
+	}
+
+	snapshot := &balanceSnapshot{addresses: addresses}
+	for _, address := range addresses {
+		amount, err := bt.TokenBalanceGet(address)
+		if err != nil {
+			return nil, err
+		}
+		snapshot.balances = append(snapshot.balances, amount)
+	}
+
+	return snapshot, nil
+}
+
+// reportBalanceSwings compares the current token balances with the snapshot
+// and emits BalanceSwingEvent for balances changed by more than the configured percentage
+func (bt *BaseToken) reportBalanceSwings(snapshot *balanceSnapshot) error {
+	if snapshot == nil {
+		return nil
+	}
+
+	percent := big.NewInt(int64(bt.TokenConfig().GetBalanceSwingAlertPercent()))
+
+	event := BalanceSwingsEvent{}
+	for i, address := range snapshot.addresses {
+		current, err := bt.TokenBalanceGet(address)
+		if err != nil {
+			return err
+		}
+
+		old := snapshot.balances[i]
+		if isBalanceSwing(old, current, percent) {
+			event.Swings = append(event.Swings, BalanceSwing{
+				Address:    address.String(),
+				OldBalance: old,
+				NewBalance: current,
+			})
+		}
+	}
+
+	if len(event.Swings) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	return bt.GetStub().SetEvent(BalanceSwingEvent, data)
+}
+
+// isBalanceSwing reports whether the balance is changed by more than percent of the old balance
+func isBalanceSwing(old *big.Int, current *big.Int, percent *big.Int) bool {
+	change := new(big.Int).Sub(current, old)
+	change.Abs(change)
+
+	if change.Sign() == 0 {
+		return false
+	}
+
+	// change / old > percent / 100
+	change.Mul(change, big.NewInt(100)) //nolint:gomnd
+	return change.Cmp(new(big.Int).Mul(old, percent)) > 0
+}
@@ -24,6 +24,10 @@ func (bt *BaseToken) CheckLimitsAndPrice(method string, amount *big.Int, currenc
 
 // TxBuyToken buys tokens for an asset
 func (bt *BaseToken) TxBuyToken(sender *types.Sender, amount *big.Int, currency string) error {
+	if err := bt.CheckPaused(); err != nil {
+		return err
+	}
+
 	if sender.Equal(bt.Issuer()) {
 		return errors.New("impossible operation")
 	}
@@ -46,6 +50,10 @@ func (bt *BaseToken) TxBuyToken(sender *types.Sender, amount *big.Int, currency
 
 // TxBuyBack buys back tokens for an asset
 func (bt *BaseToken) TxBuyBack(sender *types.Sender, amount *big.Int, currency string) error {
+	if err := bt.CheckPaused(); err != nil {
+		return err
+	}
+
 	if sender.Equal(bt.Issuer()) {
 		return errors.New("impossible operation")
 	}
@@ -0,0 +1,119 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/helpers"
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
+	"github.com/anoideaopen/foundation/proto"
+)
+
+// EmissionPerAddressCompositeType is a composite key prefix for the total amount emitted to an address
+const EmissionPerAddressCompositeType = "emission_per_address"
+
+var (
+	ErrEmissionPerAddressExceeded   = errors.New("emission per address limit exceeded")
+	ErrInvalidMaxEmissionPerAddress = errors.New("max emission per address must be a non-negative integer")
+	ErrRecipientNotRegistered       = errors.New("emission recipient is not registered in ACL")
+)
+
+// EmissionAddTo adds emission of amount issued to address.
+// The total amount ever emitted to address is counted whether max_emission_per_address is set
+// in the token config or not, so the limit set later applies to the emissions made before it.
+// If the limit is set, the total amount can not exceed it. If require_registered_emission_recipient is set,
+// the address must be registered in ACL. The amount exceeding emission_approval_threshold is rejected
+// with ErrEmissionApprovalRequired, such emissions are executed by TxApproveEmission only.
+// The emission is recorded to the emission history.
+func (bt *BaseToken) EmissionAddTo(address *types.Address, amount *big.Int) error {
+	if err := bt.checkEmissionApprovalThreshold(amount); err != nil {
+		return err
+	}
+
+	return bt.emissionAddTo(address, amount)
+}
+
+// emissionAddTo is EmissionAddTo of the emission approved by the emission approvers
+func (bt *BaseToken) emissionAddTo(address *types.Address, amount *big.Int) error {
+	if bt.TokenConfig().GetRequireRegisteredEmissionRecipient() {
+		if _, err := helpers.GetFullAddress(bt.GetStub(), address.String()); err != nil {
+			return fmt.Errorf("%w: address %s: %s", ErrRecipientNotRegistered, address, err)
+		}
+	}
+
+	limit, err := maxEmissionPerAddress(bt.TokenConfig())
+	if err != nil {
+		return err
+	}
+
+	emitted, err := bt.emittedTo(address)
+	if err != nil {
+		return err
+	}
+
+	emitted.Add(emitted, amount)
+	if limit != nil && emitted.Cmp(limit) > 0 {
+		return fmt.Errorf("%w: address %s, limit %s", ErrEmissionPerAddressExceeded, address, limit)
+	}
+
+	if err = bt.saveEmittedTo(address, emitted); err != nil {
+		return err
+	}
+
+	if err = bt.emissionAdd(amount); err != nil {
+		return err
+	}
+
+	if err = bt.recordEmission(address, amount); err != nil {
+		return err
+	}
+
+	return bt.reportEmissionSwing(address, amount)
+}
+
+// reportEmissionSwing reports the balance swing of the emission of amount
+// already added to the balance of address
+func (bt *BaseToken) reportEmissionSwing(address *types.Address, amount *big.Int) error {
+	snapshot, err := bt.snapshotBalances(address)
+	if snapshot == nil || err != nil {
+		return err
+	}
+
+	snapshot.balances[0] = new(big.Int).Sub(snapshot.balances[0], amount)
+
+	return bt.reportBalanceSwings(snapshot)
+}
+
+func (bt *BaseToken) emittedTo(address *types.Address) (*big.Int, error) {
+	key, err := bt.GetStub().CreateCompositeKey(EmissionPerAddressCompositeType, []string{address.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := bt.GetStub().GetState(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
+
+func (bt *BaseToken) saveEmittedTo(address *types.Address, amount *big.Int) error {
+	key, err := bt.GetStub().CreateCompositeKey(EmissionPerAddressCompositeType, []string{address.String()})
+	if err != nil {
+		return err
+	}
+
+	return bt.GetStub().PutState(key, amount.Bytes())
+}
+
+// maxEmissionPerAddress returns the emission limit from the token config or nil if it is not set.
+func maxEmissionPerAddress(cfg *proto.TokenConfig) (*big.Int, error) {
+	limit, ok := parseConfigAmount(cfg.GetMaxEmissionPerAddress())
+	if !ok {
+		return nil, ErrInvalidMaxEmissionPerAddress
+	}
+
+	return limit, nil
+}
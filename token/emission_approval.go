@@ -0,0 +1,285 @@
+package token
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
+	"github.com/anoideaopen/foundation/proto"
+)
+
+// EmissionProposalCompositeType is a composite key prefix for the emission proposals
+const EmissionProposalCompositeType = "emission_proposal"
+
+var (
+	ErrInvalidEmissionApprovalThreshold = errors.New("emission approval threshold must be a non-negative integer")
+	ErrEmptyEmissionApprovers           = errors.New("emission approvers are not set for the emission approval")
+	ErrUnreachableEmissionApprovals     = errors.New("emission required approvals exceed the number of emission approvers")
+	ErrEmissionProposalNotFound         = errors.New("emission proposal not found")
+	ErrEmissionProposalExists           = errors.New("emission proposal already exists")
+	ErrEmissionProposalExecuted         = errors.New("emission proposal is already executed")
+	ErrEmissionAlreadyApproved          = errors.New("emission proposal is already approved by the sender")
+	ErrNotEmissionApprover              = errors.New("sender is not an emission approver")
+	ErrEmissionApprovalRequired         = errors.New("emission exceeds the approval threshold and must be proposed")
+)
+
+// EmissionProposal is the emission proposed by the issuer and waiting for approvals
+type EmissionProposal struct {
+	ID        string   `json:"id"`
+	Address   string   `json:"address"`
+	Amount    *big.Int `json:"amount"`
+	Approvals []string `json:"approvals"`
+	Executed  bool     `json:"executed"`
+}
+
+// TxProposeEmission proposes emission of amount to address. Method can be called by the issuer only.
+// If emission_approval_threshold is not set in the token config or amount does not exceed it,
+// the emission is executed immediately. Otherwise the pending proposal with the id of
+// the transaction, followed by the sequence number of the proposal if the transaction proposes several
+// emissions, e.g. the signed batch, is created and the emission is executed by TxApproveEmission.
+func (bt *BaseToken) TxProposeEmission(sender *types.Sender, address *types.Address, amount *big.Int) (string, error) {
+	if !sender.Equal(bt.Issuer()) {
+		return "", errors.New("unauthorized")
+	}
+
+	if amount.Sign() <= 0 {
+		return "", errors.New("amount should be more than zero")
+	}
+
+	threshold, ok := parseConfigAmount(bt.TokenConfig().GetEmissionApprovalThreshold())
+	if !ok {
+		return "", ErrInvalidEmissionApprovalThreshold
+	}
+
+	if threshold == nil || amount.Cmp(threshold) <= 0 {
+		return "", bt.emit(address, amount)
+	}
+
+	id, err := newTxScopedID(bt.GetStub().GetTxID(), bt.emissionProposalExists)
+	if err != nil {
+		return "", err
+	}
+
+	proposal := &EmissionProposal{
+		ID:        id,
+		Address:   address.String(),
+		Amount:    amount,
+		Approvals: []string{},
+	}
+
+	if err = bt.createEmissionProposal(proposal); err != nil {
+		return "", err
+	}
+
+	return proposal.ID, nil
+}
+
+// TxApproveEmission approves the emission proposal. Method can be called by the emission approvers only.
+// The issuer can not approve its own proposals even if it is listed among the approvers.
+// The emission is executed once the proposal gets emission_required_approvals approvals.
+func (bt *BaseToken) TxApproveEmission(sender *types.Sender, proposalID string) error {
+	if sender.Equal(bt.Issuer()) || !isEmissionApprover(bt.TokenConfig(), sender) {
+		return ErrNotEmissionApprover
+	}
+
+	proposal, err := bt.QueryEmissionProposal(proposalID)
+	if err != nil {
+		return err
+	}
+
+	if proposal.Executed {
+		return fmt.Errorf("%w: %s", ErrEmissionProposalExecuted, proposalID)
+	}
+
+	approver := sender.Address().String()
+	for _, approval := range proposal.Approvals {
+		if approval == approver {
+			return fmt.Errorf("%w: %s", ErrEmissionAlreadyApproved, proposalID)
+		}
+	}
+
+	proposal.Approvals = append(proposal.Approvals, approver)
+
+	if uint32(len(proposal.Approvals)) >= requiredEmissionApprovals(bt.TokenConfig()) {
+		address, err := types.AddrFromBase58Check(proposal.Address)
+		if err != nil {
+			return err
+		}
+
+		if err = bt.TokenBalanceAdd(address, proposal.Amount, "emission"); err != nil {
+			return err
+		}
+
+		if err = bt.emissionAddTo(address, proposal.Amount); err != nil {
+			return err
+		}
+
+		proposal.Executed = true
+	}
+
+	return bt.saveEmissionProposal(proposal)
+}
+
+// QueryEmissionProposal returns the emission proposal by id
+func (bt *BaseToken) QueryEmissionProposal(proposalID string) (*EmissionProposal, error) {
+	key, err := bt.GetStub().CreateCompositeKey(EmissionProposalCompositeType, []string{proposalID})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := bt.GetStub().GetState(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrEmissionProposalNotFound, proposalID)
+	}
+
+	proposal := &EmissionProposal{}
+	if err = json.Unmarshal(data, proposal); err != nil {
+		return nil, err
+	}
+
+	return proposal, nil
+}
+
+// emissionProposalExists reports if the emission proposal with the id is created
+func (bt *BaseToken) emissionProposalExists(id string) (bool, error) {
+	key, err := bt.GetStub().CreateCompositeKey(EmissionProposalCompositeType, []string{id})
+	if err != nil {
+		return false, err
+	}
+
+	data, err := bt.GetStub().GetState(key)
+	if err != nil {
+		return false, err
+	}
+
+	return len(data) != 0, nil
+}
+
+// createEmissionProposal stores the new emission proposal, the id used by another proposal is rejected
+func (bt *BaseToken) createEmissionProposal(proposal *EmissionProposal) error {
+	exists, err := bt.emissionProposalExists(proposal.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: %s", ErrEmissionProposalExists, proposal.ID)
+	}
+
+	return bt.saveEmissionProposal(proposal)
+}
+
+func (bt *BaseToken) saveEmissionProposal(proposal *EmissionProposal) error {
+	key, err := bt.GetStub().CreateCompositeKey(EmissionProposalCompositeType, []string{proposal.ID})
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(proposal)
+	if err != nil {
+		return err
+	}
+
+	return bt.GetStub().PutState(key, data)
+}
+
+func (bt *BaseToken) emit(address *types.Address, amount *big.Int) error {
+	if err := bt.TokenBalanceAdd(address, amount, "emission"); err != nil {
+		return err
+	}
+
+	return bt.EmissionAddTo(address, amount)
+}
+
+// checkEmissionApprovalThreshold rejects the emission of amount exceeding emission_approval_threshold
+// of the token config, as such emissions must be approved by the emission approvers
+func (bt *BaseToken) checkEmissionApprovalThreshold(amount *big.Int) error {
+	threshold, ok := parseConfigAmount(bt.TokenConfig().GetEmissionApprovalThreshold())
+	if !ok {
+		return ErrInvalidEmissionApprovalThreshold
+	}
+
+	if threshold != nil && amount.Cmp(threshold) > 0 {
+		return fmt.Errorf("%w: threshold %s", ErrEmissionApprovalRequired, threshold)
+	}
+
+	return nil
+}
+
+func isEmissionApprover(cfg *proto.TokenConfig, sender *types.Sender) bool {
+	for _, approver := range cfg.GetEmissionApprovers() {
+		address, err := types.AddrFromBase58Check(approver.GetAddress())
+		if err != nil {
+			continue
+		}
+
+		if sender.Equal(address) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkEmissionApprovals checks that the emission proposals above emission_approval_threshold
+// of the token config can collect the required approvals from the emission approvers.
+// The issuer is not counted, as it can not approve its own proposals.
+func checkEmissionApprovals(cfg *proto.TokenConfig) error {
+	threshold, ok := parseConfigAmount(cfg.GetEmissionApprovalThreshold())
+	if !ok {
+		return ErrInvalidEmissionApprovalThreshold
+	}
+
+	if threshold == nil {
+		return nil
+	}
+
+	approvers := 0
+	for _, approver := range cfg.GetEmissionApprovers() {
+		if approver.GetAddress() != cfg.GetIssuer().GetAddress() {
+			approvers++
+		}
+	}
+	if approvers == 0 {
+		return ErrEmptyEmissionApprovers
+	}
+
+	if required := requiredEmissionApprovals(cfg); required > uint32(approvers) {
+		return fmt.Errorf("%w: required %d, approvers %d", ErrUnreachableEmissionApprovals, required, approvers)
+	}
+
+	return nil
+}
+
+// requiredEmissionApprovals returns the number of approvals required to execute the emission proposal.
+func requiredEmissionApprovals(cfg *proto.TokenConfig) uint32 {
+	if cfg.GetEmissionRequiredApprovals() == 0 {
+		return 1
+	}
+
+	return cfg.GetEmissionRequiredApprovals()
+}
+
+// newTxScopedID returns the first id of the transaction not used yet: the transaction id,
+// followed by the sequence number if the transaction creates several entities, e.g. the signed batch
+func newTxScopedID(txID string, used func(id string) (bool, error)) (string, error) {
+	for seq := 0; ; seq++ {
+		id := txID
+		if seq != 0 {
+			id = fmt.Sprintf("%s.%d", txID, seq)
+		}
+
+		exists, err := used(id)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return id, nil
+		}
+	}
+}
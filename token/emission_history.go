@@ -0,0 +1,144 @@
+package token
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
+	"github.com/anoideaopen/foundation/core/walletaudit"
+)
+
+// EmissionHistoryPrefix is a key prefix for the emission records.
+// The keys are simple keys to be queried by range: prefix and zero padded sequence number of the emission.
+const EmissionHistoryPrefix = "/emission_history/"
+
+// EmissionCountCompositeType is a composite key prefix for the number of the recorded emissions
+const EmissionCountCompositeType = "emission_count"
+
+// emissionSeqDigits is the number of digits of the greatest sequence number
+const emissionSeqDigits = 19
+
+// EmissionRecord is a single emission of the token
+type EmissionRecord struct {
+	// Recipient is the address the tokens are emitted to, empty if the emission is added by EmissionAdd
+	Recipient string   `json:"recipient,omitempty"`
+	Amount    *big.Int `json:"amount"`
+	Timestamp int64    `json:"timestamp"`
+	TxID      string   `json:"txId"`
+}
+
+// EmissionHistory is a page of the emission records
+type EmissionHistory struct {
+	Records         []EmissionRecord `json:"records"`
+	Bookmark        string           `json:"bookmark,omitempty"`
+	PageSizeClamped bool             `json:"pageSizeClamped,omitempty"`
+}
+
+// QueryEmissionHistory returns a page of the emissions of the token in the order they are made.
+// Pass the returned bookmark to get the next page, an empty bookmark means that all emissions are returned.
+// Emissions made before the history was introduced are not returned.
+func (bt *BaseToken) QueryEmissionHistory(pageSize int64, bookmark string) (*EmissionHistory, error) {
+	if pageSize <= 0 {
+		return nil, ErrInvalidHistoryPageSize
+	}
+
+	if bookmark != "" && !strings.HasPrefix(bookmark, EmissionHistoryPrefix) {
+		return nil, fmt.Errorf("invalid bookmark %s", bookmark)
+	}
+
+	pageSize, clamped := bt.ClampPageSize(pageSize)
+
+	iter, meta, err := bt.GetStub().GetStateByRangeWithPagination(
+		EmissionHistoryPrefix,
+		EmissionHistoryPrefix+"~",
+		int32(pageSize),
+		bookmark,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	history := &EmissionHistory{Records: []EmissionRecord{}, PageSizeClamped: clamped}
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		var record EmissionRecord
+		if err = json.Unmarshal(kv.GetValue(), &record); err != nil {
+			return nil, fmt.Errorf("unmarshalling emission record %s: %w", kv.GetKey(), err)
+		}
+
+		history.Records = append(history.Records, record)
+	}
+
+	if meta != nil {
+		history.Bookmark = meta.GetBookmark()
+	}
+
+	return history, nil
+}
+
+// recordEmission appends the emission of amount to the emission history and to the audit trail of the recipient,
+// recipient is nil if it is unknown
+func (bt *BaseToken) recordEmission(recipient *types.Address, amount *big.Int) error {
+	stub := bt.GetStub()
+
+	countKey, err := stub.CreateCompositeKey(EmissionCountCompositeType, []string{})
+	if err != nil {
+		return err
+	}
+
+	data, err := stub.GetState(countKey)
+	if err != nil {
+		return err
+	}
+
+	var seq uint64 = 1
+	if len(data) != 0 {
+		if seq, err = strconv.ParseUint(string(data), 10, 64); err != nil {
+			return fmt.Errorf("parsing emission count: %w", err)
+		}
+		seq++
+	}
+
+	ts, err := stub.GetTxTimestamp()
+	if err != nil {
+		return err
+	}
+
+	record := EmissionRecord{
+		Amount:    new(big.Int).Set(amount),
+		Timestamp: ts.GetSeconds(),
+		TxID:      stub.GetTxID(),
+	}
+	if recipient != nil {
+		record.Recipient = recipient.String()
+	}
+
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if err = stub.PutState(fmt.Sprintf("%s%0*d", EmissionHistoryPrefix, emissionSeqDigits, seq), value); err != nil {
+		return err
+	}
+
+	if err = stub.PutState(countKey, []byte(strconv.FormatUint(seq, 10))); err != nil {
+		return err
+	}
+
+	if recipient == nil {
+		return nil
+	}
+
+	return bt.WalletAuditAdd(recipient, walletaudit.Emission, bt.ContractConfig().GetSymbol(), amount, "")
+}
@@ -0,0 +1,83 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const (
+	// EmissionKeyCompositeType is a composite key prefix for the used emission keys
+	EmissionKeyCompositeType = "emission_key"
+	// EmissionKeySequenceCompositeType is a composite key prefix for the last sequential emission key
+	EmissionKeySequenceCompositeType = "emission_key_sequence"
+)
+
+var (
+	ErrEmptyEmissionKey         = errors.New("emission key is empty")
+	ErrEmissionKeyUsed          = errors.New("emission key is already used")
+	ErrEmissionKeyNotSequential = errors.New("emission key is not the next in the sequence")
+)
+
+// UseEmissionKey marks the idempotency key of an emission as used, so the emission
+// with the same key can not be applied twice. Tokens call it before EmissionAddTo.
+// If sequential_emission_keys is set in the token config, the key must be the decimal
+// integer following the last used key, the first key is 1.
+func (bt *BaseToken) UseEmissionKey(key string) error {
+	if key == "" {
+		return ErrEmptyEmissionKey
+	}
+
+	stub := bt.GetStub()
+
+	if bt.TokenConfig().GetSequentialEmissionKeys() {
+		if err := bt.nextEmissionKeySequence(key); err != nil {
+			return err
+		}
+	}
+
+	compositeKey, err := stub.CreateCompositeKey(EmissionKeyCompositeType, []string{key})
+	if err != nil {
+		return err
+	}
+
+	used, err := stub.GetState(compositeKey)
+	if err != nil {
+		return err
+	}
+
+	if len(used) != 0 {
+		return fmt.Errorf("%w: %s", ErrEmissionKeyUsed, key)
+	}
+
+	return stub.PutState(compositeKey, []byte(stub.GetTxID()))
+}
+
+// nextEmissionKeySequence moves the sequence of emission keys to key if it is the next one
+func (bt *BaseToken) nextEmissionKeySequence(key string) error {
+	stub := bt.GetStub()
+
+	sequenceKey, err := stub.CreateCompositeKey(EmissionKeySequenceCompositeType, []string{})
+	if err != nil {
+		return err
+	}
+
+	data, err := stub.GetState(sequenceKey)
+	if err != nil {
+		return err
+	}
+
+	var last uint64
+	if len(data) != 0 {
+		if last, err = strconv.ParseUint(string(data), 10, 64); err != nil {
+			return err
+		}
+	}
+
+	next := strconv.FormatUint(last+1, 10)
+	if key != next {
+		return fmt.Errorf("%w: got '%s', expected '%s'", ErrEmissionKeyNotSequential, key, next)
+	}
+
+	return stub.PutState(sequenceKey, []byte(next))
+}
@@ -0,0 +1,44 @@
+package token
+
+import (
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
+)
+
+// FeeRemainderCompositeType is a composite key prefix for the accumulated fee remainders of the payers
+const FeeRemainderCompositeType = "fee_remainder"
+
+// feeRateDenominator is the denominator of the rate of the fee paid in the token of the contract
+func feeRateDenominator() *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(RateDecimal), nil) //nolint:gomnd
+}
+
+// feeRemainderDenominator is the number of the fee remainder units in the base unit
+func feeRemainderDenominator() *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(feeDecimals+RateDecimal), nil) //nolint:gomnd
+}
+
+// accumulateFeeRemainder adds the fee remainder to the remainders accumulated by the payer in the currency
+// and returns the whole base units to collect with the fee, the rest is kept for the next fees.
+func (bt *BaseToken) accumulateFeeRemainder(payer *types.Address, currency string, remainder *big.Int) (*big.Int, error) {
+	stub := bt.GetStub()
+
+	key, err := stub.CreateCompositeKey(FeeRemainderCompositeType, []string{payer.String(), currency})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := stub.GetState(key)
+	if err != nil {
+		return nil, err
+	}
+
+	accumulated := new(big.Int).Add(new(big.Int).SetBytes(data), remainder)
+	collected, rest := new(big.Int).QuoRem(accumulated, feeRemainderDenominator(), new(big.Int))
+
+	if err = stub.PutState(key, rest.Bytes()); err != nil {
+		return nil, err
+	}
+
+	return collected, nil
+}
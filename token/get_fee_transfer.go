@@ -46,7 +46,7 @@ func (bt *BaseToken) QueryGetFeeTransfer(req FeeTransferRequestDTO) (*FeeTransfe
 		return nil, ErrFeeAddressNotConfigured
 	}
 
-	fee, err := bt.calcTransferFee(req.Amount, req.SenderAddress, req.RecipientAddress)
+	fee, _, err := bt.calcTransferFee(req.Amount, req.SenderAddress, req.RecipientAddress)
 	if err != nil {
 		return nil, fmt.Errorf("failed to calc transfer fee: %w", err)
 	}
@@ -0,0 +1,71 @@
+package token
+
+import (
+	"errors"
+
+	"github.com/anoideaopen/foundation/core/balance"
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
+)
+
+var ErrInvalidHistoryPageSize = errors.New("page size must be positive")
+
+// BalanceHistoryEntry is a single modification of the token balance
+type BalanceHistoryEntry struct {
+	TxID      string   `json:"txId"`
+	Timestamp int64    `json:"timestamp"`
+	Balance   *big.Int `json:"balance"`
+	IsDeleted bool     `json:"isDeleted,omitempty"`
+}
+
+// BalanceHistory is a page of the token balance history
+type BalanceHistory struct {
+	Entries         []BalanceHistoryEntry `json:"entries"`
+	Bookmark        string                `json:"bookmark,omitempty"`
+	PageSizeClamped bool                  `json:"pageSizeClamped,omitempty"`
+}
+
+// QueryBalanceHistory returns a page of the token balance history of the address, the most recent change first.
+// Pass the returned bookmark to get the next page, an empty bookmark means that all history is returned.
+func (bt *BaseToken) QueryBalanceHistory(address *types.Address, pageSize int64, bookmark string) (*BalanceHistory, error) {
+	if pageSize <= 0 {
+		return nil, ErrInvalidHistoryPageSize
+	}
+
+	pageSize, clamped := bt.ClampPageSize(pageSize)
+
+	entries, nextBookmark, err := balance.ListHistory(
+		bt.BalanceStub(),
+		balance.BalanceTypeToken,
+		address.String(),
+		"",
+		int(pageSize),
+		bookmark,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &BalanceHistory{
+		Entries:         make([]BalanceHistoryEntry, 0, len(entries)),
+		Bookmark:        nextBookmark,
+		PageSizeClamped: clamped,
+	}
+	for _, entry := range entries {
+		history.Entries = append(history.Entries, BalanceHistoryEntry{
+			TxID:      entry.TxID,
+			Timestamp: entry.Timestamp,
+			Balance:   new(big.Int).SetBytes(entry.Balance.Bytes()),
+			IsDeleted: entry.IsDeleted,
+		})
+	}
+
+	return history, nil
+}
+
+// QueryBalanceExists returns true if the token balance of the address has ever been written,
+// so the never funded address is distinguished from the address with the balance spent to zero.
+// The peer history database must be enabled to detect the balances spent to zero.
+func (bt *BaseToken) QueryBalanceExists(address *types.Address) (bool, error) {
+	return balance.Written(bt.BalanceStub(), balance.BalanceTypeToken, address.String(), "")
+}
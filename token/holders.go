@@ -0,0 +1,74 @@
+package token
+
+import (
+	"errors"
+
+	"github.com/anoideaopen/foundation/core/balance"
+)
+
+var ErrInvalidHoldersPageSize = errors.New("page size must be positive")
+
+// Holders is a page of the token holders
+type Holders struct {
+	Addresses       []string `json:"addresses"`
+	Bookmark        string   `json:"bookmark,omitempty"`
+	PageSizeClamped bool     `json:"pageSizeClamped,omitempty"`
+}
+
+// QueryHolders returns a page of addresses having non-zero token balance in the order of addresses.
+// Zero balances are skipped, so a page can contain less than pageSize addresses.
+// Pass the returned bookmark to get the next page, an empty bookmark means that all holders are listed.
+func (bt *BaseToken) QueryHolders(pageSize int64, bookmark string) (*Holders, error) {
+	if pageSize <= 0 {
+		return nil, ErrInvalidHoldersPageSize
+	}
+
+	pageSize, clamped := bt.ClampPageSize(pageSize)
+
+	stub := bt.GetStub()
+
+	iter, meta, err := stub.GetStateByPartialCompositeKeyWithPagination(
+		balance.BalanceTypeToken.String(),
+		[]string{},
+		int32(pageSize),
+		bookmark,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	holders := &Holders{Addresses: []string{}, PageSizeClamped: clamped}
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		_, components, err := stub.SplitCompositeKey(kv.GetKey())
+		if err != nil {
+			return nil, err
+		}
+
+		if len(components) == 0 {
+			continue
+		}
+
+		amount, err := balance.Decode(bt.BalanceStub(), kv.GetValue())
+		if err != nil {
+			return nil, err
+		}
+
+		if amount.Sign() == 0 {
+			continue
+		}
+
+		holders.Addresses = append(holders.Addresses, components[0])
+	}
+
+	holders.Bookmark = meta.GetBookmark()
+
+	return holders, nil
+}
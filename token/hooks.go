@@ -0,0 +1,54 @@
+package token
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/grpc"
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
+)
+
+// ErrReentrantTransfer is returned when a transfer is called from within the post transfer hook
+var ErrReentrantTransfer = errors.New("transfer is not allowed from within post transfer hook")
+
+// PostTransferHook is called synchronously after a successful token transfer within the same transaction.
+// The transaction stub is available with grpc.StubFromContext and the sender with grpc.SenderFromContext.
+// The hook can make further state changes, an error returned by it aborts the whole transaction.
+// Transfers called from within the hook are rejected with ErrReentrantTransfer.
+type PostTransferHook func(ctx context.Context, from *types.Address, to *types.Address, amount *big.Int) error
+
+// SetPostTransferHook registers hook to be called after every successful TxTransfer
+func (bt *BaseToken) SetPostTransferHook(hook PostTransferHook) {
+	bt.postTransferHook = hook
+}
+
+func (bt *BaseToken) runPostTransferHook(from *types.Address, to *types.Address, amount *big.Int) error {
+	if bt.postTransferHook == nil {
+		return nil
+	}
+
+	bt.inPostTransferHook = true
+	defer func() {
+		bt.inPostTransferHook = false
+	}()
+
+	ctx := grpc.ContextWithStub(context.Background(), bt.GetStub())
+	ctx = grpc.ContextWithSender(ctx, from.String())
+
+	if err := bt.postTransferHook(ctx, from, to, amount); err != nil {
+		return fmt.Errorf("post transfer hook: %w", err)
+	}
+
+	return nil
+}
+
+// checkReentrantTransfer rejects the transfer if it is called from within the post transfer hook
+func (bt *BaseToken) checkReentrantTransfer() error {
+	if bt.inPostTransferHook {
+		return ErrReentrantTransfer
+	}
+
+	return nil
+}
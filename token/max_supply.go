@@ -0,0 +1,71 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/types/big"
+	"github.com/anoideaopen/foundation/proto"
+)
+
+var (
+	ErrMaxSupplyExceeded = errors.New("max supply exceeded")
+	ErrInvalidMaxSupply  = errors.New("max supply must be a non-negative integer")
+)
+
+// RemainingSupply is the amount which can still be emitted
+type RemainingSupply struct {
+	Unlimited bool     `json:"unlimited,omitempty"`
+	MaxSupply *big.Int `json:"maxSupply,omitempty"`
+	Remaining *big.Int `json:"remaining,omitempty"`
+}
+
+// QueryRemainingSupply returns max_supply of the token config minus the total emission.
+// Burned tokens are subtracted from the total emission, so they can be emitted again.
+// Unlimited is set if max_supply is not configured.
+func (bt *BaseToken) QueryRemainingSupply() (*RemainingSupply, error) {
+	limit, err := maxSupply(bt.TokenConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	if limit == nil {
+		return &RemainingSupply{Unlimited: true}, nil
+	}
+
+	if err = bt.loadConfigUnlessLoaded(); err != nil {
+		return nil, err
+	}
+
+	remaining := new(big.Int).Sub(limit, new(big.Int).SetBytes(bt.config.GetTotalEmission()))
+	if remaining.Sign() < 0 {
+		// the limit is lowered below the emission
+		remaining = big.NewInt(0)
+	}
+
+	return &RemainingSupply{MaxSupply: limit, Remaining: remaining}, nil
+}
+
+// checkMaxSupply returns ErrMaxSupplyExceeded if the total emission exceeds max_supply of the token config
+func (bt *BaseToken) checkMaxSupply(totalEmission *big.Int) error {
+	limit, err := maxSupply(bt.TokenConfig())
+	if err != nil {
+		return err
+	}
+
+	if limit != nil && totalEmission.Cmp(limit) > 0 {
+		return fmt.Errorf("%w: limit %s", ErrMaxSupplyExceeded, limit)
+	}
+
+	return nil
+}
+
+// maxSupply returns the total emission limit from the token config or nil if it is not set.
+func maxSupply(cfg *proto.TokenConfig) (*big.Int, error) {
+	limit, ok := parseConfigAmount(cfg.GetMaxSupply())
+	if !ok {
+		return nil, ErrInvalidMaxSupply
+	}
+
+	return limit, nil
+}
@@ -41,6 +41,14 @@ type Fee struct {
 	Cap      *big.Int `json:"cap"`
 }
 
+// TokenMetadata is a struct for token display metadata
+type TokenMetadata struct {
+	Name            string `json:"name"`
+	Symbol          string `json:"symbol"`
+	Decimals        uint   `json:"decimals"`
+	UnderlyingAsset string `json:"underlying_asset"` //nolint:tagliatelle
+}
+
 // QueryMetadata returns Metadata
 func (bt *BaseToken) QueryMetadata() (*Metadata, error) {
 	if err := bt.loadConfigUnlessLoaded(); err != nil {
@@ -81,14 +89,79 @@ func (bt *BaseToken) QueryMetadata() (*Metadata, error) {
 	return m, nil
 }
 
-// QueryBalanceOf returns balance
-func (bt *BaseToken) QueryBalanceOf(address *types.Address) (*big.Int, error) {
-	return bt.TokenBalanceGet(address)
+// QueryTokenMetadata returns TokenMetadata from the applied token config
+func (bt *BaseToken) QueryTokenMetadata() (*TokenMetadata, error) {
+	return &TokenMetadata{
+		Name:            bt.TokenConfig().GetName(),
+		Symbol:          bt.ContractConfig().GetSymbol(),
+		Decimals:        uint(bt.TokenConfig().GetDecimals()),
+		UnderlyingAsset: bt.TokenConfig().GetUnderlyingAsset(),
+	}, nil
 }
 
-// QueryAllowedBalanceOf returns allowed balance
-func (bt *BaseToken) QueryAllowedBalanceOf(address *types.Address, token string) (*big.Int, error) {
-	return bt.AllowedBalanceGet(token, address)
+// QueryBalanceOf returns balance formatted according to the amount_format option
+func (bt *BaseToken) QueryBalanceOf(address *types.Address) (*Amount, error) {
+	value, err := bt.TokenBalanceGet(address)
+	if err != nil {
+		return nil, err
+	}
+
+	return bt.formatAmount(value), nil
+}
+
+// BalanceDetails is the balance with the decimals of the token
+type BalanceDetails struct {
+	// Balance is the balance in base units
+	Balance *big.Int `json:"balance"`
+	// Decimals is the number of decimals of the token
+	Decimals uint32 `json:"decimals"`
+	// Display is the balance in display units formatted with amount_thousands_separator
+	// and amount_decimal_mark of the chaincode options
+	Display string `json:"display"`
+}
+
+// QueryBalanceDetails returns balance with the decimals of the token and the formatted display value
+// regardless of the amount_format option, so clients do not need to query the token metadata.
+func (bt *BaseToken) QueryBalanceDetails(address *types.Address) (*BalanceDetails, error) {
+	value, err := bt.TokenBalanceGet(address)
+	if err != nil {
+		return nil, err
+	}
+
+	options := bt.ContractConfig().GetOptions()
+	decimals := bt.TokenConfig().GetDecimals()
+
+	return &BalanceDetails{
+		Balance:  value,
+		Decimals: decimals,
+		Display:  displayAmount(value, decimals, options.GetAmountThousandsSeparator(), options.GetAmountDecimalMark()),
+	}, nil
+}
+
+// QueryAllowedBalanceOf returns allowed balance formatted according to the amount_format option
+func (bt *BaseToken) QueryAllowedBalanceOf(address *types.Address, token string) (*Amount, error) {
+	value, err := bt.AllowedBalanceGet(token, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return bt.formatAmount(value), nil
+}
+
+// QueryAllowedBalancesBatch returns allowed balances of the token of the addresses by address
+// formatted like in QueryAllowedBalanceOf. Addresses without the balance have zero balance.
+func (bt *BaseToken) QueryAllowedBalancesBatch(addresses []*types.Address, token string) (map[string]*Amount, error) {
+	balances := make(map[string]*Amount, len(addresses))
+	for _, address := range addresses {
+		value, err := bt.AllowedBalanceGet(token, address)
+		if err != nil {
+			return nil, err
+		}
+
+		balances[address.String()] = bt.formatAmount(value)
+	}
+
+	return balances, nil
 }
 
 // QueryLockedBalanceOf returns locked balance
@@ -112,6 +112,31 @@ func TestMetadataMethods(t *testing.T) {
 		"lockTokenBalance", "metadata", "multiSwapBegin", "multiSwapCancel", "multiSwapGet",
 		"nameOfFiles", "predictFee", "setFee", "setFeeAddress", "setLimits", "setRate",
 		"srcFile", "srcPartFile", "swapBegin", "swapCancel", "swapGet", "systemEnv", "transfer",
-		"unlockAllowedBalance", "healthCheckNb", "unlockTokenBalance", "transferBalance"}
+		"unlockAllowedBalance", "healthCheckNb", "unlockTokenBalance", "transferBalance",
+		"verifySignature", "exportState", "importState",
+		"lockedHTLC", "lockHTLC", "claimHTLC", "refundHTLC", "tokenMetadata",
+		"balanceHistory", "maintenanceMode", "setMaintenanceMode", "transferStatus", "blockInfo", "allowedBalanceTransfer",
+		"freezeAddress", "unfreezeAddress", "frozenAddresses", "capabilities", "channelStats", "channelTransferMemo", "channelTransfer", "channelTransferCancelByCustomer", "proposeEmission", "approveEmission", "emissionProposal", "predictChannelTransferFee", "pause", "unpause", "isPaused", "transfersByStatus", "version", "sweepDust", "holders", "remainingSupply", "channelMultiTransferByAdmin", "pruneTransfers", "addressKeyType", "rotateKey", "channelTransferReceipt", "validateConfig", "channelTransferByCustomerWithExpiry", "reapExpiredTransfers", "allowedBalancesBatch", "cancelAllTransfersForAddress", "rawState", "privilegedMethods", "transfersByTimeRange", "channelTransferLinkedByCustomer", "netTransfer", "balanceExists", "pendingOperation", "topology", "channelTransferFromByCustomer", "emissionHistory", "configHash", "scheduleEmission", "executeScheduledEmissions", "balanceDetails", "reserveTransferID", "totalLocked", "publicKey", "swapsByToken", "wouldAcceptNonce", "walletAudit", "pickUpCCTransferFrom", "cancelScheduledEmission"}
 	require.ElementsMatch(t, tokenMethods, meta.Methods)
 }
+
+func TestPrivilegedMethodsExist(t *testing.T) {
+	ledger := ma.NewLedger(t)
+	issuer := ledger.NewWallet()
+
+	tt := &BaseToken{}
+	config := makeBaseTokenConfig("Test Token", "TT", 8,
+		issuer.Address(), "", "")
+	initMsg := ledger.NewCC("tt", tt, config)
+	require.Empty(t, initMsg)
+
+	var meta Metadata
+	require.NoError(t, json.Unmarshal([]byte(issuer.Invoke("tt", "metadata")), &meta))
+
+	var privileged map[string][]string
+	require.NoError(t, json.Unmarshal([]byte(issuer.Invoke("tt", "privilegedMethods")), &privileged))
+
+	for method := range privileged {
+		require.Contains(t, meta.Methods, method)
+	}
+}
@@ -0,0 +1,64 @@
+package token
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
+	"github.com/anoideaopen/foundation/proto"
+)
+
+var (
+	ErrBalanceBelowMinimum = errors.New("balance left after transfer is below the minimum")
+	ErrInvalidMinBalance   = errors.New("min balance must be a non-negative integer")
+)
+
+// checkMinBalance returns ErrBalanceBelowMinimum if the token balance of address
+// is not zero and less than min_balance from the token config.
+func (bt *BaseToken) checkMinBalance(address *types.Address) error {
+	threshold, err := minBalance(bt.TokenConfig())
+	if err != nil {
+		return err
+	}
+
+	if threshold == nil || threshold.Sign() == 0 {
+		return nil
+	}
+
+	balance, err := bt.TokenBalanceGet(address)
+	if err != nil {
+		return err
+	}
+
+	if balance.Sign() != 0 && balance.Cmp(threshold) < 0 {
+		return fmt.Errorf("%w: balance %s, minimum %s", ErrBalanceBelowMinimum, balance, threshold)
+	}
+
+	return nil
+}
+
+// minBalance returns the minimum balance from the token config or nil if it is not set.
+func minBalance(cfg *proto.TokenConfig) (*big.Int, error) {
+	threshold, ok := parseConfigAmount(cfg.GetMinBalance())
+	if !ok {
+		return nil, ErrInvalidMinBalance
+	}
+
+	return threshold, nil
+}
+
+// parseConfigAmount parses a non-negative decimal amount of the token config.
+// Returns nil if the value is empty and false if it is invalid.
+func parseConfigAmount(value string) (*big.Int, bool) {
+	if value == "" {
+		return nil, true
+	}
+
+	amount, ok := new(big.Int).SetString(value, 10) //nolint:gomnd
+	if !ok || amount.Sign() < 0 {
+		return nil, false
+	}
+
+	return amount, true
+}
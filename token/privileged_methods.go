@@ -0,0 +1,33 @@
+package token
+
+import (
+	"github.com/anoideaopen/foundation/core"
+)
+
+// PrivilegedMethods returns the methods of the base token and the base contract
+// mapped to the roles required to call them
+func (bt *BaseToken) PrivilegedMethods() map[string][]string {
+	methods := bt.BaseContract.PrivilegedMethods()
+
+	for method, roles := range map[string][]string{
+		"proposeEmission":           {core.RoleIssuer},
+		"approveEmission":           {core.RoleEmissionApprover},
+		"scheduleEmission":          {core.RoleIssuer},
+		"cancelScheduledEmission":   {core.RoleIssuer},
+		"executeScheduledEmissions": {core.RoleRobot},
+		"addDocs":                   {core.RoleIssuer},
+		"deleteDoc":                 {core.RoleIssuer},
+		"setRate":                   {core.RoleIssuer},
+		"setLimits":                 {core.RoleIssuer},
+		"deleteRate":                {core.RoleIssuer},
+		"setFee":                    {core.RoleFeeSetter},
+		"setFeeAddress":             {core.RoleFeeAddressSetter},
+		"sweepDust":                 {core.RoleAdmin},
+		"exportState":               {core.RoleAdmin},
+		"importState":               {core.RoleAdmin},
+	} {
+		methods[method] = roles
+	}
+
+	return methods
+}
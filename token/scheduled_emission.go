@@ -0,0 +1,376 @@
+package token
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anoideaopen/foundation/core/cachestub"
+	"github.com/anoideaopen/foundation/core/logger"
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
+)
+
+// ScheduledEmissionCompositeType is a composite key prefix for the scheduled emissions.
+// The attributes are the zero padded execution time in seconds and the id, so the emissions
+// are iterated in the order of the execution time.
+const ScheduledEmissionCompositeType = "scheduled_emission"
+
+// ScheduledEmissionIDCompositeType is a composite key prefix for the index of the scheduled emissions by the id,
+// the value is the zero padded execution time
+const ScheduledEmissionIDCompositeType = "scheduled_emission_id"
+
+// FailedEmissionCompositeType is a composite key prefix for the scheduled emissions failed on the execution
+// by the id, they are kept until cancelled by TxCancelScheduledEmission
+const FailedEmissionCompositeType = "scheduled_emission_failed"
+
+var (
+	ErrScheduledEmissionDisabled = errors.New("scheduled emission is not allowed by the token config")
+	ErrScheduledEmissionNotFound = errors.New("scheduled emission not found")
+	ErrScheduledEmissionExists   = errors.New("scheduled emission already exists")
+)
+
+// executeAtDigits is the number of digits of the greatest time in seconds
+const executeAtDigits = 19
+
+// ScheduledEmission is the emission executed by NBTxExecuteScheduledEmissions at the execution time
+type ScheduledEmission struct {
+	ID      string   `json:"id"`
+	Address string   `json:"address"`
+	Amount  *big.Int `json:"amount"`
+	// ExecuteAt is the execution time in seconds
+	ExecuteAt int64 `json:"executeAt"`
+	// Error is the error the emission failed with on the execution, empty if it is not executed yet
+	Error string `json:"error,omitempty"`
+}
+
+// ExecutedEmissions is the result of NBTxExecuteScheduledEmissions
+type ExecutedEmissions struct {
+	Executed []string `json:"executed"`
+	// Failed are ids of the due emissions failed on the execution
+	Failed []string `json:"failed"`
+}
+
+// TxScheduleEmission schedules the emission of amount to address at executeAt, the RFC 3339 time,
+// e.g. "2024-01-02T15:04:05Z". Method can be called by the issuer only if allow_scheduled_emission
+// is set in the token config. The amount exceeding emission_approval_threshold is rejected
+// with ErrEmissionApprovalRequired, such emissions are proposed by TxProposeEmission.
+// The emission is executed by NBTxExecuteScheduledEmissions once the time comes.
+// Returns the id of the scheduled emission: the id of the transaction, followed by the sequence number
+// of the emission if the transaction schedules several emissions, e.g. the signed batch.
+func (bt *BaseToken) TxScheduleEmission(
+	sender *types.Sender,
+	address *types.Address,
+	amount *big.Int,
+	executeAt string,
+) (string, error) {
+	if !sender.Equal(bt.Issuer()) {
+		return "", errors.New("unauthorized")
+	}
+
+	if !bt.TokenConfig().GetAllowScheduledEmission() {
+		return "", ErrScheduledEmissionDisabled
+	}
+
+	if amount.Sign() <= 0 {
+		return "", errors.New("amount should be more than zero")
+	}
+
+	if err := bt.checkEmissionApprovalThreshold(amount); err != nil {
+		return "", err
+	}
+
+	at, err := time.Parse(time.RFC3339, executeAt)
+	if err != nil {
+		return "", fmt.Errorf("parsing execution time: %w", err)
+	}
+
+	id, err := newTxScopedID(bt.GetStub().GetTxID(), bt.scheduledEmissionExists)
+	if err != nil {
+		return "", err
+	}
+
+	emission := &ScheduledEmission{
+		ID:        id,
+		Address:   address.String(),
+		Amount:    amount,
+		ExecuteAt: at.Unix(),
+	}
+
+	key, err := bt.scheduledEmissionKey(emission)
+	if err != nil {
+		return "", err
+	}
+
+	data, err := json.Marshal(emission)
+	if err != nil {
+		return "", err
+	}
+
+	if err = bt.indexScheduledEmission(emission, true); err != nil {
+		return "", err
+	}
+
+	if err = bt.GetStub().PutState(key, data); err != nil {
+		return "", err
+	}
+
+	return emission.ID, nil
+}
+
+// TxCancelScheduledEmission cancels the scheduled emission not executed yet or failed on the execution.
+// Method can be called by the issuer only.
+func (bt *BaseToken) TxCancelScheduledEmission(sender *types.Sender, id string) error {
+	if !sender.Equal(bt.Issuer()) {
+		return errors.New("unauthorized")
+	}
+
+	stub := bt.GetStub()
+
+	indexKey, err := stub.CreateCompositeKey(ScheduledEmissionIDCompositeType, []string{id})
+	if err != nil {
+		return err
+	}
+
+	executeAt, err := stub.GetState(indexKey)
+	if err != nil {
+		return err
+	}
+
+	if len(executeAt) != 0 {
+		key, err := stub.CreateCompositeKey(ScheduledEmissionCompositeType, []string{string(executeAt), id})
+		if err != nil {
+			return err
+		}
+
+		if err = stub.DelState(key); err != nil {
+			return err
+		}
+
+		return stub.DelState(indexKey)
+	}
+
+	failedKey, err := stub.CreateCompositeKey(FailedEmissionCompositeType, []string{id})
+	if err != nil {
+		return err
+	}
+
+	data, err := stub.GetState(failedKey)
+	if err != nil {
+		return err
+	}
+
+	if len(data) == 0 {
+		return fmt.Errorf("%w: %s", ErrScheduledEmissionNotFound, id)
+	}
+
+	return stub.DelState(failedKey)
+}
+
+// NBTxExecuteScheduledEmissions executes up to pageSize scheduled emissions due at the transaction time
+// in the order of their execution time and deletes them. Ids of the executed and the failed emissions
+// are returned, less than pageSize ids mean that there are no more due emissions. The emissions which
+// are not due are skipped. The failed emission, e.g. exceeding max_supply, makes no changes and is kept
+// with the error until cancelled by TxCancelScheduledEmission, so it does not block the later emissions.
+// Nothing is executed while the contract is paused. This transaction is sent only by the robot certificate.
+func (bt *BaseToken) NBTxExecuteScheduledEmissions(pageSize int64) (*ExecutedEmissions, error) {
+	if err := bt.CheckRobotCreator(); err != nil {
+		return nil, err
+	}
+
+	if pageSize <= 0 {
+		return nil, ErrInvalidHistoryPageSize
+	}
+
+	if !bt.TokenConfig().GetAllowScheduledEmission() {
+		return nil, ErrScheduledEmissionDisabled
+	}
+
+	if err := bt.CheckPaused(); err != nil {
+		return nil, err
+	}
+
+	stub := bt.GetStub()
+
+	ts, err := stub.GetTxTimestamp()
+	if err != nil {
+		return nil, err
+	}
+	now := ts.GetSeconds()
+
+	due, err := bt.dueEmissions(now, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ExecutedEmissions{Executed: []string{}, Failed: []string{}}
+	for _, emission := range due {
+		key, err := bt.scheduledEmissionKey(emission)
+		if err != nil {
+			return nil, err
+		}
+
+		if err = stub.DelState(key); err != nil {
+			return nil, err
+		}
+
+		if err = bt.indexScheduledEmission(emission, false); err != nil {
+			return nil, err
+		}
+
+		if err = bt.executeScheduledEmission(emission); err != nil {
+			logger.Logger().Warningf("scheduled emission %s failed: %s", emission.ID, err)
+
+			emission.Error = err.Error()
+			if err = bt.saveFailedEmission(emission); err != nil {
+				return nil, err
+			}
+
+			result.Failed = append(result.Failed, emission.ID)
+			continue
+		}
+
+		result.Executed = append(result.Executed, emission.ID)
+	}
+
+	return result, nil
+}
+
+// executeScheduledEmission emits the scheduled emission on the cached state, so the failed emission
+// makes no changes, and writes the changes of the succeeded one
+func (bt *BaseToken) executeScheduledEmission(emission *ScheduledEmission) error {
+	address, err := types.AddrFromBase58Check(emission.Address)
+	if err != nil {
+		return err
+	}
+
+	stub := bt.GetStub()
+	batchStub := cachestub.NewBatchCacheStub(stub)
+	txStub := batchStub.NewTxCacheStub(stub.GetTxID())
+
+	bt.SetStub(txStub)
+	err = bt.emit(address, emission.Amount)
+	bt.SetStub(stub)
+	if err != nil {
+		return err
+	}
+
+	_, events := txStub.Commit()
+	for _, event := range events {
+		if err = stub.SetEvent(event.GetName(), event.GetValue()); err != nil {
+			return err
+		}
+	}
+
+	if parent, ok := stub.(*cachestub.TxCacheStub); ok {
+		parent.Accounting = append(parent.Accounting, txStub.Accounting...)
+	}
+
+	return batchStub.Commit()
+}
+
+// scheduledEmissionExists reports if the id is used by the emission scheduled or failed on the execution
+func (bt *BaseToken) scheduledEmissionExists(id string) (bool, error) {
+	for _, objectType := range []string{ScheduledEmissionIDCompositeType, FailedEmissionCompositeType} {
+		key, err := bt.GetStub().CreateCompositeKey(objectType, []string{id})
+		if err != nil {
+			return false, err
+		}
+
+		data, err := bt.GetStub().GetState(key)
+		if err != nil {
+			return false, err
+		}
+
+		if len(data) != 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// saveFailedEmission keeps the scheduled emission failed on the execution
+func (bt *BaseToken) saveFailedEmission(emission *ScheduledEmission) error {
+	key, err := bt.GetStub().CreateCompositeKey(FailedEmissionCompositeType, []string{emission.ID})
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(emission)
+	if err != nil {
+		return err
+	}
+
+	return bt.GetStub().PutState(key, data)
+}
+
+// indexScheduledEmission adds the scheduled emission to the index by the id or removes it if scheduled is false.
+// The id used by another emission is rejected.
+func (bt *BaseToken) indexScheduledEmission(emission *ScheduledEmission, scheduled bool) error {
+	stub := bt.GetStub()
+
+	key, err := stub.CreateCompositeKey(ScheduledEmissionIDCompositeType, []string{emission.ID})
+	if err != nil {
+		return err
+	}
+
+	if !scheduled {
+		return stub.DelState(key)
+	}
+
+	exists, err := bt.scheduledEmissionExists(emission.ID)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return fmt.Errorf("%w: %s", ErrScheduledEmissionExists, emission.ID)
+	}
+
+	return stub.PutState(key, []byte(executeAtAttribute(emission)))
+}
+
+// dueEmissions returns up to limit scheduled emissions with the execution time not after now
+func (bt *BaseToken) dueEmissions(now int64, limit int64) ([]*ScheduledEmission, error) {
+	iter, err := bt.GetStub().GetStateByPartialCompositeKey(ScheduledEmissionCompositeType, []string{})
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	var due []*ScheduledEmission
+	for iter.HasNext() && int64(len(due)) < limit {
+		kv, err := iter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		emission := &ScheduledEmission{}
+		if err = json.Unmarshal(kv.GetValue(), emission); err != nil {
+			return nil, err
+		}
+
+		// the emissions are ordered by the execution time
+		if emission.ExecuteAt > now {
+			break
+		}
+
+		due = append(due, emission)
+	}
+
+	return due, nil
+}
+
+func (bt *BaseToken) scheduledEmissionKey(emission *ScheduledEmission) (string, error) {
+	return bt.GetStub().CreateCompositeKey(ScheduledEmissionCompositeType, []string{
+		executeAtAttribute(emission),
+		emission.ID,
+	})
+}
+
+func executeAtAttribute(emission *ScheduledEmission) string {
+	return fmt.Sprintf("%0*d", executeAtDigits, emission.ExecuteAt)
+}
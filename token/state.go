@@ -0,0 +1,428 @@
+package token
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/anoideaopen/foundation/core/balance"
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
+	"golang.org/x/crypto/sha3"
+)
+
+const stateBookmarkSeparator = "|"
+
+const (
+	// StateImportCompositeType is a composite key prefix for the progress of the state import
+	StateImportCompositeType = "state_import"
+	// StateImportPageCompositeType is a composite key prefix for the pages of the state already imported
+	// by the hash of the page
+	StateImportPageCompositeType = "state_import_page"
+)
+
+var (
+	ErrInvalidStatePageSize    = errors.New("page size must be positive")
+	ErrInvalidStateBookmark    = errors.New("invalid state bookmark")
+	ErrInvalidStateBalanceType = errors.New("invalid state balance type")
+	ErrStateTotalMismatch      = errors.New("sum of balances does not match state total")
+	ErrStateEmissionMismatch   = errors.New("total emission does not match already imported state")
+	ErrInvalidStateBalanceKey  = errors.New("invalid state balance key")
+	ErrStateNotFresh           = errors.New("state is imported into the ledger with balances or emission only")
+	ErrStateBalanceExists      = errors.New("balance already exists outside the imported pages")
+	ErrStateImportCompleted    = errors.New("state import is already completed")
+	ErrStateEmissionSum        = errors.New("sum of the imported token balances does not match total emission")
+)
+
+// emissionBalanceTypes lists balance types of the own token summing up to the total emission,
+// the given balances are the tokens transferred to other channels
+var emissionBalanceTypes = []balance.BalanceType{
+	balance.BalanceTypeToken,
+	balance.BalanceTypeTokenLocked,
+	balance.BalanceTypeGiven,
+}
+
+// exportedBalanceTypes lists balance types included in the exported state in the export order.
+var exportedBalanceTypes = []balance.BalanceType{
+	balance.BalanceTypeToken,
+	balance.BalanceTypeTokenLocked,
+	balance.BalanceTypeAllowed,
+	balance.BalanceTypeAllowedLocked,
+	balance.BalanceTypeGiven,
+}
+
+// stateImport is the progress of the state import
+type stateImport struct {
+	TotalEmission *big.Int `json:"totalEmission"`
+	// Emission is the sum of the imported balances of emissionBalanceTypes
+	Emission  *big.Int `json:"emission"`
+	Completed bool     `json:"completed"`
+}
+
+// StateBalance is a single balance record of the exported token state
+type StateBalance struct {
+	Type    string   `json:"type"`
+	Address string   `json:"address"`
+	Token   string   `json:"token,omitempty"`
+	Amount  *big.Int `json:"amount"`
+}
+
+// State is a page of the exported token state.
+// Total is a sum of all balances of the page, it is used to validate the page on import.
+type State struct {
+	TotalEmission   *big.Int       `json:"totalEmission"`
+	Balances        []StateBalance `json:"balances"`
+	Total           *big.Int       `json:"total"`
+	Bookmark        string         `json:"bookmark,omitempty"`
+	PageSizeClamped bool           `json:"pageSizeClamped,omitempty"`
+}
+
+// QueryExportState returns a page of token state: balances, allowed balances and total emission.
+// Pass the returned bookmark to get the next page, an empty bookmark means that all state is exported.
+// Method can be called by the contract admin only.
+func (bt *BaseToken) QueryExportState(sender *types.Sender, pageSize int64, bookmark string) (*State, error) {
+	if err := bt.CheckAdminSender(sender); err != nil {
+		return nil, err
+	}
+
+	if pageSize <= 0 {
+		return nil, ErrInvalidStatePageSize
+	}
+
+	pageSize, clamped := bt.ClampPageSize(pageSize)
+
+	typeIndex, innerBookmark, err := parseStateBookmark(bookmark)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = bt.loadConfigUnlessLoaded(); err != nil {
+		return nil, err
+	}
+
+	state := &State{
+		TotalEmission:   new(big.Int).SetBytes(bt.config.GetTotalEmission()),
+		Balances:        []StateBalance{},
+		Total:           big.NewInt(0),
+		PageSizeClamped: clamped,
+	}
+
+	for ; typeIndex < len(exportedBalanceTypes); typeIndex++ {
+		remaining := pageSize - int64(len(state.Balances))
+		if remaining <= 0 {
+			break
+		}
+
+		innerBookmark, err = bt.exportBalances(state, exportedBalanceTypes[typeIndex], int32(remaining), innerBookmark)
+		if err != nil {
+			return nil, err
+		}
+
+		if innerBookmark != "" {
+			break
+		}
+	}
+
+	if typeIndex < len(exportedBalanceTypes) {
+		state.Bookmark = exportedBalanceTypes[typeIndex].String() + stateBookmarkSeparator + innerBookmark
+	}
+
+	return state, nil
+}
+
+func (bt *BaseToken) exportBalances(
+	state *State,
+	balanceType balance.BalanceType,
+	pageSize int32,
+	bookmark string,
+) (string, error) {
+	stub := bt.GetStub()
+
+	iter, meta, err := stub.GetStateByPartialCompositeKeyWithPagination(balanceType.String(), []string{}, pageSize, bookmark)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = iter.Close()
+	}()
+
+	for iter.HasNext() {
+		kv, err := iter.Next()
+		if err != nil {
+			return "", err
+		}
+
+		_, components, err := stub.SplitCompositeKey(kv.GetKey())
+		if err != nil {
+			return "", err
+		}
+
+		if len(components) == 0 {
+			continue
+		}
+
+		amount, err := balance.Decode(bt.BalanceStub(), kv.GetValue())
+		if err != nil {
+			return "", err
+		}
+
+		record := StateBalance{
+			Type:    balanceType.String(),
+			Address: components[0],
+			Amount:  new(big.Int).SetBytes(amount.Bytes()),
+		}
+		if len(components) > 1 {
+			record.Token = components[1]
+		}
+
+		state.Balances = append(state.Balances, record)
+		state.Total.Add(state.Total, record.Amount)
+	}
+
+	return meta.GetBookmark(), nil
+}
+
+// TxImportState applies a page of token state exported by QueryExportState.
+// The state is imported into the fresh ledger only: the first page is rejected with ErrStateNotFresh
+// if the ledger has any balances or emission, the balances of the later pages must not exist
+// outside the pages already imported. Repeated import of the same page is idempotent.
+// The import is completed by the last page, the page with no bookmark, once the sum of the imported
+// token balances matches the total emission. Method can be called by the contract admin only.
+func (bt *BaseToken) TxImportState(sender *types.Sender, data string) error {
+	if err := bt.CheckAdminSender(sender); err != nil {
+		return err
+	}
+
+	var state State
+	if err := json.Unmarshal([]byte(data), &state); err != nil {
+		return fmt.Errorf("unmarshalling state: %w", err)
+	}
+
+	total := big.NewInt(0)
+	for _, record := range state.Balances {
+		if record.Amount == nil || record.Amount.Sign() < 0 {
+			return fmt.Errorf("%w: address %s", balance.ErrAmountMustBeNonNegative, record.Address)
+		}
+
+		balanceType, err := stateBalanceType(record.Type)
+		if err != nil {
+			return err
+		}
+
+		if err = checkStateBalanceKey(balanceType, record.Address); err != nil {
+			return err
+		}
+
+		total.Add(total, record.Amount)
+	}
+
+	if state.Total == nil || total.Cmp(state.Total) != 0 {
+		return ErrStateTotalMismatch
+	}
+
+	if state.TotalEmission == nil || state.TotalEmission.Sign() < 0 {
+		return errors.New("total emission must be non-negative")
+	}
+
+	pageHash := sha3.Sum256([]byte(data))
+	pageKey, err := bt.GetStub().CreateCompositeKey(StateImportPageCompositeType, []string{hex.EncodeToString(pageHash[:])})
+	if err != nil {
+		return err
+	}
+
+	imported, err := bt.GetStub().GetState(pageKey)
+	if err != nil {
+		return err
+	}
+
+	if len(imported) != 0 {
+		return nil
+	}
+
+	progress, err := bt.stateImport(state.TotalEmission)
+	if err != nil {
+		return err
+	}
+
+	for _, record := range state.Balances {
+		balanceType, _ := stateBalanceType(record.Type)
+
+		existing, err := balance.Get(bt.BalanceStub(), balanceType, record.Address, record.Token)
+		if err != nil {
+			return err
+		}
+
+		if existing.Sign() != 0 {
+			return fmt.Errorf("%w: type %s, address %s", ErrStateBalanceExists, record.Type, record.Address)
+		}
+
+		if err = balance.Put(bt.BalanceStub(), balanceType, record.Address, record.Token, &record.Amount.Int); err != nil {
+			return err
+		}
+
+		for _, t := range emissionBalanceTypes {
+			if t == balanceType {
+				progress.Emission.Add(progress.Emission, record.Amount)
+			}
+		}
+	}
+
+	if state.Bookmark == "" {
+		if progress.Emission.Cmp(progress.TotalEmission) != 0 {
+			return fmt.Errorf("%w: balances %s, total emission %s",
+				ErrStateEmissionSum, progress.Emission, progress.TotalEmission)
+		}
+
+		progress.Completed = true
+	}
+
+	if err = bt.GetStub().PutState(pageKey, []byte{1}); err != nil {
+		return err
+	}
+
+	return bt.saveStateImport(progress)
+}
+
+// stateImport returns the progress of the state import of the total emission.
+// The import is started if the ledger is fresh: it has no balances and emission,
+// the emission of the started import must match the total emission.
+func (bt *BaseToken) stateImport(totalEmission *big.Int) (*stateImport, error) {
+	key, err := bt.GetStub().CreateCompositeKey(StateImportCompositeType, []string{})
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := bt.GetStub().GetState(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) != 0 {
+		progress := &stateImport{}
+		if err = json.Unmarshal(data, progress); err != nil {
+			return nil, fmt.Errorf("unmarshalling state import: %w", err)
+		}
+
+		if progress.Completed {
+			return nil, ErrStateImportCompleted
+		}
+
+		if progress.TotalEmission.Cmp(totalEmission) != 0 {
+			return nil, ErrStateEmissionMismatch
+		}
+
+		return progress, nil
+	}
+
+	if err = bt.checkFreshState(); err != nil {
+		return nil, err
+	}
+
+	bt.config.TotalEmission = totalEmission.Bytes()
+	if err = bt.saveConfig(); err != nil {
+		return nil, err
+	}
+
+	return &stateImport{TotalEmission: totalEmission, Emission: big.NewInt(0)}, nil
+}
+
+func (bt *BaseToken) saveStateImport(progress *stateImport) error {
+	key, err := bt.GetStub().CreateCompositeKey(StateImportCompositeType, []string{})
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return err
+	}
+
+	return bt.GetStub().PutState(key, data)
+}
+
+// checkFreshState returns ErrStateNotFresh if the ledger has emission or balances of the exported types
+func (bt *BaseToken) checkFreshState() error {
+	if err := bt.loadConfigUnlessLoaded(); err != nil {
+		return err
+	}
+
+	if new(big.Int).SetBytes(bt.config.GetTotalEmission()).Sign() != 0 {
+		return fmt.Errorf("%w: total emission is set", ErrStateNotFresh)
+	}
+
+	for _, balanceType := range exportedBalanceTypes {
+		iter, err := bt.GetStub().GetStateByPartialCompositeKey(balanceType.String(), []string{})
+		if err != nil {
+			return err
+		}
+
+		exists := iter.HasNext()
+		_ = iter.Close()
+
+		if exists {
+			return fmt.Errorf("%w: balances of type %s exist", ErrStateNotFresh, balanceType)
+		}
+	}
+
+	return nil
+}
+
+func parseStateBookmark(bookmark string) (int, string, error) {
+	if bookmark == "" {
+		return 0, "", nil
+	}
+
+	balanceTypeHex, innerBookmark, ok := strings.Cut(bookmark, stateBookmarkSeparator)
+	if !ok {
+		return 0, "", ErrInvalidStateBookmark
+	}
+
+	balanceType, err := stateBalanceType(balanceTypeHex)
+	if err != nil {
+		return 0, "", ErrInvalidStateBookmark
+	}
+
+	for i, t := range exportedBalanceTypes {
+		if t == balanceType {
+			return i, innerBookmark, nil
+		}
+	}
+
+	return 0, "", ErrInvalidStateBookmark
+}
+
+// checkStateBalanceKey checks the key of the imported balance: the given balances are kept
+// by the name of the channel the tokens are given to, the other balances by the address
+func checkStateBalanceKey(balanceType balance.BalanceType, key string) error {
+	if balanceType == balance.BalanceTypeGiven {
+		if key == "" || key != strings.ToUpper(key) {
+			return fmt.Errorf("%w: channel %s", ErrInvalidStateBalanceKey, key)
+		}
+
+		return nil
+	}
+
+	if _, err := types.AddrFromBase58Check(key); err != nil {
+		return fmt.Errorf("%w: address %s: %s", ErrInvalidStateBalanceKey, key, err)
+	}
+
+	return nil
+}
+
+func stateBalanceType(s string) (balance.BalanceType, error) {
+	value, err := strconv.ParseUint(s, 16, 8)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidStateBalanceType, s)
+	}
+
+	for _, t := range exportedBalanceTypes {
+		if t == balance.BalanceType(value) {
+			return t, nil
+		}
+	}
+
+	return 0, fmt.Errorf("%w: %s", ErrInvalidStateBalanceType, s)
+}
@@ -0,0 +1,83 @@
+package token
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/anoideaopen/foundation/core/types"
+	"github.com/anoideaopen/foundation/core/types/big"
+)
+
+// SweepDustEvent - event on dust balances swept by TxSweepDust
+const SweepDustEvent = "SweepDust"
+
+var ErrInvalidDustThreshold = errors.New("dust threshold must be positive")
+
+// SweptDustEvent is the payload of SweepDustEvent
+type SweptDustEvent struct {
+	Treasury  string   `json:"treasury"`
+	Threshold *big.Int `json:"threshold"`
+	Wallets   uint64   `json:"wallets"`
+	Amount    *big.Int `json:"amount"`
+}
+
+// TxSweepDust moves token balances of the addresses below the threshold to the treasury address.
+// The addresses are taken page by page from QueryHolders: paginated queries are not available
+// in transactions and the range of the balance keys can not start after a bookmark.
+// Swept wallets have zero balance, so repeated pass over the same page moves nothing.
+// Method can be called by the contract admin only.
+func (bt *BaseToken) TxSweepDust(
+	sender *types.Sender,
+	threshold *big.Int,
+	treasury *types.Address,
+	addresses []string,
+) error {
+	if err := bt.CheckAdminSender(sender); err != nil {
+		return err
+	}
+
+	if threshold.Sign() <= 0 {
+		return ErrInvalidDustThreshold
+	}
+
+	summary := SweptDustEvent{
+		Treasury:  treasury.String(),
+		Threshold: threshold,
+		Amount:    big.NewInt(0),
+	}
+
+	for _, address := range addresses {
+		if address == treasury.String() {
+			continue
+		}
+
+		owner, err := types.AddrFromBase58Check(address)
+		if err != nil {
+			return fmt.Errorf("balance address: %w", err)
+		}
+
+		amount, err := bt.TokenBalanceGet(owner)
+		if err != nil {
+			return err
+		}
+
+		if amount.Sign() == 0 || amount.Cmp(threshold) >= 0 {
+			continue
+		}
+
+		if err = bt.TokenBalanceTransfer(owner, treasury, amount, "sweep dust"); err != nil {
+			return err
+		}
+
+		summary.Wallets++
+		summary.Amount.Add(summary.Amount, amount)
+	}
+
+	event, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+
+	return bt.GetStub().SetEvent(SweepDustEvent, event)
+}
@@ -40,6 +40,12 @@ type BaseToken struct {
 
 	// stores emission amount, fees and rates.
 	config *proto.Token
+
+	// called after every successful transfer.
+	postTransferHook PostTransferHook
+
+	// set while the post transfer hook is running.
+	inPostTransferHook bool
 }
 
 // Issuer returns the issuer of the token
@@ -106,15 +112,44 @@ func (bt *BaseToken) saveConfig() error {
 	return bt.GetStub().PutState(metadataKey, data)
 }
 
-// EmissionAdd adds emission
+// EmissionAdd adds emission, it fails with core.ErrPaused if the contract is paused
+// and with ErrMaxSupplyExceeded if the total emission exceeds max_supply of the token config.
+// The emission is recorded to the emission history without the recipient, use EmissionAddTo to record it.
+// The emission has no recipient, so it is not counted against max_emission_per_address,
+// the contracts emitting to an address must use EmissionAddTo for the limit to apply.
+// The amount exceeding emission_approval_threshold is rejected with ErrEmissionApprovalRequired.
 func (bt *BaseToken) EmissionAdd(amount *big.Int) error {
+	if err := bt.checkEmissionApprovalThreshold(amount); err != nil {
+		return err
+	}
+
+	if err := bt.emissionAdd(amount); err != nil {
+		return err
+	}
+
+	return bt.recordEmission(nil, amount)
+}
+
+func (bt *BaseToken) emissionAdd(amount *big.Int) error {
+	if err := bt.CheckPaused(); err != nil {
+		return err
+	}
+
 	if err := bt.loadConfigUnlessLoaded(); err != nil {
 		return err
 	}
 	if bt.config.GetTotalEmission() == nil {
 		bt.config.TotalEmission = new(big.Int).Bytes()
 	}
-	bt.config.TotalEmission = new(big.Int).Add(new(big.Int).SetBytes(bt.config.GetTotalEmission()), amount).Bytes()
+	total := new(big.Int).Add(new(big.Int).SetBytes(bt.config.GetTotalEmission()), amount)
+	if err := bt.checkMaxSupply(total); err != nil {
+		return err
+	}
+	totalEmission, err := total.BytesChecked()
+	if err != nil {
+		return fmt.Errorf("emission add: %w", err)
+	}
+	bt.config.TotalEmission = totalEmission
 	return bt.saveConfig()
 }
 
@@ -183,6 +218,22 @@ func (bt *BaseToken) ValidateTokenConfig(config []byte) error {
 		return fmt.Errorf("unmarshalling token config data failed: %w", err)
 	}
 
+	if _, err := maxEmissionPerAddress(cfg.GetToken()); err != nil {
+		return err
+	}
+
+	if _, err := minBalance(cfg.GetToken()); err != nil {
+		return err
+	}
+
+	if _, err := maxSupply(cfg.GetToken()); err != nil {
+		return err
+	}
+
+	if err := checkEmissionApprovals(cfg.GetToken()); err != nil {
+		return err
+	}
+
 	return cfg.Validate()
 }
 
@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/anoideaopen/foundation/core/helpers"
+	"github.com/anoideaopen/foundation/core/telemetry"
 	"github.com/anoideaopen/foundation/core/types"
 	"github.com/anoideaopen/foundation/core/types/big"
 	"github.com/anoideaopen/foundation/proto"
@@ -17,15 +18,35 @@ const (
 	RateDecimal = 8
 )
 
-var ErrFeeAddressNotConfigured = errors.New("fee address is not set in token config")
+// TransferEvent - event on tokens transferred by TxTransfer
+const TransferEvent = "Transfer"
 
-// TxTransfer transfers tokens from one account to another
+var (
+	ErrFeeAddressNotConfigured = errors.New("fee address is not set in token config")
+	ErrFeeExceedsAmount        = errors.New("fee deducted from the amount is not less than the amount")
+)
+
+// TransferredEvent is the payload of TransferEvent
+type TransferredEvent struct {
+	From   string   `json:"from"`
+	To     string   `json:"to"`
+	Amount *big.Int `json:"amount"`
+}
+
+// TxTransfer transfers tokens from one account to another.
+// If deduct_fee_from_amount is set, the fee in the token is taken from amount
+// and the recipient receives amount minus the fee.
 func (bt *BaseToken) TxTransfer(
 	sender *types.Sender,
 	recipient *types.Address,
 	amount *big.Int,
 	_ string, // ref
 ) error {
+	bt.TracingHandler().SetAttributes(
+		bt.GetTraceContext(),
+		telemetry.Token(bt.ContractConfig().GetSymbol()),
+	)
+
 	if sender.Equal(recipient) {
 		return errors.New("TxTransfer: sender and recipient are same users")
 	}
@@ -34,48 +55,138 @@ func (bt *BaseToken) TxTransfer(
 		return errors.New("TxTransfer: amount should be more than zero")
 	}
 
+	if err := bt.CheckPaused(); err != nil {
+		return fmt.Errorf("TxTransfer: %w", err)
+	}
+
+	if err := bt.checkReentrantTransfer(); err != nil {
+		return fmt.Errorf("TxTransfer: %w", err)
+	}
+
+	if err := bt.CheckFrozen(sender.Address(), recipient); err != nil {
+		return fmt.Errorf("TxTransfer: %w", err)
+	}
+
+	if !bt.IsTrustedAddress(sender.Address()) {
+		if err := bt.CheckDenylist(sender.Address(), recipient); err != nil {
+			return fmt.Errorf("TxTransfer: %w", err)
+		}
+	}
+
+	snapshot, err := bt.snapshotBalances(sender.Address(), recipient)
+	if err != nil {
+		return fmt.Errorf("TxTransfer: %w", err)
+	}
+
+	deductFee, err := bt.deductsFee()
+	if err != nil {
+		return fmt.Errorf("TxTransfer: %w", err)
+	}
+
+	// the fee is taken from the transferred amount, so it is charged before the amount is known
+	if deductFee {
+		fee, err := bt.transferFee(amount, sender.Address(), recipient)
+		if err != nil {
+			return fmt.Errorf("TxTransfer: transferring fee for operation: %w", err)
+		}
+
+		amount = new(big.Int).Sub(amount, fee)
+		if amount.Sign() <= 0 {
+			return fmt.Errorf("TxTransfer: %w", ErrFeeExceedsAmount)
+		}
+	}
+
 	if err := bt.TokenBalanceTransfer(sender.Address(), recipient, amount, "transfer"); err != nil {
 		return fmt.Errorf("TxTransfer: transferring tokens: %w", err)
 	}
 
-	if err := bt.transferFee(amount, sender.Address(), recipient); err != nil {
-		return fmt.Errorf("TxTransfer: transferring fee for operation: %w", err)
+	if err := bt.WalletAuditTransfer(sender.Address(), recipient, bt.ContractConfig().GetSymbol(), amount); err != nil {
+		return fmt.Errorf("TxTransfer: %w", err)
 	}
 
-	return nil
+	if !deductFee {
+		if _, err := bt.transferFee(amount, sender.Address(), recipient); err != nil {
+			return fmt.Errorf("TxTransfer: transferring fee for operation: %w", err)
+		}
+	}
+
+	if !bt.IsTrustedAddress(sender.Address()) {
+		if err := bt.checkMinBalance(sender.Address()); err != nil {
+			return fmt.Errorf("TxTransfer: %w", err)
+		}
+	}
+
+	if err := bt.runPostTransferHook(sender.Address(), recipient, amount); err != nil {
+		return fmt.Errorf("TxTransfer: %w", err)
+	}
+
+	if err := bt.reportBalanceSwings(snapshot); err != nil {
+		return fmt.Errorf("TxTransfer: %w", err)
+	}
+
+	event, err := json.Marshal(TransferredEvent{
+		From:   sender.Address().String(),
+		To:     recipient.String(),
+		Amount: amount,
+	})
+	if err != nil {
+		return fmt.Errorf("TxTransfer: %w", err)
+	}
+
+	return bt.GetStub().SetEvent(TransferEvent, event)
 }
 
+// deductsFee returns true if the transfer fee is taken from the transferred amount
+func (bt *BaseToken) deductsFee() (bool, error) {
+	if err := bt.loadConfigUnlessLoaded(); err != nil {
+		return false, err
+	}
+
+	return bt.TokenConfig().GetDeductFeeFromAmount() &&
+		bt.config.GetFee().GetCurrency() == bt.ContractConfig().GetSymbol(), nil
+}
+
+// transferFee transfers the fee of the transfer of amount from the sender to the fee address,
+// records it to the audit trails of the sender and the fee address and returns the charged fee, zero if no fee is charged
 func (bt *BaseToken) transferFee(
 	amount *big.Int,
 	sender *types.Address,
 	recipient *types.Address,
-) error {
+) (*big.Int, error) {
 	if err := bt.loadConfigUnlessLoaded(); err != nil {
-		return err
+		return nil, err
 	}
 
 	if bt.config.GetFee() != nil && len(bt.config.GetFeeAddress()) == 0 {
-		return ErrFeeAddressNotConfigured
+		return nil, ErrFeeAddressNotConfigured
 	}
 
 	if err := validateFeeConfig(bt.config); err != nil {
-		return fmt.Errorf("validating fee in config: %w", err)
+		return nil, fmt.Errorf("validating fee in config: %w", err)
 	}
 
-	fee, err := bt.calcTransferFee(amount, sender, recipient)
+	fee, remainder, err := bt.calcTransferFee(amount, sender, recipient)
 	if err != nil {
-		return fmt.Errorf("calculating transfer fee: %w", err)
+		return nil, fmt.Errorf("calculating transfer fee: %w", err)
+	}
+
+	if bt.TokenConfig().GetAccumulateFeeRemainders() && remainder.Sign() == 1 {
+		collected, err := bt.accumulateFeeRemainder(sender, fee.Currency, remainder)
+		if err != nil {
+			return nil, fmt.Errorf("accumulating fee remainder: %w", err)
+		}
+		fee.Fee = new(big.Int).Add(fee.Fee, collected)
 	}
 
 	if fee == nil || fee.Fee == nil || fee.Fee.Sign() != 1 {
-		return nil
+		return big.NewInt(0), nil
 	}
 
 	feeAddr := types.AddrFromBytes(bt.config.GetFeeAddress())
 	if bt.config.GetFee().GetCurrency() == bt.ContractConfig().GetSymbol() {
 		err = bt.TokenBalanceTransfer(sender, feeAddr, fee.Fee, "transfer fee")
 		if err != nil {
-			return fmt.Errorf(
+			return nil, fmt.Errorf(
 				"failed to transfer fee from token balance, from %s to %s : %w",
 				sender,
 				feeAddr,
@@ -85,7 +196,7 @@ func (bt *BaseToken) transferFee(
 	} else {
 		err = bt.AllowedBalanceTransfer(fee.Currency, sender, feeAddr, fee.Fee, "transfer fee")
 		if err != nil {
-			return fmt.Errorf(
+			return nil, fmt.Errorf(
 				"failed to transfer fee from allowed balance, currency %s, from %s to %s : %w",
 				fee.Currency,
 				sender,
@@ -95,45 +206,99 @@ func (bt *BaseToken) transferFee(
 		}
 	}
 
-	return nil
+	if err = bt.WalletAuditFee(sender, feeAddr, fee.Currency, fee.Fee); err != nil {
+		return nil, err
+	}
+
+	return fee.Fee, nil
 }
 
-func (bt *BaseToken) calcTransferFee(amount *big.Int, sender *types.Address, recipient *types.Address) (*Predict, error) {
+// calcTransferFee returns the fee of the transfer and the remainder lost by the rounding of the fee
+func (bt *BaseToken) calcTransferFee(
+	amount *big.Int,
+	sender *types.Address,
+	recipient *types.Address,
+) (*Predict, *big.Int, error) {
 	if sender == nil {
-		return nil, errors.New("sender can't be nil")
+		return nil, nil, errors.New("sender can't be nil")
 	}
 	if recipient == nil {
-		return nil, errors.New("recipient can't be nil")
+		return nil, nil, errors.New("recipient can't be nil")
 	}
 	if amount == nil {
-		return nil, errors.New("amount can't be nil")
+		return nil, nil, errors.New("amount can't be nil")
 	}
 
 	if sender.UserID == "" {
 		fullSenderAddress, err := helpers.GetFullAddress(bt.GetStub(), sender.String())
 		if err != nil {
-			return nil, errors.New("failed to recive user id by sender address")
+			return nil, nil, errors.New("failed to recive user id by sender address")
 		}
 		sender = (*types.Address)(fullSenderAddress)
 	}
 	if recipient.UserID == "" {
 		fullRecipientAddress, err := helpers.GetFullAddress(bt.GetStub(), recipient.String())
 		if err != nil {
-			return nil, errors.New("failed to recive user id by recipient address")
+			return nil, nil, errors.New("failed to recive user id by recipient address")
 		}
 		recipient = (*types.Address)(fullRecipientAddress)
 	}
 
-	fee, err := bt.calcFee(amount)
+	fee, remainder, err := bt.calcFeeWithRemainder(amount)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	if !sender.IsUserIDSame(recipient) && fee.Fee.Sign() == 1 {
-		return fee, nil
+	if !sender.IsUserIDSame(recipient) && (fee.Fee.Sign() == 1 || remainder.Sign() == 1) {
+		return fee, remainder, nil
 	}
 
-	return &Predict{Fee: big.NewInt(0), Currency: bt.ContractConfig().GetSymbol()}, nil
+	return &Predict{Fee: big.NewInt(0), Currency: bt.ContractConfig().GetSymbol()}, big.NewInt(0), nil
+}
+
+// TxAllowedBalanceTransfer transfers allowed balance of token from the sender to another account within the channel
+func (bt *BaseToken) TxAllowedBalanceTransfer(
+	sender *types.Sender,
+	to *types.Address,
+	token string,
+	amount *big.Int,
+) error {
+	bt.TracingHandler().SetAttributes(
+		bt.GetTraceContext(),
+		telemetry.Token(token),
+	)
+
+	if sender.Equal(to) {
+		return errors.New("TxAllowedBalanceTransfer: sender and recipient are same users")
+	}
+
+	if amount.Sign() <= 0 {
+		return errors.New("TxAllowedBalanceTransfer: amount should be more than zero")
+	}
+
+	if err := bt.CheckPaused(); err != nil {
+		return fmt.Errorf("TxAllowedBalanceTransfer: %w", err)
+	}
+
+	if err := bt.checkReentrantTransfer(); err != nil {
+		return fmt.Errorf("TxAllowedBalanceTransfer: %w", err)
+	}
+
+	if !bt.IsTrustedAddress(sender.Address()) {
+		if err := bt.CheckDenylist(sender.Address(), to); err != nil {
+			return fmt.Errorf("TxAllowedBalanceTransfer: %w", err)
+		}
+	}
+
+	if err := bt.AllowedBalanceTransfer(token, sender.Address(), to, amount, "transfer"); err != nil {
+		return fmt.Errorf("TxAllowedBalanceTransfer: transferring allowed balance: %w", err)
+	}
+
+	if err := bt.WalletAuditTransfer(sender.Address(), to, bt.ResolveToken(token), amount); err != nil {
+		return fmt.Errorf("TxAllowedBalanceTransfer: %w", err)
+	}
+
+	return nil
 }
 
 // TxAllowedIndustrialBalanceTransfer transfers tokens from one account to another
@@ -142,6 +307,20 @@ func (bt *BaseToken) TxAllowedIndustrialBalanceTransfer(sender *types.Sender, re
 		return errors.New("impossible operation, the sender and recipient of the transfer cannot be equal")
 	}
 
+	if err := bt.CheckPaused(); err != nil {
+		return err
+	}
+
+	if err := bt.checkReentrantTransfer(); err != nil {
+		return err
+	}
+
+	if !bt.IsTrustedAddress(sender.Address()) {
+		if err := bt.CheckDenylist(sender.Address(), recipient); err != nil {
+			return err
+		}
+	}
+
 	if err := bt.loadConfigUnlessLoaded(); err != nil {
 		return err
 	}
@@ -161,7 +340,22 @@ func (bt *BaseToken) TxAllowedIndustrialBalanceTransfer(sender *types.Sender, re
 		}
 	}
 
-	return bt.AllowedIndustrialBalanceTransfer(sender.Address(), recipient, assets, "transfer")
+	if err = bt.AllowedIndustrialBalanceTransfer(sender.Address(), recipient, assets, "transfer"); err != nil {
+		return err
+	}
+
+	for _, industrialAsset := range assets {
+		if err = bt.WalletAuditTransfer(
+			sender.Address(),
+			recipient,
+			industrialAsset.GetGroup(),
+			new(big.Int).SetBytes(industrialAsset.GetAmount()),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 // Predict is a struct for fee prediction
@@ -216,12 +410,20 @@ func (bt *BaseToken) TxSetFeeAddress(sender *types.Sender, address *types.Addres
 }
 
 func (bt *BaseToken) calcFee(amount *big.Int) (*Predict, error) {
+	fee, _, err := bt.calcFeeWithRemainder(amount)
+	return fee, err
+}
+
+// calcFeeWithRemainder returns the fee of amount rounded down and the remainder lost by the rounding
+// in units of feeRemainderDenominator of the fee currency base unit.
+// The remainder is zero if the fee is raised to the floor or lowered to the cap.
+func (bt *BaseToken) calcFeeWithRemainder(amount *big.Int) (*Predict, *big.Int, error) {
 	if err := bt.loadConfigUnlessLoaded(); err != nil {
-		return &Predict{}, err
+		return &Predict{}, nil, err
 	}
 
 	if bt.config.GetFee().GetFee() == nil || new(big.Int).SetBytes(bt.config.GetFee().GetFee()).Cmp(big.NewInt(0)) == 0 {
-		return &Predict{Fee: big.NewInt(0), Currency: bt.ContractConfig().GetSymbol()}, nil
+		return &Predict{Fee: big.NewInt(0), Currency: bt.ContractConfig().GetSymbol()}, big.NewInt(0), nil
 	}
 
 	fee := new(big.Int).Div(
@@ -236,13 +438,19 @@ func (bt *BaseToken) calcFee(amount *big.Int) (*Predict, error) {
 		),
 	)
 
+	// exact fee is amount * fee * rate / feeRemainderDenominator
+	exact := new(big.Int).Mul(
+		new(big.Int).Mul(amount, new(big.Int).SetBytes(bt.config.GetFee().GetFee())),
+		feeRateDenominator(),
+	)
+
 	if bt.config.GetFee().GetCurrency() != bt.ContractConfig().GetSymbol() {
 		rate, ok, err := bt.GetRateAndLimits("buyToken", bt.config.GetFee().GetCurrency())
 		if err != nil {
-			return &Predict{}, err
+			return &Predict{}, nil, err
 		}
 		if !ok {
-			return &Predict{}, errors.New("incorrect fee currency")
+			return &Predict{}, nil, errors.New("incorrect fee currency")
 		}
 
 		fee = new(big.Int).Div(
@@ -256,18 +464,27 @@ func (bt *BaseToken) calcFee(amount *big.Int) (*Predict, error) {
 				nil,
 			),
 		)
+
+		exact = new(big.Int).Mul(
+			new(big.Int).Mul(amount, new(big.Int).SetBytes(bt.config.GetFee().GetFee())),
+			new(big.Int).SetBytes(rate.GetRate()),
+		)
 	}
 
+	remainder := new(big.Int).Sub(exact, new(big.Int).Mul(fee, feeRemainderDenominator()))
+
 	if fee.Cmp(new(big.Int).SetBytes(bt.config.GetFee().GetFloor())) < 0 {
 		fee = new(big.Int).SetBytes(bt.config.GetFee().GetFloor())
+		remainder = big.NewInt(0)
 	}
 
 	cp := new(big.Int).SetBytes(bt.config.GetFee().GetCap())
 	if cp.Cmp(big.NewInt(0)) > 0 && fee.Cmp(cp) > 0 {
 		fee = new(big.Int).SetBytes(bt.config.GetFee().GetCap())
+		remainder = big.NewInt(0)
 	}
 
-	return &Predict{Fee: fee, Currency: bt.config.GetFee().GetCurrency()}, nil
+	return &Predict{Fee: fee, Currency: bt.config.GetFee().GetCurrency()}, remainder, nil
 }
 
 func validateFeeConfig(config *proto.Token) error {